@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"trieu_mock_project_go/internal/bootstrap"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+)
+
+const commandsUsage = `Usage: app [command]
+
+Without a command the web server is started.
+
+Commands:
+  ldap-sync [--dry-run]   synchronise users from the LDAP directory and print the report
+  migrate <command>       apply, roll back, list or create database migrations, see app migrate help
+  seed [--demo]           create the default positions, skills and admin, and optionally a demo dataset,
+                          see app seed --help
+`
+
+// runCommand runs a maintenance command instead of the server and returns the exit code
+func runCommand(args []string) int {
+	switch args[0] {
+	case "ldap-sync":
+		return runLDAPSync(args[1:])
+	case "migrate":
+		return runMigrate(args[1:])
+	case "seed":
+		return runSeed(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(commandsUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", args[0], commandsUsage)
+		return 2
+	}
+}
+
+func runLDAPSync(args []string) int {
+	flags := flag.NewFlagSet("ldap-sync", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "report what would change without saving it")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	// Interrupting rolls the sync back
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := config.InitDB(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		return 1
+	}
+	appContainer := bootstrap.NewAppContainer(config.DB, config.LoadConfig())
+	run, err := appContainer.LDAPSyncService.Sync(ctx, models.LDAPSyncTriggerCLI, *dryRun)
+	if run == nil {
+		fmt.Fprintf(os.Stderr, "LDAP sync failed: %v\n", err)
+		return 1
+	}
+
+	printLDAPSyncReport(run)
+	if err != nil {
+		return 1
+	}
+	return 0
+}
+
+func printLDAPSyncReport(run *dtos.LDAPSyncRun) {
+	title := "LDAP sync"
+	if run.DryRun {
+		title = "LDAP sync (dry run, nothing was saved)"
+	}
+	fmt.Printf("%s #%d %s\n", title, run.ID, run.Status)
+	if run.Error != nil {
+		fmt.Printf("Error: %s\n", *run.Error)
+	}
+	fmt.Printf("%d directory entries: %d created, %d updated, %d deactivated, %d reactivated, %d skipped\n",
+		run.DirectoryEntries, run.CreatedCount, run.UpdatedCount, run.DeactivatedCount, run.ReactivatedCount, run.SkippedCount)
+	if len(run.Changes) == 0 {
+		return
+	}
+
+	fmt.Println()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ACTION\tENTRY\tEMAIL\tDETAILS")
+	for _, change := range run.Changes {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", change.Action, change.UID, change.Email, change.Message)
+	}
+	writer.Flush()
+}
@@ -1,85 +1,136 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"html/template"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 	"trieu_mock_project_go/internal/bootstrap"
 	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/logging"
 	"trieu_mock_project_go/internal/routes"
-
-	"github.com/gin-contrib/sessions"
-	"github.com/gin-contrib/sessions/cookie"
-	"github.com/gin-gonic/gin"
+	"trieu_mock_project_go/internal/tracing"
+	"trieu_mock_project_go/internal/utils"
 )
 
 func main() {
 	// Load config
 	cfg := config.LoadConfig()
+	logging.Setup(cfg.Log.Level, cfg.Log.Format)
 
-	// Initialize database
-	if err := config.InitDB(); err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+	// Run a maintenance command instead of the server, see commands.go
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
 	}
 
-	// Create Gin router
-	router := gin.Default()
+	// Cancelled on SIGINT or SIGTERM, it stops the background jobs and starts the shutdown of the server
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	setupHtmlTemplate(router)
+	// Export spans of requests, services and queries, pending ones are flushed before exiting
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
 
-	router.Static("/static", "./static")
+	// Fail on start rather than on every login when a signing key is missing or invalid
+	if err := utils.LoadJWTKeys(); err != nil {
+		fatal("Failed to load JWT keys", err)
+	}
 
-	setupSessionConfiguration(router, cfg)
+	// Initialize database
+	if err := config.InitDB(); err != nil {
+		fatal("Failed to initialize database", err)
+	}
 
 	// Initialize app container
-	appContainer := bootstrap.NewAppContainer()
+	appContainer := bootstrap.NewAppContainer(config.DB, cfg)
+
+	// Create Gin router with templates, sessions and routes
+	router, err := routes.NewRouter(cfg, appContainer)
+	if err != nil {
+		fatal("Failed to create router", err)
+	}
 
-	// Setup routes
-	routes.SetupRoutes(router, appContainer)
+	// Start background jobs, each returns once ctx is cancelled
+	var jobs sync.WaitGroup
+	jobs.Go(func() { appContainer.CelebrationReminderJob.Start(ctx) })
+	jobs.Go(func() { appContainer.AdminSessionCleanupJob.Start(ctx) })
+	jobs.Go(func() { appContainer.LDAPSyncJob.Start(ctx) })
+	jobs.Go(func() { appContainer.WebhookDeliveryJob.Start(ctx) })
+	jobs.Go(func() { appContainer.OutboxDispatchJob.Start(ctx) })
+	jobs.Go(func() { appContainer.EmailDeliveryJob.Start(ctx) })
+	jobs.Go(func() { appContainer.ChatDeliveryJob.Start(ctx) })
 
 	// Start server
-	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("Starting server on %s", addr)
+	server := &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
+		Handler:           router,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("Starting server", "addr", server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
 
-	if err := router.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	select {
+	case err := <-serverErr:
+		fatal("Failed to start server", err)
+	case <-ctx.Done():
+	}
+	// A second signal kills the process without waiting for the shutdown
+	stop()
+
+	// Fail the readiness probe first and keep serving while load balancers notice it and stop routing
+	// new requests here, only then stop accepting connections
+	appContainer.HealthService.MarkShuttingDown()
+	slog.Info("Shutting down, no longer ready", "drain_delay", cfg.Server.ShutdownDrainDelay)
+	time.Sleep(cfg.Server.ShutdownDrainDelay)
+	slog.Info("Draining in-flight requests", "timeout", cfg.Server.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to drain in-flight requests", "error", err)
+	}
+	if err := <-serverErr; !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Server stopped with an error", "error", err)
+	}
+
+	jobsDone := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(jobsDone)
+	}()
+	select {
+	case <-jobsDone:
+	case <-shutdownCtx.Done():
+		slog.Error("Background jobs did not stop in time")
 	}
-}
 
-func setupHtmlTemplate(router *gin.Engine) {
-	router.SetFuncMap(template.FuncMap{
-		"add": func(a, b int) int { return a + b },
-		"sub": func(a, b int) int { return a - b },
-		"min": func(a, b int64) int64 {
-			if a < b {
-				return a
-			}
-			return b
-		},
-		"int64": func(v int) int64 {
-			return int64(v)
-		},
-		"iterate": func(start, end int) []int {
-			var items []int
-			for i := start; i <= end; i++ {
-				items = append(items, i)
-			}
-			return items
-		},
-	})
-
-	router.LoadHTMLGlob("templates/**/*")
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Failed to flush spans", "error", err)
+	}
+	if sqlDB, err := config.DB.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Error("Failed to close the database", "error", err)
+		}
+	}
+	slog.Info("Server stopped")
 }
 
-func setupSessionConfiguration(router *gin.Engine, cfg *config.Config) {
-	store := cookie.NewStore([]byte(cfg.SessionConfig.Secret))
-	store.Options(sessions.Options{
-		Path:     "/",
-		MaxAge:   cfg.SessionConfig.MaxAge,
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		Secure:   cfg.SessionConfig.Secure,
-	})
-	router.Use(sessions.Sessions("trieu_mock_project_session", store))
+// fatal logs the error that stops the server and exits
+func fatal(message string, err error) {
+	slog.Error(message, "error", err)
+	os.Exit(1)
 }
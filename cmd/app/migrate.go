@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+	"trieu_mock_project_go/internal/config"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+const migrateUsage = `Usage: app migrate <command>
+
+Commands:
+  up                apply all pending migrations
+  down [N]          roll back the last N applied migrations, 1 by default
+  goto VERSION      migrate up or down to VERSION
+  force VERSION     set VERSION without running anything, after fixing a failed migration by hand
+                    (-1 for no migration applied)
+  status            list the migrations and whether they are applied
+  create NAME       create an empty up and down migration for every database driver
+`
+
+// Migrations created with the create command are numbered by their creation time
+const migrationVersionFormat = "20060102150405"
+
+var migrationNameCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+
+// runMigrate manages the migrations of the configured database, see migrateUsage
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "create":
+		return runMigrateCreate(args)
+	case "up", "down", "goto", "force", "status":
+	case "help", "-h", "--help":
+		fmt.Print(migrateUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown migrate command %q\n\n%s", command, migrateUsage)
+		return 2
+	}
+
+	// down, goto and force take a number, it is checked before connecting
+	var number int
+	switch command {
+	case "down":
+		steps, err := migrateArgument(args, "1")
+		if err != nil || steps < 1 {
+			fmt.Fprintf(os.Stderr, "down expects the number of migrations to roll back\n\n%s", migrateUsage)
+			return 2
+		}
+		number = steps
+	case "goto":
+		version, err := migrateArgument(args, "")
+		if err != nil || version < 0 {
+			fmt.Fprintf(os.Stderr, "goto expects a migration version\n\n%s", migrateUsage)
+			return 2
+		}
+		number = version
+	case "force":
+		version, err := migrateArgument(args, "")
+		if err != nil || version < -1 {
+			fmt.Fprintf(os.Stderr, "force expects a migration version or -1\n\n%s", migrateUsage)
+			return 2
+		}
+		number = version
+	default:
+		if len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "%s takes no arguments\n\n%s", command, migrateUsage)
+			return 2
+		}
+	}
+
+	dbConfig := config.LoadConfig().Database
+	if err := config.ConnectToDatabase(dbConfig.Driver, config.BuildDSN()); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return 1
+	}
+	m, err := config.NewMigrator()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer m.Close()
+
+	// Interrupting stops once the running migration is done, the database is never left half migrated
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		m.GracefulStop <- true
+	}()
+
+	switch command {
+	case "up":
+		err = m.Up()
+	case "down":
+		err = m.Steps(-number)
+	case "goto":
+		err = m.Migrate(uint(number))
+	case "force":
+		err = m.Force(number)
+	case "status":
+		err = printMigrationStatus(m, dbConfig.Driver)
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("No change, the database is already at this version")
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migrate %s failed: %v\n", command, err)
+		return 1
+	}
+	if command != "status" {
+		printMigrationVersion(m)
+	}
+	return 0
+}
+
+// migrateArgument parses the single number a migrate command takes, defaultValue is used when it is omitted
+func migrateArgument(args []string, defaultValue string) (int, error) {
+	switch {
+	case len(args) == 0 && defaultValue != "":
+		return strconv.Atoi(defaultValue)
+	case len(args) == 1:
+		return strconv.Atoi(args[0])
+	default:
+		return 0, fmt.Errorf("expected one argument, got %d", len(args))
+	}
+}
+
+func printMigrationVersion(m *migrate.Migrate) {
+	version, dirty, err := m.Version()
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		fmt.Println("No migration is applied")
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Failed to read the migration version: %v\n", err)
+	case dirty:
+		fmt.Printf("Version %d, dirty: it failed part way, fix the database then run migrate force %d\n", version, version)
+	default:
+		fmt.Printf("Version %d\n", version)
+	}
+}
+
+// printMigrationStatus lists the migrations of the driver, the ones up to the current version are applied
+func printMigrationStatus(m *migrate.Migrate, driver string) error {
+	current, dirty, err := m.Version()
+	applied := err == nil
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return err
+	}
+
+	migrationsPath, err := config.MigrationsPath(driver)
+	if err != nil {
+		return err
+	}
+	migrations, err := source.Open("file://" + migrationsPath)
+	if err != nil {
+		return err
+	}
+	defer migrations.Close()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS")
+	pending := 0
+	version, err := migrations.First()
+	for err == nil {
+		name := ""
+		if body, identifier, readErr := migrations.ReadUp(version); readErr == nil {
+			body.Close()
+			name = identifier
+		}
+
+		status := "pending"
+		switch {
+		case applied && version == current && dirty:
+			status = "dirty"
+		case applied && version <= current:
+			status = "applied"
+		default:
+			pending++
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", version, name, status)
+		version, err = migrations.Next(version)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	writer.Flush()
+
+	fmt.Printf("\n%s database, %d pending\n", driver, pending)
+	printMigrationVersion(m)
+	return nil
+}
+
+// runMigrateCreate creates an empty pair of migrations in the migrations of every driver, the schema
+// has to stay the same on every database engine
+func runMigrateCreate(args []string) int {
+	name := strings.Trim(migrationNameCleaner.ReplaceAllString(strings.ToLower(strings.Join(args, " ")), "_"), "_")
+	if name == "" {
+		fmt.Fprintf(os.Stderr, "create expects the name of the migration\n\n%s", migrateUsage)
+		return 2
+	}
+
+	version := time.Now().UTC().Format(migrationVersionFormat)
+	for _, driver := range []string{config.DBDriverMySQL, config.DBDriverPostgres, config.DBDriverSQLite} {
+		migrationsPath, err := config.MigrationsPath(driver)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get migrations path: %v\n", err)
+			return 1
+		}
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(migrationsPath, fmt.Sprintf("%s_%s.%s.sql", version, name, direction))
+			content := fmt.Sprintf("-- %s %s migration for %s\n", strings.ReplaceAll(name, "_", " "), direction, driver)
+			// O_EXCL never overwrites a migration created in the same second
+			file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+			if err == nil {
+				_, err = file.WriteString(content)
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to create migration: %v\n", err)
+				return 1
+			}
+			fmt.Println("Created", path)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"trieu_mock_project_go/internal/bootstrap"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+)
+
+// Day the demo dates are relative to unless --demo-today is given, fixed so the dataset does not
+// change from one day to the next
+const demoTodayDefault = "2025-01-01"
+
+const seedUsage = `Usage: app seed [flags]
+
+Creates the default career tracks, positions and skills that are missing and the initial admin unless
+a user with its email exists. The admin defaults to SEED_ADMIN_NAME, SEED_ADMIN_EMAIL and
+SEED_ADMIN_PASSWORD; without a password one is generated and printed once.
+
+With --demo it also generates a demo organisation of users at demo.example.com with teams, skills,
+projects, past team memberships and promotions. The same --demo-seed, --demo-users and --demo-today
+always give the same dataset, its dates are relative to --demo-today.
+
+Flags:
+`
+
+// runSeed fills the database with what is needed to log in, and optionally with a demo dataset
+func runSeed(args []string) int {
+	seedConfig := config.LoadConfig().Seed
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), seedUsage)
+		flags.PrintDefaults()
+	}
+	adminName := flags.String("admin-name", seedConfig.AdminName, "name of the initial admin")
+	adminEmail := flags.String("admin-email", seedConfig.AdminEmail, "email of the initial admin, empty to create no admin")
+	adminPassword := flags.String("admin-password", seedConfig.AdminPassword, "password of the initial admin, generated when empty")
+	demo := flags.Bool("demo", false, "also generate the demo dataset")
+	demoSeed := flags.Uint64("demo-seed", 1, "seed of the demo dataset generator")
+	demoUsers := flags.Int("demo-users", 300, "number of demo users")
+	demoPassword := flags.String("demo-password", "Password123", "password of every demo user")
+	demoToday := flags.String("demo-today", demoTodayDefault, "day the demo dates are relative to, as YYYY-MM-DD")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(os.Stderr, "seed takes no arguments\n\n")
+		flags.Usage()
+		return 2
+	}
+	if *demo && *demoUsers < 1 {
+		fmt.Fprintln(os.Stderr, "--demo-users must be at least 1")
+		return 2
+	}
+	today, err := time.Parse(time.DateOnly, *demoToday)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "--demo-today must be a date as YYYY-MM-DD")
+		return 2
+	}
+
+	// Interrupting rolls the running step back
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := config.InitDB(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize database: %v\n", err)
+		return 1
+	}
+	appContainer := bootstrap.NewAppContainer(config.DB, config.LoadConfig())
+
+	report, err := appContainer.SeedService.SeedDefaults(ctx, dtos.SeedAdmin{
+		Name:     *adminName,
+		Email:    *adminEmail,
+		Password: *adminPassword,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Seeding failed: %v\n", err)
+		return 1
+	}
+	printSeedReport(report)
+
+	if !*demo {
+		return 0
+	}
+	demoReport, err := appContainer.SeedService.SeedDemo(ctx, dtos.SeedDemoOptions{
+		Seed:     *demoSeed,
+		Users:    *demoUsers,
+		Password: *demoPassword,
+		Today:    today,
+	})
+	if errors.Is(err, appErrors.ErrDemoDataAlreadySeeded) {
+		fmt.Println("The demo dataset is already seeded, nothing was generated")
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Generating the demo dataset failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Demo dataset: %d users, %d teams, %d projects, %d user skills, %d team memberships, %d position changes\n",
+		demoReport.Users, demoReport.Teams, demoReport.Projects, demoReport.UserSkills, demoReport.TeamMemberships, demoReport.PositionChanges)
+	fmt.Printf("Demo users log in with the password %s\n", *demoPassword)
+	return 0
+}
+
+func printSeedReport(report *dtos.SeedReport) {
+	fmt.Printf("Created %d career tracks, %d positions and %d skills\n", report.CareerTracks, report.Positions, report.Skills)
+	switch {
+	case report.AdminEmail == "":
+		fmt.Println("No admin email given, no admin was created")
+	case !report.AdminCreated:
+		fmt.Printf("Admin %s already exists\n", report.AdminEmail)
+	case report.GeneratedPassword != "":
+		fmt.Printf("Created admin %s with the password %s, it is not shown again\n", report.AdminEmail, report.GeneratedPassword)
+	default:
+		fmt.Printf("Created admin %s\n", report.AdminEmail)
+	}
+}
@@ -0,0 +1,176 @@
+// Command mockchat is a minimal Slack-compatible workspace for trying the chat integration locally.
+// It shows the messages posted to its incoming webhook and sends signed slash commands to the app.
+//
+//	go run ./cmd/mockchat
+//
+// and start the app with
+//
+//	CHAT_SIGNING_SECRET=mock-secret CHAT_WEBHOOK_URL=http://localhost:9100/hooks/channel
+//
+// then open http://localhost:9100 and type a command such as "who knows go".
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"trieu_mock_project_go/internal/utils"
+)
+
+// Only the latest messages are kept and shown
+const maxMessages = 50
+
+type message struct {
+	From string
+	Text string
+	At   time.Time
+}
+
+type workspace struct {
+	appURL        string
+	signingSecret string
+	client        *http.Client
+
+	mu       sync.Mutex
+	messages []message
+}
+
+var channelPage = template.Must(template.New("channel").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Mock Chat</title></head>
+<body style="font-family: sans-serif; max-width: 720px; margin: 40px auto">
+  <h2>#general</h2>
+  {{range .}}
+  <div style="border-bottom: 1px solid #ddd; padding: 8px 0">
+    <small><b>{{.From}}</b> {{.At.Format "15:04:05"}}</small>
+    <pre style="white-space: pre-wrap; margin: 4px 0">{{.Text}}</pre>
+  </div>
+  {{else}}
+  <p>No messages yet.</p>
+  {{end}}
+  <form method="POST" action="/command" style="margin-top: 16px">
+    <label>/trieu <input name="text" size="50" autofocus></label>
+    <button type="submit">Send</button>
+  </form>
+</body>
+</html>`))
+
+func main() {
+	addr := getEnv("MOCK_CHAT_ADDR", "localhost:9100")
+	w := &workspace{
+		appURL:        strings.TrimSuffix(getEnv("MOCK_CHAT_APP_URL", "http://localhost:8080"), "/"),
+		signingSecret: getEnv("MOCK_CHAT_SIGNING_SECRET", "mock-secret"),
+		client:        &http.Client{Timeout: 10 * time.Second},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", w.channel)
+	mux.HandleFunc("/command", w.command)
+	mux.HandleFunc("/hooks/channel", w.incomingWebhook)
+
+	log.Printf("Mock chat listening on %s, sending commands to %s", addr, w.appURL)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Failed to start mock chat: %v", err)
+	}
+}
+
+func (w *workspace) channel(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(rw, r)
+		return
+	}
+	w.mu.Lock()
+	messages := append([]message(nil), w.messages...)
+	w.mu.Unlock()
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := channelPage.Execute(rw, messages); err != nil {
+		log.Printf("Failed to render channel: %v", err)
+	}
+}
+
+// incomingWebhook receives the messages the app posts to the channel
+func (w *workspace) incomingWebhook(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var payload struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Text == "" {
+		http.Error(rw, "invalid_payload", http.StatusBadRequest)
+		return
+	}
+	log.Printf("Channel message: %s", payload.Text)
+	w.post("app", payload.Text)
+	rw.Write([]byte("ok"))
+}
+
+// command sends the typed text to the app as a signed slash command and shows the reply
+func (w *workspace) command(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	text := r.PostFormValue("text")
+	w.post("you", "/trieu "+text)
+	w.post("app", w.sendCommand(text))
+	http.Redirect(rw, r, "/", http.StatusSeeOther)
+}
+
+func (w *workspace) sendCommand(text string) string {
+	body := url.Values{
+		"command":   {"/trieu"},
+		"text":      {text},
+		"user_name": {"mockchat"},
+	}.Encode()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, w.appURL+"/integrations/chat/commands", strings.NewReader(body))
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", utils.SignChatRequest(w.signingSecret, timestamp, []byte(body)))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	var reply struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(respBody, &reply); err != nil || resp.StatusCode != http.StatusOK {
+		return resp.Status + ": " + string(respBody)
+	}
+	return reply.Text
+}
+
+func (w *workspace) post(from, text string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, message{From: from, Text: text, At: time.Now()})
+	if len(w.messages) > maxMessages {
+		w.messages = w.messages[len(w.messages)-maxMessages:]
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,250 @@
+// Command mockoidc is a minimal OpenID Connect provider for trying single sign-on locally.
+// It signs in whoever is typed into its login form, so it must never be exposed.
+//
+//	go run ./cmd/mockoidc
+//
+// and start the app with
+//
+//	OIDC_ENABLED=true OIDC_ISSUER_URL=http://localhost:9000 OIDC_CLIENT_ID=trieu-mock-project OIDC_CLIENT_SECRET=mock-secret
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "mock-key"
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	email         string
+	name          string
+	emailVerified bool
+	expiresAt     time.Time
+}
+
+type provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Mock OIDC Provider</title></head>
+<body style="font-family: sans-serif; max-width: 360px; margin: 60px auto">
+  <h2>Mock OIDC Provider</h2>
+  <p>Sign in as any identity.</p>
+  <form method="POST" action="/authorize">
+    {{range $key, $value := .Params}}<input type="hidden" name="{{$key}}" value="{{index $value 0}}">
+    {{end}}
+    <p><label>Email<br><input type="email" name="email" required style="width: 100%"></label></p>
+    <p><label>Name<br><input type="text" name="name" style="width: 100%"></label></p>
+    <p><label><input type="checkbox" name="email_verified" value="true" checked> Email verified</label></p>
+    <button type="submit">Sign in</button>
+  </form>
+</body>
+</html>`))
+
+func main() {
+	addr := getEnv("MOCK_OIDC_ADDR", "localhost:9000")
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+
+	p := &provider{
+		issuer:       strings.TrimSuffix(getEnv("MOCK_OIDC_ISSUER", "http://"+addr), "/"),
+		clientID:     getEnv("MOCK_OIDC_CLIENT_ID", "trieu-mock-project"),
+		clientSecret: getEnv("MOCK_OIDC_CLIENT_SECRET", "mock-secret"),
+		key:          key,
+		codes:        make(map[string]authorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+
+	log.Printf("Mock OIDC provider %s listening on %s, client %q", p.issuer, addr, p.clientID)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Fatalf("Failed to start mock OIDC provider: %v", err)
+	}
+}
+
+func (p *provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+	})
+}
+
+func (p *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("client_id") != p.clientID || r.Form.Get("response_type") != "code" ||
+		r.Form.Get("redirect_uri") == "" || r.Form.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		params := url.Values{}
+		for _, key := range []string{"client_id", "response_type", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			params.Set(key, r.Form.Get(key))
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		loginPage.Execute(w, map[string]interface{}{"Params": params})
+		return
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = authorization{
+		clientID:      r.Form.Get("client_id"),
+		redirectURI:   r.Form.Get("redirect_uri"),
+		nonce:         r.Form.Get("nonce"),
+		codeChallenge: r.Form.Get("code_challenge"),
+		email:         r.Form.Get("email"),
+		name:          r.Form.Get("name"),
+		emailVerified: r.Form.Get("email_verified") == "true",
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(r.Form.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", r.Form.Get("state"))
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusSeeOther)
+}
+
+func (p *provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.Form.Get("client_id"), r.Form.Get("client_secret")
+	}
+	if clientID != p.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	auth, found := p.codes[r.Form.Get("code")]
+	delete(p.codes, r.Form.Get("code"))
+	p.mu.Unlock()
+
+	verifierSum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if r.Form.Get("grant_type") != "authorization_code" || !found || time.Now().After(auth.expiresAt) ||
+		auth.clientID != clientID || auth.redirectURI != r.Form.Get("redirect_uri") ||
+		base64.RawURLEncoding.EncodeToString(verifierSum[:]) != auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.issuer,
+		"sub":            "mock|" + strings.ToLower(auth.email),
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.email,
+		"email_verified": auth.emailVerified,
+		"name":           auth.name,
+	})
+	idToken.Header["kid"] = keyID
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func (p *provider) jwks(w http.ResponseWriter, r *http.Request) {
+	publicKey := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		log.Fatalf("Failed to read random bytes: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
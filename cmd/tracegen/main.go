@@ -0,0 +1,276 @@
+// Command tracegen writes the tracing decorators of the service interfaces. Every method taking a
+// context.Context, or a *gorm.DB transaction, as its first parameter runs in a span named after the
+// interface and the method, other methods are passed through.
+//
+//	go generate ./internal/services
+//
+// runs it in the services package after an interface changed.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+	// "ctx" when the first parameter is a context, "tx" when it is a transaction, empty otherwise
+	traced string
+}
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type service struct {
+	name    string
+	methods []method
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the services package")
+	output := flag.String("output", "traced_services.go", "file to write, relative to dir")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, *dir, func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, "_test.go") && name != filepath.Base(*output)
+	}, 0)
+	if err != nil {
+		log.Fatalf("parsing %s: %v", *dir, err)
+	}
+	if len(packages) != 1 {
+		log.Fatalf("%s holds %d packages, want 1", *dir, len(packages))
+	}
+
+	var packageName string
+	var services []service
+	imports := map[string]string{}
+	for name, pkg := range packages {
+		packageName = name
+		for _, file := range pkg.Files {
+			fileImports := importsOf(file)
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					iface, ok := typeSpec.Type.(*ast.InterfaceType)
+					if !ok || !strings.HasSuffix(typeSpec.Name.Name, "Service") {
+						continue
+					}
+					services = append(services, parseService(fset, typeSpec.Name.Name, iface, fileImports, imports))
+				}
+			}
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].name < services[j].name })
+
+	source, err := format.Source(render(packageName, services, imports))
+	if err != nil {
+		log.Fatalf("formatting the output: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), source, 0o644); err != nil {
+		log.Fatalf("writing the output: %v", err)
+	}
+}
+
+// importsOf maps the names files refer to their imports by to the import paths
+func importsOf(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if strings.HasPrefix(name, "v") && strings.Contains(path, "/") {
+			// Major version suffixes such as /v5 are not the package name
+			if _, err := strconv.Atoi(name[1:]); err == nil {
+				name = filepath.Base(filepath.Dir(path))
+			}
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+func parseService(fset *token.FileSet, name string, iface *ast.InterfaceType, fileImports, imports map[string]string) service {
+	s := service{name: name}
+	for _, field := range iface.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			log.Fatalf("%s embeds %s, embedded interfaces are not supported", name, exprString(fset, field.Type))
+		}
+		useImports(field.Type, fileImports, imports)
+		m := method{name: field.Names[0].Name}
+		for _, p := range funcType.Params.List {
+			typ := p.Type
+			variadic := false
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				typ, variadic = ellipsis.Elt, true
+			}
+			count := max(len(p.Names), 1)
+			for range count {
+				m.params = append(m.params, param{
+					name:     fmt.Sprintf("p%d", len(m.params)),
+					typ:      exprString(fset, typ),
+					variadic: variadic,
+				})
+			}
+		}
+		if len(m.params) > 0 {
+			switch m.params[0].typ {
+			case "context.Context":
+				m.params[0].name, m.traced = "ctx", "ctx"
+			case "*gorm.DB":
+				m.params[0].name, m.traced = "tx", "tx"
+			}
+		}
+		if funcType.Results != nil {
+			for _, r := range funcType.Results.List {
+				for range max(len(r.Names), 1) {
+					m.results = append(m.results, exprString(fset, r.Type))
+				}
+			}
+		}
+		s.methods = append(s.methods, m)
+	}
+	return s
+}
+
+// useImports records the imports the package selectors of expr refer to
+func useImports(expr ast.Expr, fileImports, imports map[string]string) {
+	ast.Inspect(expr, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			if path, ok := fileImports[ident.Name]; ok {
+				imports[ident.Name] = path
+			}
+		}
+		return false
+	})
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		log.Fatalf("printing %T: %v", expr, err)
+	}
+	return buf.String()
+}
+
+func render(packageName string, services []service, imports map[string]string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by cmd/tracegen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", packageName)
+	imports["tracing"] = "trieu_mock_project_go/internal/tracing"
+	// The standard library and the packages of the module come first, third party packages after them
+	var local, thirdParty []string
+	for name, path := range imports {
+		spec := fmt.Sprintf("%q", path)
+		if filepath.Base(path) != name {
+			spec = name + " " + spec
+		}
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			thirdParty = append(thirdParty, spec)
+		} else {
+			local = append(local, spec)
+		}
+	}
+	for i, group := range [][]string{local, thirdParty} {
+		if i > 0 && len(group) > 0 {
+			buf.WriteString("\n")
+		}
+		sort.Slice(group, func(a, b int) bool { return importPath(group[a]) < importPath(group[b]) })
+		for _, spec := range group {
+			fmt.Fprintf(&buf, "\t%s\n", spec)
+		}
+	}
+	buf.WriteString(")\n")
+
+	for _, s := range services {
+		typeName := "traced" + s.name
+		fmt.Fprintf(&buf, "\n// Trace%s runs the methods of next in spans\nfunc Trace%s(next %s) %s {\n\treturn &%s{next: next}\n}\n",
+			s.name, s.name, s.name, s.name, typeName)
+		fmt.Fprintf(&buf, "\ntype %s struct {\n\tnext %s\n}\n", typeName, s.name)
+		for _, m := range s.methods {
+			renderMethod(&buf, typeName, s.name, m)
+		}
+	}
+	return buf.Bytes()
+}
+
+// importPath returns the quoted path of an import spec, with or without a name
+func importPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
+}
+
+func renderMethod(buf *bytes.Buffer, typeName, serviceName string, m method) {
+	var params, args []string
+	for _, p := range m.params {
+		if p.variadic {
+			params = append(params, p.name+" ..."+p.typ)
+			args = append(args, p.name+"...")
+		} else {
+			params = append(params, p.name+" "+p.typ)
+			args = append(args, p.name)
+		}
+	}
+	results := strings.Join(m.results, ", ")
+	if len(m.results) > 1 {
+		results = "(" + results + ")"
+	}
+	call := fmt.Sprintf("s.next.%s(%s)", m.name, strings.Join(args, ", "))
+
+	fmt.Fprintf(buf, "\nfunc (s *%s) %s(%s) %s {\n", typeName, m.name, strings.Join(params, ", "), results)
+	switch m.traced {
+	case "ctx":
+		fmt.Fprintf(buf, "\tctx, span := tracing.StartSpan(ctx, %q)\n", serviceName+"."+m.name)
+	case "tx":
+		fmt.Fprintf(buf, "\tctx, span := tracing.StartSpan(tx.Statement.Context, %q)\n\ttx = tx.WithContext(ctx)\n", serviceName+"."+m.name)
+	default:
+		if len(m.results) == 0 {
+			fmt.Fprintf(buf, "\t%s\n}\n", call)
+		} else {
+			fmt.Fprintf(buf, "\treturn %s\n}\n", call)
+		}
+		return
+	}
+
+	var names []string
+	for i := range m.results {
+		names = append(names, fmt.Sprintf("r%d", i))
+	}
+	errResult := "nil"
+	if len(m.results) > 0 && m.results[len(m.results)-1] == "error" {
+		names[len(names)-1] = "err"
+		errResult = "err"
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(buf, "\t%s\n\ttracing.EndSpan(span, nil)\n}\n", call)
+		return
+	}
+	fmt.Fprintf(buf, "\t%s := %s\n\ttracing.EndSpan(span, %s)\n\treturn %s\n}\n",
+		strings.Join(names, ", "), call, errResult, strings.Join(names, ", "))
+}
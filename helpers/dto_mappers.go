@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"encoding/json"
 	"trieu_mock_project_go/internal/dtos"
 	"trieu_mock_project_go/models"
 	"trieu_mock_project_go/types"
@@ -74,6 +75,30 @@ func MapUserSkillsToUserSkillSummaries(skills []models.UserSkill) []dtos.UserSki
 	return summaries
 }
 
+func MapUserSkillToSkillHolder(userSkill *models.UserSkill) *dtos.SkillHolder {
+	if userSkill == nil {
+		return nil
+	}
+	return &dtos.SkillHolder{
+		User:           *MapUserToUserSummary(&userSkill.User),
+		CurrentTeam:    MapTeamToTeamSummary(userSkill.User.CurrentTeam),
+		Skill:          userSkill.Skill.Name,
+		Level:          userSkill.Level,
+		UsedYearNumber: userSkill.UsedYearNumber,
+	}
+}
+
+func MapUserSkillsToSkillHolders(userSkills []models.UserSkill) []dtos.SkillHolder {
+	holders := make([]dtos.SkillHolder, 0, len(userSkills))
+	for _, userSkill := range userSkills {
+		holder := MapUserSkillToSkillHolder(&userSkill)
+		if holder != nil {
+			holders = append(holders, *holder)
+		}
+	}
+	return holders
+}
+
 func MapUserToUserProfile(user *models.User) *dtos.UserProfile {
 
 	currentTeam := MapTeamToTeamSummary(user.CurrentTeam)
@@ -86,11 +111,15 @@ func MapUserToUserProfile(user *models.User) *dtos.UserProfile {
 		birthday = &types.Date{Time: *user.Birthday}
 	}
 	return &dtos.UserProfile{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		Birthday:    birthday,
-		CurrentTeam: currentTeam,
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Birthday:     birthday,
+		ShowBirthday: user.ShowBirthday,
+		// Cleared again by GetPublicUserProfile
+		EmailTeamChanges:  &user.EmailTeamChanges,
+		EmailLeaveReviews: &user.EmailLeaveReviews,
+		CurrentTeam:       currentTeam,
 		Position: dtos.Position{
 			ID:           user.Position.ID,
 			Name:         user.Position.Name,
@@ -202,8 +231,9 @@ func MapPositionToPositionSummary(position *models.Position) *dtos.PositionSumma
 		return nil
 	}
 	return &dtos.PositionSummary{
-		ID:   position.ID,
-		Name: position.Name,
+		ID:    position.ID,
+		Name:  position.Name,
+		Grade: position.Grade,
 	}
 }
 
@@ -223,12 +253,71 @@ func MapPositionToPositionDto(position *models.Position) *dtos.Position {
 		return nil
 	}
 	return &dtos.Position{
-		ID:           position.ID,
-		Name:         position.Name,
-		Abbreviation: position.Abbreviation,
+		ID:             position.ID,
+		Name:           position.Name,
+		Abbreviation:   position.Abbreviation,
+		CareerTrack:    MapCareerTrackToCareerTrackSummary(position.CareerTrack),
+		Grade:          position.Grade,
+		RequiredSkills: MapPositionRequiredSkillsToDtos(position.RequiredSkills),
 	}
 }
 
+func MapPositionRequiredSkillsToDtos(requiredSkills []models.PositionRequiredSkill) []dtos.PositionRequiredSkill {
+	requiredSkillDtos := make([]dtos.PositionRequiredSkill, 0, len(requiredSkills))
+	for _, requiredSkill := range requiredSkills {
+		requiredSkillDtos = append(requiredSkillDtos, dtos.PositionRequiredSkill{
+			ID:       requiredSkill.SkillID,
+			Name:     requiredSkill.Skill.Name,
+			MinLevel: requiredSkill.MinLevel,
+		})
+	}
+	return requiredSkillDtos
+}
+
+func MapCareerTrackToCareerTrackSummary(careerTrack *models.CareerTrack) *dtos.CareerTrackSummary {
+	if careerTrack == nil {
+		return nil
+	}
+	return &dtos.CareerTrackSummary{
+		ID:   careerTrack.ID,
+		Name: careerTrack.Name,
+	}
+}
+
+func MapCareerTracksToCareerTrackSummaries(careerTracks []models.CareerTrack) []dtos.CareerTrackSummary {
+	summaries := make([]dtos.CareerTrackSummary, 0, len(careerTracks))
+	for _, careerTrack := range careerTracks {
+		summary := MapCareerTrackToCareerTrackSummary(&careerTrack)
+		if summary != nil {
+			summaries = append(summaries, *summary)
+		}
+	}
+	return summaries
+}
+
+func MapCareerTrackToCareerTrackDto(careerTrack *models.CareerTrack) *dtos.CareerTrack {
+	if careerTrack == nil {
+		return nil
+	}
+	return &dtos.CareerTrack{
+		ID:          careerTrack.ID,
+		Name:        careerTrack.Name,
+		Description: careerTrack.Description,
+		Positions:   MapPositionsToPositionSummaries(careerTrack.Positions),
+	}
+}
+
+func MapCareerTracksToCareerTrackDtos(careerTracks []models.CareerTrack) []dtos.CareerTrack {
+	careerTrackDtos := make([]dtos.CareerTrack, 0, len(careerTracks))
+	for _, careerTrack := range careerTracks {
+		dto := MapCareerTrackToCareerTrackDto(&careerTrack)
+		if dto != nil {
+			careerTrackDtos = append(careerTrackDtos, *dto)
+		}
+	}
+	return careerTrackDtos
+}
+
 func MapPositionsToPositionDtos(positions []models.Position) []dtos.Position {
 	positionDtos := make([]dtos.Position, 0, len(positions))
 	for _, position := range positions {
@@ -283,3 +372,364 @@ func MapSkillsToSkillSummaries(skills []models.Skill) []dtos.SkillSummary {
 	}
 	return skillSummaries
 }
+
+func MapUserPositionHistoryToDto(history *models.UserPositionHistory) *dtos.UserPositionHistory {
+	if history == nil {
+		return nil
+	}
+	return &dtos.UserPositionHistory{
+		ID:            history.ID,
+		OldPosition:   MapPositionToPositionSummary(history.OldPosition),
+		NewPosition:   *MapPositionToPositionSummary(&history.NewPosition),
+		Team:          MapTeamToTeamSummary(history.Team),
+		IsPromotion:   history.IsPromotion,
+		EffectiveDate: types.Date{Time: history.EffectiveDate},
+		ChangedBy:     MapUserToUserSummary(history.ChangedBy),
+		CreatedAt:     history.CreatedAt,
+	}
+}
+
+func MapUserPositionHistoriesToDtos(histories []models.UserPositionHistory) []dtos.UserPositionHistory {
+	historyDtos := make([]dtos.UserPositionHistory, 0, len(histories))
+	for _, history := range histories {
+		dto := MapUserPositionHistoryToDto(&history)
+		if dto != nil {
+			historyDtos = append(historyDtos, *dto)
+		}
+	}
+	return historyDtos
+}
+
+func MapNotificationToDto(notification *models.Notification) *dtos.Notification {
+	if notification == nil {
+		return nil
+	}
+	return &dtos.Notification{
+		ID:        notification.ID,
+		Title:     notification.Title,
+		Content:   notification.Content,
+		IsRead:    notification.IsRead,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func MapNotificationsToDtos(notifications []models.Notification) []dtos.Notification {
+	notificationDtos := make([]dtos.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		dto := MapNotificationToDto(&notification)
+		if dto != nil {
+			notificationDtos = append(notificationDtos, *dto)
+		}
+	}
+	return notificationDtos
+}
+
+func MapLeaveRequestToDto(leave *models.LeaveRequest) *dtos.LeaveRequest {
+	if leave == nil {
+		return nil
+	}
+	var team *dtos.TeamSummary
+	if leave.Team.ID != 0 {
+		team = MapTeamToTeamSummary(&leave.Team)
+	}
+	return &dtos.LeaveRequest{
+		ID:           leave.ID,
+		User:         *MapUserToUserSummary(&leave.User),
+		Team:         team,
+		Type:         leave.Type,
+		StartDate:    types.Date{Time: leave.StartDate},
+		EndDate:      types.Date{Time: leave.EndDate},
+		StartHalfDay: leave.StartHalfDay,
+		EndHalfDay:   leave.EndHalfDay,
+		Days:         CountLeaveDays(leave.StartDate, leave.EndDate, leave.StartHalfDay, leave.EndHalfDay),
+		Reason:       leave.Reason,
+		Status:       leave.Status,
+		Reviewer:     MapUserToUserSummary(leave.Reviewer),
+		ReviewNote:   leave.ReviewNote,
+		ReviewedAt:   leave.ReviewedAt,
+		CreatedAt:    leave.CreatedAt,
+	}
+}
+
+func MapLeaveRequestsToDtos(leaves []models.LeaveRequest) []dtos.LeaveRequest {
+	leaveDtos := make([]dtos.LeaveRequest, 0, len(leaves))
+	for _, leave := range leaves {
+		dto := MapLeaveRequestToDto(&leave)
+		if dto != nil {
+			leaveDtos = append(leaveDtos, *dto)
+		}
+	}
+	return leaveDtos
+}
+
+func MapTimeEntryToDto(entry *models.TimeEntry) *dtos.TimeEntry {
+	if entry == nil {
+		return nil
+	}
+	return &dtos.TimeEntry{
+		ID:          entry.ID,
+		Project:     *MapProjectToProjectSummary(&entry.Project),
+		WorkDate:    types.Date{Time: entry.WorkDate},
+		Hours:       entry.Hours,
+		Description: entry.Description,
+	}
+}
+
+func MapTimeEntriesToDtos(entries []models.TimeEntry) []dtos.TimeEntry {
+	entryDtos := make([]dtos.TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		dto := MapTimeEntryToDto(&entry)
+		if dto != nil {
+			entryDtos = append(entryDtos, *dto)
+		}
+	}
+	return entryDtos
+}
+
+func MapTimesheetToDto(timesheet *models.Timesheet) *dtos.Timesheet {
+	if timesheet == nil {
+		return nil
+	}
+	totalHours := 0.0
+	for _, entry := range timesheet.Entries {
+		totalHours += entry.Hours
+	}
+	return &dtos.Timesheet{
+		ID:          timesheet.ID,
+		User:        *MapUserToUserSummary(&timesheet.User),
+		WeekStart:   types.Date{Time: timesheet.WeekStart},
+		WeekEnd:     types.Date{Time: timesheet.WeekStart.AddDate(0, 0, 6)},
+		Status:      timesheet.Status,
+		SubmittedAt: timesheet.SubmittedAt,
+		Reviewer:    MapUserToUserSummary(timesheet.Reviewer),
+		ReviewNote:  timesheet.ReviewNote,
+		ReviewedAt:  timesheet.ReviewedAt,
+		TotalHours:  totalHours,
+		Entries:     MapTimeEntriesToDtos(timesheet.Entries),
+	}
+}
+
+func MapTimesheetsToDtos(timesheets []models.Timesheet) []dtos.Timesheet {
+	timesheetDtos := make([]dtos.Timesheet, 0, len(timesheets))
+	for _, timesheet := range timesheets {
+		dto := MapTimesheetToDto(&timesheet)
+		if dto != nil {
+			timesheetDtos = append(timesheetDtos, *dto)
+		}
+	}
+	return timesheetDtos
+}
+
+func MapLoginAttemptToDto(attempt *models.LoginAttempt) *dtos.LoginAttempt {
+	if attempt == nil {
+		return nil
+	}
+	return &dtos.LoginAttempt{
+		ID:            attempt.ID,
+		Flow:          attempt.Flow,
+		Email:         attempt.Email,
+		UserID:        attempt.UserID,
+		IPAddress:     attempt.IPAddress,
+		UserAgent:     attempt.UserAgent,
+		Success:       attempt.Success,
+		FailureReason: attempt.FailureReason,
+		CreatedAt:     attempt.CreatedAt,
+	}
+}
+
+func MapLoginAttemptsToDtos(attempts []models.LoginAttempt) []dtos.LoginAttempt {
+	attemptDtos := make([]dtos.LoginAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		dto := MapLoginAttemptToDto(&attempt)
+		if dto != nil {
+			attemptDtos = append(attemptDtos, *dto)
+		}
+	}
+	return attemptDtos
+}
+
+// MapAdminSessionToDto flags the session the request was made with as current
+func MapAdminSessionToDto(session *models.AdminSession, currentTokenHash string) *dtos.AdminSession {
+	if session == nil {
+		return nil
+	}
+	return &dtos.AdminSession{
+		ID:         session.ID,
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		Current:    session.TokenHash == currentTokenHash,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+func MapAdminSessionsToDtos(sessions []models.AdminSession, currentTokenHash string) []dtos.AdminSession {
+	sessionDtos := make([]dtos.AdminSession, 0, len(sessions))
+	for _, session := range sessions {
+		dto := MapAdminSessionToDto(&session, currentTokenHash)
+		if dto != nil {
+			sessionDtos = append(sessionDtos, *dto)
+		}
+	}
+	return sessionDtos
+}
+
+// MapLDAPSyncRunToDto includes the changes of the report when the details were loaded
+func MapLDAPSyncRunToDto(run *models.LDAPSyncRun) *dtos.LDAPSyncRun {
+	if run == nil {
+		return nil
+	}
+	dto := &dtos.LDAPSyncRun{
+		ID:               run.ID,
+		Trigger:          run.Trigger,
+		DryRun:           run.DryRun,
+		Status:           run.Status,
+		DirectoryEntries: run.DirectoryEntries,
+		CreatedCount:     run.CreatedCount,
+		UpdatedCount:     run.UpdatedCount,
+		DeactivatedCount: run.DeactivatedCount,
+		ReactivatedCount: run.ReactivatedCount,
+		SkippedCount:     run.SkippedCount,
+		Error:            run.Error,
+		StartedAt:        run.StartedAt,
+		FinishedAt:       run.FinishedAt,
+	}
+	if run.Details != nil {
+		json.Unmarshal([]byte(*run.Details), &dto.Changes)
+	}
+	return dto
+}
+
+func MapLDAPSyncRunsToDtos(runs []models.LDAPSyncRun) []dtos.LDAPSyncRun {
+	runDtos := make([]dtos.LDAPSyncRun, 0, len(runs))
+	for _, run := range runs {
+		dto := MapLDAPSyncRunToDto(&run)
+		if dto != nil {
+			runDtos = append(runDtos, *dto)
+		}
+	}
+	return runDtos
+}
+
+func MapAPITokenToDto(token *models.APIToken) *dtos.APIToken {
+	if token == nil {
+		return nil
+	}
+	return &dtos.APIToken{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		LastUsedIP:  token.LastUsedIP,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+func MapAPITokensToDtos(tokens []models.APIToken) []dtos.APIToken {
+	tokenDtos := make([]dtos.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		dto := MapAPITokenToDto(&token)
+		if dto != nil {
+			tokenDtos = append(tokenDtos, *dto)
+		}
+	}
+	return tokenDtos
+}
+
+func MapWebhookSubscriptionToDto(subscription *models.WebhookSubscription) *dtos.WebhookSubscription {
+	if subscription == nil {
+		return nil
+	}
+	return &dtos.WebhookSubscription{
+		ID:         subscription.ID,
+		Name:       subscription.Name,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: subscription.EventTypeList(),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func MapWebhookSubscriptionsToDtos(subscriptions []models.WebhookSubscription) []dtos.WebhookSubscription {
+	subscriptionDtos := make([]dtos.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		dto := MapWebhookSubscriptionToDto(&subscription)
+		if dto != nil {
+			subscriptionDtos = append(subscriptionDtos, *dto)
+		}
+	}
+	return subscriptionDtos
+}
+
+func MapWebhookDeliveryToDto(delivery *models.WebhookDelivery) *dtos.WebhookDelivery {
+	if delivery == nil {
+		return nil
+	}
+	return &dtos.WebhookDelivery{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+func MapWebhookDeliveriesToDtos(deliveries []models.WebhookDelivery) []dtos.WebhookDelivery {
+	deliveryDtos := make([]dtos.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		dto := MapWebhookDeliveryToDto(&delivery)
+		if dto != nil {
+			deliveryDtos = append(deliveryDtos, *dto)
+		}
+	}
+	return deliveryDtos
+}
+
+func MapUserToEventUser(user *models.User) dtos.EventUser {
+	return dtos.EventUser{ID: user.ID, Name: user.Name, Email: user.Email}
+}
+
+func MapTeamToEventTeam(team *models.Team) dtos.EventTeam {
+	return dtos.EventTeam{ID: team.ID, Name: team.Name}
+}
+
+func MapActivityLogToDto(activityLog *models.ActivityLog) *dtos.ActivityLog {
+	if activityLog == nil {
+		return nil
+	}
+	dto := &dtos.ActivityLog{
+		ID:          activityLog.ID,
+		Action:      activityLog.Action,
+		UserID:      activityLog.UserID,
+		Description: activityLog.Description,
+		CreatedAt:   activityLog.CreatedAt,
+	}
+	if activityLog.User != nil {
+		dto.UserName = &activityLog.User.Name
+	}
+	return dto
+}
+
+func MapActivityLogsToDtos(activityLogs []models.ActivityLog) []dtos.ActivityLog {
+	activityLogDtos := make([]dtos.ActivityLog, 0, len(activityLogs))
+	for _, activityLog := range activityLogs {
+		dto := MapActivityLogToDto(&activityLog)
+		if dto != nil {
+			activityLogDtos = append(activityLogDtos, *dto)
+		}
+	}
+	return activityLogDtos
+}
@@ -0,0 +1,26 @@
+package helpers
+
+import "time"
+
+// CountLeaveDays counts the working days (Monday to Friday) covered by a leave.
+// A half day at the start or the end counts as 0.5 when it falls on a working day.
+func CountLeaveDays(startDate, endDate time.Time, startHalfDay, endHalfDay bool) float64 {
+	days := 0.0
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		if isWeekend(d) {
+			continue
+		}
+		days++
+	}
+	if startHalfDay && !isWeekend(startDate) {
+		days -= 0.5
+	}
+	if endHalfDay && !isWeekend(endDate) && !(startHalfDay && startDate.Equal(endDate)) {
+		days -= 0.5
+	}
+	return days
+}
+
+func isWeekend(d time.Time) bool {
+	return d.Weekday() == time.Saturday || d.Weekday() == time.Sunday
+}
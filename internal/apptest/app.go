@@ -0,0 +1,183 @@
+// Package apptest boots the whole app against a fresh SQLite database for integration tests. Requests go
+// through the real router, middlewares, services and migrations, nothing is mocked and no database server
+// or container is needed.
+package apptest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"trieu_mock_project_go/internal/bootstrap"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/logging"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/routes"
+	"trieu_mock_project_go/models"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Password of every user created by CreateUser
+const Password = "Password123"
+
+// MetricsToken reads the metrics endpoint
+const MetricsToken = "apptest-metrics-token"
+
+// Config is loaded once per process, so the environment of the tests is set before the first load
+var testEnv = map[string]string{
+	"DB_DRIVER":         config.DBDriverSQLite,
+	"SESSION_STORE":     "database",
+	"JWT_ALGORITHM":     "HS256",
+	"JWT_SECRET":        "apptest-jwt-secret",
+	"MAIL_DRIVER":       "log",
+	"OIDC_ENABLED":      "false",
+	"LDAP_SYNC_ENABLED": "false",
+	"CHAT_WEBHOOK_URL":  "",
+	"METRICS_TOKEN":     MetricsToken,
+	// Low enough for the tests of the per-IP throttling to reach it
+	"LOGIN_MAX_IP_FAILURES": "3",
+}
+
+var setupOnce sync.Once
+
+// App is a running app with its own database, see New
+type App struct {
+	t         *testing.T
+	DB        *gorm.DB
+	Container *bootstrap.AppContainer
+	Server    *httptest.Server
+
+	positionID uint
+}
+
+// New migrates a new SQLite database, seeds the default positions and skills, builds the container and
+// serves the router on a local port. Everything is torn down when the test ends.
+//
+// Migrations run on the package level config.DB and from the module root, so tests using App cannot
+// run in parallel.
+func New(t *testing.T) *App {
+	t.Helper()
+	setupOnce.Do(func() {
+		for key, value := range testEnv {
+			os.Setenv(key, value)
+		}
+		gin.SetMode(gin.TestMode)
+		// Request and query logs are only worth reading when a test fails, and then the failure says more
+		slog.SetDefault(logging.New(io.Discard, "error", "json"))
+	})
+	// Migrations, templates and static files are found relative to the module root
+	t.Chdir(moduleRoot(t))
+
+	dsn := config.SQLiteDSN(filepath.Join(t.TempDir(), "app.db"))
+	if err := config.ConnectToDatabase(config.DBDriverSQLite, dsn); err != nil {
+		t.Fatalf("connecting to the test database: %v", err)
+	}
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		t.Fatalf("getting the test database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := config.RunMigrations(); err != nil {
+		t.Fatalf("migrating the test database: %v", err)
+	}
+	// Queries are only worth reading when a test fails, and then the failure says more
+	db := config.DB.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Silent)})
+
+	app := &App{
+		t:         t,
+		DB:        db,
+		Container: bootstrap.NewAppContainer(db, config.LoadConfig()),
+	}
+	if _, err := app.Container.SeedService.SeedDefaults(context.Background(), dtos.SeedAdmin{}); err != nil {
+		t.Fatalf("seeding the test database: %v", err)
+	}
+	var position models.Position
+	if err := app.DB.Order("id").First(&position).Error; err != nil {
+		t.Fatalf("finding a position: %v", err)
+	}
+	app.positionID = position.ID
+
+	router, err := routes.NewRouter(config.LoadConfig(), app.Container)
+	if err != nil {
+		t.Fatalf("creating the router: %v", err)
+	}
+	app.Server = httptest.NewServer(router)
+	t.Cleanup(app.Server.Close)
+	return app
+}
+
+// CreateUser creates a user with the role, "admin" or "user", who signs in with Password
+func (a *App) CreateUser(name, email, role string) *models.User {
+	a.t.Helper()
+	// The cheapest cost keeps the tests fast, login compares with whatever cost the hash has
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(Password), bcrypt.MinCost)
+	if err != nil {
+		a.t.Fatalf("hashing the password: %v", err)
+	}
+	user := &models.User{
+		Name:       name,
+		Email:      email,
+		Password:   string(hashedPassword),
+		PositionID: a.positionID,
+		Role:       role,
+	}
+	if err := repositories.NewUserRepository().CreateUser(a.DB, user); err != nil {
+		a.t.Fatalf("creating user %s: %v", email, err)
+	}
+	return user
+}
+
+// CreateTeam creates a team led by leader through the team service, so the leader joins it like in the app
+func (a *App) CreateTeam(name string, leader *models.User) *models.Team {
+	a.t.Helper()
+	err := a.Container.TeamsService.CreateTeam(context.Background(), dtos.CreateOrUpdateTeamRequest{
+		Name:     name,
+		LeaderID: leader.ID,
+	})
+	if err != nil {
+		a.t.Fatalf("creating team %s: %v", name, err)
+	}
+	var team models.Team
+	if err := a.DB.Where("name = ?", name).First(&team).Error; err != nil {
+		a.t.Fatalf("finding team %s: %v", name, err)
+	}
+	return &team
+}
+
+// ReloadUser reads the user again, to check what a request changed
+func (a *App) ReloadUser(user *models.User) *models.User {
+	a.t.Helper()
+	var reloaded models.User
+	if err := a.DB.First(&reloaded, user.ID).Error; err != nil {
+		a.t.Fatalf("reloading user %d: %v", user.ID, err)
+	}
+	return &reloaded
+}
+
+// moduleRoot is the closest directory above the test holding go.mod
+func moduleRoot(t *testing.T) string {
+	t.Helper()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getting the working directory: %v", err)
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			t.Fatal("go.mod not found above the test")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,209 @@
+package apptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+)
+
+var (
+	csrfTokenPattern     = regexp.MustCompile(`name="_csrf" value="([^"]+)"`)
+	csrfMetaTokenPattern = regexp.MustCompile(`<meta name="csrf-token" content="([^"]+)"`)
+)
+
+// Client sends requests to the app like a browser or a script would: it keeps cookies and does not
+// follow redirects, so tests can check where they point
+type Client struct {
+	t      *testing.T
+	app    *App
+	http   *http.Client
+	header http.Header
+}
+
+// Response is a response with its body already read
+type Response struct {
+	*http.Response
+	Body []byte
+	t    *testing.T
+}
+
+// NewClient returns a client without any session or token
+func (a *App) NewClient() *Client {
+	a.t.Helper()
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		a.t.Fatalf("creating the cookie jar: %v", err)
+	}
+	return &Client{
+		t:   a.t,
+		app: a,
+		http: &http.Client{
+			Jar: jar,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		header: http.Header{},
+	}
+}
+
+// UserClient signs the user in through POST /login and returns a client sending the access token
+func (a *App) UserClient(user *models.User) *Client {
+	a.t.Helper()
+	client := a.NewClient()
+	var login dtos.LoginResponse
+	client.Post("/login", LoginRequest(user.Email, Password)).ExpectStatus(http.StatusOK).JSON(&login)
+	client.header.Set("Authorization", "Bearer "+login.User.AccessToken)
+	return client
+}
+
+// AdminClient signs the admin in through the admin login form and returns a client holding the session
+func (a *App) AdminClient(admin *models.User) *Client {
+	a.t.Helper()
+	client := a.NewClient()
+	resp := client.PostForm("/admin/login", url.Values{"email": {admin.Email}, "password": {Password}})
+	resp.ExpectStatus(http.StatusSeeOther)
+	if location := resp.Header.Get("Location"); location != "/admin" {
+		a.t.Fatalf("admin login redirected to %q, want /admin", location)
+	}
+	return client
+}
+
+// TokenClient returns a client sending a new personal access token of the user with the scopes
+func (a *App) TokenClient(user *models.User, scopes ...string) *Client {
+	a.t.Helper()
+	token, err := a.Container.APITokenService.CreateToken(context.Background(), user.ID, dtos.CreateAPITokenRequest{
+		Name:   "apptest",
+		Scopes: scopes,
+	})
+	if err != nil {
+		a.t.Fatalf("creating an API token for %s: %v", user.Email, err)
+	}
+	client := a.NewClient()
+	client.header.Set("Authorization", "Bearer "+token.Token)
+	return client
+}
+
+// SetHeader sets a header sent with every request of the client
+func (c *Client) SetHeader(key, value string) {
+	c.header.Set(key, value)
+}
+
+func (c *Client) Get(path string) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodGet, path, nil)
+}
+
+func (c *Client) Post(path string, body any) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodPost, path, body)
+}
+
+func (c *Client) Delete(path string) *Response {
+	c.t.Helper()
+	return c.Do(http.MethodDelete, path, nil)
+}
+
+// SetCSRFToken sends the CSRF token of the admin page at path with every request, like the admin scripts do
+func (c *Client) SetCSRFToken(path string) {
+	c.t.Helper()
+	page := c.Get(path)
+	match := csrfMetaTokenPattern.FindSubmatch(page.Body)
+	if match == nil {
+		c.t.Fatalf("no CSRF token on %s, status %d", path, page.StatusCode)
+	}
+	c.header.Set("X-CSRF-Token", string(match[1]))
+}
+
+// PostForm posts an HTML form, with the CSRF token of the page at the same path like a browser would
+func (c *Client) PostForm(path string, form url.Values) *Response {
+	c.t.Helper()
+	page := c.Get(path)
+	match := csrfTokenPattern.FindSubmatch(page.Body)
+	if match == nil {
+		c.t.Fatalf("no CSRF token on %s, status %d", path, page.StatusCode)
+	}
+	form.Set("_csrf", string(match[1]))
+
+	req := c.newRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.send(req)
+}
+
+// Do sends a request, a non-nil body is sent as JSON
+func (c *Client) Do(method, path string, body any) *Response {
+	c.t.Helper()
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("encoding the body of %s %s: %v", method, path, err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req := c.newRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.send(req)
+}
+
+func (c *Client) newRequest(method, path string, body io.Reader) *http.Request {
+	c.t.Helper()
+	req, err := http.NewRequest(method, c.app.Server.URL+path, body)
+	if err != nil {
+		c.t.Fatalf("creating %s %s: %v", method, path, err)
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	return req
+}
+
+func (c *Client) send(req *http.Request) *Response {
+	c.t.Helper()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.t.Fatalf("reading the response of %s %s: %v", req.Method, req.URL.Path, err)
+	}
+	return &Response{Response: resp, Body: body, t: c.t}
+}
+
+// ExpectStatus fails the test unless the response has the status
+func (r *Response) ExpectStatus(status int) *Response {
+	r.t.Helper()
+	if r.StatusCode != status {
+		r.t.Fatalf("%s %s: status %d, want %d\n%s", r.Request.Method, r.Request.URL.Path, r.StatusCode, status, r.Body)
+	}
+	return r
+}
+
+// JSON decodes the body into v
+func (r *Response) JSON(v any) {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		r.t.Fatalf("decoding the response of %s %s: %v\n%s", r.Request.Method, r.Request.URL.Path, err, r.Body)
+	}
+}
+
+// LoginRequest is the body of POST /login
+func LoginRequest(email, password string) dtos.LoginRequest {
+	var req dtos.LoginRequest
+	req.User.Email = email
+	req.User.Password = password
+	return req
+}
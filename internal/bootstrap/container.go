@@ -1,13 +1,20 @@
 package bootstrap
 
 import (
+	"log/slog"
 	"trieu_mock_project_go/internal/config"
 	"trieu_mock_project_go/internal/handlers"
+	"trieu_mock_project_go/internal/jobs"
+	"trieu_mock_project_go/internal/mailer"
+	"trieu_mock_project_go/internal/metrics"
 	"trieu_mock_project_go/internal/middlewares"
 	"trieu_mock_project_go/internal/repositories"
 	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/internal/sessionstore"
+	"trieu_mock_project_go/internal/tracing"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type AppContainer struct {
@@ -15,30 +22,82 @@ type AppContainer struct {
 	AdminAuthMiddleware gin.HandlerFunc
 	JWTAuthMiddleware   gin.HandlerFunc
 	CSRFMiddleware      gin.HandlerFunc
+	// Verifies slash commands sent by the chat workspace
+	ChatCommandAuthMiddleware gin.HandlerFunc
+	// Lets Prometheus scrape the metrics with the configured token
+	MetricsAuthMiddleware gin.HandlerFunc
+	// Opens the calendar feeds with the secret token in their URL
+	CalendarFeedAuthMiddleware gin.HandlerFunc
+
+	// Prometheus metrics of the app
+	Metrics *metrics.Metrics
+
+	// Server-side store of admin sessions
+	SessionBackend sessionstore.Backend
 
 	// Services
-	AuthService     *services.AuthService
-	UserService     *services.UserService
-	TeamsService    *services.TeamsService
-	PositionService *services.PositionService
-	ProjectService  *services.ProjectService
-	SkillService    *services.SkillService
+	AuthService         services.AuthService
+	AdminSessionService services.AdminSessionService
+	TwoFactorService    services.TwoFactorService
+	OIDCService         services.OIDCService
+	UserService         services.UserService
+	TeamsService        services.TeamsService
+	PositionService     services.PositionService
+	ProjectService      services.ProjectService
+	SkillService        services.SkillService
+	CareerTrackService  services.CareerTrackService
+	CelebrationService  services.CelebrationService
+	NotificationService services.NotificationService
+	LeaveService        services.LeaveService
+	TimesheetService    services.TimesheetService
+	LDAPSyncService     services.LDAPSyncService
+	APITokenService     services.APITokenService
+	WebhookService      services.WebhookService
+	OutboxService       services.OutboxService
+	ActivityLogService  services.ActivityLogService
+	MailService         services.MailService
+	ChatService         services.ChatService
+	SeedService         services.SeedService
+	HealthService       services.HealthService
+
+	// Background jobs
+	CelebrationReminderJob *jobs.CelebrationReminderJob
+	AdminSessionCleanupJob *jobs.AdminSessionCleanupJob
+	LDAPSyncJob            *jobs.LDAPSyncJob
+	WebhookDeliveryJob     *jobs.WebhookDeliveryJob
+	OutboxDispatchJob      *jobs.OutboxDispatchJob
+	EmailDeliveryJob       *jobs.EmailDeliveryJob
+	ChatDeliveryJob        *jobs.ChatDeliveryJob
 
 	// Handlers
-	AuthHandler        *handlers.AuthHandler
-	DashboardHandler   *handlers.DashboardHandler
-	UserProfileHandler *handlers.UserProfileHandler
-	TeamsHandler       *handlers.TeamsHandler
+	AuthHandler         *handlers.AuthHandler
+	TwoFactorHandler    *handlers.TwoFactorHandler
+	DashboardHandler    *handlers.DashboardHandler
+	UserProfileHandler  *handlers.UserProfileHandler
+	TeamsHandler        *handlers.TeamsHandler
+	NotificationHandler *handlers.NotificationHandler
+	LeaveHandler        *handlers.LeaveHandler
+	TimesheetHandler    *handlers.TimesheetHandler
+	APITokenHandler     *handlers.APITokenHandler
+	ChatHandler         *handlers.ChatHandler
+	HealthHandler       *handlers.HealthHandler
 	// Admin Handlers
-	AdminAuthHandler      *handlers.AdminAuthHandler
-	AdminDashboardHandler *handlers.AdminDashboardHandler
-	AdminUserHandler      *handlers.AdminUserHandler
-	AdminPositionHandler  *handlers.AdminPositionHandler
-	AdminSkillHandler     *handlers.AdminSkillHandler
-	AdminTeamHandler      *handlers.AdminTeamHandler
+	AdminAuthHandler          *handlers.AdminAuthHandler
+	AdminDashboardHandler     *handlers.AdminDashboardHandler
+	AdminUserHandler          *handlers.AdminUserHandler
+	AdminPositionHandler      *handlers.AdminPositionHandler
+	AdminSkillHandler         *handlers.AdminSkillHandler
+	AdminTeamHandler          *handlers.AdminTeamHandler
+	AdminCareerTrackHandler   *handlers.AdminCareerTrackHandler
+	AdminReportHandler        *handlers.AdminReportHandler
+	AdminSecurityHandler      *handlers.AdminSecurityHandler
+	AdminDirectorySyncHandler *handlers.AdminDirectorySyncHandler
+	AdminWebhookHandler       *handlers.AdminWebhookHandler
+	AdminActivityLogHandler   *handlers.AdminActivityLogHandler
 }
 
-func NewAppContainer() *AppContainer {
+// NewAppContainer wires the repositories, services, jobs and handlers of the app to the database and config
+func NewAppContainer(db *gorm.DB, cfg *config.Config) *AppContainer {
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository()
 	teamsRepo := repositories.NewTeamsRepository()
@@ -46,40 +105,169 @@ func NewAppContainer() *AppContainer {
 	positionRepo := repositories.NewPositionRepository()
 	projectRepo := repositories.NewProjectRepository()
 	skillRepo := repositories.NewSkillRepository()
+	careerTrackRepo := repositories.NewCareerTrackRepository()
+	userPositionHistoryRepo := repositories.NewUserPositionHistoryRepository()
+	notificationRepo := repositories.NewNotificationRepository()
+	leaveRequestRepo := repositories.NewLeaveRequestRepository()
+	timesheetRepo := repositories.NewTimesheetRepository()
+	timeEntryRepo := repositories.NewTimeEntryRepository()
+	loginAttemptRepo := repositories.NewLoginAttemptRepository()
+	userRecoveryCodeRepo := repositories.NewUserRecoveryCodeRepository()
+	adminSessionRepo := repositories.NewAdminSessionRepository()
+	ldapSyncRunRepo := repositories.NewLDAPSyncRunRepository()
+	apiTokenRepo := repositories.NewAPITokenRepository()
+	webhookSubscriptionRepo := repositories.NewWebhookSubscriptionRepository()
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository()
+	outboxEventRepo := repositories.NewOutboxEventRepository()
+	outboxProcessedEventRepo := repositories.NewOutboxProcessedEventRepository()
+	activityLogRepo := repositories.NewActivityLogRepository()
+	emailMessageRepo := repositories.NewEmailMessageRepository()
+	chatMessageRepo := repositories.NewChatMessageRepository()
+	passwordResetTokenRepo := repositories.NewPasswordResetTokenRepository()
+	schemaMigrationRepo := repositories.NewSchemaMigrationRepository()
+
+	// Initialize the metrics, they time the queries run on db from now on
+	appMetrics := metrics.New(db)
+	// Queries run in spans, under the spans of the service methods wrapped with services.Trace*
+	if err := tracing.InstrumentDB(db); err != nil {
+		slog.Error("Failed to instrument SQL queries for tracing", "error", err)
+	}
+
+	// Initialize session store backend
+	var sessionBackend sessionstore.Backend
+	if cfg.SessionConfig.Store == "memory" {
+		sessionBackend = sessionstore.NewMemoryBackend()
+	} else {
+		sessionBackend = sessionstore.NewDatabaseBackend(db, adminSessionRepo)
+	}
+
+	// Initialize the mailer, "log" unless another driver is configured
+	mailConfig := cfg.Mail
+	var mailSender mailer.Mailer
+	switch mailConfig.Driver {
+	case "smtp":
+		mailSender = mailer.NewSMTPMailer(mailConfig.SMTPHost, mailConfig.SMTPPort, mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.SMTPTLS, mailConfig.Timeout)
+	case "file":
+		mailSender = mailer.NewFileMailer(mailConfig.FileDir)
+	default:
+		mailSender = mailer.NewLogMailer()
+	}
 
 	// Initialize services
-	authService := services.NewAuthService(config.DB, userRepo)
-	userService := services.NewUserService(config.DB, userRepo, teamsRepo)
-	teamsService := services.NewTeamsService(config.DB, teamsRepo, teamMemberRepo, userRepo)
-	positionService := services.NewPositionService(config.DB, positionRepo)
-	projectService := services.NewProjectService(config.DB, projectRepo)
-	skillService := services.NewSkillService(config.DB, skillRepo)
+	notificationService := services.TraceNotificationService(services.NewNotificationService(db, notificationRepo))
+	activityLogService := services.TraceActivityLogService(services.NewActivityLogService(db, activityLogRepo, userRepo))
+	webhookService := services.TraceWebhookService(services.NewWebhookService(db, webhookSubscriptionRepo, webhookDeliveryRepo, cfg.Webhook))
+	mailService := services.TraceMailService(services.NewMailService(db, emailMessageRepo, userRepo, mailSender, mailConfig))
+	chatNotifier := services.NewChatNotifier(db, chatMessageRepo, cfg.Chat)
+	// Domain events recorded by the services below are dispatched to these subscribers
+	outboxService := services.TraceOutboxService(services.NewOutboxService(db, outboxEventRepo, outboxProcessedEventRepo, []services.EventSubscriber{
+		notificationService,
+		activityLogService,
+		webhookService,
+		mailService,
+		chatNotifier,
+	}, cfg.Outbox))
+	twoFactorService := services.TraceTwoFactorService(services.NewTwoFactorService(db, userRepo, userRecoveryCodeRepo, cfg.TwoFactor))
+	authService := services.TraceAuthService(services.NewAuthService(db, userRepo, loginAttemptRepo, notificationRepo, apiTokenRepo, sessionBackend, twoFactorService, cfg.LoginProtection, appMetrics))
+	oidcService := services.TraceOIDCService(services.NewOIDCService(db, userRepo, positionRepo, userPositionHistoryRepo, authService, cfg.OIDC))
+	userService := services.TraceUserService(services.NewUserService(db, userRepo, teamsRepo, positionRepo, userPositionHistoryRepo, outboxService))
+	teamsService := services.TraceTeamsService(services.NewTeamsService(db, teamsRepo, teamMemberRepo, userRepo, outboxService))
+	positionService := services.TracePositionService(services.NewPositionService(db, positionRepo, userRepo))
+	projectService := services.TraceProjectService(services.NewProjectService(db, projectRepo))
+	skillService := services.TraceSkillService(services.NewSkillService(db, skillRepo))
+	careerTrackService := services.TraceCareerTrackService(services.NewCareerTrackService(db, careerTrackRepo))
+	celebrationService := services.TraceCelebrationService(services.NewCelebrationService(db, teamMemberRepo, notificationRepo, cfg.Celebration.ReminderDays))
+	leaveService := services.TraceLeaveService(services.NewLeaveService(db, leaveRequestRepo, teamsRepo, teamMemberRepo, userRepo, notificationRepo, mailService))
+	timesheetService := services.TraceTimesheetService(services.NewTimesheetService(db, timesheetRepo, timeEntryRepo, projectRepo, userRepo, teamsRepo, notificationRepo))
+	adminSessionService := services.TraceAdminSessionService(services.NewAdminSessionService(sessionBackend))
+	apiTokenService := services.TraceAPITokenService(services.NewAPITokenService(db, apiTokenRepo, userRepo))
+	passwordResetService := services.TracePasswordResetService(services.NewPasswordResetService(db, userRepo, passwordResetTokenRepo, apiTokenRepo, sessionBackend, mailService, mailConfig.PasswordResetTTL))
+	chatService := services.TraceChatService(services.NewChatService(teamsService, userService, skillService, cfg.Chat))
+	ldapSyncService := services.TraceLDAPSyncService(services.NewLDAPSyncService(db, userRepo, teamsRepo, teamMemberRepo, positionRepo, userPositionHistoryRepo, ldapSyncRunRepo, apiTokenRepo, sessionBackend, outboxService, cfg.LDAP))
+	// Readiness compares the schema to the last migration shipped with the app
+	latestMigration, err := config.LatestMigrationVersion(cfg.Database.Driver)
+	if err != nil {
+		slog.Error("Failed to read the migrations, readiness checks will fail", "error", err)
+	}
+	healthService := services.TraceHealthService(services.NewHealthService(db, schemaMigrationRepo, latestMigration))
+	seedService := services.TraceSeedService(services.NewSeedService(db, userRepo, careerTrackRepo, positionRepo, skillRepo, teamsRepo, teamMemberRepo, projectRepo, userPositionHistoryRepo))
 
 	return &AppContainer{
 		// Middlewares
-		JWTAuthMiddleware:   middlewares.JWTAuthMiddleware(),
-		AdminAuthMiddleware: middlewares.AdminAuthMiddleware(),
-		CSRFMiddleware:      middlewares.CSRFMiddleware(),
+		JWTAuthMiddleware:   middlewares.JWTAuthMiddleware(apiTokenService, authService),
+		AdminAuthMiddleware: middlewares.AdminAuthMiddleware(apiTokenService, authService),
+		CSRFMiddleware:      middlewares.CSRFMiddleware(cfg.SessionConfig.Secret),
+		// Verifies slash commands sent by the chat workspace
+		ChatCommandAuthMiddleware: middlewares.ChatCommandAuthMiddleware(cfg.Chat),
+		// Lets Prometheus scrape the metrics with the configured token
+		MetricsAuthMiddleware: middlewares.MetricsAuthMiddleware(cfg.Metrics),
+		// Lets calendar clients subscribe to the leave feeds with the secret URL of a user
+		CalendarFeedAuthMiddleware: middlewares.CalendarFeedAuthMiddleware(leaveService),
+
+		// Prometheus metrics of the app
+		Metrics: appMetrics,
+
+		// Server-side store of admin sessions
+		SessionBackend: sessionBackend,
 
 		// Services
-		AuthService:     authService,
-		UserService:     userService,
-		TeamsService:    teamsService,
-		PositionService: positionService,
-		ProjectService:  projectService,
-		SkillService:    skillService,
+		AuthService:         authService,
+		AdminSessionService: adminSessionService,
+		TwoFactorService:    twoFactorService,
+		OIDCService:         oidcService,
+		UserService:         userService,
+		TeamsService:        teamsService,
+		PositionService:     positionService,
+		ProjectService:      projectService,
+		SkillService:        skillService,
+		CareerTrackService:  careerTrackService,
+		CelebrationService:  celebrationService,
+		NotificationService: notificationService,
+		LeaveService:        leaveService,
+		TimesheetService:    timesheetService,
+		LDAPSyncService:     ldapSyncService,
+		APITokenService:     apiTokenService,
+		WebhookService:      webhookService,
+		OutboxService:       outboxService,
+		ActivityLogService:  activityLogService,
+		MailService:         mailService,
+		ChatService:         chatService,
+		SeedService:         seedService,
+		HealthService:       healthService,
+
+		// Background jobs
+		CelebrationReminderJob: jobs.NewCelebrationReminderJob(celebrationService),
+		AdminSessionCleanupJob: jobs.NewAdminSessionCleanupJob(adminSessionService),
+		LDAPSyncJob:            jobs.NewLDAPSyncJob(ldapSyncService),
+		WebhookDeliveryJob:     jobs.NewWebhookDeliveryJob(webhookService),
+		OutboxDispatchJob:      jobs.NewOutboxDispatchJob(outboxService),
+		EmailDeliveryJob:       jobs.NewEmailDeliveryJob(mailService),
+		ChatDeliveryJob:        jobs.NewChatDeliveryJob(chatNotifier),
 
 		// Handlers
-		AuthHandler:        handlers.NewAuthHandler(authService),
-		DashboardHandler:   handlers.NewDashboardHandler(),
-		UserProfileHandler: handlers.NewUserProfileHandler(userService),
-		TeamsHandler:       handlers.NewTeamsHandler(teamsService),
+		AuthHandler:         handlers.NewAuthHandler(authService, twoFactorService, oidcService, passwordResetService),
+		TwoFactorHandler:    handlers.NewTwoFactorHandler(twoFactorService),
+		DashboardHandler:    handlers.NewDashboardHandler(celebrationService),
+		UserProfileHandler:  handlers.NewUserProfileHandler(userService, positionService),
+		TeamsHandler:        handlers.NewTeamsHandler(teamsService),
+		NotificationHandler: handlers.NewNotificationHandler(notificationService),
+		LeaveHandler:        handlers.NewLeaveHandler(leaveService),
+		TimesheetHandler:    handlers.NewTimesheetHandler(timesheetService),
+		APITokenHandler:     handlers.NewAPITokenHandler(apiTokenService),
+		ChatHandler:         handlers.NewChatHandler(chatService),
+		HealthHandler:       handlers.NewHealthHandler(healthService),
 		// Admin Handlers
-		AdminAuthHandler:      handlers.NewAdminAuthHandler(authService),
-		AdminDashboardHandler: handlers.NewAdminDashboardHandler(userService),
-		AdminUserHandler:      handlers.NewAdminUserHandler(userService, teamsService, positionService, skillService),
-		AdminPositionHandler:  handlers.NewAdminPositionHandler(positionService),
-		AdminSkillHandler:     handlers.NewAdminSkillHandler(skillService),
-		AdminTeamHandler:      handlers.NewAdminTeamHandler(teamsService, userService),
+		AdminAuthHandler:          handlers.NewAdminAuthHandler(authService, twoFactorService, oidcService),
+		AdminDashboardHandler:     handlers.NewAdminDashboardHandler(userService),
+		AdminUserHandler:          handlers.NewAdminUserHandler(userService, teamsService, positionService, skillService, authService, twoFactorService),
+		AdminPositionHandler:      handlers.NewAdminPositionHandler(positionService, careerTrackService, skillService),
+		AdminSkillHandler:         handlers.NewAdminSkillHandler(skillService),
+		AdminTeamHandler:          handlers.NewAdminTeamHandler(teamsService, userService),
+		AdminCareerTrackHandler:   handlers.NewAdminCareerTrackHandler(careerTrackService),
+		AdminReportHandler:        handlers.NewAdminReportHandler(userService, timesheetService, projectService),
+		AdminSecurityHandler:      handlers.NewAdminSecurityHandler(authService, twoFactorService, adminSessionService),
+		AdminDirectorySyncHandler: handlers.NewAdminDirectorySyncHandler(ldapSyncService),
+		AdminWebhookHandler:       handlers.NewAdminWebhookHandler(webhookService),
+		AdminActivityLogHandler:   handlers.NewAdminActivityLogHandler(activityLogService),
 	}
 }
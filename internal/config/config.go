@@ -3,24 +3,52 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server        ServerConfig
-	Database      DatabaseConfig
-	SessionConfig SessionConfig
-	JWT           JWTConfig
+	Server          ServerConfig
+	Database        DatabaseConfig
+	SessionConfig   SessionConfig
+	JWT             JWTConfig
+	Celebration     CelebrationConfig
+	LoginProtection LoginProtectionConfig
+	TwoFactor       TwoFactorConfig
+	OIDC            OIDCConfig
+	LDAP            LDAPConfig
+	Webhook         WebhookConfig
+	Outbox          OutboxConfig
+	Mail            MailConfig
+	Chat            ChatConfig
+	Seed            SeedConfig
+	Log             LogConfig
+	Metrics         MetricsConfig
+	Tracing         TracingConfig
 }
 
+// ServerConfig sets the address and the timeouts of the HTTP server. A stopping server fails its readiness
+// probe and keeps serving for ShutdownDrainDelay, so load balancers stop sending it new requests, then
+// ShutdownTimeout bounds how long it waits for in-flight requests and background jobs to finish.
 type ServerConfig struct {
 	Host string
 	Port string
+	// IPs or CIDRs of the reverse proxies whose X-Forwarded-For header is believed, none by default so
+	// that clients cannot pick the IP their logins are throttled and audited by
+	TrustedProxies     []string
+	ReadTimeout        time.Duration
+	ReadHeaderTimeout  time.Duration
+	WriteTimeout       time.Duration
+	IdleTimeout        time.Duration
+	ShutdownTimeout    time.Duration
+	ShutdownDrainDelay time.Duration
 }
 
 type DatabaseConfig struct {
+	// "mysql", "postgres" or "sqlite"
 	Driver       string
 	Host         string
 	Port         string
@@ -29,16 +57,197 @@ type DatabaseConfig struct {
 	Database     string
 	MaxIdleConns int
 	MaxOpenConns int
+	// sslmode of PostgreSQL connections
+	SSLMode string
+	// Database file of SQLite, it has no server
+	Path string
+	// Apply pending migrations on start, otherwise they are run with the migrate command
+	AutoMigrate bool
 }
 
 type SessionConfig struct {
 	Secret string
 	MaxAge int
 	Secure bool
+	// Where admin sessions are kept, "database" or "memory" (lost on restart, for tests)
+	Store string
 }
 
 type JWTConfig struct {
-	Secret string
+	// Algorithm is HS256 with the shared Secret, or RS256 or EdDSA with SigningKeyFile
+	Algorithm string
+	Secret    string
+	// SigningKeyFile is a PEM private key new tokens are signed with
+	SigningKeyFile string
+	// VerificationKeyFiles are PEM keys of rotated out signing keys, tokens they signed stay valid until they expire
+	VerificationKeyFiles []string
+	// Issuer and Audience are the iss and aud claims of access tokens, tokens carrying others are rejected
+	Issuer   string
+	Audience string
+}
+
+// WebhookConfig controls the delivery of webhooks, a delivery that keeps failing is retried after
+// RetryBaseDelay, then twice as long each time, until MaxAttempts were made
+type WebhookConfig struct {
+	PollInterval   time.Duration
+	Timeout        time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+}
+
+// OutboxConfig controls the dispatch of domain events from the outbox to the in-process subscribers.
+// Dispatched events are kept for Retention, so that a redelivered event can still be recognised.
+type OutboxConfig struct {
+	PollInterval   time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	Retention      time.Duration
+}
+
+// MailConfig controls how emails are sent. Driver is "smtp", "file" (one .eml file per email in FileDir)
+// or "log" (written to the log), the last two are meant for development. Queued emails that fail to send
+// are retried after RetryBaseDelay, then twice as long each time, until MaxAttempts were made.
+type MailConfig struct {
+	Driver string
+	From   string
+	// Public base URL of this app, links in emails are built from it
+	BaseURL string
+	FileDir string
+	// SMTPTLS is "starttls", "tls" (implicit TLS, usually port 465) or "none"
+	SMTPHost       string
+	SMTPPort       int
+	SMTPUsername   string
+	SMTPPassword   string
+	SMTPTLS        string
+	Timeout        time.Duration
+	PollInterval   time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	// How long a password reset link stays valid
+	PasswordResetTTL time.Duration
+	// Sent and failed emails are kept for Retention, without their bodies
+	Retention time.Duration
+}
+
+// ChatConfig connects the app to a Slack or Mattermost workspace. Slash commands are accepted when
+// signed with SigningSecret (Slack) or carrying CommandToken (Mattermost), the endpoint is off when
+// neither is set. Team changes are posted to the incoming webhook at WebhookURL when it is set, a post
+// that fails is retried after RetryBaseDelay, then twice as long each time, until MaxAttempts were made.
+type ChatConfig struct {
+	SigningSecret  string
+	CommandToken   string
+	WebhookURL     string
+	Timeout        time.Duration
+	PollInterval   time.Duration
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	// Posted and failed messages are kept for Retention
+	Retention time.Duration
+	// Public base URL of this app, links in chat messages are built from it
+	BaseURL string
+}
+
+// SeedConfig is the initial admin created by the seed command, flags of the command take precedence
+type SeedConfig struct {
+	AdminName  string
+	AdminEmail string
+	// A random password is generated and printed once when empty
+	AdminPassword string
+}
+
+// LogConfig controls the logs written to stderr. Level is "debug", "info", "warn" or "error" and Format
+// "json" or "text". Failed SQL queries are always logged, queries slower than SlowQueryThreshold as
+// warnings (never when zero) and every query when SQLQueries is set.
+type LogConfig struct {
+	Level              string
+	Format             string
+	SQLQueries         bool
+	SlowQueryThreshold time.Duration
+}
+
+// MetricsConfig protects the Prometheus metrics endpoint, scrapers send Token as a bearer token. The endpoint
+// answers 404 while no token is set.
+type MetricsConfig struct {
+	Token string
+}
+
+// TracingConfig selects where OpenTelemetry spans go: Exporter is "none", "otlp" (configured with the
+// standard OTEL_EXPORTER_OTLP_* variables) or "stdout" for local use. SampleRatio is the share of new
+// traces recorded, traces started by a caller follow its sampling decision.
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	SampleRatio float64
+}
+
+type CelebrationConfig struct {
+	ReminderDays int
+}
+
+type LoginProtectionConfig struct {
+	// Failed attempts in a row after which the account is locked
+	MaxAccountFailures int
+	LockoutDuration    time.Duration
+	// Failed attempts from one IP address within IPWindow after which the IP address is throttled
+	MaxIPFailures int
+	IPWindow      time.Duration
+	// Delay enforced after a failed attempt, doubled for every further failure up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+type TwoFactorConfig struct {
+	// Issuer shown in authenticator apps
+	Issuer string
+	// Every admin must enrol in two-factor authentication before signing in
+	RequiredForAdmins bool
+}
+
+type OIDCConfig struct {
+	Enabled bool
+	// Label of the single sign-on button
+	ProviderName string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Public base URL of this app, the callback URLs registered at the provider are built from it
+	RedirectBaseURL string
+	// Claims holding the name and email of the user
+	NameClaim  string
+	EmailClaim string
+	// Treat emails as verified when the provider sends no email_verified claim, only for providers that
+	// never hand out addresses they do not own
+	TrustUnverifiedEmails bool
+	// Create an account on the first sign-on of an unknown email, in DefaultPositionID
+	AutoProvision     bool
+	DefaultPositionID uint
+}
+
+type LDAPConfig struct {
+	// Run the synchronisation every SyncInterval, it can always be run with the ldap-sync command
+	SyncEnabled  bool
+	SyncInterval time.Duration
+	// ldap:// or ldaps:// URL of the directory and the account used to read it
+	URL          string
+	BindDN       string
+	BindPassword string
+	// ldap:// connections are upgraded with StartTLS, binding over one left in cleartext has to be allowed
+	StartTLS           bool
+	AllowPlaintextBind bool
+	// Users are the entries below BaseDN matching UserFilter
+	BaseDN     string
+	UserFilter string
+	PageSize   int
+	// Attributes holding the stable ID, email, name, department and title of a user.
+	// Departments are matched to teams and titles to positions by name.
+	UIDAttribute        string
+	EmailAttribute      string
+	NameAttribute       string
+	DepartmentAttribute string
+	TitleAttribute      string
+	// Position of new users whose title matches no position
+	DefaultPositionID uint
 }
 
 var (
@@ -64,28 +273,307 @@ func LoadConfig() *Config {
 		if err != nil {
 			maxOpenConns = 100
 		}
+		dbDriver := getEnv("DB_DRIVER", "mysql")
+		defaultDBPort := "3306"
+		if dbDriver == "postgres" {
+			defaultDBPort = "5432"
+		}
+		celebrationReminderDays, err := strconv.Atoi(getEnv("CELEBRATION_REMINDER_DAYS", "3"))
+		if err != nil {
+			celebrationReminderDays = 3
+		}
+		loginMaxAccountFailures, err := strconv.Atoi(getEnv("LOGIN_MAX_ACCOUNT_FAILURES", "5"))
+		if err != nil {
+			loginMaxAccountFailures = 5
+		}
+		loginLockoutMinutes, err := strconv.Atoi(getEnv("LOGIN_LOCKOUT_MINUTES", "15"))
+		if err != nil {
+			loginLockoutMinutes = 15
+		}
+		loginMaxIPFailures, err := strconv.Atoi(getEnv("LOGIN_MAX_IP_FAILURES", "20"))
+		if err != nil {
+			loginMaxIPFailures = 20
+		}
+		loginIPWindowMinutes, err := strconv.Atoi(getEnv("LOGIN_IP_WINDOW_MINUTES", "15"))
+		if err != nil {
+			loginIPWindowMinutes = 15
+		}
+		loginMaxDelaySeconds, err := strconv.Atoi(getEnv("LOGIN_MAX_DELAY_SECONDS", "30"))
+		if err != nil {
+			loginMaxDelaySeconds = 30
+		}
+		oidcDefaultPositionID, err := strconv.Atoi(getEnv("OIDC_DEFAULT_POSITION_ID", "1"))
+		if err != nil {
+			oidcDefaultPositionID = 1
+		}
+		ldapSyncIntervalMinutes, err := strconv.Atoi(getEnv("LDAP_SYNC_INTERVAL_MINUTES", "60"))
+		if err != nil {
+			ldapSyncIntervalMinutes = 60
+		}
+		ldapPageSize, err := strconv.Atoi(getEnv("LDAP_PAGE_SIZE", "500"))
+		if err != nil {
+			ldapPageSize = 500
+		}
+		ldapDefaultPositionID, err := strconv.Atoi(getEnv("LDAP_DEFAULT_POSITION_ID", "1"))
+		if err != nil {
+			ldapDefaultPositionID = 1
+		}
+		webhookPollIntervalSeconds, err := strconv.Atoi(getEnv("WEBHOOK_POLL_INTERVAL_SECONDS", "10"))
+		if err != nil {
+			webhookPollIntervalSeconds = 10
+		}
+		webhookTimeoutSeconds, err := strconv.Atoi(getEnv("WEBHOOK_TIMEOUT_SECONDS", "10"))
+		if err != nil {
+			webhookTimeoutSeconds = 10
+		}
+		webhookMaxAttempts, err := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"))
+		if err != nil {
+			webhookMaxAttempts = 8
+		}
+		webhookRetryBaseSeconds, err := strconv.Atoi(getEnv("WEBHOOK_RETRY_BASE_SECONDS", "30"))
+		if err != nil {
+			webhookRetryBaseSeconds = 30
+		}
+		outboxPollIntervalSeconds, err := strconv.Atoi(getEnv("OUTBOX_POLL_INTERVAL_SECONDS", "2"))
+		if err != nil {
+			outboxPollIntervalSeconds = 2
+		}
+		outboxMaxAttempts, err := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "10"))
+		if err != nil {
+			outboxMaxAttempts = 10
+		}
+		outboxRetryBaseSeconds, err := strconv.Atoi(getEnv("OUTBOX_RETRY_BASE_SECONDS", "5"))
+		if err != nil {
+			outboxRetryBaseSeconds = 5
+		}
+		outboxRetentionDays, err := strconv.Atoi(getEnv("OUTBOX_RETENTION_DAYS", "7"))
+		if err != nil {
+			outboxRetentionDays = 7
+		}
+		mailSMTPPort, err := strconv.Atoi(getEnv("MAIL_SMTP_PORT", "587"))
+		if err != nil {
+			mailSMTPPort = 587
+		}
+		mailTimeoutSeconds, err := strconv.Atoi(getEnv("MAIL_TIMEOUT_SECONDS", "10"))
+		if err != nil {
+			mailTimeoutSeconds = 10
+		}
+		mailPollIntervalSeconds, err := strconv.Atoi(getEnv("MAIL_POLL_INTERVAL_SECONDS", "10"))
+		if err != nil {
+			mailPollIntervalSeconds = 10
+		}
+		mailMaxAttempts, err := strconv.Atoi(getEnv("MAIL_MAX_ATTEMPTS", "6"))
+		if err != nil {
+			mailMaxAttempts = 6
+		}
+		mailRetryBaseSeconds, err := strconv.Atoi(getEnv("MAIL_RETRY_BASE_SECONDS", "60"))
+		if err != nil {
+			mailRetryBaseSeconds = 60
+		}
+		passwordResetTTLMinutes, err := strconv.Atoi(getEnv("PASSWORD_RESET_TTL_MINUTES", "60"))
+		if err != nil {
+			passwordResetTTLMinutes = 60
+		}
+		mailRetentionDays, err := strconv.Atoi(getEnv("MAIL_RETENTION_DAYS", "30"))
+		if err != nil {
+			mailRetentionDays = 30
+		}
+		chatTimeoutSeconds, err := strconv.Atoi(getEnv("CHAT_TIMEOUT_SECONDS", "5"))
+		if err != nil {
+			chatTimeoutSeconds = 5
+		}
+		chatPollIntervalSeconds, err := strconv.Atoi(getEnv("CHAT_POLL_INTERVAL_SECONDS", "10"))
+		if err != nil {
+			chatPollIntervalSeconds = 10
+		}
+		chatMaxAttempts, err := strconv.Atoi(getEnv("CHAT_MAX_ATTEMPTS", "6"))
+		if err != nil {
+			chatMaxAttempts = 6
+		}
+		chatRetryBaseSeconds, err := strconv.Atoi(getEnv("CHAT_RETRY_BASE_SECONDS", "60"))
+		if err != nil {
+			chatRetryBaseSeconds = 60
+		}
+		chatRetentionDays, err := strconv.Atoi(getEnv("CHAT_RETENTION_DAYS", "7"))
+		if err != nil {
+			chatRetentionDays = 7
+		}
+		slowQueryMilliseconds, err := strconv.Atoi(getEnv("DB_SLOW_QUERY_MS", "200"))
+		if err != nil {
+			slowQueryMilliseconds = 200
+		}
+		tracingSampleRatio, err := strconv.ParseFloat(getEnv("TRACING_SAMPLE_RATIO", "1"), 64)
+		if err != nil {
+			tracingSampleRatio = 1
+		}
+		serverReadTimeoutSeconds, err := strconv.Atoi(getEnv("SERVER_READ_TIMEOUT_SECONDS", "15"))
+		if err != nil {
+			serverReadTimeoutSeconds = 15
+		}
+		serverReadHeaderTimeoutSeconds, err := strconv.Atoi(getEnv("SERVER_READ_HEADER_TIMEOUT_SECONDS", "5"))
+		if err != nil {
+			serverReadHeaderTimeoutSeconds = 5
+		}
+		serverWriteTimeoutSeconds, err := strconv.Atoi(getEnv("SERVER_WRITE_TIMEOUT_SECONDS", "30"))
+		if err != nil {
+			serverWriteTimeoutSeconds = 30
+		}
+		serverIdleTimeoutSeconds, err := strconv.Atoi(getEnv("SERVER_IDLE_TIMEOUT_SECONDS", "60"))
+		if err != nil {
+			serverIdleTimeoutSeconds = 60
+		}
+		serverShutdownTimeoutSeconds, err := strconv.Atoi(getEnv("SERVER_SHUTDOWN_TIMEOUT_SECONDS", "30"))
+		if err != nil {
+			serverShutdownTimeoutSeconds = 30
+		}
+		serverShutdownDrainDelaySeconds, err := strconv.Atoi(getEnv("SERVER_SHUTDOWN_DRAIN_DELAY_SECONDS", "5"))
+		if err != nil {
+			serverShutdownDrainDelaySeconds = 5
+		}
 		cfg = &Config{
 			Server: ServerConfig{
-				Host: getEnv("SERVER_HOST", "localhost"),
-				Port: getEnv("SERVER_PORT", "8080"),
+				Host:               getEnv("SERVER_HOST", "localhost"),
+				Port:               getEnv("SERVER_PORT", "8080"),
+				TrustedProxies:     splitList(getEnv("TRUSTED_PROXIES", "")),
+				ReadTimeout:        time.Duration(serverReadTimeoutSeconds) * time.Second,
+				ReadHeaderTimeout:  time.Duration(serverReadHeaderTimeoutSeconds) * time.Second,
+				WriteTimeout:       time.Duration(serverWriteTimeoutSeconds) * time.Second,
+				IdleTimeout:        time.Duration(serverIdleTimeoutSeconds) * time.Second,
+				ShutdownTimeout:    time.Duration(serverShutdownTimeoutSeconds) * time.Second,
+				ShutdownDrainDelay: time.Duration(serverShutdownDrainDelaySeconds) * time.Second,
 			},
 			Database: DatabaseConfig{
-				Driver:       getEnv("DB_DRIVER", "mysql"),
+				Driver:       dbDriver,
 				Host:         getEnv("DB_HOST", "localhost"),
-				Port:         getEnv("DB_PORT", "3306"),
+				Port:         getEnv("DB_PORT", defaultDBPort),
 				User:         getEnv("DB_USER", "root"),
 				Password:     getEnv("DB_PASSWORD", "password"),
 				Database:     getEnv("DB_NAME", "trieu_mock_project_go"),
 				MaxIdleConns: maxIdleConns,
 				MaxOpenConns: maxOpenConns,
+				SSLMode:      getEnv("DB_SSL_MODE", "disable"),
+				Path:         getEnv("DB_PATH", "trieu_mock_project_go.db"),
+				AutoMigrate:  getEnv("DB_AUTO_MIGRATE", "true") == "true",
 			},
 			SessionConfig: SessionConfig{
 				Secret: getEnv("SESSION_SECRET", "trieu-mock-project-go-secret"),
 				MaxAge: sessionMaxAge,
 				Secure: getEnv("SESSION_SECURE", "false") == "true",
+				Store:  getEnv("SESSION_STORE", "database"),
 			},
 			JWT: JWTConfig{
-				Secret: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+				Algorithm:            getEnv("JWT_ALGORITHM", "HS256"),
+				Secret:               getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+				SigningKeyFile:       getEnv("JWT_SIGNING_KEY_FILE", ""),
+				VerificationKeyFiles: splitList(getEnv("JWT_VERIFICATION_KEY_FILES", "")),
+				Issuer:               getEnv("JWT_ISSUER", "trieu-mock-project"),
+				Audience:             getEnv("JWT_AUDIENCE", "trieu-mock-project-api"),
+			},
+			Celebration: CelebrationConfig{
+				ReminderDays: celebrationReminderDays,
+			},
+			LoginProtection: LoginProtectionConfig{
+				MaxAccountFailures: loginMaxAccountFailures,
+				LockoutDuration:    time.Duration(loginLockoutMinutes) * time.Minute,
+				MaxIPFailures:      loginMaxIPFailures,
+				IPWindow:           time.Duration(loginIPWindowMinutes) * time.Minute,
+				BaseDelay:          time.Second,
+				MaxDelay:           time.Duration(loginMaxDelaySeconds) * time.Second,
+			},
+			TwoFactor: TwoFactorConfig{
+				Issuer:            getEnv("TOTP_ISSUER", "Trieu Mock Project"),
+				RequiredForAdmins: getEnv("ADMIN_2FA_REQUIRED", "false") == "true",
+			},
+			OIDC: OIDCConfig{
+				Enabled:               getEnv("OIDC_ENABLED", "false") == "true",
+				ProviderName:          getEnv("OIDC_PROVIDER_NAME", "SSO"),
+				IssuerURL:             getEnv("OIDC_ISSUER_URL", ""),
+				ClientID:              getEnv("OIDC_CLIENT_ID", ""),
+				ClientSecret:          getEnv("OIDC_CLIENT_SECRET", ""),
+				Scopes:                strings.Fields(getEnv("OIDC_SCOPES", "openid email profile")),
+				RedirectBaseURL:       strings.TrimSuffix(getEnv("OIDC_REDIRECT_BASE_URL", "http://localhost:8080"), "/"),
+				NameClaim:             getEnv("OIDC_NAME_CLAIM", "name"),
+				EmailClaim:            getEnv("OIDC_EMAIL_CLAIM", "email"),
+				TrustUnverifiedEmails: getEnv("OIDC_TRUST_UNVERIFIED_EMAILS", "false") == "true",
+				AutoProvision:         getEnv("OIDC_AUTO_PROVISION", "true") == "true",
+				DefaultPositionID:     uint(oidcDefaultPositionID),
+			},
+			LDAP: LDAPConfig{
+				SyncEnabled:         getEnv("LDAP_SYNC_ENABLED", "false") == "true",
+				SyncInterval:        time.Duration(ldapSyncIntervalMinutes) * time.Minute,
+				URL:                 getEnv("LDAP_URL", "ldap://localhost:389"),
+				BindDN:              getEnv("LDAP_BIND_DN", ""),
+				BindPassword:        getEnv("LDAP_BIND_PASSWORD", ""),
+				StartTLS:            getEnv("LDAP_START_TLS", "true") == "true",
+				AllowPlaintextBind:  getEnv("LDAP_ALLOW_PLAINTEXT_BIND", "false") == "true",
+				BaseDN:              getEnv("LDAP_BASE_DN", ""),
+				UserFilter:          getEnv("LDAP_USER_FILTER", "(objectClass=inetOrgPerson)"),
+				PageSize:            ldapPageSize,
+				UIDAttribute:        getEnv("LDAP_UID_ATTRIBUTE", "uid"),
+				EmailAttribute:      getEnv("LDAP_EMAIL_ATTRIBUTE", "mail"),
+				NameAttribute:       getEnv("LDAP_NAME_ATTRIBUTE", "cn"),
+				DepartmentAttribute: getEnv("LDAP_DEPARTMENT_ATTRIBUTE", "departmentNumber"),
+				TitleAttribute:      getEnv("LDAP_TITLE_ATTRIBUTE", "title"),
+				DefaultPositionID:   uint(ldapDefaultPositionID),
+			},
+			Webhook: WebhookConfig{
+				PollInterval:   time.Duration(webhookPollIntervalSeconds) * time.Second,
+				Timeout:        time.Duration(webhookTimeoutSeconds) * time.Second,
+				MaxAttempts:    webhookMaxAttempts,
+				RetryBaseDelay: time.Duration(webhookRetryBaseSeconds) * time.Second,
+			},
+			Outbox: OutboxConfig{
+				PollInterval:   time.Duration(outboxPollIntervalSeconds) * time.Second,
+				MaxAttempts:    outboxMaxAttempts,
+				RetryBaseDelay: time.Duration(outboxRetryBaseSeconds) * time.Second,
+				Retention:      time.Duration(outboxRetentionDays) * 24 * time.Hour,
+			},
+			Mail: MailConfig{
+				Driver:           getEnv("MAIL_DRIVER", "log"),
+				From:             getEnv("MAIL_FROM", "Trieu Mock Project <no-reply@localhost>"),
+				BaseURL:          strings.TrimSuffix(getEnv("APP_BASE_URL", "http://localhost:8080"), "/"),
+				FileDir:          getEnv("MAIL_FILE_DIR", "tmp/mail"),
+				SMTPHost:         getEnv("MAIL_SMTP_HOST", "localhost"),
+				SMTPPort:         mailSMTPPort,
+				SMTPUsername:     getEnv("MAIL_SMTP_USERNAME", ""),
+				SMTPPassword:     getEnv("MAIL_SMTP_PASSWORD", ""),
+				SMTPTLS:          getEnv("MAIL_SMTP_TLS", "starttls"),
+				Timeout:          time.Duration(mailTimeoutSeconds) * time.Second,
+				PollInterval:     time.Duration(mailPollIntervalSeconds) * time.Second,
+				MaxAttempts:      mailMaxAttempts,
+				RetryBaseDelay:   time.Duration(mailRetryBaseSeconds) * time.Second,
+				PasswordResetTTL: time.Duration(passwordResetTTLMinutes) * time.Minute,
+				Retention:        time.Duration(mailRetentionDays) * 24 * time.Hour,
+			},
+			Chat: ChatConfig{
+				SigningSecret:  getEnv("CHAT_SIGNING_SECRET", ""),
+				CommandToken:   getEnv("CHAT_COMMAND_TOKEN", ""),
+				WebhookURL:     getEnv("CHAT_WEBHOOK_URL", ""),
+				Timeout:        time.Duration(chatTimeoutSeconds) * time.Second,
+				PollInterval:   time.Duration(chatPollIntervalSeconds) * time.Second,
+				MaxAttempts:    chatMaxAttempts,
+				RetryBaseDelay: time.Duration(chatRetryBaseSeconds) * time.Second,
+				Retention:      time.Duration(chatRetentionDays) * 24 * time.Hour,
+				BaseURL:        strings.TrimSuffix(getEnv("APP_BASE_URL", "http://localhost:8080"), "/"),
+			},
+			Seed: SeedConfig{
+				AdminName:     getEnv("SEED_ADMIN_NAME", "Administrator"),
+				AdminEmail:    getEnv("SEED_ADMIN_EMAIL", "admin@example.com"),
+				AdminPassword: getEnv("SEED_ADMIN_PASSWORD", ""),
+			},
+			Log: LogConfig{
+				Level:              getEnv("LOG_LEVEL", "info"),
+				Format:             getEnv("LOG_FORMAT", "json"),
+				SQLQueries:         getEnv("DB_LOG_QUERIES", "false") == "true",
+				SlowQueryThreshold: time.Duration(slowQueryMilliseconds) * time.Millisecond,
+			},
+			Metrics: MetricsConfig{
+				Token: getEnv("METRICS_TOKEN", ""),
+			},
+			Tracing: TracingConfig{
+				Exporter:    getEnv("TRACING_EXPORTER", "none"),
+				ServiceName: getEnv("OTEL_SERVICE_NAME", "trieu-mock-project-go"),
+				SampleRatio: tracingSampleRatio,
 			},
 		}
 	})
@@ -99,3 +587,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
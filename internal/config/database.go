@@ -1,31 +1,83 @@
 package config
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
 	"path/filepath"
+	"strings"
+	"trieu_mock_project_go/internal/logging"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	mysqlmigrate "github.com/golang-migrate/migrate/v4/database/mysql"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	sqlitemigrate "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+
+	// Pure Go SQLite, registered as the "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// Database engines selected with DB_DRIVER, each has its own migrations in migrations/<driver>
+const (
+	DBDriverMySQL    = "mysql"
+	DBDriverPostgres = "postgres"
+	DBDriverSQLite   = "sqlite"
 )
 
 var DB *gorm.DB
 
-// BuildDSN builds the MySQL DSN from database config
+// BuildDSN builds the DSN of the configured database driver from database config
 func BuildDSN() string {
 	dbConfig := LoadConfig().Database
-	return dbConfig.User + ":" + dbConfig.Password + "@tcp(" + dbConfig.Host + ":" + dbConfig.Port + ")/" + dbConfig.Database + "?charset=utf8mb4&parseTime=True&loc=Local&multiStatements=true"
+	switch dbConfig.Driver {
+	case DBDriverPostgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(dbConfig.User, dbConfig.Password),
+			Host:     net.JoinHostPort(dbConfig.Host, dbConfig.Port),
+			Path:     "/" + dbConfig.Database,
+			RawQuery: url.Values{"sslmode": {dbConfig.SSLMode}}.Encode(),
+		}
+		return dsn.String()
+	case DBDriverSQLite:
+		return SQLiteDSN(dbConfig.Path)
+	default:
+		return dbConfig.User + ":" + dbConfig.Password + "@tcp(" + dbConfig.Host + ":" + dbConfig.Port + ")/" + dbConfig.Database + "?charset=utf8mb4&parseTime=True&loc=Local&multiStatements=true"
+	}
+}
+
+// SQLiteDSN builds the DSN of the SQLite database file at path
+func SQLiteDSN(path string) string {
+	// Foreign keys are off by default in SQLite, writers wait for each other instead of failing and
+	// times are stored in a sortable format so they compare correctly in queries
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_time_format=sqlite"
 }
 
-// ConnectToMySQL establishes a connection to the MySQL database
-func ConnectToMySQL(dsn string) error {
-	var err error
-	DB, err = gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+// ConnectToDatabase establishes a connection to the database of the driver
+func ConnectToDatabase(driver, dsn string) error {
+	dialector, err := openDialector(driver, dsn)
+	if err != nil {
+		return err
+	}
+
+	logConfig := LoadConfig().Log
+	DB, err = gorm.Open(dialector, &gorm.Config{
+		Logger: logging.NewGormLogger(logConfig.SQLQueries, logConfig.SlowQueryThreshold),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
@@ -42,54 +94,147 @@ func ConnectToMySQL(dsn string) error {
 	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
 	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
 
-	log.Println("✅ Database connected successfully")
+	slog.Info("Database connected", "driver", driver)
 	return nil
 }
 
-// RunMigrations applies database migrations using golang-migrate
-func RunMigrations() error {
+func openDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DBDriverMySQL:
+		return mysql.Open(dsn), nil
+	case DBDriverPostgres:
+		return postgres.Open(dsn), nil
+	case DBDriverSQLite:
+		// The directory of a database file is created on first start, URIs and in-memory databases are left alone
+		if path, _, _ := strings.Cut(dsn, "?"); path != ":memory:" && !strings.HasPrefix(path, "file:") {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return nil, fmt.Errorf("failed to create database directory: %w", err)
+			}
+		}
+		return sqlite.Dialector{DriverName: "sqlite", DSN: dsn}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, expected mysql, postgres or sqlite", driver)
+	}
+}
+
+// MigrationsPath returns the absolute path of the migrations of the database driver
+func MigrationsPath(driver string) (string, error) {
+	return filepath.Abs(filepath.Join("migrations", driver))
+}
+
+// LatestMigrationVersion returns the version of the last migration of the database driver, the version
+// a database with every migration applied is at
+func LatestMigrationVersion(driver string) (uint, error) {
+	migrationsPath, err := MigrationsPath(driver)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to get migrations path: %w", err)
+	}
+	migrations, err := source.Open("file://" + migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to open migrations: %w", err)
+	}
+	defer migrations.Close()
+
+	version, err := migrations.First()
+	if err != nil {
+		return 0, fmt.Errorf("Failed to read migrations: %w", err)
+	}
+	for {
+		next, err := migrations.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Failed to read migrations: %w", err)
+		}
+		version = next
+	}
+}
+
+// NewMigrator returns a migrator of the connected database and the migrations of its driver
+func NewMigrator() (*migrate.Migrate, error) {
 	sqlDB, err := DB.DB()
 	if err != nil {
-		return fmt.Errorf("Failed to get database instance: %w", err)
+		return nil, fmt.Errorf("Failed to get database instance: %w", err)
 	}
 
-	driver, err := mysqlmigrate.WithInstance(sqlDB, &mysqlmigrate.Config{})
+	driverName := LoadConfig().Database.Driver
+	driver, err := migrationDriver(driverName, sqlDB)
 	if err != nil {
-		return fmt.Errorf("Failed to create MySQL driver: %w", err)
+		return nil, fmt.Errorf("Failed to create %s migration driver: %w", driverName, err)
 	}
 
-	migrationsPath, err := filepath.Abs("migrations")
+	migrationsPath, err := MigrationsPath(driverName)
 	if err != nil {
-		return fmt.Errorf("Failed to get migrations path: %w", err)
+		return nil, fmt.Errorf("Failed to get migrations path: %w", err)
 	}
 
 	m, err := migrate.NewWithDatabaseInstance(
 		"file://"+migrationsPath,
-		"mysql",
+		driverName,
 		driver,
 	)
 	if err != nil {
-		return fmt.Errorf("Failed to initialize migrations: %w", err)
+		return nil, fmt.Errorf("Failed to initialize migrations: %w", err)
+	}
+	m.Log = migrationLogger{}
+	return m, nil
+}
+
+// RunMigrations applies database migrations using golang-migrate
+func RunMigrations() error {
+	m, err := NewMigrator()
+	if err != nil {
+		return err
 	}
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("Failed to apply migrations: %w", err)
 	}
 
-	log.Println("Migrations applied successfully")
+	slog.Info("Migrations applied")
 	return nil
 }
 
-// InitDB initializes database connection and performs migration
+func migrationDriver(driver string, sqlDB *sql.DB) (database.Driver, error) {
+	switch driver {
+	case DBDriverMySQL:
+		return mysqlmigrate.WithInstance(sqlDB, &mysqlmigrate.Config{})
+	case DBDriverPostgres:
+		return pgxmigrate.WithInstance(sqlDB, &pgxmigrate.Config{})
+	case DBDriverSQLite:
+		return sqlitemigrate.WithInstance(sqlDB, &sqlitemigrate.Config{})
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+}
+
+// InitDB initializes database connection and performs migration, unless DB_AUTO_MIGRATE is false
 func InitDB() error {
 	dsn := BuildDSN()
-	if err := ConnectToMySQL(dsn); err != nil {
+	if err := ConnectToDatabase(LoadConfig().Database.Driver, dsn); err != nil {
 		return err
 	}
 
+	if !LoadConfig().Database.AutoMigrate {
+		slog.Info("Automatic migrations are disabled, apply them with the migrate command")
+		return nil
+	}
+
 	if err := RunMigrations(); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// migrationLogger logs every migration applied or rolled back
+type migrationLogger struct{}
+
+func (migrationLogger) Printf(format string, v ...any) {
+	slog.Info(strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
+
+func (migrationLogger) Verbose() bool {
+	return false
+}
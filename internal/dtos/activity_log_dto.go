@@ -0,0 +1,23 @@
+package dtos
+
+import "time"
+
+type ActivityLog struct {
+	ID          uint      `json:"id"`
+	Action      string    `json:"action"`
+	UserID      *uint     `json:"user_id"`
+	UserName    *string   `json:"user_name"`
+	Description *string   `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ActivityLogSearchRequest struct {
+	Action *string `form:"action" binding:"omitempty,oneof=user.created user.deleted team.created team.deleted team.member_added team.member_removed team.leader_changed"`
+	Limit  int     `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int     `form:"offset" binding:"min=0"`
+}
+
+type ActivityLogSearchResponse struct {
+	ActivityLogs []ActivityLog      `json:"activity_logs"`
+	Page         PaginationResponse `json:"page"`
+}
@@ -0,0 +1,31 @@
+package dtos
+
+import "time"
+
+type APIToken struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIP  *string    `json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type CreateAPITokenRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=profile:read teams:read admin:write"`
+	// ExpiresInDays of 0 creates a token that never expires
+	ExpiresInDays int `json:"expires_in_days" binding:"min=0,max=365"`
+}
+
+// CreateAPITokenResponse carries the token itself, which is only ever shown once
+type CreateAPITokenResponse struct {
+	Token    string   `json:"token"`
+	APIToken APIToken `json:"api_token"`
+}
+
+type APITokenListResponse struct {
+	Tokens []APIToken `json:"tokens"`
+}
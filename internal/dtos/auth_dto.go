@@ -1,5 +1,7 @@
 package dtos
 
+import "time"
+
 type LoginRequest struct {
 	User struct {
 		Email    string `json:"email" binding:"required,email"`
@@ -15,3 +17,113 @@ type LoginResponse struct {
 		AccessToken string `json:"access_token"`
 	} `json:"user"`
 }
+
+type LoginAttempt struct {
+	ID            uint      `json:"id"`
+	Flow          string    `json:"flow"`
+	Email         string    `json:"email"`
+	UserID        *uint     `json:"user_id"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     *string   `json:"user_agent"`
+	Success       bool      `json:"success"`
+	FailureReason *string   `json:"failure_reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type LoginAttemptSearchRequest struct {
+	Email     string `form:"email"`
+	IPAddress string `form:"ip_address"`
+	Result    string `form:"result" binding:"omitempty,oneof=success failure"`
+	Limit     int    `form:"limit,default=50" binding:"min=1,max=100"`
+	Offset    int    `form:"offset" binding:"min=0"`
+}
+
+type LoginAttemptSearchResponse struct {
+	Attempts []LoginAttempt     `json:"attempts"`
+	Page     PaginationResponse `json:"page"`
+}
+
+type AccountLoginSecurity struct {
+	FailedLoginCount  uint           `json:"failed_login_count"`
+	LastFailedLoginAt *time.Time     `json:"last_failed_login_at"`
+	LockedUntil       *time.Time     `json:"locked_until"`
+	IsLocked          bool           `json:"is_locked"`
+	DeactivatedAt     *time.Time     `json:"deactivated_at"`
+	TwoFactorEnabled  bool           `json:"two_factor_enabled"`
+	TwoFactorRequired bool           `json:"two_factor_required"`
+	RecentAttempts    []LoginAttempt `json:"recent_attempts"`
+}
+
+// TwoFactorChallengeResponse is returned by login instead of an access token when a second factor is needed.
+// The challenge token is exchanged for an access token on /login/2fa, or on /login/2fa/enable when SetupRequired.
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	SetupRequired     bool   `json:"setup_required"`
+	ChallengeToken    string `json:"challenge_token"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,max=20"`
+}
+
+type TwoFactorSetupLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+type TwoFactorEnableLoginResponse struct {
+	LoginResponse
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool  `json:"enabled"`
+	Required               bool  `json:"required"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,max=20"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type UpdateTwoFactorRequirementRequest struct {
+	Required *bool `json:"required" binding:"required"`
+}
+
+type AdminSession struct {
+	ID         uint      `json:"id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  *string   `json:"user_agent"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type RevokeSessionsResponse struct {
+	Message string `json:"message"`
+	Revoked int64  `json:"revoked"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72,nefield=CurrentPassword"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=72"`
+}
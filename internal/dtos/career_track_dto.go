@@ -0,0 +1,18 @@
+package dtos
+
+type CareerTrack struct {
+	ID          uint              `json:"id"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description"`
+	Positions   []PositionSummary `json:"positions"`
+}
+
+type CareerTrackSearchResponse struct {
+	CareerTracks []CareerTrack      `json:"career_tracks"`
+	Page         PaginationResponse `json:"page"`
+}
+
+type CreateOrUpdateCareerTrackRequest struct {
+	Name        string  `json:"name" binding:"required,max=255"`
+	Description *string `json:"description"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+import "trieu_mock_project_go/types"
+
+const (
+	CelebrationTypeBirthday        = "birthday"
+	CelebrationTypeWorkAnniversary = "work_anniversary"
+)
+
+type Celebration struct {
+	Type      string      `json:"type"`
+	User      UserSummary `json:"user"`
+	Date      types.Date  `json:"date"`
+	DaysUntil int         `json:"days_until"`
+	Years     int         `json:"years,omitempty"`
+}
+
+type UpcomingCelebrationsRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=90"`
+}
+
+type UpcomingCelebrationsResponse struct {
+	Celebrations []Celebration `json:"celebrations"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+// ChatCommandRequest is the form Slack and Mattermost post for a slash command
+type ChatCommandRequest struct {
+	Command string `form:"command"`
+	Text    string `form:"text"`
+}
+
+// ChatCommandResponse is shown only to the user who ran the command
+type ChatCommandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// ChatMessage is posted to the incoming webhook of a channel
+type ChatMessage struct {
+	Text string `json:"text"`
+}
@@ -14,6 +14,12 @@ type TeamSummary struct {
 }
 
 type PositionSummary struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Grade *int   `json:"grade,omitempty"`
+}
+
+type CareerTrackSummary struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
 }
@@ -0,0 +1,38 @@
+package dtos
+
+// Payloads of the domain events, stored in the outbox and sent as the data of webhooks
+
+type EventUser struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type EventTeam struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEventData is the payload of user.created and user.deleted
+type UserEventData struct {
+	User EventUser `json:"user"`
+}
+
+// TeamEventData is the payload of team.created and team.deleted
+type TeamEventData struct {
+	Team     EventTeam `json:"team"`
+	LeaderID uint      `json:"leader_id"`
+}
+
+// TeamMemberEventData is the payload of team.member_added and team.member_removed,
+// a user moved between teams is removed from one and added to the other
+type TeamMemberEventData struct {
+	Team EventTeam `json:"team"`
+	User EventUser `json:"user"`
+}
+
+type TeamLeaderChangedEventData struct {
+	Team             EventTeam `json:"team"`
+	PreviousLeaderID uint      `json:"previous_leader_id"`
+	Leader           EventUser `json:"leader"`
+}
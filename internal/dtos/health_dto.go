@@ -0,0 +1,21 @@
+package dtos
+
+// Statuses of a HealthResponse and of its checks
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+	HealthStatusFailing     = "failing"
+)
+
+// HealthCheck is the result of checking one dependency of the app
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthResponse is ok when every check passed, unavailable otherwise
+type HealthResponse struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
@@ -0,0 +1,53 @@
+package dtos
+
+import "time"
+
+// Actions of an LDAPSyncChange
+const (
+	LDAPSyncActionCreated     = "created"
+	LDAPSyncActionUpdated     = "updated"
+	LDAPSyncActionDeactivated = "deactivated"
+	LDAPSyncActionReactivated = "reactivated"
+	LDAPSyncActionSkipped     = "skipped"
+	LDAPSyncActionWarning     = "warning"
+)
+
+// LDAPSyncChange is one line of the sync report, what happened to one user
+type LDAPSyncChange struct {
+	UID     string `json:"uid"`
+	Email   string `json:"email"`
+	UserID  *uint  `json:"user_id"`
+	Action  string `json:"action"`
+	Message string `json:"message"`
+}
+
+type LDAPSyncRun struct {
+	ID               uint             `json:"id"`
+	Trigger          string           `json:"trigger"`
+	DryRun           bool             `json:"dry_run"`
+	Status           string           `json:"status"`
+	DirectoryEntries int              `json:"directory_entries"`
+	CreatedCount     int              `json:"created_count"`
+	UpdatedCount     int              `json:"updated_count"`
+	DeactivatedCount int              `json:"deactivated_count"`
+	ReactivatedCount int              `json:"reactivated_count"`
+	SkippedCount     int              `json:"skipped_count"`
+	Error            *string          `json:"error"`
+	Changes          []LDAPSyncChange `json:"changes,omitempty"`
+	StartedAt        time.Time        `json:"started_at"`
+	FinishedAt       *time.Time       `json:"finished_at"`
+}
+
+type LDAPSyncRunSearchRequest struct {
+	Limit  int `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int `form:"offset" binding:"min=0"`
+}
+
+type LDAPSyncRunSearchResponse struct {
+	Runs []LDAPSyncRun      `json:"runs"`
+	Page PaginationResponse `json:"page"`
+}
+
+type LDAPSyncRequest struct {
+	DryRun bool `json:"dry_run"`
+}
@@ -0,0 +1,66 @@
+package dtos
+
+import (
+	"time"
+	"trieu_mock_project_go/types"
+)
+
+type LeaveRequest struct {
+	ID           uint         `json:"id"`
+	User         UserSummary  `json:"user"`
+	Team         *TeamSummary `json:"team,omitempty"`
+	Type         string       `json:"type"`
+	StartDate    types.Date   `json:"start_date"`
+	EndDate      types.Date   `json:"end_date"`
+	StartHalfDay bool         `json:"start_half_day"`
+	EndHalfDay   bool         `json:"end_half_day"`
+	Days         float64      `json:"days"`
+	Reason       *string      `json:"reason,omitempty"`
+	Status       string       `json:"status"`
+	Reviewer     *UserSummary `json:"reviewer,omitempty"`
+	ReviewNote   *string      `json:"review_note,omitempty"`
+	ReviewedAt   *time.Time   `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type CreateLeaveRequest struct {
+	Type         string      `json:"type" binding:"required,oneof=annual sick unpaid other"`
+	StartDate    *types.Date `json:"start_date" binding:"required"`
+	EndDate      *types.Date `json:"end_date" binding:"required"`
+	StartHalfDay bool        `json:"start_half_day"`
+	EndHalfDay   bool        `json:"end_half_day"`
+	Reason       *string     `json:"reason" binding:"omitempty,max=1000"`
+}
+
+type ReviewLeaveRequest struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
+}
+
+type ListLeaveRequestsResponse struct {
+	Leaves []LeaveRequest     `json:"leaves"`
+	Page   PaginationResponse `json:"page"`
+}
+
+type PendingLeaveRequestsResponse struct {
+	Leaves []LeaveRequest `json:"leaves"`
+}
+
+type TeamLeaveCalendarRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+type TeamLeaveCalendarResponse struct {
+	TeamID uint           `json:"team_id"`
+	From   types.Date     `json:"from"`
+	To     types.Date     `json:"to"`
+	Leaves []LeaveRequest `json:"leaves"`
+}
+
+// CalendarFeedResponse holds the secret feed URLs calendar clients subscribe to, shown once.
+// TeamLeavesPath is the feed of the user's current team, empty when they are in none.
+type CalendarFeedResponse struct {
+	Token          string `json:"token"`
+	LeavesPath     string `json:"leaves_path"`
+	TeamLeavesPath string `json:"team_leaves_path,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+type Notification struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListNotificationsResponse struct {
+	Notifications []Notification     `json:"notifications"`
+	UnreadCount   int64              `json:"unread_count"`
+	Page          PaginationResponse `json:"page"`
+}
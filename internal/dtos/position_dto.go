@@ -1,9 +1,18 @@
 package dtos
 
 type Position struct {
-	ID           uint   `json:"id"`
-	Name         string `json:"name"`
-	Abbreviation string `json:"abbreviation"`
+	ID             uint                    `json:"id"`
+	Name           string                  `json:"name"`
+	Abbreviation   string                  `json:"abbreviation"`
+	CareerTrack    *CareerTrackSummary     `json:"career_track,omitempty"`
+	Grade          *int                    `json:"grade,omitempty"`
+	RequiredSkills []PositionRequiredSkill `json:"required_skills,omitempty"`
+}
+
+type PositionRequiredSkill struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	MinLevel int    `json:"min_level"`
 }
 
 type PositionSearchResponse struct {
@@ -12,6 +21,32 @@ type PositionSearchResponse struct {
 }
 
 type CreateOrUpdatePositionRequest struct {
-	Name         string `json:"name" binding:"required,max=255"`
-	Abbreviation string `json:"abbreviation" binding:"required,max=50"`
+	Name           string                        `json:"name" binding:"required,max=255"`
+	Abbreviation   string                        `json:"abbreviation" binding:"required,max=50"`
+	CareerTrackID  *uint                         `json:"career_track_id"`
+	Grade          *int                          `json:"grade" binding:"omitempty,min=1,max=100"`
+	RequiredSkills []UpdatePositionRequiredSkill `json:"required_skills" binding:"dive"`
+}
+
+type UpdatePositionRequiredSkill struct {
+	ID       uint `json:"id" binding:"required"`
+	MinLevel int  `json:"min_level" binding:"required,min=1,max=10"`
+}
+
+type SkillRequirementStatus struct {
+	SkillID       uint   `json:"skill_id"`
+	SkillName     string `json:"skill_name"`
+	RequiredLevel int    `json:"required_level"`
+	CurrentLevel  int    `json:"current_level"`
+	IsMet         bool   `json:"is_met"`
+}
+
+type PromotionReadiness struct {
+	UserID          uint                     `json:"user_id"`
+	CurrentPosition Position                 `json:"current_position"`
+	NextPosition    *Position                `json:"next_position"`
+	Requirements    []SkillRequirementStatus `json:"requirements"`
+	MetCount        int                      `json:"met_count"`
+	TotalCount      int                      `json:"total_count"`
+	IsReady         bool                     `json:"is_ready"`
 }
@@ -0,0 +1,56 @@
+package dtos
+
+import "time"
+
+type PromotionReportRequest struct {
+	From   time.Time `form:"from" time_format:"2006-01-02"`
+	To     time.Time `form:"to" time_format:"2006-01-02"`
+	Period string    `form:"period" binding:"omitempty,oneof=month quarter year"`
+}
+
+type PromotionReportRow struct {
+	Period   string `json:"period"`
+	TeamID   *uint  `json:"team_id"`
+	TeamName string `json:"team_name"`
+	Count    int    `json:"count"`
+}
+
+type PromotionReport struct {
+	From   time.Time            `json:"from"`
+	To     time.Time            `json:"to"`
+	Period string               `json:"period"`
+	Rows   []PromotionReportRow `json:"rows"`
+	Total  int                  `json:"total"`
+}
+
+type ProjectEffortReportRequest struct {
+	ProjectID uint      `form:"project_id"`
+	From      time.Time `form:"from" time_format:"2006-01-02"`
+	To        time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// ProjectEffortRow is the approved effort of one member in one week
+type ProjectEffortRow struct {
+	UserID    uint    `json:"user_id"`
+	UserName  string  `json:"user_name"`
+	UserEmail string  `json:"user_email"`
+	WeekStart string  `json:"week_start"`
+	Hours     float64 `json:"hours"`
+}
+
+type ProjectEffortMemberTotal struct {
+	UserID    uint    `json:"user_id"`
+	UserName  string  `json:"user_name"`
+	UserEmail string  `json:"user_email"`
+	Hours     float64 `json:"hours"`
+	Days      int     `json:"days"`
+}
+
+type ProjectEffortReport struct {
+	Project    ProjectSummary             `json:"project"`
+	From       time.Time                  `json:"from"`
+	To         time.Time                  `json:"to"`
+	Rows       []ProjectEffortRow         `json:"rows"`
+	Members    []ProjectEffortMemberTotal `json:"members"`
+	TotalHours float64                    `json:"total_hours"`
+}
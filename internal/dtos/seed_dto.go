@@ -0,0 +1,39 @@
+package dtos
+
+import "time"
+
+// SeedAdmin is the initial admin, created unless a user with the email exists
+type SeedAdmin struct {
+	Name     string
+	Email    string
+	Password string
+}
+
+// SeedReport counts what the seed created, existing rows are left as they are
+type SeedReport struct {
+	CareerTracks int
+	Positions    int
+	Skills       int
+	AdminCreated bool
+	AdminEmail   string
+	// Set when the admin was created without a password, it is shown once
+	GeneratedPassword string
+}
+
+// SeedDemoOptions describes the demo dataset, the same seed, user count and Today always give the same
+// people, teams and projects. Hiring dates, birthdays and project dates are relative to Today.
+type SeedDemoOptions struct {
+	Seed     uint64
+	Users    int
+	Password string
+	Today    time.Time
+}
+
+type SeedDemoReport struct {
+	Users           int
+	Teams           int
+	Projects        int
+	UserSkills      int
+	TeamMemberships int
+	PositionChanges int
+}
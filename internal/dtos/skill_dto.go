@@ -8,3 +8,12 @@ type SkillSearchResponse struct {
 	Skills []SkillSummary     `json:"skills"`
 	Page   PaginationResponse `json:"page"`
 }
+
+// SkillHolder is a user who has a skill, with how well they know it
+type SkillHolder struct {
+	User           UserSummary  `json:"user"`
+	CurrentTeam    *TeamSummary `json:"current_team,omitempty"`
+	Skill          string       `json:"skill"`
+	Level          int          `json:"level"`
+	UsedYearNumber int          `json:"used_year_number"`
+}
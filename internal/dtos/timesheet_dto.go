@@ -0,0 +1,56 @@
+package dtos
+
+import (
+	"time"
+	"trieu_mock_project_go/types"
+)
+
+type TimeEntry struct {
+	ID          uint           `json:"id"`
+	Project     ProjectSummary `json:"project"`
+	WorkDate    types.Date     `json:"work_date"`
+	Hours       float64        `json:"hours"`
+	Description *string        `json:"description,omitempty"`
+}
+
+// Timesheet of a user for one week, ID is 0 while nothing has been logged for the week
+type Timesheet struct {
+	ID          uint         `json:"id"`
+	User        UserSummary  `json:"user"`
+	WeekStart   types.Date   `json:"week_start"`
+	WeekEnd     types.Date   `json:"week_end"`
+	Status      string       `json:"status"`
+	SubmittedAt *time.Time   `json:"submitted_at,omitempty"`
+	Reviewer    *UserSummary `json:"reviewer,omitempty"`
+	ReviewNote  *string      `json:"review_note,omitempty"`
+	ReviewedAt  *time.Time   `json:"reviewed_at,omitempty"`
+	TotalHours  float64      `json:"total_hours"`
+	Entries     []TimeEntry  `json:"entries"`
+}
+
+type GetTimesheetRequest struct {
+	Week time.Time `form:"week" time_format:"2006-01-02"`
+}
+
+type SaveTimeEntryRequest struct {
+	ProjectID   uint        `json:"project_id" binding:"required"`
+	WorkDate    *types.Date `json:"work_date" binding:"required"`
+	Hours       float64     `json:"hours" binding:"required,gt=0,lte=24"`
+	Description *string     `json:"description" binding:"omitempty,max=1000"`
+}
+
+type SubmitTimesheetRequest struct {
+	WeekStart *types.Date `json:"week_start" binding:"required"`
+}
+
+type ReviewTimesheetRequest struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
+}
+
+type PendingTimesheetsResponse struct {
+	Timesheets []Timesheet `json:"timesheets"`
+}
+
+type TimesheetProjectsResponse struct {
+	Projects []ProjectSummary `json:"projects"`
+}
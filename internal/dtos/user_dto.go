@@ -1,14 +1,19 @@
 package dtos
 
 import (
+	"time"
 	"trieu_mock_project_go/types"
 )
 
 type UserProfile struct {
-	ID       uint        `json:"id"`
-	Name     string      `json:"name"`
-	Email    string      `json:"email"`
-	Birthday *types.Date `json:"birthday"`
+	ID           uint        `json:"id"`
+	Name         string      `json:"name"`
+	Email        string      `json:"email"`
+	Birthday     *types.Date `json:"birthday"`
+	ShowBirthday bool        `json:"show_birthday"`
+	// Email preferences, only shown to the user themselves
+	EmailTeamChanges  *bool `json:"email_team_changes,omitempty"`
+	EmailLeaveReviews *bool `json:"email_leave_reviews,omitempty"`
 
 	CurrentTeam *TeamSummary       `json:"current_team,omitempty"`
 	Position    Position           `json:"position"`
@@ -16,6 +21,13 @@ type UserProfile struct {
 	Skills      []UserSkillSummary `json:"skills"`
 }
 
+// UpdateProfilePreferencesRequest changes the preferences that are set, at least one must be
+type UpdateProfilePreferencesRequest struct {
+	ShowBirthday      *bool `json:"show_birthday" binding:"required_without_all=EmailTeamChanges EmailLeaveReviews"`
+	EmailTeamChanges  *bool `json:"email_team_changes"`
+	EmailLeaveReviews *bool `json:"email_leave_reviews"`
+}
+
 type UserSearchRequest struct {
 	Name   *string `form:"name"`
 	TeamId *uint   `form:"team_id"`
@@ -42,6 +54,9 @@ type CreateOrUpdateUserRequest struct {
 	PositionID uint              `json:"position_id" binding:"required"`
 	TeamID     *uint             `json:"team_id"`
 	Skills     []UpdateUserSkill `json:"skills"`
+
+	// PositionEffectiveDate is recorded in the position history when PositionID changes, defaults to today
+	PositionEffectiveDate *types.Date `json:"position_effective_date"`
 }
 
 type UpdateUserSkill struct {
@@ -49,3 +64,14 @@ type UpdateUserSkill struct {
 	Level          int  `json:"level" binding:"required,min=1,max=10"`
 	UsedYearNumber int  `json:"used_year_number" binding:"min=0,max=100"`
 }
+
+type UserPositionHistory struct {
+	ID            uint             `json:"id"`
+	OldPosition   *PositionSummary `json:"old_position"`
+	NewPosition   PositionSummary  `json:"new_position"`
+	Team          *TeamSummary     `json:"team"`
+	IsPromotion   bool             `json:"is_promotion"`
+	EffectiveDate types.Date       `json:"effective_date"`
+	ChangedBy     *UserSummary     `json:"changed_by"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
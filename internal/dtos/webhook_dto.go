@@ -0,0 +1,60 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookSubscription struct {
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type CreateOrUpdateWebhookSubscriptionRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	URL        string   `json:"url" binding:"required,max=2048,url,startswith=http"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=user.created user.deleted team.created team.deleted team.member_added team.member_removed team.leader_changed"`
+	Active     bool     `json:"active"`
+}
+
+type WebhookDelivery struct {
+	ID             uint       `json:"id"`
+	SubscriptionID uint       `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at"`
+	ResponseStatus *int       `json:"response_status"`
+	ResponseBody   *string    `json:"response_body"`
+	Error          *string    `json:"error"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type WebhookDeliverySearchRequest struct {
+	Status *string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Limit  int     `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int     `form:"offset" binding:"min=0"`
+}
+
+type WebhookDeliverySearchResponse struct {
+	Deliveries []WebhookDelivery  `json:"deliveries"`
+	Page       PaginationResponse `json:"page"`
+}
+
+// WebhookEvent is the JSON body posted to a subscription
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
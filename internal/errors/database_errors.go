@@ -0,0 +1,156 @@
+package errors
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Kinds of constraint violations
+const (
+	ConstraintUnique     = "unique"
+	ConstraintForeignKey = "foreign_key"
+	ConstraintNotNull    = "not_null"
+	ConstraintCheck      = "check"
+)
+
+// ConstraintViolation is a write rejected by a constraint, described the same way for every database engine
+type ConstraintViolation struct {
+	Kind string
+	// Name of the constraint or unique index, SQLite does not report it
+	Name string
+	// Columns of the constraint, reported by PostgreSQL for unique keys and by SQLite
+	Columns []string
+}
+
+var (
+	// Duplicate entry '1-2' for key 'positions.ux_positions_career_track_grade'
+	mysqlKeyPattern = regexp.MustCompile(`for key '(?:[^.']+\.)?([^']+)'`)
+	// ... a foreign key constraint fails (`db`.`users`, CONSTRAINT `fk_users_position_id` FOREIGN KEY ...
+	mysqlConstraintPattern = regexp.MustCompile("CONSTRAINT `([^`]+)`")
+	// Key (career_track_id, grade)=(1, 2) already exists.
+	postgresKeyPattern = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+	// UNIQUE constraint failed: positions.career_track_id, positions.grade
+	sqliteColumnsPattern = regexp.MustCompile(`constraint failed: (\w+\.\w+(?:, \w+\.\w+)*)`)
+)
+
+// AsConstraintViolation reports whether err was raised by a constraint of MySQL, PostgreSQL or SQLite
+func AsConstraintViolation(err error) (*ConstraintViolation, bool) {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlConstraintViolation(mysqlErr)
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return postgresConstraintViolation(pgErr)
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteConstraintViolation(sqliteErr)
+	}
+	return nil, false
+}
+
+func IsDuplicatedEntryError(err error) bool {
+	violation, ok := AsConstraintViolation(err)
+	return ok && violation.Kind == ConstraintUnique
+}
+
+// IsDuplicatedEntryErrorOnKey reports whether err is a duplicate entry error raised by the named unique key.
+// SQLite only reports the columns of the key, they are compared instead there.
+func IsDuplicatedEntryErrorOnKey(err error, key string, columns ...string) bool {
+	violation, ok := AsConstraintViolation(err)
+	if !ok || violation.Kind != ConstraintUnique {
+		return false
+	}
+	if violation.Name != "" {
+		return violation.Name == key
+	}
+	return len(columns) > 0 && slices.Equal(violation.Columns, columns)
+}
+
+// IsForeignKeyViolationError reports whether err was raised by a missing or still referenced row
+func IsForeignKeyViolationError(err error) bool {
+	violation, ok := AsConstraintViolation(err)
+	return ok && violation.Kind == ConstraintForeignKey
+}
+
+func mysqlConstraintViolation(err *mysql.MySQLError) (*ConstraintViolation, bool) {
+	switch err.Number {
+	// ER_DUP_ENTRY, ER_DUP_ENTRY_WITH_KEY_NAME
+	case 1062, 1586:
+		violation := &ConstraintViolation{Kind: ConstraintUnique}
+		if match := mysqlKeyPattern.FindStringSubmatch(err.Message); match != nil {
+			violation.Name = match[1]
+		}
+		return violation, true
+	// ER_ROW_IS_REFERENCED_2, ER_NO_REFERENCED_ROW_2
+	case 1451, 1452:
+		violation := &ConstraintViolation{Kind: ConstraintForeignKey}
+		if match := mysqlConstraintPattern.FindStringSubmatch(err.Message); match != nil {
+			violation.Name = match[1]
+		}
+		return violation, true
+	// ER_BAD_NULL_ERROR
+	case 1048:
+		return &ConstraintViolation{Kind: ConstraintNotNull}, true
+	// ER_CHECK_CONSTRAINT_VIOLATED
+	case 3819:
+		return &ConstraintViolation{Kind: ConstraintCheck}, true
+	default:
+		return nil, false
+	}
+}
+
+func postgresConstraintViolation(err *pgconn.PgError) (*ConstraintViolation, bool) {
+	violation := &ConstraintViolation{Name: err.ConstraintName}
+	switch err.Code {
+	case "23505":
+		violation.Kind = ConstraintUnique
+		if match := postgresKeyPattern.FindStringSubmatch(err.Detail); match != nil {
+			violation.Columns = strings.Split(match[1], ", ")
+		}
+	case "23503":
+		violation.Kind = ConstraintForeignKey
+	case "23502":
+		violation.Kind = ConstraintNotNull
+		violation.Columns = []string{err.ColumnName}
+	case "23514":
+		violation.Kind = ConstraintCheck
+	default:
+		return nil, false
+	}
+	return violation, true
+}
+
+func sqliteConstraintViolation(err *sqlite.Error) (*ConstraintViolation, bool) {
+	violation := &ConstraintViolation{}
+	switch err.Code() {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		violation.Kind = ConstraintUnique
+	case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		violation.Kind = ConstraintForeignKey
+	case sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+		violation.Kind = ConstraintNotNull
+	case sqlite3.SQLITE_CONSTRAINT_CHECK:
+		violation.Kind = ConstraintCheck
+	default:
+		return nil, false
+	}
+	// Columns are reported as table.column
+	if violation.Kind != ConstraintCheck {
+		if match := sqliteColumnsPattern.FindStringSubmatch(err.Error()); match != nil {
+			for _, column := range strings.Split(match[1], ", ") {
+				_, name, _ := strings.Cut(column, ".")
+				violation.Columns = append(violation.Columns, name)
+			}
+		}
+	}
+	return violation, true
+}
@@ -1,12 +1,13 @@
 package errors
 
 import (
-	"errors"
+	"log/slog"
 	"net/http"
+	"time"
+	"trieu_mock_project_go/internal/logging"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/go-sql-driver/mysql"
 )
 
 type AppError struct {
@@ -22,6 +23,16 @@ func NewAppError(status int, message string) *AppError {
 	return &AppError{Status: status, Message: message}
 }
 
+// RetryAfterError is an AppError for a request that may be retried once RetryAfter has passed
+type RetryAfterError struct {
+	*AppError
+	RetryAfter time.Duration
+}
+
+func NewRetryAfterError(appErr *AppError, retryAfter time.Duration) *RetryAfterError {
+	return &RetryAfterError{AppError: appErr, RetryAfter: retryAfter}
+}
+
 // Errors definitions
 var (
 	ErrInternalServerError             = NewAppError(http.StatusInternalServerError, "internal server error")
@@ -31,12 +42,46 @@ var (
 	ErrMissingAuthHeader               = NewAppError(http.StatusUnauthorized, "missing authorization header")
 	ErrInvalidAuthHeader               = NewAppError(http.StatusUnauthorized, "invalid authorization header format")
 	ErrInvalidToken                    = NewAppError(http.StatusUnauthorized, "invalid or expired token")
+	ErrTooManyLoginAttempts            = NewAppError(http.StatusTooManyRequests, "too many failed login attempts, please try again later")
+	ErrAccountLocked                   = NewAppError(http.StatusTooManyRequests, "account is temporarily locked after too many failed login attempts")
+	ErrTwoFactorAlreadyEnabled         = NewAppError(http.StatusConflict, "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled             = NewAppError(http.StatusBadRequest, "two-factor authentication is not enabled")
+	ErrTwoFactorSetupNotStarted        = NewAppError(http.StatusBadRequest, "two-factor setup has not been started")
+	ErrTwoFactorRequired               = NewAppError(http.StatusBadRequest, "two-factor authentication is required for this account and cannot be disabled")
+	ErrInvalidTwoFactorCode            = NewAppError(http.StatusBadRequest, "invalid two-factor code")
+	ErrInvalidTwoFactorChallenge       = NewAppError(http.StatusUnauthorized, "invalid or expired two-factor challenge")
+	ErrIncorrectPassword               = NewAppError(http.StatusBadRequest, "current password is incorrect")
+	ErrSessionNotFound                 = NewAppError(http.StatusNotFound, "session not found")
+	ErrCannotRevokeCurrentSession      = NewAppError(http.StatusBadRequest, "the current session cannot be revoked, log out instead")
+	ErrSSODisabled                     = NewAppError(http.StatusNotFound, "single sign-on is not enabled")
+	ErrSSOFailed                       = NewAppError(http.StatusUnauthorized, "single sign-on failed, please try again")
+	ErrSSONoAccount                    = NewAppError(http.StatusForbidden, "no account is linked to this identity, contact an administrator")
+	ErrAccountDeactivated              = NewAppError(http.StatusForbidden, "account has been deactivated")
+	ErrLDAPSyncInProgress              = NewAppError(http.StatusConflict, "a directory sync is already running")
+	ErrLDAPSyncFailed                  = NewAppError(http.StatusBadGateway, "directory sync failed, see the sync report for details")
+	ErrLDAPSyncRunNotFound             = NewAppError(http.StatusNotFound, "directory sync run not found")
+	ErrDemoDataAlreadySeeded           = NewAppError(http.StatusConflict, "the demo dataset has already been seeded")
+	ErrAPITokenNotFound                = NewAppError(http.StatusNotFound, "API token not found")
+	ErrAPITokenScopeNotAllowed         = NewAppError(http.StatusForbidden, "only admins can create tokens with the admin:write scope")
+	ErrAPITokenLimitReached            = NewAppError(http.StatusBadRequest, "the maximum number of API tokens has been reached, revoke one first")
+	ErrAPITokenInsufficientScope       = NewAppError(http.StatusForbidden, "API token does not have the scope required for this request")
+	ErrWebhookSubscriptionNotFound     = NewAppError(http.StatusNotFound, "webhook subscription not found")
+	ErrWebhookDeliveryNotFound         = NewAppError(http.StatusNotFound, "webhook delivery not found")
+	ErrInvalidPasswordResetToken       = NewAppError(http.StatusBadRequest, "password reset link is invalid or has expired")
 	ErrUnexpectedSigningMethod         = NewAppError(http.StatusUnauthorized, "unexpected signing method")
 	ErrEmailAlreadyExists              = NewAppError(http.StatusConflict, "email already exists")
 	ErrUserNotFound                    = NewAppError(http.StatusNotFound, "user not found")
 	ErrPositionNotFound                = NewAppError(http.StatusNotFound, "position not found")
 	ErrPositionAlreadyExists           = NewAppError(http.StatusConflict, "position with name already exists")
-	ErrPositionInUse                   = NewAppError(http.StatusBadRequest, "position is assigned to one or more users")
+	ErrPositionInUse                   = NewAppError(http.StatusBadRequest, "position is assigned to one or more users or recorded in their position history")
+	ErrPositionGradeAlreadyExists      = NewAppError(http.StatusConflict, "another position in the career track already has this grade")
+	ErrPositionCareerTrackGradeInvalid = NewAppError(http.StatusBadRequest, "career track and grade must be set together")
+	ErrPositionCareerTrackInvalid      = NewAppError(http.StatusBadRequest, "career track does not exist")
+	ErrPositionRequiredSkillInvalid    = NewAppError(http.StatusBadRequest, "required skill does not exist")
+	ErrPositionRequiredSkillDuplicated = NewAppError(http.StatusBadRequest, "a skill can only be required once")
+	ErrCareerTrackNotFound             = NewAppError(http.StatusNotFound, "career track not found")
+	ErrCareerTrackAlreadyExists        = NewAppError(http.StatusConflict, "career track with name already exists")
+	ErrCareerTrackInUse                = NewAppError(http.StatusBadRequest, "career track has one or more positions")
 	ErrSkillNotFound                   = NewAppError(http.StatusNotFound, "skill not found")
 	ErrSkillAlreadyExists              = NewAppError(http.StatusConflict, "skill with name already exists")
 	ErrSkillInUse                      = NewAppError(http.StatusBadRequest, "skill is assigned to one or more users")
@@ -46,14 +91,36 @@ var (
 	ErrUserAlreadyInTeam               = NewAppError(http.StatusBadRequest, "user is already a member of the team")
 	ErrUserNotInTeam                   = NewAppError(http.StatusBadRequest, "user is not a member of the team")
 	ErrCannotRemoveOrMoveTeamLeader    = NewAppError(http.StatusBadRequest, "cannot remove or move the team leader from the team")
+	ErrNotificationNotFound            = NewAppError(http.StatusNotFound, "notification not found")
+	ErrLeaveRequestNotFound            = NewAppError(http.StatusNotFound, "leave request not found")
+	ErrLeaveDateRangeInvalid           = NewAppError(http.StatusBadRequest, "leave end date must not be before start date")
+	ErrLeaveHalfDayInvalid             = NewAppError(http.StatusBadRequest, "a single day leave can only be half a day once")
+	ErrLeaveNoWorkingDays              = NewAppError(http.StatusBadRequest, "leave does not cover any working day")
+	ErrLeaveOverlaps                   = NewAppError(http.StatusConflict, "leave overlaps another pending or approved leave")
+	ErrLeaveUserNotInTeam              = NewAppError(http.StatusBadRequest, "user must belong to a team to request leave")
+	ErrLeaveNotPending                 = NewAppError(http.StatusBadRequest, "leave request is not pending")
+	ErrLeaveCannotBeCancelled          = NewAppError(http.StatusBadRequest, "only pending or upcoming approved leave can be cancelled")
+	ErrLeaveDateRangeTooLong           = NewAppError(http.StatusBadRequest, "date range must not exceed 366 days")
+	ErrProjectNotFound                 = NewAppError(http.StatusNotFound, "project not found")
+	ErrTimesheetNotFound               = NewAppError(http.StatusNotFound, "timesheet not found")
+	ErrTimeEntryNotFound               = NewAppError(http.StatusNotFound, "time entry not found")
+	ErrTimesheetNotEditable            = NewAppError(http.StatusBadRequest, "timesheet has been submitted and can no longer be edited")
+	ErrTimesheetNotSubmitted           = NewAppError(http.StatusBadRequest, "timesheet is not submitted")
+	ErrTimesheetEmpty                  = NewAppError(http.StatusBadRequest, "timesheet has no time entries")
+	ErrTimesheetUserNotInTeam          = NewAppError(http.StatusBadRequest, "user must belong to a team to submit a timesheet")
+	ErrTimeEntryNotProjectMember       = NewAppError(http.StatusForbidden, "user is not a member of the project")
+	ErrTimeEntryOutsideProjectDates    = NewAppError(http.StatusBadRequest, "work date is outside of the project dates")
+	ErrTimeEntryFutureDate             = NewAppError(http.StatusBadRequest, "cannot log time for a future date")
+	ErrTimeEntryDailyHoursExceeded     = NewAppError(http.StatusBadRequest, "total hours logged on a day cannot exceed 24")
 	ErrCannotDeleteUserBeingTeamLeader = NewAppError(http.StatusBadRequest, "user cannot be deleted because they are a team leader")
 )
 
-// Error response
+// Error response, RequestID matches the X-Request-ID header and the logs of the request
 type APIErrorResponse struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Details interface{} `json:"details,omitempty"`
+	Code      int         `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
 func RespondError(
@@ -67,9 +134,10 @@ func RespondError(
 		detail = details[0]
 	}
 	c.JSON(status, APIErrorResponse{
-		Code:    status,
-		Message: message,
-		Details: detail,
+		Code:      status,
+		Message:   message,
+		Details:   detail,
+		RequestID: logging.RequestIDFromContext(c.Request.Context()),
 	})
 }
 
@@ -79,6 +147,8 @@ func RespondCustomError(c *gin.Context, err error, defaultMessage string) {
 		return
 	}
 
+	// The client only gets the default message, the cause is in the log of the request
+	slog.ErrorContext(c.Request.Context(), defaultMessage, "error", err)
 	RespondError(c, http.StatusInternalServerError, defaultMessage)
 }
 
@@ -135,11 +205,3 @@ func HandleBindError(c *gin.Context, err error) bool {
 	)
 	return true
 }
-
-func IsDuplicatedEntryError(err error) bool {
-	var mysqlErr *mysql.MySQLError
-	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
-		return true
-	}
-	return false
-}
@@ -0,0 +1,28 @@
+package fakes
+
+import (
+	"trieu_mock_project_go/internal/services"
+
+	"gorm.io/gorm"
+)
+
+// Event is a domain event recorded through OutboxService
+type Event struct {
+	Type string
+	Data interface{}
+}
+
+// OutboxService records domain events in Store.Events instead of the outbox, nothing is dispatched
+type OutboxService struct {
+	services.OutboxService
+	store *Store
+}
+
+func NewOutboxService(store *Store) *OutboxService {
+	return &OutboxService{store: store}
+}
+
+func (s *OutboxService) Record(_ *gorm.DB, eventType string, data interface{}) error {
+	s.store.Events = append(s.store.Events, Event{Type: eventType, Data: data})
+	return nil
+}
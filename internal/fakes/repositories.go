@@ -0,0 +1,142 @@
+package fakes
+
+import (
+	"fmt"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// duplicateEntry is the error MySQL returns for a duplicate key, so services classify it like a real one
+func duplicateEntry(value, key string) error {
+	return &mysql.MySQLError{Number: 1062, Message: fmt.Sprintf("Duplicate entry '%s' for key '%s'", value, key)}
+}
+
+// TeamsRepository keeps teams in Store.Teams, names are unique
+type TeamsRepository struct {
+	repositories.TeamsRepository
+	store *Store
+}
+
+func NewTeamsRepository(store *Store) *TeamsRepository {
+	return &TeamsRepository{store: store}
+}
+
+func (r *TeamsRepository) FindByID(_ *gorm.DB, id uint) (*models.Team, error) {
+	team, ok := r.store.Teams[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &team, nil
+}
+
+func (r *TeamsRepository) Create(_ *gorm.DB, team *models.Team) error {
+	if err := r.checkName(0, team.Name); err != nil {
+		return err
+	}
+	team.ID = r.store.nextID()
+	r.store.Teams[team.ID] = *team
+	return nil
+}
+
+func (r *TeamsRepository) Update(_ *gorm.DB, team *models.Team) error {
+	existing, ok := r.store.Teams[team.ID]
+	if !ok {
+		return nil
+	}
+	if err := r.checkName(team.ID, team.Name); err != nil {
+		return err
+	}
+	existing.Name = team.Name
+	existing.Description = team.Description
+	existing.LeaderID = team.LeaderID
+	r.store.Teams[team.ID] = existing
+	return nil
+}
+
+func (r *TeamsRepository) checkName(id uint, name string) error {
+	for _, team := range r.store.Teams {
+		if team.ID != id && team.Name == name {
+			return duplicateEntry(name, "teams.name")
+		}
+	}
+	return nil
+}
+
+// UserRepository keeps users in Store.Users
+type UserRepository struct {
+	repositories.UserRepository
+	store *Store
+}
+
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{store: store}
+}
+
+func (r *UserRepository) FindByID(_ *gorm.DB, id uint) (*models.User, error) {
+	user, ok := r.store.Users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) CreateUser(_ *gorm.DB, user *models.User) error {
+	user.ID = r.store.nextID()
+	r.store.Users[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) UpdateUser(_ *gorm.DB, user *models.User) error {
+	existing, ok := r.store.Users[user.ID]
+	if !ok {
+		return nil
+	}
+	existing.Name = user.Name
+	existing.Email = user.Email
+	existing.Birthday = user.Birthday
+	existing.CurrentTeamID = user.CurrentTeamID
+	existing.PositionID = user.PositionID
+	existing.Role = user.Role
+	r.store.Users[user.ID] = existing
+	return nil
+}
+
+// TeamMemberRepository keeps memberships in Store.TeamMembers, a user has one membership without LeftAt at most
+type TeamMemberRepository struct {
+	repositories.TeamMemberRepository
+	store *Store
+}
+
+func NewTeamMemberRepository(store *Store) *TeamMemberRepository {
+	return &TeamMemberRepository{store: store}
+}
+
+func (r *TeamMemberRepository) FindActiveMemberByUserID(_ *gorm.DB, userID uint) (*models.TeamMember, error) {
+	for _, member := range r.store.TeamMembers {
+		if member.UserID == userID && member.LeftAt == nil {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *TeamMemberRepository) Create(db *gorm.DB, member *models.TeamMember) error {
+	if member.LeftAt == nil {
+		if active, _ := r.FindActiveMemberByUserID(db, member.UserID); active != nil {
+			return duplicateEntry(fmt.Sprint(member.UserID), "team_members.ux_active_user_in_team")
+		}
+	}
+	member.ID = r.store.nextID()
+	r.store.TeamMembers[member.ID] = *member
+	return nil
+}
+
+func (r *TeamMemberRepository) Update(_ *gorm.DB, member *models.TeamMember) error {
+	if _, ok := r.store.TeamMembers[member.ID]; ok {
+		r.store.TeamMembers[member.ID] = *member
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package fakes has in-memory repositories and services to unit test services without a database.
+//
+// Fakes embed the interface they replace and only implement the methods tests have needed so far. The
+// embedded interface is nil, so calling any other method panics and tells which one to add.
+package fakes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"sync"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// ErrNoDatabase is returned by statements sent to the DB of a Store, services under test may only reach
+// the data through repositories
+var ErrNoDatabase = errors.New("fakes: the fake database runs no SQL, use a fake repository")
+
+// Store holds the rows of the fake repositories. Transactions on its DB copy the rows when they begin and
+// put the copy back on rollback, so a service call that fails leaves the rows as they were.
+type Store struct {
+	mu          sync.Mutex
+	Teams       map[uint]models.Team
+	Users       map[uint]models.User
+	TeamMembers map[uint]models.TeamMember
+	// Domain events recorded through OutboxService, in order
+	Events []Event
+
+	lastID    uint
+	snapshots []storeSnapshot
+	db        *gorm.DB
+}
+
+type storeSnapshot struct {
+	teams       map[uint]models.Team
+	users       map[uint]models.User
+	teamMembers map[uint]models.TeamMember
+	events      []Event
+}
+
+func NewStore() *Store {
+	store := &Store{
+		Teams:       map[uint]models.Team{},
+		Users:       map[uint]models.User{},
+		TeamMembers: map[uint]models.TeamMember{},
+	}
+	db, err := gorm.Open(dialector{store: store}, &gorm.Config{
+		Logger:                 logger.Default.LogMode(logger.Silent),
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	store.db = db
+	return store
+}
+
+// DB is the database to construct services with, it only supports transactions
+func (s *Store) DB() *gorm.DB {
+	return s.db
+}
+
+// EventTypes returns the types of the recorded events, in order
+func (s *Store) EventTypes() []string {
+	types := make([]string, 0, len(s.Events))
+	for _, event := range s.Events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// nextID returns a new primary key, unique across all the rows of the store
+func (s *Store) nextID() uint {
+	s.lastID++
+	return s.lastID
+}
+
+func (s *Store) begin() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots = append(s.snapshots, storeSnapshot{
+		teams:       maps.Clone(s.Teams),
+		users:       maps.Clone(s.Users),
+		teamMembers: maps.Clone(s.TeamMembers),
+		events:      append([]Event(nil), s.Events...),
+	})
+}
+
+func (s *Store) end(commit bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last := s.snapshots[len(s.snapshots)-1]
+	s.snapshots = s.snapshots[:len(s.snapshots)-1]
+	if commit {
+		return
+	}
+	s.Teams, s.Users, s.TeamMembers, s.Events = last.teams, last.users, last.teamMembers, last.events
+}
+
+// dialector opens a gorm.DB on connPool, only what gorm needs to run transactions is implemented
+type dialector struct {
+	store *Store
+}
+
+func (d dialector) Name() string {
+	return "fake"
+}
+
+func (d dialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	db.ConnPool = &connPool{store: d.store}
+	return nil
+}
+
+func (d dialector) Migrator(*gorm.DB) gorm.Migrator {
+	return nil
+}
+
+func (d dialector) DataTypeOf(*schema.Field) string {
+	return ""
+}
+
+func (d dialector) DefaultValueOf(*schema.Field) clause.Expression {
+	return clause.Expr{SQL: "DEFAULT"}
+}
+
+func (d dialector) BindVarTo(writer clause.Writer, _ *gorm.Statement, _ interface{}) {
+	writer.WriteByte('?')
+}
+
+func (d dialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteString(str)
+}
+
+func (d dialector) Explain(sql string, _ ...interface{}) string {
+	return sql
+}
+
+// connPool fails every statement and begins transactions on the store
+type connPool struct {
+	store *Store
+}
+
+func (p *connPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, ErrNoDatabase
+}
+
+func (p *connPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, ErrNoDatabase
+}
+
+func (p *connPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, ErrNoDatabase
+}
+
+func (p *connPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	panic(ErrNoDatabase)
+}
+
+func (p *connPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	p.store.begin()
+	return &txPool{connPool: p}, nil
+}
+
+type txPool struct {
+	*connPool
+}
+
+func (t *txPool) Commit() error {
+	t.store.end(true)
+	return nil
+}
+
+func (t *txPool) Rollback() error {
+	t.store.end(false)
+	return nil
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminActivityLogHandler struct {
+	activityLogService services.ActivityLogService
+}
+
+func NewAdminActivityLogHandler(activityLogService services.ActivityLogService) *AdminActivityLogHandler {
+	return &AdminActivityLogHandler{activityLogService: activityLogService}
+}
+
+func (h *AdminActivityLogHandler) ActivityLogPage(c *gin.Context) {
+	templateName := "pages/admin_activity_log.html"
+	var query dtos.ActivityLogSearchRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+	// The action filter submits an empty value for all actions
+	if query.Action != nil && *query.Action == "" {
+		query.Action = nil
+	}
+
+	resp, err := h.activityLogService.SearchActivityLogs(c.Request.Context(), query)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load the activity log")
+		return
+	}
+
+	action := ""
+	if query.Action != nil {
+		action = *query.Action
+	}
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":        "Activity Log",
+		"action":       action,
+		"actions":      models.DomainEventTypes,
+		"query":        query,
+		"activityLogs": resp.ActivityLogs,
+		"page":         resp.Page,
+		"hasPrev":      query.Offset > 0,
+		"prevOffset":   max(query.Offset-query.Limit, 0),
+		"hasNext":      int64(query.Offset+query.Limit) < resp.Page.Total,
+		"nextOffset":   query.Offset + query.Limit,
+	})
+}
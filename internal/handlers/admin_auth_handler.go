@@ -2,45 +2,181 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	csrf "github.com/utrack/gin-csrf"
 )
 
+// How long a password check stays valid while the admin enters their second factor
+const adminPendingLoginTTL = 5 * time.Minute
+
 type AdminAuthHandler struct {
-	authService *services.AuthService
+	authService      services.AuthService
+	twoFactorService services.TwoFactorService
+	oidcService      services.OIDCService
 }
 
-func NewAdminAuthHandler(authService *services.AuthService) *AdminAuthHandler {
-	return &AdminAuthHandler{authService: authService}
+func NewAdminAuthHandler(
+	authService services.AuthService,
+	twoFactorService services.TwoFactorService,
+	oidcService services.OIDCService) *AdminAuthHandler {
+	return &AdminAuthHandler{authService: authService, twoFactorService: twoFactorService, oidcService: oidcService}
 }
 
 func (h *AdminAuthHandler) AdminShowLogin(c *gin.Context) {
-	c.HTML(http.StatusOK, "pages/admin_login.html", gin.H{
-		"title":     "Admin Login",
-		"csrfToken": csrf.GetToken(c),
-	})
+	h.renderLogin(c, http.StatusOK, "")
 }
 
 func (h *AdminAuthHandler) AdminLogin(c *gin.Context) {
 	email := c.PostForm("email")
 	password := c.PostForm("password")
 
-	user, err := h.authService.Login(c.Request.Context(), email, password)
-	if err != nil || user.Role != "admin" {
-		c.HTML(http.StatusUnauthorized, "pages/admin_login.html", gin.H{
-			"title":     "Admin Login",
-			"error":     "Invalid email or password, or not an admin",
+	result, err := h.authService.Login(c.Request.Context(), email, password, adminLoginMeta(c))
+	if retryErr, ok := setRetryAfter(c, err); ok {
+		h.renderLogin(c, retryErr.Status, "Too many failed login attempts, please try again later")
+		return
+	}
+	if err != nil {
+		h.renderLogin(c, http.StatusUnauthorized, "Invalid email or password, or not an admin")
+		return
+	}
+
+	h.continueLogin(c, result)
+}
+
+// AdminOIDCLogin sends the browser to the OpenID Connect provider
+func (h *AdminAuthHandler) AdminOIDCLogin(c *gin.Context) {
+	if err := beginOIDCLogin(c, h.oidcService, models.LoginFlowAdmin, "/admin/login/oidc/callback"); err != nil {
+		h.renderLogin(c, http.StatusBadRequest, ssoErrorMessage(err))
+	}
+}
+
+// AdminOIDCCallback completes the sign-on when the provider sends the browser back,
+// the second factor still applies like after a password login
+func (h *AdminAuthHandler) AdminOIDCCallback(c *gin.Context) {
+	result, err := finishOIDCLogin(c, h.oidcService, "/admin/login/oidc/callback", adminLoginMeta(c))
+	if retryErr, ok := setRetryAfter(c, err); ok {
+		h.renderLogin(c, retryErr.Status, "Too many failed login attempts, please try again later")
+		return
+	}
+	if err != nil {
+		h.renderLogin(c, http.StatusUnauthorized, ssoErrorMessage(err))
+		return
+	}
+
+	h.continueLogin(c, result)
+}
+
+func (h *AdminAuthHandler) AdminShowTwoFactor(c *gin.Context) {
+	if _, ok := pendingAdminLogin(c, services.SecondFactorVerify); !ok {
+		c.Redirect(http.StatusSeeOther, "/admin/login")
+		return
+	}
+
+	c.HTML(http.StatusOK, "pages/admin_login_2fa.html", gin.H{
+		"title":     "Two-Factor Authentication",
+		"csrfToken": csrf.GetToken(c),
+	})
+}
+
+func (h *AdminAuthHandler) AdminVerifyTwoFactor(c *gin.Context) {
+	templateName := "pages/admin_login_2fa.html"
+	userID, ok := pendingAdminLogin(c, services.SecondFactorVerify)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/admin/login")
+		return
+	}
+
+	user, err := h.authService.CompleteSecondFactor(c.Request.Context(), userID, c.PostForm("code"), adminLoginMeta(c))
+	if retryErr, ok := setRetryAfter(c, err); ok {
+		c.HTML(retryErr.Status, templateName, gin.H{
+			"title":     "Two-Factor Authentication",
+			"error":     "Too many failed login attempts, please try again later",
+			"csrfToken": csrf.GetToken(c),
+		})
+		return
+	}
+	if err != nil {
+		c.HTML(http.StatusUnauthorized, templateName, gin.H{
+			"title":     "Two-Factor Authentication",
+			"error":     "Invalid authentication or recovery code",
 			"csrfToken": csrf.GetToken(c),
 		})
 		return
 	}
 
+	if !h.startAdminSession(c, user, templateName) {
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/admin")
+}
+
+func (h *AdminAuthHandler) AdminShowTwoFactorSetup(c *gin.Context) {
+	templateName := "pages/admin_login_2fa_setup.html"
+	userID, ok := pendingAdminLogin(c, services.SecondFactorSetup)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/admin/login")
+		return
+	}
+
+	setup, err := h.twoFactorService.BeginSetup(c.Request.Context(), userID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, templateName, gin.H{
+			"title": "Set Up Two-Factor Authentication",
+			"error": "Failed to start two-factor setup",
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":     "Set Up Two-Factor Authentication",
+		"setup":     setup,
+		"csrfToken": csrf.GetToken(c),
+	})
+}
+
+func (h *AdminAuthHandler) AdminCompleteTwoFactorSetup(c *gin.Context) {
+	templateName := "pages/admin_login_2fa_setup.html"
+	userID, ok := pendingAdminLogin(c, services.SecondFactorSetup)
+	if !ok {
+		c.Redirect(http.StatusSeeOther, "/admin/login")
+		return
+	}
+
+	user, recoveryCodes, err := h.authService.CompleteTwoFactorSetup(c.Request.Context(), userID, c.PostForm("code"), adminLoginMeta(c))
+	if retryErr, ok := setRetryAfter(c, err); ok {
+		c.HTML(retryErr.Status, templateName, gin.H{
+			"title": "Set Up Two-Factor Authentication",
+			"error": "Too many failed login attempts, please try again later",
+		})
+		return
+	}
+	if err != nil {
+		// The secret stays pending, a fresh one is generated when the setup page is opened again
+		c.HTML(http.StatusUnauthorized, templateName, gin.H{
+			"title":     "Set Up Two-Factor Authentication",
+			"error":     "Invalid authentication code, scan the new QR code and try again",
+			"retryLink": "/admin/login/2fa/setup",
+		})
+		return
+	}
+
+	if !h.startAdminSession(c, user, templateName) {
+		return
+	}
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":         "Set Up Two-Factor Authentication",
+		"recoveryCodes": recoveryCodes,
+	})
+}
+
+func (h *AdminAuthHandler) AdminLogout(c *gin.Context) {
 	session := sessions.Default(c)
-	session.Set("user_id", user.ID)
-	session.Set("role", user.Role)
+	session.Clear()
 	if err := session.Save(); err != nil {
 		c.HTML(http.StatusInternalServerError, "pages/admin_login.html", gin.H{
 			"title":     "Admin Login",
@@ -49,20 +185,85 @@ func (h *AdminAuthHandler) AdminLogin(c *gin.Context) {
 		})
 		return
 	}
+	c.Redirect(http.StatusSeeOther, "/admin/login")
+}
+
+// continueLogin signs the admin in, or sends them to the second factor step first
+func (h *AdminAuthHandler) continueLogin(c *gin.Context, result *services.LoginResult) {
+	if result.SecondFactor != "" {
+		// The admin is not signed in until the second factor is verified
+		session := sessions.Default(c)
+		session.Clear()
+		session.Set("pending_user_id", result.User.ID)
+		session.Set("pending_second_factor", result.SecondFactor)
+		session.Set("pending_at", time.Now().Unix())
+		if err := session.Save(); err != nil {
+			h.renderLogin(c, http.StatusInternalServerError, "Failed to save session")
+			return
+		}
+
+		if result.SecondFactor == services.SecondFactorSetup {
+			c.Redirect(http.StatusSeeOther, "/admin/login/2fa/setup")
+			return
+		}
+		c.Redirect(http.StatusSeeOther, "/admin/login/2fa")
+		return
+	}
 
+	if !h.startAdminSession(c, result.User, "pages/admin_login.html") {
+		return
+	}
 	c.Redirect(http.StatusSeeOther, "/admin")
 }
 
-func (h *AdminAuthHandler) AdminLogout(c *gin.Context) {
+func (h *AdminAuthHandler) renderLogin(c *gin.Context, status int, errorMessage string) {
+	data := gin.H{
+		"title":           "Admin Login",
+		"csrfToken":       csrf.GetToken(c),
+		"ssoEnabled":      h.oidcService.Enabled(),
+		"ssoProviderName": h.oidcService.ProviderName(),
+	}
+	if errorMessage != "" {
+		data["error"] = errorMessage
+	}
+	c.HTML(status, "pages/admin_login.html", data)
+}
+
+// startAdminSession signs the admin in, replacing any pending second factor state
+func (h *AdminAuthHandler) startAdminSession(c *gin.Context, user *models.User, templateName string) bool {
 	session := sessions.Default(c)
 	session.Clear()
+	session.Set("user_id", user.ID)
+	session.Set("role", user.Role)
 	if err := session.Save(); err != nil {
-		c.HTML(http.StatusInternalServerError, "pages/admin_login.html", gin.H{
+		c.HTML(http.StatusInternalServerError, templateName, gin.H{
 			"title":     "Admin Login",
 			"error":     "Failed to save session",
 			"csrfToken": csrf.GetToken(c),
 		})
-		return
+		return false
+	}
+	return true
+}
+
+// pendingAdminLogin returns the admin who passed the password check and still needs the second factor
+func pendingAdminLogin(c *gin.Context, secondFactor string) (uint, bool) {
+	session := sessions.Default(c)
+	userID, ok := session.Get("pending_user_id").(uint)
+	if !ok || session.Get("pending_second_factor") != secondFactor {
+		return 0, false
+	}
+	pendingAt, ok := session.Get("pending_at").(int64)
+	if !ok || time.Since(time.Unix(pendingAt, 0)) > adminPendingLoginTTL {
+		return 0, false
+	}
+	return userID, true
+}
+
+func adminLoginMeta(c *gin.Context) services.LoginMeta {
+	return services.LoginMeta{
+		Flow:      models.LoginFlowAdmin,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
 	}
-	c.Redirect(http.StatusSeeOther, "/admin/login")
 }
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+	csrf "github.com/utrack/gin-csrf"
+)
+
+type AdminCareerTrackHandler struct {
+	careerTrackService services.CareerTrackService
+}
+
+func NewAdminCareerTrackHandler(careerTrackService services.CareerTrackService) *AdminCareerTrackHandler {
+	return &AdminCareerTrackHandler{careerTrackService: careerTrackService}
+}
+
+func (h *AdminCareerTrackHandler) ListCareerTrackPage(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/admin_career_tracks.html", gin.H{
+		"title":     "Admin Career Tracks Management",
+		"csrfToken": csrf.GetToken(c),
+	})
+}
+
+func (h *AdminCareerTrackHandler) CareerTrackSearchPartial(c *gin.Context) {
+	templateName := "partials/admin_career_tracks_search.html"
+	var requestQuery dtos.PaginationRequestQuery
+	if err := c.ShouldBindQuery(&requestQuery); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+
+	resp, err := h.careerTrackService.SearchCareerTracks(c.Request.Context(), requestQuery.Limit, requestQuery.Offset)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load career tracks")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"careerTracks": resp.CareerTracks,
+		"page":         resp.Page,
+	})
+}
+
+func (h *AdminCareerTrackHandler) CreateCareerTrackPage(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/admin_career_track_create.html", gin.H{
+		"title":     "Create Career Track",
+		"csrfToken": csrf.GetToken(c),
+	})
+}
+
+func (h *AdminCareerTrackHandler) CreateCareerTrack(c *gin.Context) {
+	var request dtos.CreateOrUpdateCareerTrackRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	if err := h.careerTrackService.CreateCareerTrack(c.Request.Context(), request); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to create career track")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Career track created successfully"})
+}
+
+func (h *AdminCareerTrackHandler) EditCareerTrackPage(c *gin.Context) {
+	templateName := "pages/admin_career_track_edit.html"
+	careerTrackIdParam := c.Param("careerTrackId")
+	careerTrackId, err := strconv.Atoi(careerTrackIdParam)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid career track ID")
+		return
+	}
+
+	careerTrack, err := h.careerTrackService.GetCareerTrackByID(c.Request.Context(), uint(careerTrackId))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load career track")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":       "Edit Career Track",
+		"careerTrack": careerTrack,
+		"csrfToken":   csrf.GetToken(c),
+	})
+}
+
+func (h *AdminCareerTrackHandler) UpdateCareerTrack(c *gin.Context) {
+	careerTrackIdParam := c.Param("careerTrackId")
+	careerTrackId, err := strconv.Atoi(careerTrackIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid career track ID")
+		return
+	}
+
+	var request dtos.CreateOrUpdateCareerTrackRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	if err := h.careerTrackService.UpdateCareerTrack(c.Request.Context(), uint(careerTrackId), request); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to update career track")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Career track updated successfully"})
+}
+
+func (h *AdminCareerTrackHandler) DeleteCareerTrack(c *gin.Context) {
+	careerTrackIdParam := c.Param("careerTrackId")
+	careerTrackId, err := strconv.Atoi(careerTrackIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid career track ID")
+		return
+	}
+
+	if err := h.careerTrackService.DeleteCareerTrack(c.Request.Context(), uint(careerTrackId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to delete career track")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Career track deleted successfully"})
+}
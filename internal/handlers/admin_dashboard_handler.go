@@ -8,10 +8,10 @@ import (
 )
 
 type AdminDashboardHandler struct {
-	userService *services.UserService
+	userService services.UserService
 }
 
-func NewAdminDashboardHandler(userService *services.UserService) *AdminDashboardHandler {
+func NewAdminDashboardHandler(userService services.UserService) *AdminDashboardHandler {
 	return &AdminDashboardHandler{
 		userService: userService,
 	}
@@ -21,4 +21,4 @@ func (h *AdminDashboardHandler) AdminDashboardPage(c *gin.Context) {
 	c.HTML(http.StatusOK, "pages/admin_dashboard.html", gin.H{
 		"title": "Admin Dashboard",
 	})
-}
\ No newline at end of file
+}
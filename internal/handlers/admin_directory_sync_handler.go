@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
+
+	"github.com/gin-gonic/gin"
+	csrf "github.com/utrack/gin-csrf"
+)
+
+type AdminDirectorySyncHandler struct {
+	ldapSyncService services.LDAPSyncService
+}
+
+func NewAdminDirectorySyncHandler(ldapSyncService services.LDAPSyncService) *AdminDirectorySyncHandler {
+	return &AdminDirectorySyncHandler{
+		ldapSyncService: ldapSyncService,
+	}
+}
+
+func (h *AdminDirectorySyncHandler) SyncRunsPage(c *gin.Context) {
+	templateName := "pages/admin_directory_sync.html"
+	var query dtos.LDAPSyncRunSearchRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+
+	resp, err := h.ldapSyncService.SearchRuns(c.Request.Context(), query)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load directory sync runs")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":           "Directory Sync",
+		"query":           query,
+		"runs":            resp.Runs,
+		"page":            resp.Page,
+		"hasPrev":         query.Offset > 0,
+		"prevOffset":      max(query.Offset-query.Limit, 0),
+		"hasNext":         int64(query.Offset+query.Limit) < resp.Page.Total,
+		"nextOffset":      query.Offset + query.Limit,
+		"scheduleEnabled": h.ldapSyncService.ScheduleEnabled(),
+		"syncInterval":    h.ldapSyncService.SyncInterval(),
+		"csrfToken":       csrf.GetToken(c),
+	})
+}
+
+func (h *AdminDirectorySyncHandler) SyncRunPage(c *gin.Context) {
+	templateName := "pages/admin_directory_sync_run.html"
+	runID, err := strconv.Atoi(c.Param("runId"))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid run ID")
+		return
+	}
+
+	run, err := h.ldapSyncService.GetRun(c.Request.Context(), uint(runID))
+	if err != nil {
+		if err == appErrors.ErrLDAPSyncRunNotFound {
+			appErrors.RespondPageError(c, http.StatusNotFound, templateName, "Directory sync run not found")
+			return
+		}
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load directory sync run")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title": "Directory Sync Run",
+		"run":   run,
+	})
+}
+
+// RunSync synchronises the directory now, the report is returned even when the sync failed
+func (h *AdminDirectorySyncHandler) RunSync(c *gin.Context) {
+	var req dtos.LDAPSyncRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	run, err := h.ldapSyncService.Sync(c.Request.Context(), models.LDAPSyncTriggerAdmin, req.DryRun)
+	if err != nil {
+		if run != nil && run.Error != nil {
+			appErrors.RespondError(c, http.StatusBadGateway, *run.Error)
+			return
+		}
+		appErrors.RespondCustomError(c, err, "Failed to synchronise the directory")
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
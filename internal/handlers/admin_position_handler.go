@@ -12,11 +12,20 @@ import (
 )
 
 type AdminPositionHandler struct {
-	positionsService *services.PositionService
+	positionsService   services.PositionService
+	careerTrackService services.CareerTrackService
+	skillService       services.SkillService
 }
 
-func NewAdminPositionHandler(positionService *services.PositionService) *AdminPositionHandler {
-	return &AdminPositionHandler{positionsService: positionService}
+func NewAdminPositionHandler(
+	positionService services.PositionService,
+	careerTrackService services.CareerTrackService,
+	skillService services.SkillService) *AdminPositionHandler {
+	return &AdminPositionHandler{
+		positionsService:   positionService,
+		careerTrackService: careerTrackService,
+		skillService:       skillService,
+	}
 }
 
 func (h *AdminPositionHandler) ListPositionPage(c *gin.Context) {
@@ -47,9 +56,14 @@ func (h *AdminPositionHandler) PositionSearchPartial(c *gin.Context) {
 }
 
 func (h *AdminPositionHandler) CreatePositionPage(c *gin.Context) {
+	careerTracks := h.careerTrackService.GetAllCareerTracksSummary(c.Request.Context())
+	skills := h.skillService.GetAllSkillsSummary(c.Request.Context())
+
 	c.HTML(http.StatusOK, "pages/admin_position_create.html", gin.H{
-		"title":     "Create Position",
-		"csrfToken": csrf.GetToken(c),
+		"title":        "Create Position",
+		"careerTracks": careerTracks,
+		"skills":       skills,
+		"csrfToken":    csrf.GetToken(c),
 	})
 }
 
@@ -82,10 +96,15 @@ func (h *AdminPositionHandler) EditPositionPage(c *gin.Context) {
 		return
 	}
 
+	careerTracks := h.careerTrackService.GetAllCareerTracksSummary(c.Request.Context())
+	skills := h.skillService.GetAllSkillsSummary(c.Request.Context())
+
 	c.HTML(http.StatusOK, templateName, gin.H{
-		"title":     "Edit Position",
-		"position":  position,
-		"csrfToken": csrf.GetToken(c),
+		"title":        "Edit Position",
+		"position":     position,
+		"careerTracks": careerTracks,
+		"skills":       skills,
+		"csrfToken":    csrf.GetToken(c),
 	})
 }
 
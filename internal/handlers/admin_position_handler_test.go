@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+)
+
+func TestAdminPositionDelete(t *testing.T) {
+	t.Run("position in the history of a user is kept", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		member := app.CreateUser("Member", "member@example.com", "user")
+		client := app.TokenClient(admin, models.APITokenScopeAdminWrite)
+
+		// The member moved from former to later and on to their current position
+		former, later := createPosition(t, app, "Former"), createPosition(t, app, "Later")
+		createHistory(t, app, member, nil, former.ID)
+		createHistory(t, app, member, &former.ID, later.ID)
+		createHistory(t, app, member, &later.ID, member.PositionID)
+
+		client.Delete(fmt.Sprintf("/admin/positions/%d", former.ID)).ExpectStatus(http.StatusBadRequest)
+		client.Delete(fmt.Sprintf("/admin/positions/%d", later.ID)).ExpectStatus(http.StatusBadRequest)
+
+		var count int64
+		app.DB.Model(&models.UserPositionHistory{}).Where("user_id = ? AND old_position_id IS NOT NULL", member.ID).Count(&count)
+		if count != 2 {
+			t.Errorf("member has %d history rows with their old position, want 2", count)
+		}
+	})
+
+	t.Run("unused position is deleted", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.TokenClient(app.CreateUser("Admin", "admin@example.com", "admin"), models.APITokenScopeAdminWrite)
+		position := createPosition(t, app, "Unused")
+
+		client.Delete(fmt.Sprintf("/admin/positions/%d", position.ID)).ExpectStatus(http.StatusOK)
+		client.Delete(fmt.Sprintf("/admin/positions/%d", position.ID)).ExpectStatus(http.StatusNotFound)
+	})
+}
+
+func TestAdminPositionWrite(t *testing.T) {
+	t.Run("invalid career track and required skills are rejected", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.TokenClient(app.CreateUser("Admin", "admin@example.com", "admin"), models.APITokenScopeAdminWrite)
+		var skill models.Skill
+		if err := app.DB.Order("id").First(&skill).Error; err != nil {
+			t.Fatalf("finding a skill: %v", err)
+		}
+		unknownID, grade := uint(9999), 1
+
+		client.Post("/admin/positions", dtos.CreateOrUpdatePositionRequest{
+			Name: "Architect", Abbreviation: "ARC", CareerTrackID: &unknownID, Grade: &grade,
+		}).ExpectStatus(http.StatusBadRequest)
+		client.Post("/admin/positions", dtos.CreateOrUpdatePositionRequest{
+			Name: "Architect", Abbreviation: "ARC",
+			RequiredSkills: []dtos.UpdatePositionRequiredSkill{{ID: skill.ID, MinLevel: 2}, {ID: skill.ID, MinLevel: 3}},
+		}).ExpectStatus(http.StatusBadRequest)
+		client.Post("/admin/positions", dtos.CreateOrUpdatePositionRequest{
+			Name: "Architect", Abbreviation: "ARC",
+			RequiredSkills: []dtos.UpdatePositionRequiredSkill{{ID: unknownID, MinLevel: 2}},
+		}).ExpectStatus(http.StatusBadRequest)
+
+		var count int64
+		app.DB.Model(&models.Position{}).Where("name = ?", "Architect").Count(&count)
+		if count != 0 {
+			t.Errorf("%d positions created from invalid requests, want 0", count)
+		}
+
+		position := createPosition(t, app, "Designer")
+		client.Do(http.MethodPut, fmt.Sprintf("/admin/positions/%d", position.ID), dtos.CreateOrUpdatePositionRequest{
+			Name: "Designer", Abbreviation: "DES",
+			RequiredSkills: []dtos.UpdatePositionRequiredSkill{{ID: unknownID, MinLevel: 2}},
+		}).ExpectStatus(http.StatusBadRequest)
+	})
+}
+
+func createPosition(t *testing.T, app *apptest.App, name string) *models.Position {
+	t.Helper()
+	position := &models.Position{Name: name, Abbreviation: name[:3]}
+	if err := app.DB.Create(position).Error; err != nil {
+		t.Fatalf("creating position %s: %v", name, err)
+	}
+	return position
+}
+
+func createHistory(t *testing.T, app *apptest.App, user *models.User, oldPositionID *uint, newPositionID uint) {
+	t.Helper()
+	history := &models.UserPositionHistory{
+		UserID:        user.ID,
+		OldPositionID: oldPositionID,
+		NewPositionID: newPositionID,
+		EffectiveDate: time.Now().UTC().Truncate(24 * time.Hour),
+	}
+	if err := app.DB.Create(history).Error; err != nil {
+		t.Fatalf("creating position history: %v", err)
+	}
+}
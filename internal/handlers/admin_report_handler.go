@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminReportHandler struct {
+	userService      services.UserService
+	timesheetService services.TimesheetService
+	projectService   services.ProjectService
+}
+
+func NewAdminReportHandler(
+	userService services.UserService,
+	timesheetService services.TimesheetService,
+	projectService services.ProjectService,
+) *AdminReportHandler {
+	return &AdminReportHandler{
+		userService:      userService,
+		timesheetService: timesheetService,
+		projectService:   projectService,
+	}
+}
+
+func (h *AdminReportHandler) PromotionReportPage(c *gin.Context) {
+	templateName := "pages/admin_promotion_report.html"
+	var query dtos.PromotionReportRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+
+	// Default to the last 12 months, grouped by month
+	now := time.Now()
+	if query.To.IsZero() {
+		query.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddDate(-1, 0, 0)
+	}
+	if query.Period == "" {
+		query.Period = "month"
+	}
+	if query.From.After(query.To) {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "From date must be before to date")
+		return
+	}
+
+	report, err := h.userService.GetPromotionReport(c.Request.Context(), query.From, query.To, query.Period)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load promotion report")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":  "Promotion Report",
+		"report": report,
+	})
+}
+
+func (h *AdminReportHandler) ProjectEffortReportPage(c *gin.Context) {
+	templateName := "pages/admin_project_effort_report.html"
+	projects := h.projectService.GetAllProjectSummary(c.Request.Context())
+
+	var query dtos.ProjectEffortReportRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+	if !bindProjectEffortReportDefaults(c, templateName, &query) {
+		return
+	}
+
+	data := gin.H{
+		"title":    "Project Effort Report",
+		"projects": projects,
+		"query":    query,
+	}
+	if query.ProjectID != 0 {
+		report, err := h.timesheetService.GetProjectEffortReport(c.Request.Context(), query.ProjectID, query.From, query.To)
+		if err != nil {
+			if err == appErrors.ErrProjectNotFound {
+				appErrors.RespondPageError(c, http.StatusNotFound, templateName, "Project not found")
+				return
+			}
+			appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load project effort report")
+			return
+		}
+		data["report"] = report
+	}
+
+	c.HTML(http.StatusOK, templateName, data)
+}
+
+func (h *AdminReportHandler) ProjectEffortReportCSV(c *gin.Context) {
+	var query dtos.ProjectEffortReportRequest
+	if err := c.ShouldBindQuery(&query); err != nil || query.ProjectID == 0 {
+		c.String(http.StatusBadRequest, "Invalid query parameters")
+		return
+	}
+	if !bindProjectEffortReportDefaults(c, "", &query) {
+		return
+	}
+
+	report, err := h.timesheetService.GetProjectEffortReport(c.Request.Context(), query.ProjectID, query.From, query.To)
+	if err != nil {
+		if err == appErrors.ErrProjectNotFound {
+			c.String(http.StatusNotFound, "Project not found")
+			return
+		}
+		c.String(http.StatusInternalServerError, "Failed to load project effort report")
+		return
+	}
+
+	filename := fmt.Sprintf("project-%d-effort-%s-%s.csv", report.Project.ID, query.From.Format("20060102"), query.To.Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write([]string{"project", "week_start", "user_id", "user_name", "user_email", "hours"})
+	for _, row := range report.Rows {
+		_ = writer.Write([]string{
+			escapeCSVFormula(report.Project.Name),
+			row.WeekStart,
+			strconv.FormatUint(uint64(row.UserID), 10),
+			escapeCSVFormula(row.UserName),
+			escapeCSVFormula(row.UserEmail),
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+}
+
+// escapeCSVFormula prefixes a value that a spreadsheet would evaluate as a formula with a quote
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// bindProjectEffortReportDefaults defaults the range to the current month, and responds with an error
+// (as a page when templateName is set) when the range is invalid
+func bindProjectEffortReportDefaults(c *gin.Context, templateName string, query *dtos.ProjectEffortReportRequest) bool {
+	now := time.Now()
+	if query.From.IsZero() {
+		query.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	}
+	if query.To.IsZero() {
+		query.To = query.From.AddDate(0, 1, -1)
+	}
+	if query.From.After(query.To) {
+		if templateName != "" {
+			appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "From date must be before to date")
+		} else {
+			c.String(http.StatusBadRequest, "From date must be before to date")
+		}
+		return false
+	}
+	return true
+}
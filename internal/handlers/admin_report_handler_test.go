@@ -0,0 +1,55 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/models"
+)
+
+func TestAdminProjectEffortReportCSV(t *testing.T) {
+	t.Run("values read as formulas are escaped", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		member := app.CreateUser("=HYPERLINK(\"https://example.com\")", "member@example.com", "user")
+		team := app.CreateTeam("Atlas", member)
+
+		project := &models.Project{Name: "+Apollo", Abbreviation: "APL", LeaderID: member.ID, TeamID: team.ID}
+		if err := app.DB.Create(project).Error; err != nil {
+			t.Fatalf("creating project: %v", err)
+		}
+		monday := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.Local)
+		timesheet := &models.Timesheet{UserID: member.ID, WeekStart: monday, Status: models.TimesheetStatusApproved}
+		if err := app.DB.Create(timesheet).Error; err != nil {
+			t.Fatalf("creating timesheet: %v", err)
+		}
+		entry := &models.TimeEntry{TimesheetID: timesheet.ID, UserID: member.ID, ProjectID: project.ID, WorkDate: monday, Hours: 8}
+		if err := app.DB.Create(entry).Error; err != nil {
+			t.Fatalf("creating time entry: %v", err)
+		}
+
+		resp := app.AdminClient(admin).
+			Get(fmt.Sprintf("/admin/reports/project-effort.csv?project_id=%d&from=2025-03-01&to=2025-03-31", project.ID)).
+			ExpectStatus(http.StatusOK)
+		records, err := csv.NewReader(bytes.NewReader(resp.Body)).ReadAll()
+		if err != nil {
+			t.Fatalf("reading csv: %v", err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("csv has %d records, want 2", len(records))
+		}
+		if got := records[1][0]; got != "'+Apollo" {
+			t.Errorf("project = %q, want %q", got, "'+Apollo")
+		}
+		if got, want := records[1][3], "'"+member.Name; got != want {
+			t.Errorf("user name = %q, want %q", got, want)
+		}
+		if got := records[1][4]; got != member.Email {
+			t.Errorf("user email = %q, want %q", got, member.Email)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	csrf "github.com/utrack/gin-csrf"
+)
+
+type AdminSecurityHandler struct {
+	authService         services.AuthService
+	twoFactorService    services.TwoFactorService
+	adminSessionService services.AdminSessionService
+}
+
+func NewAdminSecurityHandler(
+	authService services.AuthService,
+	twoFactorService services.TwoFactorService,
+	adminSessionService services.AdminSessionService) *AdminSecurityHandler {
+	return &AdminSecurityHandler{
+		authService:         authService,
+		twoFactorService:    twoFactorService,
+		adminSessionService: adminSessionService,
+	}
+}
+
+func (h *AdminSecurityHandler) LoginAttemptsPage(c *gin.Context) {
+	templateName := "pages/admin_login_attempts.html"
+	var query dtos.LoginAttemptSearchRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+
+	resp, err := h.authService.SearchLoginAttempts(c.Request.Context(), query)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load login attempts")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":      "Login Attempts",
+		"query":      query,
+		"attempts":   resp.Attempts,
+		"page":       resp.Page,
+		"hasPrev":    query.Offset > 0,
+		"prevOffset": max(query.Offset-query.Limit, 0),
+		"hasNext":    int64(query.Offset+query.Limit) < resp.Page.Total,
+		"nextOffset": query.Offset + query.Limit,
+	})
+}
+
+func (h *AdminSecurityHandler) TwoFactorPage(c *gin.Context) {
+	templateName := "pages/admin_two_factor.html"
+	status, err := h.twoFactorService.GetStatus(c.Request.Context(), c.GetUint("user_id"))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load two-factor status")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":     "Two-Factor Authentication",
+		"status":    status,
+		"csrfToken": csrf.GetToken(c),
+	})
+}
+
+func (h *AdminSecurityHandler) SessionsPage(c *gin.Context) {
+	templateName := "pages/admin_sessions.html"
+	adminSessions, err := h.adminSessionService.ListSessions(c.Request.Context(), c.GetUint("user_id"), sessions.Default(c).ID())
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load sessions")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":     "Sessions",
+		"sessions":  adminSessions,
+		"csrfToken": csrf.GetToken(c),
+	})
+}
+
+func (h *AdminSecurityHandler) RevokeSession(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	sessionIdParam := c.Param("sessionId")
+	sessionId, err := strconv.Atoi(sessionIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	if err := h.adminSessionService.RevokeSession(c.Request.Context(), userId, uint(sessionId), sessions.Default(c).ID()); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to revoke session")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+func (h *AdminSecurityHandler) RevokeOtherSessions(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	revoked, err := h.adminSessionService.RevokeOtherSessions(c.Request.Context(), userId, sessions.Default(c).ID())
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to revoke sessions")
+		return
+	}
+	c.JSON(http.StatusOK, dtos.RevokeSessionsResponse{
+		Message: fmt.Sprintf("Signed out of %d other session(s)", revoked),
+		Revoked: revoked,
+	})
+}
+
+// ChangePassword updates the admin's password and signs them out of every other session and API token
+func (h *AdminSecurityHandler) ChangePassword(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var req dtos.ChangePasswordRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	revoked, err := h.authService.ChangePassword(c.Request.Context(), userId, req.CurrentPassword, req.NewPassword, sessions.Default(c).ID())
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to change password")
+		return
+	}
+	c.JSON(http.StatusOK, dtos.RevokeSessionsResponse{
+		Message: fmt.Sprintf("Password changed, signed out of %d other session(s)", revoked),
+		Revoked: revoked,
+	})
+}
@@ -12,10 +12,10 @@ import (
 )
 
 type AdminSkillHandler struct {
-	skillService *services.SkillService
+	skillService services.SkillService
 }
 
-func NewAdminSkillHandler(skillService *services.SkillService) *AdminSkillHandler {
+func NewAdminSkillHandler(skillService services.SkillService) *AdminSkillHandler {
 	return &AdminSkillHandler{skillService: skillService}
 }
 
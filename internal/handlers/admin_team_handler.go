@@ -12,11 +12,11 @@ import (
 )
 
 type AdminTeamHandler struct {
-	teamService *services.TeamsService
-	userService *services.UserService
+	teamService services.TeamsService
+	userService services.UserService
 }
 
-func NewAdminTeamHandler(teamService *services.TeamsService, userService *services.UserService) *AdminTeamHandler {
+func NewAdminTeamHandler(teamService services.TeamsService, userService services.UserService) *AdminTeamHandler {
 	return &AdminTeamHandler{teamService: teamService, userService: userService}
 }
 
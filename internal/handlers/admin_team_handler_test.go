@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+)
+
+func TestAdminTeamMembership(t *testing.T) {
+	t.Run("leader cannot be removed", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		leader := app.CreateUser("Leader", "leader@example.com", "user")
+		team := app.CreateTeam("Atlas", leader)
+		client := app.TokenClient(admin, models.APITokenScopeAdminWrite)
+
+		client.Delete(fmt.Sprintf("/admin/teams/%d/members/%d", team.ID, leader.ID)).ExpectStatus(http.StatusBadRequest)
+
+		expectCurrentTeam(t, app, leader, team)
+		expectActiveMemberships(t, app, leader, team)
+	})
+
+	t.Run("leader cannot be moved to another team", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		leader := app.CreateUser("Leader", "leader@example.com", "user")
+		team := app.CreateTeam("Atlas", leader)
+		otherTeam := app.CreateTeam("Borealis", app.CreateUser("Other Leader", "other@example.com", "user"))
+		client := app.TokenClient(admin, models.APITokenScopeAdminWrite)
+
+		client.Post(fmt.Sprintf("/admin/teams/%d/members", otherTeam.ID), dtos.AddMemberRequest{UserID: leader.ID}).
+			ExpectStatus(http.StatusBadRequest)
+
+		expectCurrentTeam(t, app, leader, team)
+		expectActiveMemberships(t, app, leader, team)
+	})
+
+	t.Run("member is added and removed", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		team := app.CreateTeam("Atlas", app.CreateUser("Leader", "leader@example.com", "user"))
+		member := app.CreateUser("Member", "member@example.com", "user")
+		client := app.TokenClient(admin, models.APITokenScopeAdminWrite)
+
+		client.Post(fmt.Sprintf("/admin/teams/%d/members", team.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusOK)
+		expectCurrentTeam(t, app, member, team)
+		expectActiveMemberships(t, app, member, team)
+
+		client.Post(fmt.Sprintf("/admin/teams/%d/members", team.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusBadRequest)
+
+		client.Delete(fmt.Sprintf("/admin/teams/%d/members/%d", team.ID, member.ID)).ExpectStatus(http.StatusOK)
+		expectCurrentTeam(t, app, member, nil)
+		expectActiveMemberships(t, app, member)
+
+		client.Delete(fmt.Sprintf("/admin/teams/%d/members/%d", team.ID, member.ID)).ExpectStatus(http.StatusBadRequest)
+	})
+
+	t.Run("member moved to another team leaves the previous one", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		team := app.CreateTeam("Atlas", app.CreateUser("Leader", "leader@example.com", "user"))
+		otherTeam := app.CreateTeam("Borealis", app.CreateUser("Other Leader", "other@example.com", "user"))
+		member := app.CreateUser("Member", "member@example.com", "user")
+		client := app.TokenClient(admin, models.APITokenScopeAdminWrite)
+
+		client.Post(fmt.Sprintf("/admin/teams/%d/members", team.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusOK)
+		client.Post(fmt.Sprintf("/admin/teams/%d/members", otherTeam.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusOK)
+
+		expectCurrentTeam(t, app, member, otherTeam)
+		expectActiveMemberships(t, app, member, otherTeam)
+		var left int64
+		app.DB.Model(&models.TeamMember{}).
+			Where("user_id = ? AND team_id = ? AND left_at IS NOT NULL", member.ID, team.ID).
+			Count(&left)
+		if left != 1 {
+			t.Errorf("%d past memberships of the previous team, want 1", left)
+		}
+	})
+
+	t.Run("regular users cannot change teams", func(t *testing.T) {
+		app := apptest.New(t)
+		team := app.CreateTeam("Atlas", app.CreateUser("Leader", "leader@example.com", "user"))
+		member := app.CreateUser("Member", "member@example.com", "user")
+		client := app.UserClient(member)
+
+		// Admin routes take a session or a personal access token, never the access token of the API
+		client.Post(fmt.Sprintf("/admin/teams/%d/members", team.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusUnauthorized)
+		expectCurrentTeam(t, app, member, nil)
+	})
+}
+
+func expectCurrentTeam(t *testing.T, app *apptest.App, user *models.User, team *models.Team) {
+	t.Helper()
+	current := app.ReloadUser(user).CurrentTeamID
+	switch {
+	case team == nil && current != nil:
+		t.Errorf("user %s is in team %d, want no team", user.Email, *current)
+	case team != nil && (current == nil || *current != team.ID):
+		t.Errorf("user %s is in team %v, want %d", user.Email, current, team.ID)
+	}
+}
+
+// expectActiveMemberships checks the teams the user has a membership without left_at in, a user is in one team at most
+func expectActiveMemberships(t *testing.T, app *apptest.App, user *models.User, teams ...*models.Team) {
+	t.Helper()
+	var memberships []models.TeamMember
+	if err := app.DB.Where("user_id = ? AND left_at IS NULL", user.ID).Find(&memberships).Error; err != nil {
+		t.Fatalf("finding the memberships of %s: %v", user.Email, err)
+	}
+	if len(memberships) != len(teams) {
+		t.Fatalf("user %s has %d active memberships, want %d", user.Email, len(memberships), len(teams))
+	}
+	for i, team := range teams {
+		if memberships[i].TeamID != team.ID {
+			t.Errorf("user %s is an active member of team %d, want %d", user.Email, memberships[i].TeamID, team.ID)
+		}
+	}
+}
@@ -12,22 +12,28 @@ import (
 )
 
 type AdminUserHandler struct {
-	userService     *services.UserService
-	teamService     *services.TeamsService
-	positionService *services.PositionService
-	skillService    *services.SkillService
+	userService      services.UserService
+	teamService      services.TeamsService
+	positionService  services.PositionService
+	skillService     services.SkillService
+	authService      services.AuthService
+	twoFactorService services.TwoFactorService
 }
 
 func NewAdminUserHandler(
-	userService *services.UserService,
-	teamService *services.TeamsService,
-	positionService *services.PositionService,
-	skillService *services.SkillService) *AdminUserHandler {
+	userService services.UserService,
+	teamService services.TeamsService,
+	positionService services.PositionService,
+	skillService services.SkillService,
+	authService services.AuthService,
+	twoFactorService services.TwoFactorService) *AdminUserHandler {
 	return &AdminUserHandler{
-		userService:     userService,
-		teamService:     teamService,
-		positionService: positionService,
-		skillService:    skillService,
+		userService:      userService,
+		teamService:      teamService,
+		positionService:  positionService,
+		skillService:     skillService,
+		authService:      authService,
+		twoFactorService: twoFactorService,
 	}
 }
 
@@ -91,10 +97,31 @@ func (h *AdminUserHandler) AdminUserDetailPage(c *gin.Context) {
 		return
 	}
 
+	promotionReadiness, err := h.positionService.GetPromotionReadiness(c.Request.Context(), uint(userId))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load promotion readiness")
+		return
+	}
+
+	positionHistory, err := h.userService.GetUserPositionHistory(c.Request.Context(), uint(userId))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load position history")
+		return
+	}
+
+	loginSecurity, err := h.authService.GetAccountLoginSecurity(c.Request.Context(), uint(userId))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load login security")
+		return
+	}
+
 	c.HTML(http.StatusOK, templateName, gin.H{
-		"title":     "User Detail",
-		"user":      userProfile,
-		"csrfToken": csrf.GetToken(c),
+		"title":              "User Detail",
+		"user":               userProfile,
+		"promotionReadiness": promotionReadiness,
+		"positionHistory":    positionHistory,
+		"loginSecurity":      loginSecurity,
+		"csrfToken":          csrf.GetToken(c),
 	})
 }
 
@@ -118,7 +145,7 @@ func (h *AdminUserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.CreateUser(c.Request.Context(), request); err != nil {
+	if err := h.userService.CreateUser(c.Request.Context(), request, c.GetUint("user_id")); err != nil {
 		appErrors.RespondCustomError(c, err, "Failed to create user")
 		return
 	}
@@ -169,7 +196,7 @@ func (h *AdminUserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.UpdateUser(c.Request.Context(), uint(userId), request); err != nil {
+	if err := h.userService.UpdateUser(c.Request.Context(), uint(userId), request, c.GetUint("user_id")); err != nil {
 		appErrors.RespondCustomError(c, err, "Failed to update user")
 		return
 	}
@@ -191,3 +218,53 @@ func (h *AdminUserHandler) DeleteUser(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
+
+func (h *AdminUserHandler) UnlockUser(c *gin.Context) {
+	userIdParam := c.Param("userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.authService.UnlockUser(c.Request.Context(), uint(userId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to unlock user")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
+func (h *AdminUserHandler) UpdateTwoFactorRequirement(c *gin.Context) {
+	userIdParam := c.Param("userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var request dtos.UpdateTwoFactorRequirementRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	if err := h.twoFactorService.SetRequired(c.Request.Context(), uint(userId), *request.Required); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to update two-factor requirement")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor requirement updated successfully"})
+}
+
+func (h *AdminUserHandler) ResetTwoFactor(c *gin.Context) {
+	userIdParam := c.Param("userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.twoFactorService.Reset(c.Request.Context(), uint(userId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to reset two-factor authentication")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset successfully"})
+}
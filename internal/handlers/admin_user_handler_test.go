@@ -0,0 +1,33 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+)
+
+func TestAdminUserUpdate(t *testing.T) {
+	t.Run("edit keeps the role of the user", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		member := app.CreateUser("Member", "member@example.com", "user")
+		client := app.TokenClient(admin, models.APITokenScopeAdminWrite)
+
+		client.Do(http.MethodPut, fmt.Sprintf("/admin/users/%d", member.ID), dtos.CreateOrUpdateUserRequest{
+			Name:       "Renamed Member",
+			Email:      member.Email,
+			PositionID: member.PositionID,
+		}).ExpectStatus(http.StatusOK)
+
+		var user models.User
+		if err := app.DB.First(&user, member.ID).Error; err != nil {
+			t.Fatalf("reloading the member: %v", err)
+		}
+		if user.Name != "Renamed Member" || user.Role != "user" {
+			t.Errorf("member is %q with role %q, want Renamed Member with role user", user.Name, user.Role)
+		}
+	})
+}
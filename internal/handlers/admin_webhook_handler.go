@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
+
+	"github.com/gin-gonic/gin"
+	csrf "github.com/utrack/gin-csrf"
+)
+
+type AdminWebhookHandler struct {
+	webhookService services.WebhookService
+}
+
+func NewAdminWebhookHandler(webhookService services.WebhookService) *AdminWebhookHandler {
+	return &AdminWebhookHandler{webhookService: webhookService}
+}
+
+func (h *AdminWebhookHandler) ListWebhookPage(c *gin.Context) {
+	templateName := "pages/admin_webhooks.html"
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load webhooks")
+		return
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":         "Webhooks",
+		"subscriptions": subscriptions,
+		"csrfToken":     csrf.GetToken(c),
+	})
+}
+
+func (h *AdminWebhookHandler) CreateWebhookPage(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/admin_webhook_create.html", gin.H{
+		"title":      "Create Webhook",
+		"eventTypes": models.DomainEventTypes,
+		"csrfToken":  csrf.GetToken(c),
+	})
+}
+
+// CreateWebhook returns the subscription with its secret, which is generated when none was given
+func (h *AdminWebhookHandler) CreateWebhook(c *gin.Context) {
+	var request dtos.CreateOrUpdateWebhookSubscriptionRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), request)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *AdminWebhookHandler) EditWebhookPage(c *gin.Context) {
+	templateName := "pages/admin_webhook_edit.html"
+	webhookId, err := strconv.Atoi(c.Param("webhookId"))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid webhook ID")
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), uint(webhookId))
+	if err != nil {
+		if err == appErrors.ErrWebhookSubscriptionNotFound {
+			appErrors.RespondPageError(c, http.StatusNotFound, templateName, "Webhook not found")
+			return
+		}
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load webhook")
+		return
+	}
+
+	selectedEventTypes := make(map[string]bool, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		selectedEventTypes[eventType] = true
+	}
+
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":              "Edit Webhook",
+		"subscription":       subscription,
+		"eventTypes":         models.DomainEventTypes,
+		"selectedEventTypes": selectedEventTypes,
+		"csrfToken":          csrf.GetToken(c),
+	})
+}
+
+func (h *AdminWebhookHandler) UpdateWebhook(c *gin.Context) {
+	webhookId, err := strconv.Atoi(c.Param("webhookId"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	var request dtos.CreateOrUpdateWebhookSubscriptionRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	if err := h.webhookService.UpdateSubscription(c.Request.Context(), uint(webhookId), request); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook updated successfully"})
+}
+
+func (h *AdminWebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhookId, err := strconv.Atoi(c.Param("webhookId"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), uint(webhookId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+func (h *AdminWebhookHandler) DeliveriesPage(c *gin.Context) {
+	templateName := "pages/admin_webhook_deliveries.html"
+	webhookId, err := strconv.Atoi(c.Param("webhookId"))
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid webhook ID")
+		return
+	}
+	var query dtos.WebhookDeliverySearchRequest
+	if err := c.ShouldBindQuery(&query); err != nil {
+		appErrors.RespondPageError(c, http.StatusBadRequest, templateName, "Invalid query parameters")
+		return
+	}
+	// The status filter submits an empty value for all statuses
+	if query.Status != nil && *query.Status == "" {
+		query.Status = nil
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), uint(webhookId))
+	if err != nil {
+		if err == appErrors.ErrWebhookSubscriptionNotFound {
+			appErrors.RespondPageError(c, http.StatusNotFound, templateName, "Webhook not found")
+			return
+		}
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load webhook")
+		return
+	}
+
+	resp, err := h.webhookService.SearchDeliveries(c.Request.Context(), subscription.ID, query)
+	if err != nil {
+		appErrors.RespondPageError(c, http.StatusInternalServerError, templateName, "Failed to load webhook deliveries")
+		return
+	}
+
+	status := ""
+	if query.Status != nil {
+		status = *query.Status
+	}
+	c.HTML(http.StatusOK, templateName, gin.H{
+		"title":        "Webhook Deliveries",
+		"subscription": subscription,
+		"status":       status,
+		"query":        query,
+		"deliveries":   resp.Deliveries,
+		"page":         resp.Page,
+		"hasPrev":      query.Offset > 0,
+		"prevOffset":   max(query.Offset-query.Limit, 0),
+		"hasNext":      int64(query.Offset+query.Limit) < resp.Page.Total,
+		"nextOffset":   query.Offset + query.Limit,
+		"csrfToken":    csrf.GetToken(c),
+	})
+}
+
+func (h *AdminWebhookHandler) Redeliver(c *gin.Context) {
+	webhookId, err := strconv.Atoi(c.Param("webhookId"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+	deliveryId, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid delivery ID")
+		return
+	}
+
+	if _, err := h.webhookService.Redeliver(c.Request.Context(), uint(webhookId), uint(deliveryId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to queue the redelivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Redelivery queued successfully"})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APITokenHandler manages the personal access tokens of the signed in user
+type APITokenHandler struct {
+	apiTokenService services.APITokenService
+}
+
+func NewAPITokenHandler(apiTokenService services.APITokenService) *APITokenHandler {
+	return &APITokenHandler{apiTokenService: apiTokenService}
+}
+
+func (h *APITokenHandler) ListTokens(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	resp, err := h.apiTokenService.ListTokens(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to list API tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var req dtos.CreateAPITokenRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	resp, err := h.apiTokenService.CreateToken(c.Request.Context(), userId, req)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to create API token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	tokenId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid token ID")
+		return
+	}
+
+	if err := h.apiTokenService.RevokeToken(c.Request.Context(), userId, uint(tokenId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to revoke API token")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API token revoked successfully"})
+}
@@ -1,26 +1,51 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 	"trieu_mock_project_go/internal/dtos"
 	appErrors "trieu_mock_project_go/internal/errors"
 	"trieu_mock_project_go/internal/services"
 	"trieu_mock_project_go/internal/utils"
+	"trieu_mock_project_go/models"
 
+	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
+// How long the result of a single sign-on waits in the session for the login page to pick it up
+const ssoLoginResultTTL = 5 * time.Minute
+
 type AuthHandler struct {
-	authService *services.AuthService
+	authService          services.AuthService
+	twoFactorService     services.TwoFactorService
+	oidcService          services.OIDCService
+	passwordResetService services.PasswordResetService
 }
 
-func NewAuthHandler(authService *services.AuthService) *AuthHandler {
-	return &AuthHandler{authService: authService}
+func NewAuthHandler(
+	authService services.AuthService,
+	twoFactorService services.TwoFactorService,
+	oidcService services.OIDCService,
+	passwordResetService services.PasswordResetService) *AuthHandler {
+	return &AuthHandler{
+		authService:          authService,
+		twoFactorService:     twoFactorService,
+		oidcService:          oidcService,
+		passwordResetService: passwordResetService,
+	}
 }
 
 func (h *AuthHandler) ShowLoginPage(c *gin.Context) {
 	c.HTML(http.StatusOK, "pages/login.html", gin.H{
-		"title": "User Login",
+		"title":           "User Login",
+		"ssoEnabled":      h.oidcService.Enabled(),
+		"ssoProviderName": h.oidcService.ProviderName(),
 	})
 }
 
@@ -33,23 +58,265 @@ func (h *AuthHandler) UserLogin(c *gin.Context) {
 	}
 
 	// Login user
-	user, err := h.authService.Login(c.Request.Context(), req.User.Email, req.User.Password)
+	result, err := h.authService.Login(c.Request.Context(), req.User.Email, req.User.Password, userLoginMeta(c))
 	if err != nil {
+		if retryErr, ok := setRetryAfter(c, err); ok {
+			appErrors.RespondError(c, retryErr.Status, retryErr.Message)
+			return
+		}
 		appErrors.RespondError(c, http.StatusUnauthorized, "Invalid email or password")
 		return
 	}
 
-	token, err := utils.GenerateJWTToken(user.ID, user.Email)
+	resp, err := loginResultResponse(result)
 	if err != nil {
 		appErrors.RespondError(c, http.StatusInternalServerError, "Failed to generate access token")
 		return
 	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AuthHandler) UserLoginTwoFactor(c *gin.Context) {
+	var req dtos.TwoFactorLoginRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	claims, err := utils.ParseTwoFactorChallengeToken(req.ChallengeToken, utils.TwoFactorPurposeVerify)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Invalid two-factor challenge")
+		return
+	}
+
+	user, err := h.authService.CompleteSecondFactor(c.Request.Context(), claims.UserID, req.Code, userLoginMeta(c))
+	if err != nil {
+		if retryErr, ok := setRetryAfter(c, err); ok {
+			appErrors.RespondError(c, retryErr.Status, retryErr.Message)
+			return
+		}
+		appErrors.RespondCustomError(c, err, "Failed to verify two-factor code")
+		return
+	}
+
+	resp, err := buildLoginResponse(user)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "Failed to generate access token")
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AuthHandler) UserLoginTwoFactorSetup(c *gin.Context) {
+	var req dtos.TwoFactorSetupLoginRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	claims, err := utils.ParseTwoFactorChallengeToken(req.ChallengeToken, utils.TwoFactorPurposeSetup)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Invalid two-factor challenge")
+		return
+	}
 
-	resp := dtos.LoginResponse{}
+	resp, err := h.twoFactorService.BeginSetup(c.Request.Context(), claims.UserID)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to start two-factor setup")
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AuthHandler) UserLoginTwoFactorEnable(c *gin.Context) {
+	var req dtos.TwoFactorLoginRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	claims, err := utils.ParseTwoFactorChallengeToken(req.ChallengeToken, utils.TwoFactorPurposeSetup)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Invalid two-factor challenge")
+		return
+	}
+
+	user, recoveryCodes, err := h.authService.CompleteTwoFactorSetup(c.Request.Context(), claims.UserID, req.Code, userLoginMeta(c))
+	if err != nil {
+		if retryErr, ok := setRetryAfter(c, err); ok {
+			appErrors.RespondError(c, retryErr.Status, retryErr.Message)
+			return
+		}
+		appErrors.RespondCustomError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	loginResp, err := buildLoginResponse(user)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "Failed to generate access token")
+		return
+	}
+	c.JSON(http.StatusOK, dtos.TwoFactorEnableLoginResponse{
+		LoginResponse: *loginResp,
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// UserOIDCLogin sends the browser to the OpenID Connect provider
+func (h *AuthHandler) UserOIDCLogin(c *gin.Context) {
+	if err := beginOIDCLogin(c, h.oidcService, models.LoginFlowUser, "/login/oidc/callback"); err != nil {
+		c.Redirect(http.StatusSeeOther, "/login?sso_error="+url.QueryEscape(ssoErrorMessage(err)))
+	}
+}
+
+// UserOIDCCallback completes the sign-on when the provider sends the browser back. The login page
+// picks the result up through UserOIDCComplete, like the response of a password login.
+func (h *AuthHandler) UserOIDCCallback(c *gin.Context) {
+	result, err := finishOIDCLogin(c, h.oidcService, "/login/oidc/callback", userLoginMeta(c))
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login?sso_error="+url.QueryEscape(ssoErrorMessage(err)))
+		return
+	}
+
+	resp, err := loginResultResponse(result)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login?sso_error="+url.QueryEscape("Failed to generate access token"))
+		return
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		c.Redirect(http.StatusSeeOther, "/login?sso_error="+url.QueryEscape("Failed to generate access token"))
+		return
+	}
+
+	session := sessions.Default(c)
+	session.Set("sso_login_response", string(body))
+	session.Set("sso_login_at", time.Now().Unix())
+	if err := session.Save(); err != nil {
+		c.Redirect(http.StatusSeeOther, "/login?sso_error="+url.QueryEscape("Failed to save session"))
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/login?sso=1")
+}
+
+// UserOIDCComplete hands the result of the sign-on to the login page, once
+func (h *AuthHandler) UserOIDCComplete(c *gin.Context) {
+	session := sessions.Default(c)
+	body, ok := session.Get("sso_login_response").(string)
+	loginAt, _ := session.Get("sso_login_at").(int64)
+	session.Delete("sso_login_response")
+	session.Delete("sso_login_at")
+	if err := session.Save(); err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "Failed to save session")
+		return
+	}
+
+	if !ok || time.Since(time.Unix(loginAt, 0)) > ssoLoginResultTTL {
+		// Not a 401, the login page would only redirect to itself
+		appErrors.RespondError(c, http.StatusBadRequest, "Single sign-on expired, please sign in again")
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(body))
+}
+
+// JWKS publishes the public keys access tokens are signed with, so other services can verify them
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	jwks, err := utils.JWKS()
+	if err != nil {
+		appErrors.RespondError(c, http.StatusInternalServerError, "Failed to load signing keys")
+		return
+	}
+
+	// Verifiers cache the keys, a rotated in key is published before tokens are signed with it
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks)
+}
+
+func (h *AuthHandler) ShowForgotPasswordPage(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/forgot_password.html", gin.H{
+		"title": "Forgot Password",
+	})
+}
+
+// ForgotPassword answers the same whether or not an account has the email, see PasswordResetService.RequestReset
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dtos.ForgotPasswordRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	if err := h.passwordResetService.RequestReset(c.Request.Context(), req.Email); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to request a password reset")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "If an account uses this email, a link to reset the password was sent to it"})
+}
+
+func (h *AuthHandler) ShowResetPasswordPage(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/reset_password.html", gin.H{
+		"title": "Reset Password",
+		"token": c.Query("token"),
+	})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dtos.ResetPasswordRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to reset password")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// loginResultResponse is the body answering a login, either the access token or the second factor challenge
+func loginResultResponse(result *services.LoginResult) (interface{}, error) {
+	if result.SecondFactor != "" {
+		purpose := utils.TwoFactorPurposeVerify
+		if result.SecondFactor == services.SecondFactorSetup {
+			purpose = utils.TwoFactorPurposeSetup
+		}
+		challengeToken, err := utils.GenerateTwoFactorChallengeToken(result.User.ID, result.User.Email, purpose)
+		if err != nil {
+			return nil, err
+		}
+		return dtos.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			SetupRequired:     result.SecondFactor == services.SecondFactorSetup,
+			ChallengeToken:    challengeToken,
+		}, nil
+	}
+	return buildLoginResponse(result.User)
+}
+
+func buildLoginResponse(user *models.User) (*dtos.LoginResponse, error) {
+	token, err := utils.GenerateJWTToken(user.ID, user.Email, user.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dtos.LoginResponse{}
 	resp.User.ID = user.ID
 	resp.User.Name = user.Name
 	resp.User.Email = user.Email
 	resp.User.AccessToken = token
+	return resp, nil
+}
 
-	c.JSON(http.StatusOK, resp)
+func userLoginMeta(c *gin.Context) services.LoginMeta {
+	return services.LoginMeta{
+		Flow:      models.LoginFlowUser,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// setRetryAfter sets the Retry-After header when err tells the client to retry the login later
+func setRetryAfter(c *gin.Context, err error) (*appErrors.RetryAfterError, bool) {
+	var retryErr *appErrors.RetryAfterError
+	if !errors.As(err, &retryErr) {
+		return nil, false
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+	return retryErr, true
 }
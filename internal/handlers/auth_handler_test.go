@@ -0,0 +1,289 @@
+package handlers_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestUserLogin(t *testing.T) {
+	t.Run("access token opens the API", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+
+		var profile dtos.UserProfile
+		app.UserClient(user).Get("/api/profile").ExpectStatus(http.StatusOK).JSON(&profile)
+		if profile.Email != user.Email {
+			t.Errorf("profile of %s, want %s", profile.Email, user.Email)
+		}
+	})
+
+	t.Run("access token of a deactivated user is rejected before it expires", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+		client := app.UserClient(user)
+
+		app.DB.Model(user).Update("deactivated_at", time.Now())
+		client.Get("/api/profile").ExpectStatus(http.StatusForbidden)
+	})
+
+	t.Run("access and challenge tokens are not accepted for each other", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+		var login dtos.LoginResponse
+		app.NewClient().Post("/login", apptest.LoginRequest(user.Email, apptest.Password)).
+			ExpectStatus(http.StatusOK).JSON(&login)
+
+		app.DB.Model(user).Update("two_factor_required", true)
+		var challenge dtos.TwoFactorChallengeResponse
+		app.NewClient().Post("/login", apptest.LoginRequest(user.Email, apptest.Password)).
+			ExpectStatus(http.StatusOK).JSON(&challenge)
+		if !challenge.SetupRequired {
+			t.Fatal("login did not ask for two-factor setup")
+		}
+
+		// Both are signed with the published keys, other services tell them apart by their type and audience
+		accessClaims, accessType := unverifiedClaims(t, login.User.AccessToken)
+		challengeClaims, challengeType := unverifiedClaims(t, challenge.ChallengeToken)
+		if accessClaims.Issuer == "" || len(accessClaims.Audience) == 0 {
+			t.Errorf("access token has issuer %q and audience %v, want both set", accessClaims.Issuer, accessClaims.Audience)
+		}
+		if accessType == challengeType || slices.Equal(accessClaims.Audience, challengeClaims.Audience) {
+			t.Errorf("challenge token has the type %q and audience %v of access tokens", challengeType, challengeClaims.Audience)
+		}
+
+		client := app.NewClient()
+		client.SetHeader("Authorization", "Bearer "+challenge.ChallengeToken)
+		client.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+		app.NewClient().Post("/login/2fa/setup", dtos.TwoFactorSetupLoginRequest{ChallengeToken: login.User.AccessToken}).
+			ExpectStatus(http.StatusUnauthorized)
+		app.NewClient().Post("/login/2fa/setup", dtos.TwoFactorSetupLoginRequest{ChallengeToken: challenge.ChallengeToken}).
+			ExpectStatus(http.StatusOK)
+	})
+
+	t.Run("missing or invalid token is rejected", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		client.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+		client.SetHeader("Authorization", "Bearer not-a-token")
+		client.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+	})
+
+	t.Run("errors carry the request ID", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		var body appErrors.APIErrorResponse
+		resp := client.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+		resp.JSON(&body)
+		if requestID := resp.Header.Get("X-Request-ID"); requestID == "" || body.RequestID != requestID {
+			t.Errorf("response of request %q has request_id %q", requestID, body.RequestID)
+		}
+
+		// The ID given by a proxy in front of the app is kept
+		client.SetHeader("X-Request-ID", "proxy-1234")
+		client.Get("/api/profile").ExpectStatus(http.StatusUnauthorized).JSON(&body)
+		if body.RequestID != "proxy-1234" {
+			t.Errorf("request_id is %q, want proxy-1234", body.RequestID)
+		}
+	})
+
+	t.Run("wrong password is rejected and the next attempt throttled", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+		client := app.NewClient()
+
+		client.Post("/login", apptest.LoginRequest(user.Email, "wrong-password")).ExpectStatus(http.StatusUnauthorized)
+		client.Post("/login", apptest.LoginRequest("nobody@example.com", apptest.Password)).ExpectStatus(http.StatusUnauthorized)
+
+		// Even the right password waits for the delay after a failure
+		resp := client.Post("/login", apptest.LoginRequest(user.Email, apptest.Password)).ExpectStatus(http.StatusTooManyRequests)
+		if resp.Header.Get("Retry-After") == "" {
+			t.Error("throttled login without a Retry-After header")
+		}
+		if failures := app.ReloadUser(user).FailedLoginCount; failures != 1 {
+			t.Errorf("%d failed logins counted, want 1", failures)
+		}
+	})
+
+	t.Run("forged X-Forwarded-For does not reset the per-IP throttling", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		// Every attempt claims another client IP, the app trusts no proxy so they all count for the caller
+		for _, forwardedFor := range []string{"203.0.113.1", "203.0.113.2", "203.0.113.3", "203.0.113.4"} {
+			client.SetHeader("X-Forwarded-For", forwardedFor)
+			status := http.StatusUnauthorized
+			if forwardedFor == "203.0.113.4" {
+				status = http.StatusTooManyRequests
+			}
+			client.Post("/login", apptest.LoginRequest(forwardedFor+"@example.com", apptest.Password)).ExpectStatus(status)
+		}
+
+		var forged int64
+		app.DB.Model(&models.LoginAttempt{}).Where("ip_address LIKE ?", "203.0.113.%").Count(&forged)
+		if forged != 0 {
+			t.Errorf("%d login attempts recorded with the forged IP, want 0", forged)
+		}
+	})
+}
+
+// unverifiedClaims decodes the claims and typ header of a token without checking its signature
+func unverifiedClaims(t *testing.T, tokenString string) (*jwt.RegisteredClaims, string) {
+	t.Helper()
+	claims := &jwt.RegisteredClaims{}
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, claims)
+	if err != nil {
+		t.Fatalf("decoding token: %v", err)
+	}
+	typ, _ := token.Header["typ"].(string)
+	return claims, typ
+}
+
+func TestAdminLogin(t *testing.T) {
+	t.Run("session records the IP of the login, not a forged one", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		client := app.NewClient()
+
+		client.SetHeader("X-Forwarded-For", "203.0.113.7")
+		client.PostForm("/admin/login", url.Values{"email": {admin.Email}, "password": {apptest.Password}}).
+			ExpectStatus(http.StatusSeeOther)
+
+		var session models.AdminSession
+		var attempt models.LoginAttempt
+		if err := app.DB.Where("user_id = ?", admin.ID).First(&session).Error; err != nil {
+			t.Fatalf("finding the admin session: %v", err)
+		}
+		if err := app.DB.Where("user_id = ? AND success = ?", admin.ID, true).First(&attempt).Error; err != nil {
+			t.Fatalf("finding the login attempt: %v", err)
+		}
+		if session.IPAddress == "203.0.113.7" || session.IPAddress != attempt.IPAddress {
+			t.Errorf("session IP is %q and login IP %q, want the same peer IP", session.IPAddress, attempt.IPAddress)
+		}
+	})
+
+	t.Run("admin session opens the admin pages until logout", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.AdminClient(app.CreateUser("Admin", "admin@example.com", "admin"))
+
+		client.Get("/admin/teams").ExpectStatus(http.StatusOK)
+
+		client.Get("/admin/logout").ExpectStatus(http.StatusSeeOther)
+		client.Get("/admin/teams").ExpectStatus(http.StatusFound)
+	})
+
+	t.Run("session of a deactivated admin ends", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		client := app.AdminClient(admin)
+
+		app.DB.Model(admin).Update("deactivated_at", time.Now())
+		client.Get("/admin/teams").ExpectStatus(http.StatusFound)
+
+		// The session is gone, reactivating the admin does not bring it back
+		app.DB.Model(admin).Update("deactivated_at", nil)
+		client.Get("/admin/teams").ExpectStatus(http.StatusFound)
+	})
+
+	t.Run("admin pages redirect to the login without a session", func(t *testing.T) {
+		app := apptest.New(t)
+
+		resp := app.NewClient().Get("/admin/teams").ExpectStatus(http.StatusFound)
+		if location := resp.Header.Get("Location"); location != "/admin/login" {
+			t.Errorf("redirected to %q, want /admin/login", location)
+		}
+	})
+
+	t.Run("regular users cannot sign in", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+
+		app.NewClient().PostForm("/admin/login", url.Values{"email": {user.Email}, "password": {apptest.Password}}).
+			ExpectStatus(http.StatusUnauthorized)
+	})
+
+	t.Run("session requests need the CSRF token", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		client := app.AdminClient(admin)
+
+		client.Post("/admin/teams", dtos.CreateOrUpdateTeamRequest{Name: "Atlas", LeaderID: admin.ID}).
+			ExpectStatus(http.StatusBadRequest)
+	})
+}
+
+func TestPasswordReset(t *testing.T) {
+	t.Run("reset signs the account out of its admin sessions and API tokens", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		sessionClient := app.AdminClient(admin)
+		tokenClient := app.TokenClient(admin, models.APITokenScopeProfileRead)
+		tokenClient.Get("/api/profile").ExpectStatus(http.StatusOK)
+
+		sum := sha256.Sum256([]byte("reset-token"))
+		app.DB.Create(&models.PasswordResetToken{UserID: admin.ID, TokenHash: hex.EncodeToString(sum[:]), ExpiresAt: time.Now().Add(time.Hour)})
+		app.NewClient().Post("/reset-password", dtos.ResetPasswordRequest{Token: "reset-token", NewPassword: "a-new-password"}).
+			ExpectStatus(http.StatusOK)
+
+		sessionClient.Get("/admin/teams").ExpectStatus(http.StatusFound)
+		tokenClient.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+	})
+
+	t.Run("access tokens issued before the reset are rejected before they expire", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+		client := app.UserClient(user)
+		client.Get("/api/profile").ExpectStatus(http.StatusOK)
+
+		sum := sha256.Sum256([]byte("reset-token"))
+		app.DB.Create(&models.PasswordResetToken{UserID: user.ID, TokenHash: hex.EncodeToString(sum[:]), ExpiresAt: time.Now().Add(time.Hour)})
+		app.NewClient().Post("/reset-password", dtos.ResetPasswordRequest{Token: "reset-token", NewPassword: "a-new-password"}).
+			ExpectStatus(http.StatusOK)
+
+		client.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+		var login dtos.LoginResponse
+		newClient := app.NewClient()
+		newClient.Post("/login", apptest.LoginRequest(user.Email, "a-new-password")).ExpectStatus(http.StatusOK).JSON(&login)
+		newClient.SetHeader("Authorization", "Bearer "+login.User.AccessToken)
+		newClient.Get("/api/profile").ExpectStatus(http.StatusOK)
+	})
+}
+
+func TestAdminChangePassword(t *testing.T) {
+	t.Run("change signs the admin out everywhere but in the current session", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.CreateUser("Admin", "admin@example.com", "admin")
+		client, otherClient := app.AdminClient(admin), app.AdminClient(admin)
+		tokenClient := app.TokenClient(admin, models.APITokenScopeProfileRead)
+		client.SetCSRFToken("/admin/security/sessions")
+
+		client.Do(http.MethodPut, "/admin/security/password", dtos.ChangePasswordRequest{
+			CurrentPassword: "wrong-password", NewPassword: "a-new-password",
+		}).ExpectStatus(http.StatusBadRequest)
+		otherClient.Get("/admin/teams").ExpectStatus(http.StatusOK)
+
+		var resp dtos.RevokeSessionsResponse
+		client.Do(http.MethodPut, "/admin/security/password", dtos.ChangePasswordRequest{
+			CurrentPassword: apptest.Password, NewPassword: "a-new-password",
+		}).ExpectStatus(http.StatusOK).JSON(&resp)
+		if resp.Revoked != 1 {
+			t.Errorf("revoked %d sessions, want 1", resp.Revoked)
+		}
+
+		client.Get("/admin/teams").ExpectStatus(http.StatusOK)
+		otherClient.Get("/admin/teams").ExpectStatus(http.StatusFound)
+		tokenClient.Get("/api/profile").ExpectStatus(http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatHandler struct {
+	chatService services.ChatService
+}
+
+func NewChatHandler(chatService services.ChatService) *ChatHandler {
+	return &ChatHandler{chatService: chatService}
+}
+
+// HandleCommand answers a slash command, the request was verified by the ChatCommandAuthMiddleware
+func (h *ChatHandler) HandleCommand(c *gin.Context) {
+	var req dtos.ChatCommandRequest
+	if appErrors.HandleBindError(c, c.ShouldBind(&req)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.ChatCommandResponse{
+		ResponseType: "ephemeral",
+		Text:         h.chatService.HandleCommand(c.Request.Context(), req.Text),
+	})
+}
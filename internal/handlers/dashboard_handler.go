@@ -2,14 +2,19 @@ package handlers
 
 import (
 	"net/http"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-type DashboardHandler struct{}
+type DashboardHandler struct {
+	celebrationService services.CelebrationService
+}
 
-func NewDashboardHandler() *DashboardHandler {
-	return &DashboardHandler{}
+func NewDashboardHandler(celebrationService services.CelebrationService) *DashboardHandler {
+	return &DashboardHandler{celebrationService: celebrationService}
 }
 
 func (h *DashboardHandler) DashboardPageHandler(c *gin.Context) {
@@ -17,3 +22,27 @@ func (h *DashboardHandler) DashboardPageHandler(c *gin.Context) {
 		"title": "Dashboard",
 	})
 }
+
+func (h *DashboardHandler) GetUpcomingCelebrations(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var query dtos.UpcomingCelebrationsRequest
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+	if query.Days == 0 {
+		query.Days = 30
+	}
+
+	resp, err := h.celebrationService.GetUpcomingCelebrations(c.Request.Context(), userId, query.Days)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to get upcoming celebrations")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
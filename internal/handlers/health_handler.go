@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	healthService services.HealthService
+}
+
+func NewHealthHandler(healthService services.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// Liveness answers as long as the process serves requests, it checks no dependency so that an outage of
+// the database does not get the app restarted
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, dtos.HealthResponse{Status: dtos.HealthStatusOK})
+}
+
+// Readiness answers 503 while the app can't serve requests, see HealthService
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	response := h.healthService.Readiness(c.Request.Context())
+	status := http.StatusOK
+	if response.Status != dtos.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, response)
+}
@@ -0,0 +1,79 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+)
+
+func TestHealth(t *testing.T) {
+	t.Run("a migrated app is alive and ready", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		var liveness dtos.HealthResponse
+		client.Get("/healthz").ExpectStatus(http.StatusOK).JSON(&liveness)
+		if liveness.Status != dtos.HealthStatusOK {
+			t.Errorf("liveness status is %q, want ok", liveness.Status)
+		}
+
+		var readiness dtos.HealthResponse
+		client.Get("/readyz").ExpectStatus(http.StatusOK).JSON(&readiness)
+		if readiness.Status != dtos.HealthStatusOK || len(readiness.Checks) != 2 {
+			t.Errorf("readiness is %+v, want ok with the database and migrations checks", readiness)
+		}
+	})
+
+	t.Run("pending or failed migrations make the app unready", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		app.DB.Exec("UPDATE schema_migrations SET version = 1")
+		expectFailingCheck(t, client, "migrations")
+
+		app.DB.Exec("UPDATE schema_migrations SET version = 99990101000000, dirty = ?", true)
+		expectFailingCheck(t, client, "migrations")
+
+		// A schema migrated ahead by a newer release still serves this one
+		app.DB.Exec("UPDATE schema_migrations SET dirty = ?", false)
+		client.Get("/readyz").ExpectStatus(http.StatusOK)
+	})
+
+	t.Run("an unreachable database makes the app unready but not dead", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+		sqlDB, err := app.DB.DB()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sqlDB.Close()
+		expectFailingCheck(t, client, "database")
+		client.Get("/healthz").ExpectStatus(http.StatusOK)
+	})
+
+	t.Run("a stopping app is unready", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		app.Container.HealthService.MarkShuttingDown()
+		expectFailingCheck(t, client, "server")
+	})
+}
+
+// expectFailingCheck expects the readiness probe to answer 503 because of the named check
+func expectFailingCheck(t *testing.T, client *apptest.Client, name string) {
+	t.Helper()
+	var readiness dtos.HealthResponse
+	client.Get("/readyz").ExpectStatus(http.StatusServiceUnavailable).JSON(&readiness)
+	if readiness.Status != dtos.HealthStatusUnavailable {
+		t.Errorf("readiness status is %q, want unavailable", readiness.Status)
+	}
+	for _, check := range readiness.Checks {
+		if check.Name == name && check.Status == dtos.HealthStatusFailing && check.Message != "" {
+			return
+		}
+	}
+	t.Errorf("checks are %+v, want %s failing", readiness.Checks, name)
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LeaveHandler struct {
+	leaveService services.LeaveService
+}
+
+func NewLeaveHandler(leaveService services.LeaveService) *LeaveHandler {
+	return &LeaveHandler{leaveService: leaveService}
+}
+
+func (h *LeaveHandler) LeavesPageHandler(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/leaves.html", gin.H{
+		"title": "Leaves",
+	})
+}
+
+func (h *LeaveHandler) TeamCalendarPageHandler(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/team_calendar.html", gin.H{
+		"title": "Team Calendar",
+	})
+}
+
+func (h *LeaveHandler) CreateLeave(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var request dtos.CreateLeaveRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	leave, err := h.leaveService.CreateLeave(c.Request.Context(), userId, request)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to create leave request")
+		return
+	}
+
+	c.JSON(http.StatusCreated, leave)
+}
+
+func (h *LeaveHandler) ListMyLeaves(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var query dtos.PaginationRequestQuery
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	resp, err := h.leaveService.ListMyLeaves(c.Request.Context(), userId, query.Limit, query.Offset)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to list leave requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *LeaveHandler) ListPendingLeaves(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	resp, err := h.leaveService.ListPendingLeaves(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to list pending leave requests")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *LeaveHandler) ApproveLeave(c *gin.Context) {
+	h.reviewLeave(c, h.leaveService.ApproveLeave, "Leave request approved")
+}
+
+func (h *LeaveHandler) RejectLeave(c *gin.Context) {
+	h.reviewLeave(c, h.leaveService.RejectLeave, "Leave request rejected")
+}
+
+func (h *LeaveHandler) reviewLeave(
+	c *gin.Context,
+	review func(c context.Context, id, reviewerID uint, req dtos.ReviewLeaveRequest) error,
+	successMessage string,
+) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	leaveId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid leave request ID")
+		return
+	}
+
+	var request dtos.ReviewLeaveRequest
+	if c.Request.ContentLength > 0 {
+		if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+			return
+		}
+	}
+
+	if err := review(c.Request.Context(), uint(leaveId), userId, request); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to review leave request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": successMessage})
+}
+
+func (h *LeaveHandler) CancelLeave(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	leaveId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid leave request ID")
+		return
+	}
+
+	if err := h.leaveService.CancelLeave(c.Request.Context(), uint(leaveId), userId); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to cancel leave request")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Leave request cancelled"})
+}
+
+func (h *LeaveHandler) GetTeamCalendar(c *gin.Context) {
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	var query dtos.TeamLeaveCalendarRequest
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+	// Default to the current month
+	if query.From.IsZero() {
+		now := time.Now()
+		query.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	if query.To.IsZero() {
+		query.To = query.From.AddDate(0, 1, -1)
+	}
+
+	resp, err := h.leaveService.GetTeamCalendar(c.Request.Context(), uint(teamId), query.From, query.To)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to get team calendar")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *LeaveHandler) GetMyICalendar(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	calendar, err := h.leaveService.GetUserICalendar(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to build calendar")
+		return
+	}
+
+	respondICalendar(c, "my-leaves.ics", calendar)
+}
+
+func (h *LeaveHandler) GetTeamICalendar(c *gin.Context) {
+	teamId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	calendar, err := h.leaveService.GetTeamICalendar(c.Request.Context(), uint(teamId))
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to build calendar")
+		return
+	}
+
+	respondICalendar(c, "team-"+strconv.Itoa(teamId)+"-leaves.ics", calendar)
+}
+
+// CreateCalendarFeed gives the user new secret feed URLs to subscribe to in a calendar client
+func (h *LeaveHandler) CreateCalendarFeed(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	feed, err := h.leaveService.CreateCalendarFeed(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to create calendar feed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+// RevokeCalendarFeed stops the secret feed URLs of the user from working
+func (h *LeaveHandler) RevokeCalendarFeed(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	if err := h.leaveService.RevokeCalendarFeed(c.Request.Context(), userId); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to revoke calendar feed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+func respondICalendar(c *gin.Context, filename, calendar string) {
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
@@ -0,0 +1,90 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+	"trieu_mock_project_go/types"
+)
+
+func TestLeaveReview(t *testing.T) {
+	t.Run("member who moved teams is reviewed by their new leader", func(t *testing.T) {
+		app := apptest.New(t)
+		admin := app.TokenClient(app.CreateUser("Admin", "admin@example.com", "admin"), models.APITokenScopeAdminWrite)
+		formerLeader := app.CreateUser("Former Leader", "former@example.com", "user")
+		newLeader := app.CreateUser("New Leader", "new@example.com", "user")
+		member := app.CreateUser("Member", "member@example.com", "user")
+		formerTeam, newTeam := app.CreateTeam("Atlas", formerLeader), app.CreateTeam("Borealis", newLeader)
+
+		admin.Post(fmt.Sprintf("/admin/teams/%d/members", formerTeam.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusOK)
+		var leave dtos.LeaveRequest
+		app.UserClient(member).Post("/api/leaves", nextWeekLeave()).ExpectStatus(http.StatusCreated).JSON(&leave)
+		admin.Post(fmt.Sprintf("/admin/teams/%d/members", newTeam.ID), dtos.AddMemberRequest{UserID: member.ID}).
+			ExpectStatus(http.StatusOK)
+
+		var pending dtos.PendingLeaveRequestsResponse
+		app.UserClient(formerLeader).Get("/api/leaves/pending").ExpectStatus(http.StatusOK).JSON(&pending)
+		if len(pending.Leaves) != 0 {
+			t.Errorf("former leader has %d pending leaves, want 0", len(pending.Leaves))
+		}
+		app.UserClient(newLeader).Get("/api/leaves/pending").ExpectStatus(http.StatusOK).JSON(&pending)
+		if len(pending.Leaves) != 1 {
+			t.Errorf("new leader has %d pending leaves, want 1", len(pending.Leaves))
+		}
+
+		approvePath := fmt.Sprintf("/api/leaves/%d/approve", leave.ID)
+		app.UserClient(formerLeader).Do(http.MethodPut, approvePath, dtos.ReviewLeaveRequest{}).ExpectStatus(http.StatusForbidden)
+		app.UserClient(newLeader).Do(http.MethodPut, approvePath, dtos.ReviewLeaveRequest{}).ExpectStatus(http.StatusOK)
+	})
+}
+
+func TestLeaveCalendarFeed(t *testing.T) {
+	t.Run("calendar clients subscribe with the secret URL until it is revoked", func(t *testing.T) {
+		app := apptest.New(t)
+		// The summary with the long name is folded over several lines
+		leader := app.CreateUser(strings.Repeat("Leader With A Long Name ", 8), "leader@example.com", "user")
+		team := app.CreateTeam("Atlas", leader)
+		client := app.UserClient(leader)
+		client.Post("/api/leaves", nextWeekLeave()).ExpectStatus(http.StatusCreated)
+
+		var feed dtos.CalendarFeedResponse
+		client.Post("/api/leaves/calendar-feed", nil).ExpectStatus(http.StatusCreated).JSON(&feed)
+		if want := fmt.Sprintf("/calendar/teams/%d/leaves.ics?token=%s", team.ID, feed.Token); feed.TeamLeavesPath != want {
+			t.Errorf("team feed is %q, want %q", feed.TeamLeavesPath, want)
+		}
+
+		// A calendar client sends no Authorization header
+		subscriber := app.NewClient()
+		body := string(subscriber.Get(feed.LeavesPath).ExpectStatus(http.StatusOK).Body)
+		if !strings.Contains(body, "BEGIN:VEVENT") {
+			t.Errorf("feed has no event:\n%s", body)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(body, "\r\n"), "\r\n") {
+			if len(line) > 75 {
+				t.Errorf("line of %d octets, want 75 at most: %q", len(line), line)
+			}
+		}
+		subscriber.Get(feed.TeamLeavesPath).ExpectStatus(http.StatusOK)
+		subscriber.Get("/calendar/leaves.ics?token=not-a-token").ExpectStatus(http.StatusUnauthorized)
+
+		client.Delete("/api/leaves/calendar-feed").ExpectStatus(http.StatusOK)
+		subscriber.Get(feed.LeavesPath).ExpectStatus(http.StatusUnauthorized)
+	})
+}
+
+// nextWeekLeave asks for Monday to Wednesday of the coming week
+func nextWeekLeave() dtos.CreateLeaveRequest {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	monday := today.AddDate(0, 0, 8-int(today.Weekday()))
+	return dtos.CreateLeaveRequest{
+		Type:      models.LeaveTypeAnnual,
+		StartDate: &types.Date{Time: monday},
+		EndDate:   &types.Date{Time: monday.AddDate(0, 0, 2)},
+	}
+}
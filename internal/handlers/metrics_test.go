@@ -0,0 +1,47 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"trieu_mock_project_go/internal/apptest"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("scrapes need the token", func(t *testing.T) {
+		app := apptest.New(t)
+		client := app.NewClient()
+
+		client.Get("/metrics").ExpectStatus(http.StatusUnauthorized)
+		client.SetHeader("Authorization", "Bearer wrong-token")
+		client.Get("/metrics").ExpectStatus(http.StatusUnauthorized)
+	})
+
+	t.Run("requests, logins and records are counted", func(t *testing.T) {
+		app := apptest.New(t)
+		team := app.CreateTeam("Atlas", app.CreateUser("Leader", "leader@example.com", "user"))
+		member := app.CreateUser("Member", "member@example.com", "user")
+		client := app.NewClient()
+
+		app.UserClient(member).Get(fmt.Sprintf("/api/teams/%d", team.ID)).ExpectStatus(http.StatusOK)
+		client.Post("/login", apptest.LoginRequest(member.Email, "wrong-password")).ExpectStatus(http.StatusUnauthorized)
+
+		client.SetHeader("Authorization", "Bearer "+apptest.MetricsToken)
+		body := string(client.Get("/metrics").ExpectStatus(http.StatusOK).Body)
+		for _, want := range []string{
+			`http_requests_total{method="GET",route="/api/teams/:id",status="200"} 1`,
+			`app_logins_total{flow="user",result="invalid_credentials"} 1`,
+			`app_logins_total{flow="user",result="success"} 1`,
+			`app_users{state="active"} 2`,
+			`app_teams 1`,
+			`app_active_team_memberships 1`,
+			`db_query_duration_seconds_count{operation="query",table="users"}`,
+			`go_sql_open_connections{db_name="sqlite"}`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("metrics lack %s", want)
+			}
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var query dtos.PaginationRequestQuery
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+
+	resp, err := h.notificationService.ListNotifications(c.Request.Context(), userId, query.Limit, query.Offset)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to list notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	notificationIdParam := c.Param("id")
+	notificationId, err := strconv.Atoi(notificationIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(c.Request.Context(), uint(notificationId), userId); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to mark notification as read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
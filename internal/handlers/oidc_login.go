@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/oidc"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// How long the provider may take to send the browser back after a single sign-on started
+const oidcPendingLoginTTL = 10 * time.Minute
+
+var oidcSessionKeys = []string{"oidc_flow", "oidc_state", "oidc_nonce", "oidc_code_verifier", "oidc_started_at"}
+
+// beginOIDCLogin keeps the auth request in the session and sends the browser to the provider
+func beginOIDCLogin(c *gin.Context, oidcService services.OIDCService, flow, callbackPath string) error {
+	request, authURL, err := oidcService.BeginLogin(c.Request.Context(), oidcService.RedirectURI(callbackPath))
+	if err != nil {
+		return err
+	}
+
+	session := sessions.Default(c)
+	session.Set("oidc_flow", flow)
+	session.Set("oidc_state", request.State)
+	session.Set("oidc_nonce", request.Nonce)
+	session.Set("oidc_code_verifier", request.CodeVerifier)
+	session.Set("oidc_started_at", time.Now().Unix())
+	if err := session.Save(); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	c.Redirect(http.StatusSeeOther, authURL)
+	return nil
+}
+
+// finishOIDCLogin checks that the provider callback answers the sign-on this browser started and completes it.
+// The pending auth request is consumed either way.
+func finishOIDCLogin(c *gin.Context, oidcService services.OIDCService, callbackPath string, meta services.LoginMeta) (*services.LoginResult, error) {
+	session := sessions.Default(c)
+	request, ok := pendingOIDCRequest(session, meta.Flow)
+	for _, key := range oidcSessionKeys {
+		session.Delete(key)
+	}
+	if err := session.Save(); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	if !ok || c.Query("error") != "" || c.Query("code") == "" || c.Query("state") != request.State {
+		return nil, appErrors.ErrSSOFailed
+	}
+	return oidcService.FinishLogin(c.Request.Context(), c.Query("code"), oidcService.RedirectURI(callbackPath), request, meta)
+}
+
+func pendingOIDCRequest(session sessions.Session, flow string) (*oidc.AuthRequest, bool) {
+	if session.Get("oidc_flow") != flow {
+		return nil, false
+	}
+	startedAt, ok := session.Get("oidc_started_at").(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > oidcPendingLoginTTL {
+		return nil, false
+	}
+
+	state, _ := session.Get("oidc_state").(string)
+	nonce, _ := session.Get("oidc_nonce").(string)
+	codeVerifier, _ := session.Get("oidc_code_verifier").(string)
+	if state == "" || nonce == "" || codeVerifier == "" {
+		return nil, false
+	}
+	return &oidc.AuthRequest{State: state, Nonce: nonce, CodeVerifier: codeVerifier}, true
+}
+
+// ssoErrorMessage is the message shown on the login page when a single sign-on fails
+func ssoErrorMessage(err error) string {
+	if err == appErrors.ErrForbidden {
+		return "Your account does not have access to the admin panel"
+	}
+	var retryErr *appErrors.RetryAfterError
+	if errors.As(err, &retryErr) {
+		return retryErr.Message
+	}
+	var appErr *appErrors.AppError
+	if errors.As(err, &appErr) && appErr.Status != http.StatusInternalServerError {
+		return appErr.Message
+	}
+	return "Single sign-on failed, please try again"
+}
@@ -11,10 +11,10 @@ import (
 )
 
 type TeamsHandler struct {
-	teamsService *services.TeamsService
+	teamsService services.TeamsService
 }
 
-func NewTeamsHandler(teamsService *services.TeamsService) *TeamsHandler {
+func NewTeamsHandler(teamsService services.TeamsService) *TeamsHandler {
 	return &TeamsHandler{teamsService: teamsService}
 }
 
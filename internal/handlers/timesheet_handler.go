@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimesheetHandler struct {
+	timesheetService services.TimesheetService
+}
+
+func NewTimesheetHandler(timesheetService services.TimesheetService) *TimesheetHandler {
+	return &TimesheetHandler{timesheetService: timesheetService}
+}
+
+func (h *TimesheetHandler) TimesheetPageHandler(c *gin.Context) {
+	c.HTML(http.StatusOK, "pages/timesheet.html", gin.H{
+		"title": "Timesheet",
+	})
+}
+
+func (h *TimesheetHandler) GetProjects(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	resp, err := h.timesheetService.GetProjectsForTimesheet(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to list projects")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TimesheetHandler) GetTimesheet(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var query dtos.GetTimesheetRequest
+	if appErrors.HandleBindError(c, c.ShouldBindQuery(&query)) {
+		return
+	}
+	if query.Week.IsZero() {
+		query.Week = time.Now()
+	}
+
+	resp, err := h.timesheetService.GetTimesheet(c.Request.Context(), userId, query.Week)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to get timesheet")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TimesheetHandler) SaveTimeEntry(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var request dtos.SaveTimeEntryRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	resp, err := h.timesheetService.SaveTimeEntry(c.Request.Context(), userId, request)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to save time entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TimesheetHandler) DeleteTimeEntry(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	entryId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid time entry ID")
+		return
+	}
+
+	if err := h.timesheetService.DeleteTimeEntry(c.Request.Context(), userId, uint(entryId)); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to delete time entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry deleted successfully"})
+}
+
+func (h *TimesheetHandler) SubmitTimesheet(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var request dtos.SubmitTimesheetRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	resp, err := h.timesheetService.SubmitTimesheet(c.Request.Context(), userId, request)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to submit timesheet")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TimesheetHandler) ListPendingTimesheets(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	resp, err := h.timesheetService.ListPendingTimesheets(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to list pending timesheets")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TimesheetHandler) ApproveTimesheet(c *gin.Context) {
+	h.reviewTimesheet(c, h.timesheetService.ApproveTimesheet, "Timesheet approved")
+}
+
+func (h *TimesheetHandler) RejectTimesheet(c *gin.Context) {
+	h.reviewTimesheet(c, h.timesheetService.RejectTimesheet, "Timesheet rejected")
+}
+
+func (h *TimesheetHandler) reviewTimesheet(
+	c *gin.Context,
+	review func(c context.Context, id, reviewerID uint, req dtos.ReviewTimesheetRequest) error,
+	successMessage string,
+) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	timesheetId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid timesheet ID")
+		return
+	}
+
+	var request dtos.ReviewTimesheetRequest
+	if c.Request.ContentLength > 0 {
+		if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+			return
+		}
+	}
+
+	if err := review(c.Request.Context(), uint(timesheetId), userId, request); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to review timesheet")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": successMessage})
+}
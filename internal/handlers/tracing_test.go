@@ -0,0 +1,58 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"trieu_mock_project_go/internal/apptest"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	t.Run("queries are children of the service call of the request", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		t.Cleanup(func() { provider.Shutdown(context.Background()) })
+		app := apptest.New(t)
+		app.CreateTeam("Atlas", app.CreateUser("Leader", "leader@example.com", "user"))
+		client := app.UserClient(app.CreateUser("Member", "member@example.com", "user"))
+
+		// Continues the trace of the caller
+		client.SetHeader("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		client.Get("/api/teams?limit=10").ExpectStatus(http.StatusOK)
+
+		spans := map[string]sdktrace.ReadOnlySpan{}
+		for _, span := range recorder.Ended() {
+			if span.SpanContext().TraceID().String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+				spans[span.Name()] = span
+			}
+		}
+		request, service, query := spans["GET /api/teams"], spans["TeamsService.ListTeams"], spans["db.query teams"]
+		if request == nil || service == nil || query == nil {
+			t.Fatalf("spans of the request are %v, want the request, the service call and the teams query", keys(spans))
+		}
+		if parent := request.Parent().SpanID().String(); parent != "00f067aa0ba902b7" {
+			t.Errorf("request span has parent %s, want the span of the caller", parent)
+		}
+		if service.Parent().SpanID() != request.SpanContext().SpanID() {
+			t.Error("service span is not a child of the request span")
+		}
+		if query.Parent().SpanID() != service.SpanContext().SpanID() {
+			t.Error("query span is not a child of the service span")
+		}
+	})
+}
+
+func keys(spans map[string]sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for name := range spans {
+		names = append(names, name)
+	}
+	return names
+}
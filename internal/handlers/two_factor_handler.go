@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorHandler manages the two-factor enrolment of the signed in user,
+// it serves both the JWT API and the admin panel
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	resp, err := h.twoFactorService.GetStatus(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to get two-factor status")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TwoFactorHandler) BeginSetup(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	resp, err := h.twoFactorService.BeginSetup(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to start two-factor setup")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var req dtos.TwoFactorCodeRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	recoveryCodes, err := h.twoFactorService.Enable(c.Request.Context(), userId, req.Code)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var req dtos.TwoFactorCodeRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c.Request.Context(), userId, req.Code); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled successfully"})
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var req dtos.TwoFactorCodeRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&req)) {
+		return
+	}
+
+	recoveryCodes, err := h.twoFactorService.RegenerateRecoveryCodes(c.Request.Context(), userId, req.Code)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
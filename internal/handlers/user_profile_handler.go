@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"trieu_mock_project_go/internal/dtos"
 	appErrors "trieu_mock_project_go/internal/errors"
 	"trieu_mock_project_go/internal/services"
 
@@ -10,12 +11,14 @@ import (
 )
 
 type UserProfileHandler struct {
-	userService *services.UserService
+	userService     services.UserService
+	positionService services.PositionService
 }
 
-func NewUserProfileHandler(userService *services.UserService) *UserProfileHandler {
+func NewUserProfileHandler(userService services.UserService, positionService services.PositionService) *UserProfileHandler {
 	return &UserProfileHandler{
-		userService: userService,
+		userService:     userService,
+		positionService: positionService,
 	}
 }
 
@@ -54,7 +57,12 @@ func (h *UserProfileHandler) GetUserProfile(c *gin.Context) {
 		return
 	}
 
-	userProfile, err := h.userService.GetUserProfile(c.Request.Context(), uint(userId))
+	var userProfile *dtos.UserProfile
+	if uint(userId) == c.GetUint("user_id") {
+		userProfile, err = h.userService.GetUserProfile(c.Request.Context(), uint(userId))
+	} else {
+		userProfile, err = h.userService.GetPublicUserProfile(c.Request.Context(), uint(userId))
+	}
 	if err != nil {
 		appErrors.RespondCustomError(c, err, "Failed to get user profile")
 		return
@@ -62,3 +70,56 @@ func (h *UserProfileHandler) GetUserProfile(c *gin.Context) {
 
 	c.JSON(http.StatusOK, userProfile)
 }
+
+func (h *UserProfileHandler) UpdateMyPreferences(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	var request dtos.UpdateProfilePreferencesRequest
+	if appErrors.HandleBindError(c, c.ShouldBindJSON(&request)) {
+		return
+	}
+
+	if err := h.userService.UpdateProfilePreferences(c.Request.Context(), userId, request); err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to update preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Preferences updated successfully"})
+}
+
+func (h *UserProfileHandler) GetMyPromotionReadiness(c *gin.Context) {
+	userId := c.GetUint("user_id")
+	if userId == 0 {
+		appErrors.RespondError(c, http.StatusUnauthorized, "Unauthorized access")
+		return
+	}
+
+	readiness, err := h.positionService.GetPromotionReadiness(c.Request.Context(), userId)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to get promotion readiness")
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
+
+func (h *UserProfileHandler) GetUserPromotionReadiness(c *gin.Context) {
+	userIdParam := c.Param("userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		appErrors.RespondError(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	readiness, err := h.positionService.GetPromotionReadiness(c.Request.Context(), uint(userId))
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "Failed to get promotion readiness")
+		return
+	}
+
+	c.JSON(http.StatusOK, readiness)
+}
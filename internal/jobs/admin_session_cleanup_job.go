@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+)
+
+// AdminSessionCleanupJob runs once at start and then hourly, deleting expired admin sessions
+type AdminSessionCleanupJob struct {
+	adminSessionService services.AdminSessionService
+	interval            time.Duration
+}
+
+func NewAdminSessionCleanupJob(adminSessionService services.AdminSessionService) *AdminSessionCleanupJob {
+	return &AdminSessionCleanupJob{
+		adminSessionService: adminSessionService,
+		interval:            time.Hour,
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *AdminSessionCleanupJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *AdminSessionCleanupJob) run(ctx context.Context) {
+	deleted, err := j.adminSessionService.DeleteExpiredSessions(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Admin session cleanup job failed", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Admin session cleanup job deleted expired sessions", "deleted", deleted)
+}
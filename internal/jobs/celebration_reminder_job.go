@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+)
+
+// CelebrationReminderJob runs once at start and then daily, notifying team members
+// about upcoming birthdays and work anniversaries of their teammates
+type CelebrationReminderJob struct {
+	celebrationService services.CelebrationService
+	interval           time.Duration
+}
+
+func NewCelebrationReminderJob(celebrationService services.CelebrationService) *CelebrationReminderJob {
+	return &CelebrationReminderJob{
+		celebrationService: celebrationService,
+		interval:           24 * time.Hour,
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *CelebrationReminderJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *CelebrationReminderJob) run(ctx context.Context) {
+	sent, err := j.celebrationService.SendCelebrationReminders(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Celebration reminder job failed", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Celebration reminder job sent notifications", "sent", sent)
+}
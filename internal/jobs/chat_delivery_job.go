@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+)
+
+// ChatDeliveryJob polls for queued chat messages that are due, new ones as well as retries, and hourly
+// deletes the messages posted or failed longer ago than the retention
+type ChatDeliveryJob struct {
+	chatNotifier    services.ChatNotifier
+	interval        time.Duration
+	cleanupInterval time.Duration
+	lastCleanup     time.Time
+}
+
+func NewChatDeliveryJob(chatNotifier services.ChatNotifier) *ChatDeliveryJob {
+	return &ChatDeliveryJob{
+		chatNotifier:    chatNotifier,
+		interval:        chatNotifier.PollInterval(),
+		cleanupInterval: time.Hour,
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *ChatDeliveryJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *ChatDeliveryJob) run(ctx context.Context) {
+	now := time.Now()
+	attempted, err := j.chatNotifier.PostDue(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Chat delivery job failed", "error", err)
+	} else if attempted > 0 {
+		// Quiet when idle, the job runs every few seconds
+		slog.InfoContext(ctx, "Chat delivery job attempted messages", "attempted", attempted)
+	}
+
+	if now.Sub(j.lastCleanup) < j.cleanupInterval {
+		return
+	}
+	j.lastCleanup = now
+	deleted, err := j.chatNotifier.DeleteFinishedMessages(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Chat message cleanup failed", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Chat delivery job deleted finished messages", "deleted", deleted)
+}
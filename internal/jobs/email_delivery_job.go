@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+)
+
+// EmailDeliveryJob polls for queued emails that are due, new ones as well as retries, and hourly
+// deletes the emails sent or failed longer ago than the retention
+type EmailDeliveryJob struct {
+	mailService     services.MailService
+	interval        time.Duration
+	cleanupInterval time.Duration
+	lastCleanup     time.Time
+}
+
+func NewEmailDeliveryJob(mailService services.MailService) *EmailDeliveryJob {
+	return &EmailDeliveryJob{
+		mailService:     mailService,
+		interval:        mailService.PollInterval(),
+		cleanupInterval: time.Hour,
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *EmailDeliveryJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *EmailDeliveryJob) run(ctx context.Context) {
+	now := time.Now()
+	attempted, err := j.mailService.SendDue(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Email delivery job failed", "error", err)
+	} else if attempted > 0 {
+		// Quiet when idle, the job runs every few seconds
+		slog.InfoContext(ctx, "Email delivery job attempted emails", "attempted", attempted)
+	}
+
+	if now.Sub(j.lastCleanup) < j.cleanupInterval {
+		return
+	}
+	j.lastCleanup = now
+	deleted, err := j.mailService.DeleteFinishedEmails(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Email cleanup failed", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Email delivery job deleted finished emails", "deleted", deleted)
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
+)
+
+// LDAPSyncJob runs once at start and then every LDAP_SYNC_INTERVAL_MINUTES, synchronising users
+// from the LDAP directory. It does nothing unless LDAP_SYNC_ENABLED is set.
+type LDAPSyncJob struct {
+	ldapSyncService services.LDAPSyncService
+	interval        time.Duration
+}
+
+func NewLDAPSyncJob(ldapSyncService services.LDAPSyncService) *LDAPSyncJob {
+	return &LDAPSyncJob{
+		ldapSyncService: ldapSyncService,
+		interval:        ldapSyncService.SyncInterval(),
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *LDAPSyncJob) Start(ctx context.Context) {
+	if !j.ldapSyncService.ScheduleEnabled() || j.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *LDAPSyncJob) run(ctx context.Context) {
+	run, err := j.ldapSyncService.Sync(ctx, models.LDAPSyncTriggerSchedule, false)
+	if err != nil {
+		if run != nil && run.Error != nil {
+			slog.ErrorContext(ctx, "LDAP sync job failed", "error", *run.Error, "run_id", run.ID)
+			return
+		}
+		slog.ErrorContext(ctx, "LDAP sync job failed", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "LDAP sync job finished",
+		"entries", run.DirectoryEntries,
+		"created", run.CreatedCount,
+		"updated", run.UpdatedCount,
+		"deactivated", run.DeactivatedCount,
+		"reactivated", run.ReactivatedCount,
+		"skipped", run.SkippedCount,
+	)
+}
@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+)
+
+// OutboxDispatchJob polls the outbox for domain events to dispatch, and hourly deletes
+// the events dispatched longer ago than the retention
+type OutboxDispatchJob struct {
+	outboxService   services.OutboxService
+	interval        time.Duration
+	cleanupInterval time.Duration
+	lastCleanup     time.Time
+}
+
+func NewOutboxDispatchJob(outboxService services.OutboxService) *OutboxDispatchJob {
+	return &OutboxDispatchJob{
+		outboxService:   outboxService,
+		interval:        outboxService.PollInterval(),
+		cleanupInterval: time.Hour,
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *OutboxDispatchJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *OutboxDispatchJob) run(ctx context.Context) {
+	now := time.Now()
+	if _, err := j.outboxService.DispatchDue(ctx, now); err != nil {
+		slog.ErrorContext(ctx, "Outbox dispatch job failed", "error", err)
+	}
+
+	if now.Sub(j.lastCleanup) < j.cleanupInterval {
+		return
+	}
+	j.lastCleanup = now
+	deleted, err := j.outboxService.DeleteDispatchedEvents(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "Outbox cleanup failed", "error", err)
+		return
+	}
+	slog.InfoContext(ctx, "Outbox dispatch job deleted dispatched events", "deleted", deleted)
+}
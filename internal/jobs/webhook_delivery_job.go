@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"trieu_mock_project_go/internal/services"
+)
+
+// WebhookDeliveryJob polls for webhook deliveries that are due, new ones as well as retries
+type WebhookDeliveryJob struct {
+	webhookService services.WebhookService
+	interval       time.Duration
+}
+
+func NewWebhookDeliveryJob(webhookService services.WebhookService) *WebhookDeliveryJob {
+	return &WebhookDeliveryJob{
+		webhookService: webhookService,
+		interval:       webhookService.PollInterval(),
+	}
+}
+
+// Start blocks until ctx is cancelled
+func (j *WebhookDeliveryJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+func (j *WebhookDeliveryJob) run(ctx context.Context) {
+	attempted, err := j.webhookService.DeliverDue(ctx, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "Webhook delivery job failed", "error", err)
+		return
+	}
+	// Quiet when idle, the job runs every few seconds
+	if attempted > 0 {
+		slog.InfoContext(ctx, "Webhook delivery job attempted deliveries", "attempted", attempted)
+	}
+}
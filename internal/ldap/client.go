@@ -0,0 +1,154 @@
+// Package ldap reads users out of a directory with go-ldap, over TLS unless plaintext is explicitly allowed.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// ErrPlaintextBind refuses to send the bind password unencrypted
+var ErrPlaintextBind = errors.New("ldap: refusing to bind over an unencrypted connection, use ldaps://, StartTLS or allow plaintext binds")
+
+// Config describes how to reach and authenticate to the directory
+type Config struct {
+	// URL is ldap://host[:389] or ldaps://host[:636]
+	URL          string
+	BindDN       string
+	BindPassword string
+	// StartTLS upgrades ldap:// connections to TLS before binding
+	StartTLS bool
+	// AllowPlaintextBind lets an ldap:// connection without StartTLS bind with the password in cleartext
+	AllowPlaintextBind bool
+	Timeout            time.Duration
+}
+
+// Entry is one object returned by a search
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Value returns the first value of the attribute, attribute names are case-insensitive
+func (e *Entry) Value(attribute string) string {
+	values := e.Values(attribute)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (e *Entry) Values(attribute string) []string {
+	for name, values := range e.Attributes {
+		if strings.EqualFold(name, attribute) {
+			return values
+		}
+	}
+	return nil
+}
+
+// Conn is a bound connection to the directory
+type Conn struct {
+	conn *goldap.Conn
+	stop func() bool
+}
+
+// Dial connects to the directory and binds with the configured credentials, an empty BindDN binds anonymously.
+// A connection that is not encrypted, with ldaps:// or StartTLS, is refused before the password is sent
+// unless AllowPlaintextBind is set.
+func Dial(ctx context.Context, cfg Config) (*Conn, error) {
+	target, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid URL %q: %w", cfg.URL, err)
+	}
+	if target.Scheme != "ldap" && target.Scheme != "ldaps" {
+		return nil, fmt.Errorf("ldap: unsupported URL scheme %q", target.Scheme)
+	}
+	encrypted := target.Scheme == "ldaps" || cfg.StartTLS
+	if !encrypted && cfg.BindDN != "" && !cfg.AllowPlaintextBind {
+		return nil, ErrPlaintextBind
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	tlsConfig := &tls.Config{ServerName: target.Hostname()}
+	conn, err := goldap.DialURL(cfg.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+
+	if target.Scheme == "ldap" && cfg.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("ldap: StartTLS failed: %w", err)
+		}
+	}
+	if cfg.BindDN != "" {
+		err = conn.Bind(cfg.BindDN, cfg.BindPassword)
+	} else {
+		err = conn.UnauthenticatedBind("")
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// Stops a search in flight when the caller gives up
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	if ctx.Err() != nil {
+		stop()
+		return nil, ctx.Err()
+	}
+	return &Conn{conn: conn, stop: stop}, nil
+}
+
+// Close unbinds and closes the connection
+func (c *Conn) Close() error {
+	c.stop()
+	return c.conn.Unbind()
+}
+
+// Search returns the entries below baseDN matching the filter, reading them in pages of pageSize
+// so that servers with a size limit, such as Active Directory, return everything
+func (c *Conn) Search(ctx context.Context, baseDN, filter string, attributes []string, pageSize int) ([]Entry, error) {
+	request := goldap.NewSearchRequest(baseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false, filter, attributes, nil)
+
+	var result *goldap.SearchResult
+	var err error
+	if pageSize > 0 {
+		result, err = c.conn.SearchWithPaging(request, uint32(pageSize))
+	} else {
+		result, err = c.conn.Search(request)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		attributes := make(map[string][]string, len(entry.Attributes))
+		for _, attribute := range entry.Attributes {
+			attributes[attribute.Name] = append(attributes[attribute.Name], attribute.Values...)
+		}
+		entries = append(entries, Entry{DN: entry.DN, Attributes: attributes})
+	}
+	return entries, nil
+}
@@ -0,0 +1,82 @@
+package ldap_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/ldap"
+)
+
+func TestDial(t *testing.T) {
+	t.Run("bind over a cleartext connection is refused before connecting", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listening: %v", err)
+		}
+		defer listener.Close()
+		accepted := make(chan struct{}, 1)
+		go func() {
+			if conn, err := listener.Accept(); err == nil {
+				accepted <- struct{}{}
+				conn.Close()
+			}
+		}()
+
+		_, err = ldap.Dial(context.Background(), ldap.Config{
+			URL:          "ldap://" + listener.Addr().String(),
+			BindDN:       "cn=reader,dc=example,dc=com",
+			BindPassword: "secret",
+			Timeout:      time.Second,
+		})
+		if !errors.Is(err, ldap.ErrPlaintextBind) {
+			t.Errorf("dial returned %v, want %v", err, ldap.ErrPlaintextBind)
+		}
+		select {
+		case <-accepted:
+			t.Error("the directory was contacted before the bind was refused")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("StartTLS failure does not fall back to cleartext", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listening: %v", err)
+		}
+		defer listener.Close()
+		// A server that hangs up on the StartTLS request
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Close()
+			}
+		}()
+
+		conn, err := ldap.Dial(context.Background(), ldap.Config{
+			URL:          "ldap://" + listener.Addr().String(),
+			BindDN:       "cn=reader,dc=example,dc=com",
+			BindPassword: "secret",
+			StartTLS:     true,
+			Timeout:      time.Second,
+		})
+		if err == nil {
+			conn.Close()
+			t.Error("dial succeeded without TLS")
+		}
+	})
+}
+
+func TestEntry(t *testing.T) {
+	entry := ldap.Entry{Attributes: map[string][]string{"mail": {"member@example.com", "alias@example.com"}}}
+	if value := entry.Value("MAIL"); value != "member@example.com" {
+		t.Errorf("MAIL is %q, want the first mail value", value)
+	}
+	if value := entry.Value("title"); value != "" {
+		t.Errorf("missing title is %q, want empty", value)
+	}
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// gormLogger writes the queries of GORM to the default slog logger, in the context of the request that ran
+// them. Failed queries are logged as errors, queries slower than slowThreshold as warnings and every other
+// query only at the Info level. Queries are logged with placeholders, never with their values.
+type gormLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormLogger returns the logger of GORM, logging every query when logQueries is set. A slowThreshold of
+// zero turns slow query warnings off.
+func NewGormLogger(logQueries bool, slowThreshold time.Duration) logger.Interface {
+	level := logger.Warn
+	if logQueries {
+		level = logger.Info
+	}
+	return &gormLogger{level: level, slowThreshold: slowThreshold}
+}
+
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	var level slog.Level
+	message := "sql query"
+	switch {
+	// Missing rows are answered with not found errors, they are not failures of the database
+	case err != nil && !errors.Is(err, logger.ErrRecordNotFound) && l.level >= logger.Error:
+		level, message = slog.LevelError, "sql query failed"
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= logger.Warn:
+		level, message = slog.LevelWarn, "slow sql query"
+	case l.level >= logger.Info:
+		level = slog.LevelInfo
+	default:
+		return
+	}
+	if !slog.Default().Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if rows >= 0 {
+		attrs = append(attrs, slog.Int64("rows", rows))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	slog.LogAttrs(ctx, level, message, attrs...)
+}
+
+// ParamsFilter drops the values of the query so that passwords, tokens and personal data are not logged
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
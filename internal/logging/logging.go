@@ -0,0 +1,130 @@
+// Package logging sets up the structured logs of the app: JSON or text records written with log/slog,
+// tagged with the ID of the request they were written for and with passwords and tokens redacted.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redacted replaces the value of a sensitive attribute or query parameter
+const Redacted = "[REDACTED]"
+
+// Key fragments of the attributes and query parameters whose value is never logged
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+
+// Setup makes a logger writing to stderr the default of log/slog and of the log package. Level is "debug",
+// "info", "warn" or "error" and format is "json" or "text", unknown values fall back to info and JSON.
+func Setup(level, format string) *slog.Logger {
+	logger := New(os.Stderr, level, format)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New returns a logger writing to w, see Setup
+func New(w io.Writer, level, format string) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level:       ParseLevel(level),
+		ReplaceAttr: redactAttr,
+	}
+	var handler slog.Handler
+	if strings.EqualFold(format, "text") {
+		handler = slog.NewTextHandler(w, options)
+	} else {
+		handler = slog.NewJSONHandler(w, options)
+	}
+	return slog.New(contextHandler{Handler: handler})
+}
+
+// ParseLevel parses the name of a level, info when it is unknown
+func ParseLevel(level string) slog.Level {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return parsed
+}
+
+// IsSensitiveKey tells if the value of an attribute or query parameter named key must not be logged
+func IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	// The authorization code of OAuth and OIDC callbacks
+	if key == "code" {
+		return true
+	}
+	for _, fragment := range sensitiveKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactQuery returns the raw query with the values of sensitive parameters replaced
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Redacted
+	}
+	for key := range values {
+		if IsSensitiveKey(key) {
+			values[key] = []string{Redacted}
+		}
+	}
+	return values.Encode()
+}
+
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	return attr
+}
+
+// contextHandler adds the ID of the request and the trace of the context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()), slog.String("span_id", spanContext.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, logs written with it are tagged with the ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, empty outside of a request
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,54 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"trieu_mock_project_go/internal/logging"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("records carry the request ID and hide secrets", func(t *testing.T) {
+		var out bytes.Buffer
+		logger := logging.New(&out, "info", "json")
+		ctx := logging.ContextWithRequestID(context.Background(), "req-1")
+
+		logger.InfoContext(ctx, "login", "email", "member@example.com", "password", "hunter2",
+			slog.Group("oidc", "client_secret", "s3cret", "code", "abc"), "Authorization", "Bearer xyz")
+
+		var record map[string]any
+		if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+			t.Fatalf("decoding %s: %v", out.String(), err)
+		}
+		if record["request_id"] != "req-1" || record["email"] != "member@example.com" {
+			t.Errorf("record %v lost the request ID or the email", record)
+		}
+		for _, secret := range []string{"hunter2", "s3cret", "abc", "xyz"} {
+			if strings.Contains(out.String(), secret) {
+				t.Errorf("record %s shows %q", out.String(), secret)
+			}
+		}
+	})
+
+	t.Run("records below the level are dropped", func(t *testing.T) {
+		var out bytes.Buffer
+		logger := logging.New(&out, "warn", "text")
+
+		logger.Info("ignored")
+		logger.Warn("kept")
+		if strings.Contains(out.String(), "ignored") || !strings.Contains(out.String(), "kept") {
+			t.Errorf("logged %q, want only the warning", out.String())
+		}
+	})
+}
+
+func TestRedactQuery(t *testing.T) {
+	got := logging.RedactQuery("page=2&token=abc&code=xyz&state=s")
+	want := "code=%5BREDACTED%5D&page=2&state=s&token=%5BREDACTED%5D"
+	if got != want {
+		t.Errorf("RedactQuery is %q, want %q", got, want)
+	}
+}
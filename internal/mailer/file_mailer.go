@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+)
+
+type fileMailer struct {
+	dir string
+}
+
+// NewFileMailer writes every email to an .eml file in dir instead of sending it, for development
+func NewFileMailer(dir string) Mailer {
+	return &fileMailer{dir: dir}
+}
+
+func (m *fileMailer) Send(ctx context.Context, message *Message) error {
+	now := time.Now()
+	data, err := message.Build(now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(m.dir, now.Format("20060102-150405")+"-*.eml")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Email written to file", "to", message.To, "subject", message.Subject, "file", file.Name())
+	return nil
+}
@@ -0,0 +1,18 @@
+package mailer
+
+import (
+	"context"
+	"log/slog"
+)
+
+type logMailer struct{}
+
+// NewLogMailer writes the plain text of every email to the log instead of sending it, for development
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(ctx context.Context, message *Message) error {
+	slog.InfoContext(ctx, "Email", "to", message.To, "from", message.From, "subject", message.Subject, "body", message.TextBody)
+	return nil
+}
@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"context"
+	"time"
+)
+
+// Message is an email with an HTML body and a plain text alternative
+type Message struct {
+	From     string
+	To       string
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Mailer sends emails. Send returning nil means the email was handed over, not that it was delivered.
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// deadline bounds ctx by timeout, the earlier of both wins
+func deadline(ctx context.Context, timeout time.Duration) time.Time {
+	limit := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(limit) {
+		return d
+	}
+	return limit
+}
@@ -0,0 +1,128 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// TLS modes of an SMTP connection
+const (
+	SMTPTLSStartTLS = "starttls"
+	SMTPTLSImplicit = "tls"
+	SMTPTLSNone     = "none"
+)
+
+type smtpMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	tlsMode  string
+	timeout  time.Duration
+}
+
+// NewSMTPMailer sends every email over a new connection to the SMTP server. The credentials are
+// only used over TLS, with tlsMode "none" they must be empty.
+func NewSMTPMailer(host string, port int, username, password, tlsMode string, timeout time.Duration) Mailer {
+	return &smtpMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		tlsMode:  tlsMode,
+		timeout:  timeout,
+	}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, message *Message) error {
+	data, err := message.Build(time.Now())
+	if err != nil {
+		return err
+	}
+	from, err := envelopeAddress(message.From)
+	if err != nil {
+		return err
+	}
+	to, err := envelopeAddress(message.To)
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support authentication")
+		}
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// dial connects and greets the server, upgrading the connection to TLS as configured
+func (m *smtpMailer) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	dialer := &net.Dialer{Deadline: deadline(ctx, m.timeout)}
+	tlsConfig := &tls.Config{ServerName: m.host}
+
+	var conn net.Conn
+	var err error
+	if m.tlsMode == SMTPTLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Bounds the whole conversation, not only the connect
+	if err := conn.SetDeadline(dialer.Deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if m.tlsMode == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
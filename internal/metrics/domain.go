@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"time"
+	"trieu_mock_project_go/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// Counting is given up after this long, so that a slow database does not hold scrapes
+const domainCountTimeout = 5 * time.Second
+
+var (
+	usersDesc             = prometheus.NewDesc("app_users", "Users, by state: active or deactivated.", []string{"state"}, nil)
+	teamsDesc             = prometheus.NewDesc("app_teams", "Teams.", nil, nil)
+	activeMembershipsDesc = prometheus.NewDesc("app_active_team_memberships",
+		"Team memberships that have not ended.", nil, nil)
+)
+
+// domainCollector counts the users, teams and active memberships when the metrics are scraped
+type domainCollector struct {
+	db *gorm.DB
+}
+
+func newDomainCollector(db *gorm.DB) prometheus.Collector {
+	return &domainCollector{db: db}
+}
+
+func (c *domainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- usersDesc
+	ch <- teamsDesc
+	ch <- activeMembershipsDesc
+}
+
+func (c *domainCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), domainCountTimeout)
+	defer cancel()
+	db := c.db.WithContext(ctx)
+
+	c.collectCount(ch, usersDesc, db.Model(&models.User{}).Where("deactivated_at IS NULL"), "active")
+	c.collectCount(ch, usersDesc, db.Model(&models.User{}).Where("deactivated_at IS NOT NULL"), "deactivated")
+	c.collectCount(ch, teamsDesc, db.Model(&models.Team{}))
+	c.collectCount(ch, activeMembershipsDesc, db.Model(&models.TeamMember{}).Where("left_at IS NULL"))
+}
+
+func (c *domainCollector) collectCount(ch chan<- prometheus.Metric, desc *prometheus.Desc, query *gorm.DB, labelValues ...string) {
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		ch <- prometheus.NewInvalidMetric(desc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), labelValues...)
+}
@@ -0,0 +1,97 @@
+// Package metrics collects the Prometheus metrics of the app: HTTP requests per route, SQL queries, the
+// connection pool, logins and counts of the main domain records, served in the text format by Handler.
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+// Route label of the requests that matched no route, so that random paths do not each create a series
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the collectors of one app, each app has its own registry so tests can start several
+type Metrics struct {
+	registry      *prometheus.Registry
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+	loginsTotal   *prometheus.CounterVec
+}
+
+// New creates the collectors and instruments the queries of db. Counting the domain records runs queries
+// on db when the metrics are scraped.
+func New(db *gorm.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests handled, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time taken to handle HTTP requests, by method and route template.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Time taken by SQL queries run through GORM, by operation and table.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"operation", "table"}),
+		loginsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "app_logins_total",
+			Help: "Login attempts, by flow and result: success or the reason of the failure.",
+		}, []string{"flow", "result"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.queryDuration,
+		m.loginsTotal,
+		newDomainCollector(db),
+	)
+
+	if sqlDB, err := db.DB(); err == nil {
+		m.registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, db.Dialector.Name()))
+	}
+	if err := m.instrumentQueries(db); err != nil {
+		slog.Error("Failed to instrument SQL queries for metrics", "error", err)
+	}
+	return m
+}
+
+// Handler serves the metrics, a collector failing leaves its metrics out instead of failing the scrape
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(slog.Default().Handler(), slog.LevelError),
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// ObserveRequest counts a handled request, route is the template it matched or empty when none did
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = unmatchedRoute
+	}
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// LoginSucceeded counts a completed login of the flow
+func (m *Metrics) LoginSucceeded(flow string) {
+	m.loginsTotal.WithLabelValues(flow, "success").Inc()
+}
+
+// LoginFailed counts a rejected login of the flow, reason is one of the login failure reasons of the audit
+func (m *Metrics) LoginFailed(flow, reason string) {
+	m.loginsTotal.WithLabelValues(flow, reason).Inc()
+}
@@ -2,69 +2,117 @@ package middlewares
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
 	"trieu_mock_project_go/internal/utils"
+	"trieu_mock_project_go/models"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 )
 
-// extractAndValidateToken extracts and validates JWT token from Authorization header
-// Returns (userID, email, error) with error constants from errors package
-func extractAndValidateToken(c *gin.Context) (uint, string, error) {
+// extractBearerToken extracts the token from the "Bearer <token>" Authorization header
+func extractBearerToken(c *gin.Context) (string, error) {
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
-		return 0, "", appErrors.ErrMissingAuthHeader
+		return "", appErrors.ErrMissingAuthHeader
 	}
 
-	// Extract token from "Bearer <token>"
 	parts := strings.SplitN(authHeader, " ", 2)
 	if len(parts) != 2 || parts[0] != "Bearer" {
-		return 0, "", appErrors.ErrInvalidAuthHeader
+		return "", appErrors.ErrInvalidAuthHeader
 	}
+	return parts[1], nil
+}
 
-	tokenString := parts[1]
-	claims, err := utils.ParseJWTToken(tokenString)
+// authenticateAPIToken validates a personal access token and sets the user and the token scopes on the context
+func authenticateAPIToken(c *gin.Context, apiTokenService services.APITokenService, tokenString string) (*models.APIToken, error) {
+	token, err := apiTokenService.Authenticate(c.Request.Context(), tokenString, c.ClientIP())
 	if err != nil {
-		return 0, "", appErrors.ErrInvalidToken
+		return nil, err
 	}
 
-	return claims.UserID, claims.Email, nil
+	c.Set("user_id", token.UserID)
+	c.Set("email", token.User.Email)
+	c.Set("api_token_scopes", token.ScopeList())
+	return token, nil
 }
 
-// JWTAuthMiddleware checks JWT token from Authorization header (required)
-func JWTAuthMiddleware() gin.HandlerFunc {
+// JWTAuthMiddleware checks the JWT or personal access token from Authorization header (required).
+// JWTs of users deactivated or whose password changed since they signed in are rejected before they expire.
+func JWTAuthMiddleware(apiTokenService services.APITokenService, authService services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID, email, err := extractAndValidateToken(c)
+		tokenString, err := extractBearerToken(c)
 		if err != nil {
-			switch err {
-			case appErrors.ErrMissingAuthHeader, appErrors.ErrInvalidAuthHeader, appErrors.ErrInvalidToken:
-				appErrors.RespondError(c, http.StatusUnauthorized, err.Error())
-			default:
-				appErrors.RespondError(c, http.StatusUnauthorized, "authentication failed")
+			appErrors.RespondError(c, http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		if services.IsAPIToken(tokenString) {
+			if _, err := authenticateAPIToken(c, apiTokenService, tokenString); err != nil {
+				appErrors.RespondCustomError(c, err, "authentication failed")
+				c.Abort()
+				return
 			}
+			c.Next()
+			return
+		}
+
+		claims, err := utils.ParseJWTToken(tokenString)
+		if err != nil {
+			appErrors.RespondError(c, http.StatusUnauthorized, appErrors.ErrInvalidToken.Error())
+			c.Abort()
+			return
+		}
+		if err := authService.CheckAccessToken(c.Request.Context(), claims.UserID, claims.TokenVersion); err != nil {
+			appErrors.RespondCustomError(c, err, "authentication failed")
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", userID)
-		c.Set("email", email)
+		c.Set("user_id", claims.UserID)
+		c.Set("email", claims.Email)
 		c.Next()
 	}
 }
 
-func AdminAuthMiddleware() gin.HandlerFunc {
+// APITokenScopeMiddleware limits requests made with a personal access token to the routes its scopes grant,
+// routeScopes maps "METHOD /full/path" to the required scope and routes missing from it are denied.
+// Requests authenticated otherwise pass through.
+func APITokenScopeMiddleware(routeScopes map[string]string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		value, ok := c.Get("api_token_scopes")
+		if !ok {
+			c.Next()
+			return
+		}
+		scopes, _ := value.([]string)
+
+		required, ok := routeScopes[c.Request.Method+" "+c.FullPath()]
+		if !ok || !slices.Contains(scopes, required) {
+			appErrors.RespondCustomError(c, appErrors.ErrAPITokenInsufficientScope, "forbidden")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// AdminAuthMiddleware checks the admin session, or a personal access token of an admin sent as a Bearer token.
+// The session of an admin deactivated since they signed in is ended.
+func AdminAuthMiddleware(apiTokenService services.APITokenService, authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "" {
+			adminAPITokenAuth(c, apiTokenService)
+			return
+		}
+
 		session := sessions.Default(c)
 		if session.Get("user_id") == nil {
-			if c.GetHeader("HX-Request") == "true" {
-				c.Header("HX-Redirect", "/admin/login")
-				c.Abort()
-			} else {
-				c.Redirect(302, "/admin/login")
-				c.Abort()
-			}
+			redirectToAdminLogin(c)
 			return
 		}
 		role := session.Get("role")
@@ -75,7 +123,61 @@ func AdminAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		userID, ok := session.Get("user_id").(uint)
+		if !ok {
+			redirectToAdminLogin(c)
+			return
+		}
+		if err := authService.CheckActive(c.Request.Context(), userID); err != nil {
+			if err == appErrors.ErrInternalServerError {
+				appErrors.RespondCustomError(c, err, "authentication failed")
+				c.Abort()
+				return
+			}
+			session.Clear()
+			session.Save()
+			redirectToAdminLogin(c)
+			return
+		}
+		c.Set("user_id", userID)
 		c.Next()
 	}
 
 }
+
+func redirectToAdminLogin(c *gin.Context) {
+	if c.GetHeader("HX-Request") == "true" {
+		c.Header("HX-Redirect", "/admin/login")
+	} else {
+		c.Redirect(302, "/admin/login")
+	}
+	c.Abort()
+}
+
+func adminAPITokenAuth(c *gin.Context, apiTokenService services.APITokenService) {
+	tokenString, err := extractBearerToken(c)
+	if err == nil && !services.IsAPIToken(tokenString) {
+		err = appErrors.ErrInvalidToken
+	}
+	if err != nil {
+		appErrors.RespondError(c, http.StatusUnauthorized, err.Error())
+		c.Abort()
+		return
+	}
+
+	token, err := authenticateAPIToken(c, apiTokenService, tokenString)
+	if err != nil {
+		appErrors.RespondCustomError(c, err, "authentication failed")
+		c.Abort()
+		return
+	}
+	if token.User.Role != "admin" {
+		appErrors.RespondCustomError(c, appErrors.ErrForbidden, "forbidden")
+		c.Abort()
+		return
+	}
+
+	// Bearer tokens are never attached by the browser, so these requests skip the CSRF check
+	c.Set("api_token_auth", true)
+	c.Next()
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarFeedAuthMiddleware authenticates the calendar feeds with the secret token in their URL, calendar
+// clients subscribe to a URL and cannot send a Bearer token. The token only opens the feeds.
+func CalendarFeedAuthMiddleware(leaveService services.LeaveService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := leaveService.AuthenticateCalendarFeed(c.Request.Context(), c.Query("token"))
+		if err != nil {
+			appErrors.RespondCustomError(c, err, "Unauthorized access")
+			c.Abort()
+			return
+		}
+		c.Set("user_id", userID)
+		c.Next()
+	}
+}
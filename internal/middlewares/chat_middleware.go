@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Slash command bodies are small form posts
+const maxChatCommandBodySize = 64 << 10
+
+// ChatCommandAuthMiddleware accepts slash commands signed with the Slack signing secret, or carrying the
+// Mattermost command token. The endpoint answers 404 while neither is configured.
+func ChatCommandAuthMiddleware(cfg config.ChatConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.SigningSecret == "" && cfg.CommandToken == "" {
+			appErrors.RespondError(c, http.StatusNotFound, "chat commands are not enabled")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxChatCommandBodySize+1))
+		if err != nil || len(body) > maxChatCommandBodySize {
+			appErrors.RespondError(c, http.StatusBadRequest, "invalid chat command")
+			c.Abort()
+			return
+		}
+		// Put the body back for the form parsing below and in the handler
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if !chatCommandAuthorized(c, cfg, body) {
+			appErrors.RespondError(c, http.StatusUnauthorized, "invalid chat command signature")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func chatCommandAuthorized(c *gin.Context, cfg config.ChatConfig, body []byte) bool {
+	if signature := c.GetHeader("X-Slack-Signature"); signature != "" && cfg.SigningSecret != "" {
+		return utils.VerifyChatRequestSignature(cfg.SigningSecret, c.GetHeader("X-Slack-Request-Timestamp"), body, signature, time.Now())
+	}
+	if cfg.CommandToken != "" {
+		token := c.PostForm("token")
+		if token == "" {
+			// Mattermost also sends the token as "Authorization: Token <token>"
+			if headerToken, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Token "); ok {
+				token = headerToken
+			}
+		}
+		return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(cfg.CommandToken)) == 1
+	}
+	return false
+}
@@ -2,19 +2,26 @@ package middlewares
 
 import (
 	"net/http"
-	"trieu_mock_project_go/internal/config"
 
 	"github.com/gin-gonic/gin"
 	csrf "github.com/utrack/gin-csrf"
 )
 
-func CSRFMiddleware() gin.HandlerFunc {
-	cfg := config.LoadConfig()
-	return csrf.Middleware(csrf.Options{
-		Secret: cfg.SessionConfig.Secret,
+// CSRFMiddleware checks the CSRF token of unsafe requests, secret signs the tokens
+func CSRFMiddleware(secret string) gin.HandlerFunc {
+	csrfMiddleware := csrf.Middleware(csrf.Options{
+		Secret: secret,
 		ErrorFunc: func(c *gin.Context) {
 			c.String(http.StatusBadRequest, "CSRF token mismatch")
 			c.Abort()
 		},
 	})
+	return func(c *gin.Context) {
+		// Requests authenticated with an API token carry no cookies a forged request could ride on
+		if c.GetBool("api_token_auth") {
+			c.Next()
+			return
+		}
+		csrfMiddleware(c)
+	}
 }
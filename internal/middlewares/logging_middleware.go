@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/logging"
+	"trieu_mock_project_go/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the ID of a request, from a proxy in front of the app and back in the response
+const RequestIDHeader = "X-Request-ID"
+
+// Request IDs set by clients are kept when they are short and printable, otherwise a new one is generated
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware gives every request an ID, returned in the X-Request-ID header and in error responses
+// and added to the logs written in the context of the request, including its SQL queries
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			generated, err := utils.NewUUID()
+			if err != nil {
+				generated = "unknown"
+			}
+			requestID = generated
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logging.ContextWithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// probePaths are the health checks of the orchestrator
+var probePaths = map[string]bool{"/healthz": true, "/readyz": true}
+
+// RequestLoggerMiddleware logs every request once it is handled, server errors as errors and client errors
+// as warnings, successful probes at debug level. Sensitive query parameters such as tokens and authorization
+// codes are redacted.
+func RequestLoggerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case probePaths[c.Request.URL.Path]:
+			// Probes of the orchestrator arrive every few seconds, only their failures are worth reading
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if query := logging.RedactQuery(c.Request.URL.RawQuery); query != "" {
+			attrs = append(attrs, slog.String("query", query))
+		}
+		if userID, ok := c.Get("user_id"); ok {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		if errs := c.Errors.String(); errs != "" {
+			attrs = append(attrs, slog.String("errors", errs))
+		}
+		slog.LogAttrs(c.Request.Context(), level, "http request", attrs...)
+	}
+}
+
+// RecoveryMiddleware answers 500 when a handler panics and logs the panic with its stack trace
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		slog.ErrorContext(c.Request.Context(), "panic while handling request",
+			"panic", recovered,
+			"stack", string(debug.Stack()),
+		)
+		appErrors.RespondError(c, http.StatusInternalServerError, "internal server error")
+		c.Abort()
+	})
+}
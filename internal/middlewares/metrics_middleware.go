@@ -0,0 +1,42 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware counts and times every request by the route template it matched, not by its path,
+// so that /teams/1 and /teams/2 share their series
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		m.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// MetricsAuthMiddleware lets scrapers sending the configured token as a bearer token read the metrics.
+// The endpoint answers 404 while no token is configured.
+func MetricsAuthMiddleware(cfg config.MetricsConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if cfg.Token == "" {
+			appErrors.RespondError(c, http.StatusNotFound, "metrics are not enabled")
+			c.Abort()
+			return
+		}
+
+		token, err := extractBearerToken(c)
+		if err != nil || subtle.ConstantTimeCompare([]byte(token), []byte(cfg.Token)) != 1 {
+			appErrors.RespondError(c, http.StatusUnauthorized, "invalid metrics token")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
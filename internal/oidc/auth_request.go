@@ -0,0 +1,45 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// AuthRequest holds the values that tie the provider callback to the login that started it.
+// They are kept server-side until the callback arrives.
+type AuthRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+func NewAuthRequest() (*AuthRequest, error) {
+	state, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := randomString(48)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthRequest{State: state, Nonce: nonce, CodeVerifier: codeVerifier}, nil
+}
+
+// CodeChallenge is the S256 PKCE challenge of the code verifier, RFC 7636 section 4.2
+func (r *AuthRequest) CodeChallenge() string {
+	sum := sha256.Sum256([]byte(r.CodeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type ActivityLogRepository interface {
+	Create(db *gorm.DB, activityLog *models.ActivityLog) error
+	Search(db *gorm.DB, action *string, limit, offset int) ([]models.ActivityLog, int64, error)
+}
+
+type activityLogRepository struct {
+}
+
+func NewActivityLogRepository() ActivityLogRepository {
+	return &activityLogRepository{}
+}
+
+func (r *activityLogRepository) Create(db *gorm.DB, activityLog *models.ActivityLog) error {
+	return db.Create(activityLog).Error
+}
+
+// Search returns the entries with the action, or all of them, newest first
+func (r *activityLogRepository) Search(db *gorm.DB, action *string, limit, offset int) ([]models.ActivityLog, int64, error) {
+	query := db.Model(&models.ActivityLog{})
+	if action != nil {
+		query = query.Where("action = ?", *action)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var activityLogs []models.ActivityLog
+	result := query.
+		Preload("User").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&activityLogs)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return activityLogs, count, nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type AdminSessionRepository interface {
+	FindActiveByTokenHash(db *gorm.DB, tokenHash string, now time.Time) (*models.AdminSession, error)
+	Save(db *gorm.DB, session *models.AdminSession) error
+	Touch(db *gorm.DB, tokenHash string, lastSeenAt, expiresAt time.Time) error
+	DeleteByTokenHash(db *gorm.DB, tokenHash string) error
+	FindActiveByUserID(db *gorm.DB, userID uint, now time.Time) ([]models.AdminSession, error)
+	DeleteByIDAndUserID(db *gorm.DB, id, userID uint) (bool, error)
+	DeleteByUserIDExcept(db *gorm.DB, userID uint, exceptTokenHash string) (int64, error)
+	DeleteExpired(db *gorm.DB, now time.Time) (int64, error)
+}
+
+type adminSessionRepository struct {
+}
+
+func NewAdminSessionRepository() AdminSessionRepository {
+	return &adminSessionRepository{}
+}
+
+// FindActiveByTokenHash returns the session unless it has expired
+func (r *adminSessionRepository) FindActiveByTokenHash(db *gorm.DB, tokenHash string, now time.Time) (*models.AdminSession, error) {
+	var session models.AdminSession
+	result := db.Where("token_hash = ? AND expires_at > ?", tokenHash, now).First(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// Save inserts the session, or updates all of its columns when it already has an ID
+func (r *adminSessionRepository) Save(db *gorm.DB, session *models.AdminSession) error {
+	return db.Save(session).Error
+}
+
+func (r *adminSessionRepository) Touch(db *gorm.DB, tokenHash string, lastSeenAt, expiresAt time.Time) error {
+	return db.Model(&models.AdminSession{}).
+		Where("token_hash = ?", tokenHash).
+		Updates(map[string]interface{}{
+			"last_seen_at": lastSeenAt,
+			"expires_at":   expiresAt,
+		}).Error
+}
+
+func (r *adminSessionRepository) DeleteByTokenHash(db *gorm.DB, tokenHash string) error {
+	return db.Where("token_hash = ?", tokenHash).Delete(&models.AdminSession{}).Error
+}
+
+func (r *adminSessionRepository) FindActiveByUserID(db *gorm.DB, userID uint, now time.Time) ([]models.AdminSession, error) {
+	var sessions []models.AdminSession
+	result := db.
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC, id DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+// DeleteByIDAndUserID deletes a session of the user, reporting false when the user has no such session
+func (r *adminSessionRepository) DeleteByIDAndUserID(db *gorm.DB, id, userID uint) (bool, error) {
+	result := db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.AdminSession{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteByUserIDExcept deletes every session of the user but the one with the given token hash
+func (r *adminSessionRepository) DeleteByUserIDExcept(db *gorm.DB, userID uint, exceptTokenHash string) (int64, error) {
+	result := db.Where("user_id = ? AND token_hash <> ?", userID, exceptTokenHash).Delete(&models.AdminSession{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *adminSessionRepository) DeleteExpired(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Where("expires_at <= ?", now).Delete(&models.AdminSession{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type APITokenRepository interface {
+	Create(db *gorm.DB, token *models.APIToken) error
+	FindByTokenHash(db *gorm.DB, tokenHash string) (*models.APIToken, error)
+	FindByUserID(db *gorm.DB, userID uint) ([]models.APIToken, error)
+	CountByUserID(db *gorm.DB, userID uint) (int64, error)
+	UpdateLastUsed(db *gorm.DB, id uint, lastUsedAt time.Time, ipAddress string) error
+	DeleteByIDAndUserID(db *gorm.DB, id, userID uint) (bool, error)
+	DeleteByUserID(db *gorm.DB, userID uint) (int64, error)
+}
+
+type apiTokenRepository struct {
+}
+
+func NewAPITokenRepository() APITokenRepository {
+	return &apiTokenRepository{}
+}
+
+func (r *apiTokenRepository) Create(db *gorm.DB, token *models.APIToken) error {
+	return db.Create(token).Error
+}
+
+// FindByTokenHash returns the token with its user, expired tokens included
+func (r *apiTokenRepository) FindByTokenHash(db *gorm.DB, tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	result := db.
+		Preload("User").
+		Where("token_hash = ?", tokenHash).
+		First(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) FindByUserID(db *gorm.DB, userID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	result := db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) CountByUserID(db *gorm.DB, userID uint) (int64, error) {
+	var count int64
+	result := db.Model(&models.APIToken{}).
+		Where("user_id = ?", userID).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+func (r *apiTokenRepository) UpdateLastUsed(db *gorm.DB, id uint, lastUsedAt time.Time, ipAddress string) error {
+	return db.Model(&models.APIToken{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": lastUsedAt,
+			"last_used_ip": ipAddress,
+		}).Error
+}
+
+// DeleteByIDAndUserID deletes the token only if it belongs to the user, reporting whether it did
+func (r *apiTokenRepository) DeleteByIDAndUserID(db *gorm.DB, id, userID uint) (bool, error) {
+	result := db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.APIToken{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteByUserID revokes every token of the user and returns how many there were
+func (r *apiTokenRepository) DeleteByUserID(db *gorm.DB, userID uint) (int64, error) {
+	result := db.Where("user_id = ?", userID).Delete(&models.APIToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type CareerTrackRepository interface {
+	FindAllCareerTrackSummary(db *gorm.DB) ([]models.CareerTrack, error)
+	FindByID(db *gorm.DB, id uint) (*models.CareerTrack, error)
+	SearchCareerTracks(db *gorm.DB, limit, offset int) ([]models.CareerTrack, int64, error)
+	Create(db *gorm.DB, careerTrack *models.CareerTrack) error
+	Update(db *gorm.DB, careerTrack *models.CareerTrack) error
+	Delete(db *gorm.DB, id uint) error
+	ExistsPositionsWithCareerTrackID(db *gorm.DB, careerTrackID uint) (bool, error)
+}
+
+type careerTrackRepository struct {
+}
+
+func NewCareerTrackRepository() CareerTrackRepository {
+	return &careerTrackRepository{}
+}
+
+func (r *careerTrackRepository) FindAllCareerTrackSummary(db *gorm.DB) ([]models.CareerTrack, error) {
+	var careerTracks []models.CareerTrack
+	result := db.
+		Select("id", "name").
+		Find(&careerTracks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return careerTracks, nil
+}
+
+func (r *careerTrackRepository) FindByID(db *gorm.DB, id uint) (*models.CareerTrack, error) {
+	var careerTrack models.CareerTrack
+	result := db.
+		Preload("Positions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("grade ASC")
+		}).
+		First(&careerTrack, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &careerTrack, nil
+}
+
+func (r *careerTrackRepository) SearchCareerTracks(db *gorm.DB, limit, offset int) ([]models.CareerTrack, int64, error) {
+	var careerTracks []models.CareerTrack
+	query := db.Model(&models.CareerTrack{})
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Positions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("grade ASC")
+		}).
+		Limit(limit).
+		Offset(offset).
+		Find(&careerTracks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return careerTracks, count, nil
+}
+
+func (r *careerTrackRepository) Create(db *gorm.DB, careerTrack *models.CareerTrack) error {
+	return db.Create(careerTrack).Error
+}
+
+func (r *careerTrackRepository) Update(db *gorm.DB, careerTrack *models.CareerTrack) error {
+	return db.Model(&models.CareerTrack{}).
+		Where("id = ?", careerTrack.ID).
+		Updates(map[string]interface{}{
+			"name":        careerTrack.Name,
+			"description": careerTrack.Description,
+		}).Error
+}
+
+func (r *careerTrackRepository) Delete(db *gorm.DB, id uint) error {
+	return db.Delete(&models.CareerTrack{}, id).Error
+}
+
+func (r *careerTrackRepository) ExistsPositionsWithCareerTrackID(db *gorm.DB, careerTrackID uint) (bool, error) {
+	var position models.Position
+	err := db.
+		Select("id").
+		Where("career_track_id = ?", careerTrackID).
+		First(&position).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type ChatMessageRepository interface {
+	Create(db *gorm.DB, message *models.ChatMessage) error
+	Update(db *gorm.DB, message *models.ChatMessage) error
+	FindDue(db *gorm.DB, now time.Time, limit int) ([]models.ChatMessage, error)
+	Claim(db *gorm.DB, message *models.ChatMessage, leaseUntil time.Time) (bool, error)
+	DeleteFinishedBefore(db *gorm.DB, createdBefore time.Time) (int64, error)
+}
+
+type chatMessageRepository struct {
+}
+
+func NewChatMessageRepository() ChatMessageRepository {
+	return &chatMessageRepository{}
+}
+
+func (r *chatMessageRepository) Create(db *gorm.DB, message *models.ChatMessage) error {
+	return db.Create(message).Error
+}
+
+func (r *chatMessageRepository) Update(db *gorm.DB, message *models.ChatMessage) error {
+	return db.Save(message).Error
+}
+
+// FindDue returns pending messages whose next attempt is due, oldest first
+func (r *chatMessageRepository) FindDue(db *gorm.DB, now time.Time, limit int) ([]models.ChatMessage, error) {
+	var messages []models.ChatMessage
+	result := db.
+		Where("status = ? AND next_attempt_at <= ?", models.ChatMessageStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return messages, nil
+}
+
+// Claim moves the next attempt of a due message to leaseUntil, so that no other worker picks it up while it is posted.
+// It reports false when another worker claimed the message first.
+func (r *chatMessageRepository) Claim(db *gorm.DB, message *models.ChatMessage, leaseUntil time.Time) (bool, error) {
+	result := db.Model(&models.ChatMessage{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", message.ID, models.ChatMessageStatusPending, message.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteFinishedBefore deletes the sent and failed messages queued before createdBefore
+func (r *chatMessageRepository) DeleteFinishedBefore(db *gorm.DB, createdBefore time.Time) (int64, error) {
+	result := db.
+		Where("status IN ? AND created_at < ?", []string{models.ChatMessageStatusSent, models.ChatMessageStatusFailed}, createdBefore).
+		Delete(&models.ChatMessage{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type EmailMessageRepository interface {
+	Create(db *gorm.DB, message *models.EmailMessage) error
+	Update(db *gorm.DB, message *models.EmailMessage) error
+	FindDue(db *gorm.DB, now time.Time, limit int) ([]models.EmailMessage, error)
+	Claim(db *gorm.DB, message *models.EmailMessage, leaseUntil time.Time) (bool, error)
+	FailPendingBefore(db *gorm.DB, kind string, createdBefore time.Time, errMessage string) (int64, error)
+	DeleteFinishedBefore(db *gorm.DB, createdBefore time.Time) (int64, error)
+}
+
+type emailMessageRepository struct {
+}
+
+func NewEmailMessageRepository() EmailMessageRepository {
+	return &emailMessageRepository{}
+}
+
+func (r *emailMessageRepository) Create(db *gorm.DB, message *models.EmailMessage) error {
+	return db.Create(message).Error
+}
+
+func (r *emailMessageRepository) Update(db *gorm.DB, message *models.EmailMessage) error {
+	return db.Save(message).Error
+}
+
+// FindDue returns pending emails whose next attempt is due, oldest first
+func (r *emailMessageRepository) FindDue(db *gorm.DB, now time.Time, limit int) ([]models.EmailMessage, error) {
+	var messages []models.EmailMessage
+	result := db.
+		Where("status = ? AND next_attempt_at <= ?", models.EmailMessageStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return messages, nil
+}
+
+// Claim moves the next attempt of a due email to leaseUntil, so that no other worker picks it up while it is sent.
+// It reports false when another worker claimed the email first.
+func (r *emailMessageRepository) Claim(db *gorm.DB, message *models.EmailMessage, leaseUntil time.Time) (bool, error) {
+	result := db.Model(&models.EmailMessage{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", message.ID, models.EmailMessageStatusPending, message.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FailPendingBefore gives up on the pending emails of the kind queued before createdBefore, and clears their bodies
+func (r *emailMessageRepository) FailPendingBefore(db *gorm.DB, kind string, createdBefore time.Time, errMessage string) (int64, error) {
+	result := db.Model(&models.EmailMessage{}).
+		Where("kind = ? AND status = ? AND created_at < ?", kind, models.EmailMessageStatusPending, createdBefore).
+		Updates(map[string]interface{}{
+			"status":          models.EmailMessageStatusFailed,
+			"error":           errMessage,
+			"next_attempt_at": nil,
+			"html_body":       "",
+			"text_body":       "",
+		})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteFinishedBefore deletes the sent and failed emails queued before createdBefore
+func (r *emailMessageRepository) DeleteFinishedBefore(db *gorm.DB, createdBefore time.Time) (int64, error) {
+	result := db.
+		Where("status IN ? AND created_at < ?", []string{models.EmailMessageStatusSent, models.EmailMessageStatusFailed}, createdBefore).
+		Delete(&models.EmailMessage{})
+	return result.RowsAffected, result.Error
+}
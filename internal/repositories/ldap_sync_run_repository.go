@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type LDAPSyncRunRepository interface {
+	Create(db *gorm.DB, run *models.LDAPSyncRun) error
+	Update(db *gorm.DB, run *models.LDAPSyncRun) error
+	FindByID(db *gorm.DB, id uint) (*models.LDAPSyncRun, error)
+	SearchRuns(db *gorm.DB, limit, offset int) ([]models.LDAPSyncRun, int64, error)
+}
+
+type ldapSyncRunRepository struct {
+}
+
+func NewLDAPSyncRunRepository() LDAPSyncRunRepository {
+	return &ldapSyncRunRepository{}
+}
+
+func (r *ldapSyncRunRepository) Create(db *gorm.DB, run *models.LDAPSyncRun) error {
+	return db.Create(run).Error
+}
+
+func (r *ldapSyncRunRepository) Update(db *gorm.DB, run *models.LDAPSyncRun) error {
+	return db.Save(run).Error
+}
+
+func (r *ldapSyncRunRepository) FindByID(db *gorm.DB, id uint) (*models.LDAPSyncRun, error) {
+	var run models.LDAPSyncRun
+	result := db.First(&run, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &run, nil
+}
+
+// SearchRuns returns the runs without their details, newest first
+func (r *ldapSyncRunRepository) SearchRuns(db *gorm.DB, limit, offset int) ([]models.LDAPSyncRun, int64, error) {
+	var count int64
+	if err := db.Model(&models.LDAPSyncRun{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var runs []models.LDAPSyncRun
+	result := db.
+		Omit("details").
+		Order("started_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&runs)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return runs, count, nil
+}
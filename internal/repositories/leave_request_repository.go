@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type LeaveRequestRepository interface {
+	Create(db *gorm.DB, leave *models.LeaveRequest) error
+	FindByID(db *gorm.DB, id uint) (*models.LeaveRequest, error)
+	FindByUserID(db *gorm.DB, userID uint, limit, offset int) ([]models.LeaveRequest, error)
+	CountByUserID(db *gorm.DB, userID uint) (int64, error)
+	FindPendingByLeaderID(db *gorm.DB, leaderID uint) ([]models.LeaveRequest, error)
+	FindByTeamIDBetween(db *gorm.DB, teamID uint, from, to time.Time, statuses []string) ([]models.LeaveRequest, error)
+	FindByUserIDAndStatuses(db *gorm.DB, userID uint, statuses []string) ([]models.LeaveRequest, error)
+	FindByTeamIDAndStatuses(db *gorm.DB, teamID uint, statuses []string) ([]models.LeaveRequest, error)
+	ExistsOverlapping(db *gorm.DB, userID uint, from, to time.Time) (bool, error)
+	UpdateStatus(db *gorm.DB, leave *models.LeaveRequest) error
+}
+
+type leaveRequestRepository struct {
+}
+
+func NewLeaveRequestRepository() LeaveRequestRepository {
+	return &leaveRequestRepository{}
+}
+
+func (r *leaveRequestRepository) Create(db *gorm.DB, leave *models.LeaveRequest) error {
+	return db.Create(leave).Error
+}
+
+func (r *leaveRequestRepository) FindByID(db *gorm.DB, id uint) (*models.LeaveRequest, error) {
+	var leave models.LeaveRequest
+	result := db.
+		Preload("User").
+		Preload("Team").
+		Preload("Reviewer").
+		First(&leave, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &leave, nil
+}
+
+func (r *leaveRequestRepository) FindByUserID(db *gorm.DB, userID uint, limit, offset int) ([]models.LeaveRequest, error) {
+	var leaves []models.LeaveRequest
+	result := db.
+		Preload("User").
+		Preload("Team").
+		Preload("Reviewer").
+		Where("user_id = ?", userID).
+		Order("start_date DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&leaves)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return leaves, nil
+}
+
+func (r *leaveRequestRepository) CountByUserID(db *gorm.DB, userID uint) (int64, error) {
+	var count int64
+	result := db.Model(&models.LeaveRequest{}).
+		Where("user_id = ?", userID).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// FindPendingByLeaderID returns pending requests of the current members of the teams led by the user,
+// excluding the leader's own requests
+func (r *leaveRequestRepository) FindPendingByLeaderID(db *gorm.DB, leaderID uint) ([]models.LeaveRequest, error) {
+	var leaves []models.LeaveRequest
+	result := db.
+		Preload("User").
+		Preload("Team").
+		Joins("JOIN team_members ON team_members.user_id = leave_requests.user_id AND team_members.left_at IS NULL").
+		Joins("JOIN teams ON teams.id = team_members.team_id").
+		Where("teams.leader_id = ? AND leave_requests.user_id <> ? AND leave_requests.status = ?", leaderID, leaderID, models.LeaveStatusPending).
+		Order("leave_requests.start_date ASC, leave_requests.id ASC").
+		Find(&leaves)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return leaves, nil
+}
+
+// FindByTeamIDBetween returns leaves of the team with the given statuses overlapping the [from, to] date range
+func (r *leaveRequestRepository) FindByTeamIDBetween(db *gorm.DB, teamID uint, from, to time.Time, statuses []string) ([]models.LeaveRequest, error) {
+	var leaves []models.LeaveRequest
+	result := db.
+		Preload("User").
+		Where("team_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?", teamID, statuses, to, from).
+		Order("start_date ASC, id ASC").
+		Find(&leaves)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return leaves, nil
+}
+
+// FindByUserIDAndStatuses returns every leave of the user with the given statuses, used for calendar feeds
+func (r *leaveRequestRepository) FindByUserIDAndStatuses(db *gorm.DB, userID uint, statuses []string) ([]models.LeaveRequest, error) {
+	var leaves []models.LeaveRequest
+	result := db.
+		Preload("User").
+		Where("user_id = ? AND status IN ?", userID, statuses).
+		Order("start_date ASC, id ASC").
+		Find(&leaves)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return leaves, nil
+}
+
+// FindByTeamIDAndStatuses returns every leave of the team with the given statuses, used for calendar feeds
+func (r *leaveRequestRepository) FindByTeamIDAndStatuses(db *gorm.DB, teamID uint, statuses []string) ([]models.LeaveRequest, error) {
+	var leaves []models.LeaveRequest
+	result := db.
+		Preload("User").
+		Where("team_id = ? AND status IN ?", teamID, statuses).
+		Order("start_date ASC, id ASC").
+		Find(&leaves)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return leaves, nil
+}
+
+// ExistsOverlapping reports whether the user already has a pending or approved leave overlapping the date range
+func (r *leaveRequestRepository) ExistsOverlapping(db *gorm.DB, userID uint, from, to time.Time) (bool, error) {
+	var count int64
+	result := db.Model(&models.LeaveRequest{}).
+		Where("user_id = ? AND status IN ? AND start_date <= ? AND end_date >= ?",
+			userID, []string{models.LeaveStatusPending, models.LeaveStatusApproved}, to, from).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+func (r *leaveRequestRepository) UpdateStatus(db *gorm.DB, leave *models.LeaveRequest) error {
+	return db.Model(&models.LeaveRequest{}).
+		Where("id = ?", leave.ID).
+		Updates(map[string]interface{}{
+			"status":      leave.Status,
+			"reviewer_id": leave.ReviewerID,
+			"review_note": leave.ReviewNote,
+			"reviewed_at": leave.ReviewedAt,
+		}).Error
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository interface {
+	Create(db *gorm.DB, attempt *models.LoginAttempt) error
+	CountFailuresByIPSince(db *gorm.DB, ipAddress string, since time.Time, reasons []string) (int64, error)
+	FindRecentByUserID(db *gorm.DB, userID uint, limit int) ([]models.LoginAttempt, error)
+	SearchLoginAttempts(db *gorm.DB, email, ipAddress *string, success *bool, limit, offset int) ([]models.LoginAttempt, int64, error)
+}
+
+type loginAttemptRepository struct {
+}
+
+func NewLoginAttemptRepository() LoginAttemptRepository {
+	return &loginAttemptRepository{}
+}
+
+func (r *loginAttemptRepository) Create(db *gorm.DB, attempt *models.LoginAttempt) error {
+	return db.Create(attempt).Error
+}
+
+// CountFailuresByIPSince counts failed attempts from the IP with one of the reasons since the given time
+func (r *loginAttemptRepository) CountFailuresByIPSince(db *gorm.DB, ipAddress string, since time.Time, reasons []string) (int64, error) {
+	var count int64
+	result := db.Model(&models.LoginAttempt{}).
+		Where("ip_address = ? AND success = ? AND failure_reason IN ? AND created_at >= ?", ipAddress, false, reasons, since).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+func (r *loginAttemptRepository) FindRecentByUserID(db *gorm.DB, userID uint, limit int) ([]models.LoginAttempt, error) {
+	var attempts []models.LoginAttempt
+	result := db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&attempts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return attempts, nil
+}
+
+func (r *loginAttemptRepository) SearchLoginAttempts(db *gorm.DB, email, ipAddress *string, success *bool, limit, offset int) ([]models.LoginAttempt, int64, error) {
+	var attempts []models.LoginAttempt
+	query := db.Model(&models.LoginAttempt{})
+
+	if email != nil {
+		query = query.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(*email)+"%")
+	}
+	if ipAddress != nil {
+		query = query.Where("ip_address = ?", *ipAddress)
+	}
+	if success != nil {
+		query = query.Where("success = ?", *success)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&attempts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return attempts, count, nil
+}
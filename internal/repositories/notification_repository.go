@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(db *gorm.DB, notification *models.Notification) error
+	FindByUserID(db *gorm.DB, userID uint, limit, offset int) ([]models.Notification, error)
+	CountByUserID(db *gorm.DB, userID uint) (int64, error)
+	CountUnreadByUserID(db *gorm.DB, userID uint) (int64, error)
+	MarkAsRead(db *gorm.DB, id, userID uint) (bool, error)
+	ExistsByUserIDAndTitleSince(db *gorm.DB, userID uint, title string, since time.Time) (bool, error)
+}
+
+type notificationRepository struct {
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepository{}
+}
+
+func (r *notificationRepository) Create(db *gorm.DB, notification *models.Notification) error {
+	return db.Create(notification).Error
+}
+
+func (r *notificationRepository) FindByUserID(db *gorm.DB, userID uint, limit, offset int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	result := db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountByUserID(db *gorm.DB, userID uint) (int64, error) {
+	var count int64
+	result := db.Model(&models.Notification{}).
+		Where("user_id = ?", userID).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+func (r *notificationRepository) CountUnreadByUserID(db *gorm.DB, userID uint) (int64, error) {
+	var count int64
+	result := db.Model(&models.Notification{}).
+		Where("user_id = ? AND is_read = ?", userID, false).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// MarkAsRead marks the notification as read and reports whether it belonged to the user
+func (r *notificationRepository) MarkAsRead(db *gorm.DB, id, userID uint) (bool, error) {
+	result := db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("is_read", true)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *notificationRepository) ExistsByUserIDAndTitleSince(db *gorm.DB, userID uint, title string, since time.Time) (bool, error) {
+	var count int64
+	result := db.Model(&models.Notification{}).
+		Where("user_id = ? AND title = ? AND created_at >= ?", userID, title, since).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type OutboxEventRepository interface {
+	Create(db *gorm.DB, event *models.OutboxEvent) error
+	Update(db *gorm.DB, event *models.OutboxEvent) error
+	FindDue(db *gorm.DB, now time.Time, limit int) ([]models.OutboxEvent, error)
+	Claim(db *gorm.DB, event *models.OutboxEvent, leaseUntil time.Time) (bool, error)
+	DeleteDispatchedBefore(db *gorm.DB, before time.Time) (int64, error)
+}
+
+type outboxEventRepository struct {
+}
+
+func NewOutboxEventRepository() OutboxEventRepository {
+	return &outboxEventRepository{}
+}
+
+func (r *outboxEventRepository) Create(db *gorm.DB, event *models.OutboxEvent) error {
+	return db.Create(event).Error
+}
+
+func (r *outboxEventRepository) Update(db *gorm.DB, event *models.OutboxEvent) error {
+	return db.Save(event).Error
+}
+
+// FindDue returns pending events whose next attempt is due, in the order they occurred
+func (r *outboxEventRepository) FindDue(db *gorm.DB, now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	result := db.
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxEventStatusPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
+
+// Claim moves the next attempt of a due event to leaseUntil, so that no other dispatcher picks it up meanwhile.
+// It reports false when another dispatcher claimed the event first.
+func (r *outboxEventRepository) Claim(db *gorm.DB, event *models.OutboxEvent, leaseUntil time.Time) (bool, error) {
+	result := db.Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", event.ID, models.OutboxEventStatusPending, event.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteDispatchedBefore deletes the events dispatched before the time, their processed markers go with them
+func (r *outboxEventRepository) DeleteDispatchedBefore(db *gorm.DB, before time.Time) (int64, error) {
+	result := db.
+		Where("status = ? AND dispatched_at < ?", models.OutboxEventStatusDispatched, before).
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
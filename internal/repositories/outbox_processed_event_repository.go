@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type OutboxProcessedEventRepository interface {
+	Create(db *gorm.DB, processed *models.OutboxProcessedEvent) error
+	Exists(db *gorm.DB, subscriber, eventID string) (bool, error)
+}
+
+type outboxProcessedEventRepository struct {
+}
+
+func NewOutboxProcessedEventRepository() OutboxProcessedEventRepository {
+	return &outboxProcessedEventRepository{}
+}
+
+func (r *outboxProcessedEventRepository) Create(db *gorm.DB, processed *models.OutboxProcessedEvent) error {
+	return db.Create(processed).Error
+}
+
+func (r *outboxProcessedEventRepository) Exists(db *gorm.DB, subscriber, eventID string) (bool, error) {
+	var count int64
+	result := db.Model(&models.OutboxProcessedEvent{}).
+		Where("subscriber = ? AND event_id = ?", subscriber, eventID).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
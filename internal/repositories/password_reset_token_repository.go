@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenRepository interface {
+	Create(db *gorm.DB, token *models.PasswordResetToken) error
+	FindValidByTokenHash(db *gorm.DB, tokenHash string, now time.Time) (*models.PasswordResetToken, error)
+	CountByUserIDSince(db *gorm.DB, userID uint, since time.Time) (int64, error)
+	DeleteByUserID(db *gorm.DB, userID uint) (int64, error)
+}
+
+type passwordResetTokenRepository struct {
+}
+
+func NewPasswordResetTokenRepository() PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{}
+}
+
+func (r *passwordResetTokenRepository) Create(db *gorm.DB, token *models.PasswordResetToken) error {
+	return db.Create(token).Error
+}
+
+// FindValidByTokenHash returns the token unless it expired
+func (r *passwordResetTokenRepository) FindValidByTokenHash(db *gorm.DB, tokenHash string, now time.Time) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	result := db.
+		Where("token_hash = ? AND expires_at > ?", tokenHash, now).
+		First(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+func (r *passwordResetTokenRepository) CountByUserIDSince(db *gorm.DB, userID uint, since time.Time) (int64, error) {
+	var count int64
+	result := db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// DeleteByUserID drops every token of the user, so a used or superseded link stops working
+func (r *passwordResetTokenRepository) DeleteByUserID(db *gorm.DB, userID uint) (int64, error) {
+	result := db.Where("user_id = ?", userID).Delete(&models.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
@@ -6,14 +6,27 @@ import (
 	"gorm.io/gorm"
 )
 
-type PositionRepository struct {
+type PositionRepository interface {
+	FindAllPositionsSummary(db *gorm.DB) ([]models.Position, error)
+	FindByID(db *gorm.DB, id uint) (*models.Position, error)
+	SearchPositions(db *gorm.DB, limit, offset int) ([]models.Position, int64, error)
+	Create(db *gorm.DB, position *models.Position) error
+	Update(db *gorm.DB, position *models.Position) error
+	UpdateRequiredSkills(db *gorm.DB, positionID uint, requiredSkills []models.PositionRequiredSkill) error
+	FindNextPositionInTrack(db *gorm.DB, careerTrackID uint, grade int) (*models.Position, error)
+	Delete(db *gorm.DB, id uint) error
+	ExistsUsersWithPositionID(db *gorm.DB, positionID uint) (bool, error)
+	ExistsHistoriesWithPositionID(db *gorm.DB, positionID uint) (bool, error)
 }
 
-func NewPositionRepository() *PositionRepository {
-	return &PositionRepository{}
+type positionRepository struct {
 }
 
-func (r *PositionRepository) FindAllPositionsSummary(db *gorm.DB) ([]models.Position, error) {
+func NewPositionRepository() PositionRepository {
+	return &positionRepository{}
+}
+
+func (r *positionRepository) FindAllPositionsSummary(db *gorm.DB) ([]models.Position, error) {
 	var positions []models.Position
 	result := db.Find(&positions)
 	if result.Error != nil {
@@ -22,16 +35,19 @@ func (r *PositionRepository) FindAllPositionsSummary(db *gorm.DB) ([]models.Posi
 	return positions, nil
 }
 
-func (r *PositionRepository) FindByID(db *gorm.DB, id uint) (*models.Position, error) {
+func (r *positionRepository) FindByID(db *gorm.DB, id uint) (*models.Position, error) {
 	var position models.Position
-	result := db.First(&position, id)
+	result := db.
+		Preload("CareerTrack").
+		Preload("RequiredSkills.Skill").
+		First(&position, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &position, nil
 }
 
-func (r *PositionRepository) SearchPositions(db *gorm.DB, limit, offset int) ([]models.Position, int64, error) {
+func (r *positionRepository) SearchPositions(db *gorm.DB, limit, offset int) ([]models.Position, int64, error) {
 	var positions []models.Position
 	query := db.Model(&models.Position{})
 
@@ -40,31 +56,75 @@ func (r *PositionRepository) SearchPositions(db *gorm.DB, limit, offset int) ([]
 		return nil, 0, err
 	}
 
-	if err := query.Limit(limit).Offset(offset).Find(&positions).Error; err != nil {
+	if err := query.
+		Preload("CareerTrack").
+		Order("career_track_id ASC, grade ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&positions).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return positions, count, nil
 }
 
-func (r *PositionRepository) Create(db *gorm.DB, position *models.Position) error {
+func (r *positionRepository) Create(db *gorm.DB, position *models.Position) error {
 	return db.Create(position).Error
 }
 
-func (r *PositionRepository) Update(db *gorm.DB, position *models.Position) error {
+func (r *positionRepository) Update(db *gorm.DB, position *models.Position) error {
 	return db.Model(&models.Position{}).
 		Where("id = ?", position.ID).
 		Updates(map[string]interface{}{
-			"name":         position.Name,
-			"abbreviation": position.Abbreviation,
+			"name":            position.Name,
+			"abbreviation":    position.Abbreviation,
+			"career_track_id": position.CareerTrackID,
+			"grade":           position.Grade,
 		}).Error
 }
 
-func (r *PositionRepository) Delete(db *gorm.DB, id uint) error {
+func (r *positionRepository) UpdateRequiredSkills(db *gorm.DB, positionID uint, requiredSkills []models.PositionRequiredSkill) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Delete existing requirements
+		if err := tx.Where("position_id = ?", positionID).Delete(&models.PositionRequiredSkill{}).Error; err != nil {
+			return err
+		}
+
+		// Insert new requirements
+		if len(requiredSkills) > 0 {
+			if err := tx.Create(&requiredSkills).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FindNextPositionInTrack returns the position with the lowest grade above the given one
+// in the same career track, or nil when the given grade is already the top of the track
+func (r *positionRepository) FindNextPositionInTrack(db *gorm.DB, careerTrackID uint, grade int) (*models.Position, error) {
+	var position models.Position
+	result := db.
+		Preload("CareerTrack").
+		Preload("RequiredSkills.Skill").
+		Where("career_track_id = ? AND grade > ?", careerTrackID, grade).
+		Order("grade ASC").
+		First(&position)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &position, nil
+}
+
+func (r *positionRepository) Delete(db *gorm.DB, id uint) error {
 	return db.Delete(&models.Position{}, id).Error
 }
 
-func (r *PositionRepository) ExistsUsersWithPositionID(db *gorm.DB, positionID uint) (bool, error) {
+func (r *positionRepository) ExistsUsersWithPositionID(db *gorm.DB, positionID uint) (bool, error) {
 	var user models.User
 	err := db.
 		Select("id").
@@ -79,3 +139,21 @@ func (r *PositionRepository) ExistsUsersWithPositionID(db *gorm.DB, positionID u
 	}
 	return true, nil
 }
+
+// ExistsHistoriesWithPositionID reports whether the position history of any user goes through the position,
+// as their old or their new position
+func (r *positionRepository) ExistsHistoriesWithPositionID(db *gorm.DB, positionID uint) (bool, error) {
+	var history models.UserPositionHistory
+	err := db.
+		Select("id").
+		Where("new_position_id = ? OR old_position_id = ?", positionID, positionID).
+		First(&history).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -6,14 +6,23 @@ import (
 	"gorm.io/gorm"
 )
 
-type ProjectRepository struct {
+type ProjectRepository interface {
+	FindAllProjectSummary(db *gorm.DB) ([]models.Project, error)
+	FindByID(db *gorm.DB, id uint) (*models.Project, error)
+	FindByMemberID(db *gorm.DB, userID uint) ([]models.Project, error)
+	Create(db *gorm.DB, project *models.Project) error
+	AddMembers(db *gorm.DB, projectID uint, userIDs []uint) error
+	IsMember(db *gorm.DB, projectID, userID uint) (bool, error)
 }
 
-func NewProjectRepository() *ProjectRepository {
-	return &ProjectRepository{}
+type projectRepository struct {
 }
 
-func (r *ProjectRepository) FindAllProjectSummary(db *gorm.DB) ([]models.Project, error) {
+func NewProjectRepository() ProjectRepository {
+	return &projectRepository{}
+}
+
+func (r *projectRepository) FindAllProjectSummary(db *gorm.DB) ([]models.Project, error) {
 	var projects []models.Project
 	result := db.
 		Select("id", "name", "abbreviation", "start_date", "end_date").
@@ -23,3 +32,54 @@ func (r *ProjectRepository) FindAllProjectSummary(db *gorm.DB) ([]models.Project
 	}
 	return projects, nil
 }
+
+func (r *projectRepository) FindByID(db *gorm.DB, id uint) (*models.Project, error) {
+	var project models.Project
+	result := db.
+		Preload("Leader").
+		Preload("Team").
+		First(&project, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) FindByMemberID(db *gorm.DB, userID uint) ([]models.Project, error) {
+	var projects []models.Project
+	result := db.
+		Joins("JOIN project_members ON project_members.project_id = projects.id").
+		Where("project_members.user_id = ?", userID).
+		Order("projects.name ASC").
+		Find(&projects)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) Create(db *gorm.DB, project *models.Project) error {
+	return db.Create(project).Error
+}
+
+func (r *projectRepository) AddMembers(db *gorm.DB, projectID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	members := make([]map[string]interface{}, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, map[string]interface{}{"project_id": projectID, "user_id": userID})
+	}
+	return db.Table("project_members").CreateInBatches(members, createBatchSize).Error
+}
+
+func (r *projectRepository) IsMember(db *gorm.DB, projectID, userID uint) (bool, error) {
+	var count int64
+	result := db.Table("project_members").
+		Where("project_id = ? AND user_id = ?", projectID, userID).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+)
+
+// schemaMigrationsTable is where golang-migrate records the version of the schema, on every driver
+const schemaMigrationsTable = "schema_migrations"
+
+// SchemaMigrationRepository reads the version of the schema recorded by the migrations
+type SchemaMigrationRepository interface {
+	CurrentVersion(db *gorm.DB) (version uint, dirty bool, err error)
+}
+
+type schemaMigrationRepository struct {
+}
+
+func NewSchemaMigrationRepository() SchemaMigrationRepository {
+	return &schemaMigrationRepository{}
+}
+
+// CurrentVersion returns the version of the last migration applied, zero when none was. Dirty is set
+// when that migration failed part way.
+func (r *schemaMigrationRepository) CurrentVersion(db *gorm.DB) (uint, bool, error) {
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	if err := db.Table(schemaMigrationsTable).Select("version", "dirty").Limit(1).Scan(&row).Error; err != nil {
+		return 0, false, err
+	}
+	return row.Version, row.Dirty, nil
+}
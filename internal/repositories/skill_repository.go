@@ -1,19 +1,31 @@
 package repositories
 
 import (
+	"strings"
 	"trieu_mock_project_go/models"
 
 	"gorm.io/gorm"
 )
 
-type SkillRepository struct {
+type SkillRepository interface {
+	FindAllSkillSummary(db *gorm.DB) ([]models.Skill, error)
+	FindByID(db *gorm.DB, id uint) (*models.Skill, error)
+	SearchSkills(db *gorm.DB, limit, offset int) ([]models.Skill, int64, error)
+	Create(db *gorm.DB, skill *models.Skill) error
+	Update(db *gorm.DB, skill *models.Skill) error
+	Delete(db *gorm.DB, id uint) error
+	ExistsUsersWithSkillID(db *gorm.DB, skillID uint) (bool, error)
+	FindUserSkillsBySkillName(db *gorm.DB, name string, limit int) ([]models.UserSkill, error)
 }
 
-func NewSkillRepository() *SkillRepository {
-	return &SkillRepository{}
+type skillRepository struct {
 }
 
-func (r *SkillRepository) FindAllSkillSummary(db *gorm.DB) ([]models.Skill, error) {
+func NewSkillRepository() SkillRepository {
+	return &skillRepository{}
+}
+
+func (r *skillRepository) FindAllSkillSummary(db *gorm.DB) ([]models.Skill, error) {
 	var skills []models.Skill
 	result := db.
 		Select("id", "name").
@@ -24,7 +36,7 @@ func (r *SkillRepository) FindAllSkillSummary(db *gorm.DB) ([]models.Skill, erro
 	return skills, nil
 }
 
-func (r *SkillRepository) FindByID(db *gorm.DB, id uint) (*models.Skill, error) {
+func (r *skillRepository) FindByID(db *gorm.DB, id uint) (*models.Skill, error) {
 	var skill models.Skill
 	result := db.First(&skill, id)
 	if result.Error != nil {
@@ -33,7 +45,7 @@ func (r *SkillRepository) FindByID(db *gorm.DB, id uint) (*models.Skill, error)
 	return &skill, nil
 }
 
-func (r *SkillRepository) SearchSkills(db *gorm.DB, limit, offset int) ([]models.Skill, int64, error) {
+func (r *skillRepository) SearchSkills(db *gorm.DB, limit, offset int) ([]models.Skill, int64, error) {
 	var skills []models.Skill
 	query := db.Model(&models.Skill{})
 
@@ -49,11 +61,11 @@ func (r *SkillRepository) SearchSkills(db *gorm.DB, limit, offset int) ([]models
 	return skills, count, nil
 }
 
-func (r *SkillRepository) Create(db *gorm.DB, skill *models.Skill) error {
+func (r *skillRepository) Create(db *gorm.DB, skill *models.Skill) error {
 	return db.Create(skill).Error
 }
 
-func (r *SkillRepository) Update(db *gorm.DB, skill *models.Skill) error {
+func (r *skillRepository) Update(db *gorm.DB, skill *models.Skill) error {
 	return db.Model(&models.Skill{}).
 		Where("id = ?", skill.ID).
 		Updates(map[string]interface{}{
@@ -61,11 +73,11 @@ func (r *SkillRepository) Update(db *gorm.DB, skill *models.Skill) error {
 		}).Error
 }
 
-func (r *SkillRepository) Delete(db *gorm.DB, id uint) error {
+func (r *skillRepository) Delete(db *gorm.DB, id uint) error {
 	return db.Delete(&models.Skill{}, id).Error
 }
 
-func (r *SkillRepository) ExistsUsersWithSkillID(db *gorm.DB, skillID uint) (bool, error) {
+func (r *skillRepository) ExistsUsersWithSkillID(db *gorm.DB, skillID uint) (bool, error) {
 	var userSkill models.UserSkill
 	err := db.
 		Select("user_id").
@@ -80,3 +92,22 @@ func (r *SkillRepository) ExistsUsersWithSkillID(db *gorm.DB, skillID uint) (boo
 	}
 	return true, nil
 }
+
+// FindUserSkillsBySkillName returns the skills of active users whose name contains name,
+// most experienced first, with the user and their current team
+func (r *skillRepository) FindUserSkillsBySkillName(db *gorm.DB, name string, limit int) ([]models.UserSkill, error) {
+	var userSkills []models.UserSkill
+	result := db.
+		Joins("JOIN skills ON skills.id = user_skills.skill_id").
+		Joins("JOIN users ON users.id = user_skills.user_id").
+		Where("LOWER(skills.name) LIKE ? AND users.deactivated_at IS NULL", "%"+strings.ToLower(name)+"%").
+		Preload("Skill").
+		Preload("User.CurrentTeam").
+		Order("user_skills.level DESC, user_skills.used_year_number DESC, users.name ASC").
+		Limit(limit).
+		Find(&userSkills)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return userSkills, nil
+}
@@ -1,19 +1,34 @@
 package repositories
 
 import (
+	"time"
 	"trieu_mock_project_go/models"
 
 	"gorm.io/gorm"
 )
 
-type TeamMemberRepository struct {
+type TeamMemberRepository interface {
+	FindActiveMembersByTeamID(db *gorm.DB, teamID uint, limit, offset int) ([]models.TeamMember, error)
+	CountActiveMembersByTeamID(db *gorm.DB, teamID uint) (int64, error)
+	FindTeamMembersByTeamID(db *gorm.DB, teamID uint, limit, offset int) ([]models.TeamMember, error)
+	CountTeamMembersByTeamID(db *gorm.DB, teamID uint) (int64, error)
+	FindActiveMemberByUserID(db *gorm.DB, userID uint) (*models.TeamMember, error)
+	Create(db *gorm.DB, member *models.TeamMember) error
+	CreateMembers(db *gorm.DB, members []models.TeamMember) error
+	Update(db *gorm.DB, member *models.TeamMember) error
+	FindAllActiveMembers(db *gorm.DB) ([]models.TeamMember, error)
+	FindAllActiveMembersByTeamID(db *gorm.DB, teamID uint) ([]models.TeamMember, error)
+	FindFirstJoinedAtByUserIDs(db *gorm.DB, userIDs []uint) (map[uint]time.Time, error)
 }
 
-func NewTeamMemberRepository() *TeamMemberRepository {
-	return &TeamMemberRepository{}
+type teamMemberRepository struct {
 }
 
-func (r *TeamMemberRepository) FindActiveMembersByTeamID(db *gorm.DB, teamID uint, limit, offset int) ([]models.TeamMember, error) {
+func NewTeamMemberRepository() TeamMemberRepository {
+	return &teamMemberRepository{}
+}
+
+func (r *teamMemberRepository) FindActiveMembersByTeamID(db *gorm.DB, teamID uint, limit, offset int) ([]models.TeamMember, error) {
 	var members []models.TeamMember
 	result := db.
 		Preload("User").
@@ -27,7 +42,7 @@ func (r *TeamMemberRepository) FindActiveMembersByTeamID(db *gorm.DB, teamID uin
 	return members, nil
 }
 
-func (r *TeamMemberRepository) CountActiveMembersByTeamID(db *gorm.DB, teamID uint) (int64, error) {
+func (r *teamMemberRepository) CountActiveMembersByTeamID(db *gorm.DB, teamID uint) (int64, error) {
 	var count int64
 	result := db.Model(&models.TeamMember{}).
 		Where("team_id = ? AND left_at IS NULL", teamID).
@@ -38,7 +53,7 @@ func (r *TeamMemberRepository) CountActiveMembersByTeamID(db *gorm.DB, teamID ui
 	return count, nil
 }
 
-func (r *TeamMemberRepository) FindTeamMembersByTeamID(db *gorm.DB, teamID uint, limit, offset int) ([]models.TeamMember, error) {
+func (r *teamMemberRepository) FindTeamMembersByTeamID(db *gorm.DB, teamID uint, limit, offset int) ([]models.TeamMember, error) {
 	var members []models.TeamMember
 	result := db.
 		Preload("User").
@@ -53,7 +68,7 @@ func (r *TeamMemberRepository) FindTeamMembersByTeamID(db *gorm.DB, teamID uint,
 	return members, nil
 }
 
-func (r *TeamMemberRepository) CountTeamMembersByTeamID(db *gorm.DB, teamID uint) (int64, error) {
+func (r *teamMemberRepository) CountTeamMembersByTeamID(db *gorm.DB, teamID uint) (int64, error) {
 	var count int64
 	result := db.Model(&models.TeamMember{}).
 		Where("team_id = ?", teamID).
@@ -64,7 +79,7 @@ func (r *TeamMemberRepository) CountTeamMembersByTeamID(db *gorm.DB, teamID uint
 	return count, nil
 }
 
-func (r *TeamMemberRepository) FindActiveMemberByUserID(db *gorm.DB, userID uint) (*models.TeamMember, error) {
+func (r *teamMemberRepository) FindActiveMemberByUserID(db *gorm.DB, userID uint) (*models.TeamMember, error) {
 	var member models.TeamMember
 	result := db.Where("user_id = ? AND left_at IS NULL", userID).First(&member)
 	if result.Error != nil {
@@ -76,11 +91,18 @@ func (r *TeamMemberRepository) FindActiveMemberByUserID(db *gorm.DB, userID uint
 	return &member, nil
 }
 
-func (r *TeamMemberRepository) Create(db *gorm.DB, member *models.TeamMember) error {
+func (r *teamMemberRepository) Create(db *gorm.DB, member *models.TeamMember) error {
 	return db.Create(member).Error
 }
 
-func (r *TeamMemberRepository) Update(db *gorm.DB, member *models.TeamMember) error {
+func (r *teamMemberRepository) CreateMembers(db *gorm.DB, members []models.TeamMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(&members, createBatchSize).Error
+}
+
+func (r *teamMemberRepository) Update(db *gorm.DB, member *models.TeamMember) error {
 	return db.Model(&models.TeamMember{}).
 		Where("id = ?", member.ID).
 		Updates(map[string]interface{}{
@@ -90,3 +112,49 @@ func (r *TeamMemberRepository) Update(db *gorm.DB, member *models.TeamMember) er
 			"left_at":   member.LeftAt,
 		}).Error
 }
+
+func (r *teamMemberRepository) FindAllActiveMembers(db *gorm.DB) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	result := db.
+		Preload("User").
+		Where("left_at IS NULL").
+		Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+func (r *teamMemberRepository) FindAllActiveMembersByTeamID(db *gorm.DB, teamID uint) ([]models.TeamMember, error) {
+	var members []models.TeamMember
+	result := db.
+		Preload("User").
+		Where("team_id = ? AND left_at IS NULL", teamID).
+		Find(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+// FindFirstJoinedAtByUserIDs returns the earliest team joining time of each user, used as their start date
+func (r *teamMemberRepository) FindFirstJoinedAtByUserIDs(db *gorm.DB, userIDs []uint) (map[uint]time.Time, error) {
+	var rows []struct {
+		UserID   uint
+		JoinedAt time.Time
+	}
+	result := db.Model(&models.TeamMember{}).
+		Select("user_id, MIN(joined_at) AS joined_at").
+		Where("user_id IN ?", userIDs).
+		Group("user_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	firstJoinedAt := make(map[uint]time.Time, len(rows))
+	for _, row := range rows {
+		firstJoinedAt[row.UserID] = row.JoinedAt
+	}
+	return firstJoinedAt, nil
+}
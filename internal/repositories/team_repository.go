@@ -6,14 +6,25 @@ import (
 	"gorm.io/gorm"
 )
 
-type TeamsRepository struct {
+type TeamsRepository interface {
+	ListTeams(db *gorm.DB, limit, offset int) ([]models.Team, error)
+	CountTeams(db *gorm.DB) (int64, error)
+	FindByID(db *gorm.DB, id uint) (*models.Team, error)
+	FindAllTeamsSummary(db *gorm.DB) ([]models.Team, error)
+	Create(db *gorm.DB, team *models.Team) error
+	Update(db *gorm.DB, team *models.Team) error
+	Delete(db *gorm.DB, id uint) error
+	ExistByLeaderID(db *gorm.DB, leaderID uint) (bool, error)
 }
 
-func NewTeamsRepository() *TeamsRepository {
-	return &TeamsRepository{}
+type teamsRepository struct {
 }
 
-func (r *TeamsRepository) ListTeams(db *gorm.DB, limit, offset int) ([]models.Team, error) {
+func NewTeamsRepository() TeamsRepository {
+	return &teamsRepository{}
+}
+
+func (r *teamsRepository) ListTeams(db *gorm.DB, limit, offset int) ([]models.Team, error) {
 	var teams []models.Team
 	result := db.
 		Preload("Leader").
@@ -28,7 +39,7 @@ func (r *TeamsRepository) ListTeams(db *gorm.DB, limit, offset int) ([]models.Te
 	return teams, nil
 }
 
-func (r *TeamsRepository) CountTeams(db *gorm.DB) (int64, error) {
+func (r *teamsRepository) CountTeams(db *gorm.DB) (int64, error) {
 	var count int64
 	result := db.Model(&models.Team{}).Count(&count)
 	if result.Error != nil {
@@ -37,7 +48,7 @@ func (r *TeamsRepository) CountTeams(db *gorm.DB) (int64, error) {
 	return count, nil
 }
 
-func (r *TeamsRepository) FindByID(db *gorm.DB, id uint) (*models.Team, error) {
+func (r *teamsRepository) FindByID(db *gorm.DB, id uint) (*models.Team, error) {
 	var team models.Team
 	result := db.
 		Preload("Leader").
@@ -50,7 +61,7 @@ func (r *TeamsRepository) FindByID(db *gorm.DB, id uint) (*models.Team, error) {
 	return &team, nil
 }
 
-func (r *TeamsRepository) FindAllTeamsSummary(db *gorm.DB) ([]models.Team, error) {
+func (r *teamsRepository) FindAllTeamsSummary(db *gorm.DB) ([]models.Team, error) {
 	var teams []models.Team
 	result := db.
 		Select("id", "name").
@@ -61,11 +72,11 @@ func (r *TeamsRepository) FindAllTeamsSummary(db *gorm.DB) ([]models.Team, error
 	return teams, nil
 }
 
-func (r *TeamsRepository) Create(db *gorm.DB, team *models.Team) error {
+func (r *teamsRepository) Create(db *gorm.DB, team *models.Team) error {
 	return db.Create(team).Error
 }
 
-func (r *TeamsRepository) Update(db *gorm.DB, team *models.Team) error {
+func (r *teamsRepository) Update(db *gorm.DB, team *models.Team) error {
 	return db.Model(&models.Team{}).
 		Where("id = ?", team.ID).
 		Updates(map[string]interface{}{
@@ -75,11 +86,11 @@ func (r *TeamsRepository) Update(db *gorm.DB, team *models.Team) error {
 		}).Error
 }
 
-func (r *TeamsRepository) Delete(db *gorm.DB, id uint) error {
+func (r *teamsRepository) Delete(db *gorm.DB, id uint) error {
 	return db.Delete(&models.Team{}, id).Error
 }
 
-func (r *TeamsRepository) ExistByLeaderID(db *gorm.DB, leaderID uint) (bool, error) {
+func (r *teamsRepository) ExistByLeaderID(db *gorm.DB, leaderID uint) (bool, error) {
 	var count int64
 	result := db.Model(&models.Team{}).
 		Where("leader_id = ?", leaderID).
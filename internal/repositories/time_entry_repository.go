@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type TimeEntryRepository interface {
+	Create(db *gorm.DB, entry *models.TimeEntry) error
+	Update(db *gorm.DB, entry *models.TimeEntry) error
+	Delete(db *gorm.DB, id uint) error
+	FindByID(db *gorm.DB, id uint) (*models.TimeEntry, error)
+	FindByUserIDProjectIDAndDate(db *gorm.DB, userID, projectID uint, workDate time.Time) (*models.TimeEntry, error)
+	SumHoursByUserIDAndDate(db *gorm.DB, userID uint, workDate time.Time, excludeID uint) (float64, error)
+	FindApprovedByProjectIDBetween(db *gorm.DB, projectID uint, from, to time.Time) ([]models.TimeEntry, error)
+}
+
+type timeEntryRepository struct {
+}
+
+func NewTimeEntryRepository() TimeEntryRepository {
+	return &timeEntryRepository{}
+}
+
+func (r *timeEntryRepository) Create(db *gorm.DB, entry *models.TimeEntry) error {
+	return db.Create(entry).Error
+}
+
+func (r *timeEntryRepository) Update(db *gorm.DB, entry *models.TimeEntry) error {
+	return db.Model(&models.TimeEntry{}).
+		Where("id = ?", entry.ID).
+		Updates(map[string]interface{}{
+			"hours":       entry.Hours,
+			"description": entry.Description,
+		}).Error
+}
+
+func (r *timeEntryRepository) Delete(db *gorm.DB, id uint) error {
+	return db.Delete(&models.TimeEntry{}, id).Error
+}
+
+func (r *timeEntryRepository) FindByID(db *gorm.DB, id uint) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	result := db.
+		Preload("Timesheet").
+		First(&entry, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &entry, nil
+}
+
+// FindByUserIDProjectIDAndDate returns nil when no entry exists
+func (r *timeEntryRepository) FindByUserIDProjectIDAndDate(db *gorm.DB, userID, projectID uint, workDate time.Time) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	result := db.
+		Where("user_id = ? AND project_id = ? AND work_date = ?", userID, projectID, workDate).
+		First(&entry)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &entry, nil
+}
+
+// SumHoursByUserIDAndDate sums the hours logged by the user on a day, excluding one entry if excludeID is not zero
+func (r *timeEntryRepository) SumHoursByUserIDAndDate(db *gorm.DB, userID uint, workDate time.Time, excludeID uint) (float64, error) {
+	var total float64
+	result := db.Model(&models.TimeEntry{}).
+		Select("COALESCE(SUM(hours), 0)").
+		Where("user_id = ? AND work_date = ? AND id <> ?", userID, workDate, excludeID).
+		Scan(&total)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return total, nil
+}
+
+// FindApprovedByProjectIDBetween returns entries of approved timesheets logged to the project in [from, to]
+func (r *timeEntryRepository) FindApprovedByProjectIDBetween(db *gorm.DB, projectID uint, from, to time.Time) ([]models.TimeEntry, error) {
+	var entries []models.TimeEntry
+	result := db.
+		Preload("User").
+		Joins("JOIN timesheets ON timesheets.id = time_entries.timesheet_id").
+		Where("time_entries.project_id = ? AND time_entries.work_date BETWEEN ? AND ? AND timesheets.status = ?",
+			projectID, from, to, models.TimesheetStatusApproved).
+		Order("time_entries.work_date ASC, time_entries.user_id ASC").
+		Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
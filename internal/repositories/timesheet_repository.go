@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type TimesheetRepository interface {
+	Create(db *gorm.DB, timesheet *models.Timesheet) error
+	FindByID(db *gorm.DB, id uint) (*models.Timesheet, error)
+	FindByUserIDAndWeekStart(db *gorm.DB, userID uint, weekStart time.Time) (*models.Timesheet, error)
+	FindSubmittedByLeaderID(db *gorm.DB, leaderID uint) ([]models.Timesheet, error)
+	UpdateStatus(db *gorm.DB, timesheet *models.Timesheet) error
+}
+
+type timesheetRepository struct {
+}
+
+func NewTimesheetRepository() TimesheetRepository {
+	return &timesheetRepository{}
+}
+
+func (r *timesheetRepository) Create(db *gorm.DB, timesheet *models.Timesheet) error {
+	return db.Create(timesheet).Error
+}
+
+func (r *timesheetRepository) FindByID(db *gorm.DB, id uint) (*models.Timesheet, error) {
+	var timesheet models.Timesheet
+	result := db.
+		Preload("User").
+		Preload("Reviewer").
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("work_date ASC, project_id ASC")
+		}).
+		Preload("Entries.Project").
+		First(&timesheet, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &timesheet, nil
+}
+
+// FindByUserIDAndWeekStart returns nil when the user has no timesheet for the week yet
+func (r *timesheetRepository) FindByUserIDAndWeekStart(db *gorm.DB, userID uint, weekStart time.Time) (*models.Timesheet, error) {
+	var timesheet models.Timesheet
+	result := db.
+		Preload("User").
+		Preload("Reviewer").
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("work_date ASC, project_id ASC")
+		}).
+		Preload("Entries.Project").
+		Where("user_id = ? AND week_start = ?", userID, weekStart).
+		First(&timesheet)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &timesheet, nil
+}
+
+// FindSubmittedByLeaderID returns submitted timesheets of the current members of the teams led by the user
+func (r *timesheetRepository) FindSubmittedByLeaderID(db *gorm.DB, leaderID uint) ([]models.Timesheet, error) {
+	var timesheets []models.Timesheet
+	result := db.
+		Preload("User").
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("work_date ASC, project_id ASC")
+		}).
+		Preload("Entries.Project").
+		Joins("JOIN users ON users.id = timesheets.user_id").
+		Joins("JOIN teams ON teams.id = users.current_team_id").
+		Where("teams.leader_id = ? AND timesheets.user_id <> ? AND timesheets.status = ?", leaderID, leaderID, models.TimesheetStatusSubmitted).
+		Order("timesheets.week_start ASC, timesheets.id ASC").
+		Find(&timesheets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return timesheets, nil
+}
+
+func (r *timesheetRepository) UpdateStatus(db *gorm.DB, timesheet *models.Timesheet) error {
+	return db.Model(&models.Timesheet{}).
+		Where("id = ?", timesheet.ID).
+		Updates(map[string]interface{}{
+			"status":       timesheet.Status,
+			"submitted_at": timesheet.SubmittedAt,
+			"reviewer_id":  timesheet.ReviewerID,
+			"review_note":  timesheet.ReviewNote,
+			"reviewed_at":  timesheet.ReviewedAt,
+		}).Error
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type UserPositionHistoryRepository interface {
+	Create(db *gorm.DB, history *models.UserPositionHistory) error
+	CreateHistories(db *gorm.DB, histories []models.UserPositionHistory) error
+	FindByUserID(db *gorm.DB, userID uint) ([]models.UserPositionHistory, error)
+	FindPromotionsBetween(db *gorm.DB, from, to time.Time) ([]models.UserPositionHistory, error)
+}
+
+type userPositionHistoryRepository struct {
+}
+
+func NewUserPositionHistoryRepository() UserPositionHistoryRepository {
+	return &userPositionHistoryRepository{}
+}
+
+func (r *userPositionHistoryRepository) Create(db *gorm.DB, history *models.UserPositionHistory) error {
+	return db.Create(history).Error
+}
+
+func (r *userPositionHistoryRepository) CreateHistories(db *gorm.DB, histories []models.UserPositionHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(&histories, createBatchSize).Error
+}
+
+func (r *userPositionHistoryRepository) FindByUserID(db *gorm.DB, userID uint) ([]models.UserPositionHistory, error) {
+	var histories []models.UserPositionHistory
+	result := db.
+		Preload("OldPosition").
+		Preload("NewPosition").
+		Preload("Team").
+		Preload("ChangedBy").
+		Where("user_id = ?", userID).
+		Order("effective_date DESC, id DESC").
+		Find(&histories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return histories, nil
+}
+
+func (r *userPositionHistoryRepository) FindPromotionsBetween(db *gorm.DB, from, to time.Time) ([]models.UserPositionHistory, error) {
+	var histories []models.UserPositionHistory
+	result := db.
+		Preload("Team").
+		Where("is_promotion = ? AND effective_date >= ? AND effective_date <= ?", true, from, to).
+		Order("effective_date ASC").
+		Find(&histories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return histories, nil
+}
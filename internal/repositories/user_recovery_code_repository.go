@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type UserRecoveryCodeRepository interface {
+	ReplaceByUserID(db *gorm.DB, userID uint, codes []models.UserRecoveryCode) error
+	DeleteByUserID(db *gorm.DB, userID uint) error
+	MarkUsed(db *gorm.DB, userID uint, codeHash string, usedAt time.Time) (bool, error)
+	CountUnusedByUserID(db *gorm.DB, userID uint) (int64, error)
+}
+
+type userRecoveryCodeRepository struct {
+}
+
+func NewUserRecoveryCodeRepository() UserRecoveryCodeRepository {
+	return &userRecoveryCodeRepository{}
+}
+
+// ReplaceByUserID deletes all recovery codes of the user and stores the new ones
+func (r *userRecoveryCodeRepository) ReplaceByUserID(db *gorm.DB, userID uint, codes []models.UserRecoveryCode) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if len(codes) > 0 {
+			if err := tx.Create(&codes).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *userRecoveryCodeRepository) DeleteByUserID(db *gorm.DB, userID uint) error {
+	return db.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error
+}
+
+// MarkUsed consumes an unused recovery code of the user and reports whether one matched
+func (r *userRecoveryCodeRepository) MarkUsed(db *gorm.DB, userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	result := db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *userRecoveryCodeRepository) CountUnusedByUserID(db *gorm.DB, userID uint) (int64, error) {
+	var count int64
+	result := db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
@@ -1,19 +1,58 @@
 package repositories
 
 import (
+	"strings"
+	"time"
 	"trieu_mock_project_go/models"
 
 	"gorm.io/gorm"
 )
 
-type UserRepository struct {
+// Rows inserted per statement by bulk creates, below the placeholder limit of every database engine
+const createBatchSize = 500
+
+type UserRepository interface {
+	FindByEmail(db *gorm.DB, email string) (*models.User, error)
+	FindByOIDCIdentity(db *gorm.DB, issuer, subject string) (*models.User, error)
+	FindByID(db *gorm.DB, id uint) (*models.User, error)
+	FindContactByID(db *gorm.DB, id uint) (*models.User, error)
+	SearchUsers(db *gorm.DB, name *string, teamId *uint, limit, offset int) ([]models.User, int64, error)
+	ExistByID(db *gorm.DB, id uint) (bool, error)
+	CreateUser(db *gorm.DB, user *models.User) error
+	CreateUsers(db *gorm.DB, users []models.User) error
+	CountByEmailDomain(db *gorm.DB, domain string) (int64, error)
+	UpdateCurrentTeamID(db *gorm.DB, userIDs []uint, teamID uint) error
+	CreateUserSkills(db *gorm.DB, userSkills []models.UserSkill) error
+	UpdateUser(db *gorm.DB, user *models.User) error
+	UpdateUserSkills(db *gorm.DB, userID uint, skills []models.UserSkill) error
+	UpdateUsersCurrentTeamToNullByTeamID(db *gorm.DB, teamID uint) error
+	UpdatePreferences(db *gorm.DB, userID uint, preferences map[string]interface{}) error
+	UpdatePassword(db *gorm.DB, id uint, hashedPassword string) error
+	IncrementFailedLoginCount(db *gorm.DB, id uint, failedAt time.Time) error
+	LockUntil(db *gorm.DB, id uint, lockedUntil time.Time) error
+	ResetLoginFailures(db *gorm.DB, id uint) error
+	UpdateTwoFactorSecret(db *gorm.DB, id uint, secret string) error
+	EnableTwoFactor(db *gorm.DB, id uint, lastUsedStep int64) error
+	DisableTwoFactor(db *gorm.DB, id uint) error
+	UpdateTwoFactorLastUsedStep(db *gorm.DB, id uint, step int64) (bool, error)
+	UpdateTwoFactorRequired(db *gorm.DB, id uint, required bool) error
+	LinkOIDCIdentity(db *gorm.DB, id uint, issuer, subject string) error
+	FindAllForDirectorySync(db *gorm.DB) ([]models.User, error)
+	LinkLDAPUID(db *gorm.DB, id uint, uid string) error
+	UpdateDeactivatedAt(db *gorm.DB, id uint, deactivatedAt *time.Time) error
+	FindAccessState(db *gorm.DB, id uint) (*models.User, error)
+	UpdateCalendarFeedTokenHash(db *gorm.DB, id uint, tokenHash *string) error
+	FindByCalendarFeedTokenHash(db *gorm.DB, tokenHash string) (*models.User, error)
+}
+
+type userRepository struct {
 }
 
-func NewUserRepository() *UserRepository {
-	return &UserRepository{}
+func NewUserRepository() UserRepository {
+	return &userRepository{}
 }
 
-func (r *UserRepository) FindByEmail(db *gorm.DB, email string) (*models.User, error) {
+func (r *userRepository) FindByEmail(db *gorm.DB, email string) (*models.User, error) {
 	var user models.User
 	result := db.Where("email = ?", email).First(&user)
 	if result.Error != nil {
@@ -22,7 +61,16 @@ func (r *UserRepository) FindByEmail(db *gorm.DB, email string) (*models.User, e
 	return &user, nil
 }
 
-func (r *UserRepository) FindByID(db *gorm.DB, id uint) (*models.User, error) {
+func (r *userRepository) FindByOIDCIdentity(db *gorm.DB, issuer, subject string) (*models.User, error) {
+	var user models.User
+	result := db.Where("oidc_issuer = ? AND oidc_subject = ?", issuer, subject).First(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByID(db *gorm.DB, id uint) (*models.User, error) {
 	var user models.User
 	result := db.
 		Preload("CurrentTeam").
@@ -36,14 +84,27 @@ func (r *UserRepository) FindByID(db *gorm.DB, id uint) (*models.User, error) {
 	return &user, nil
 }
 
-func (r *UserRepository) SearchUsers(db *gorm.DB, name *string, teamId *uint, limit, offset int) ([]models.User, int64, error) {
+// FindContactByID returns the user with only the fields needed to email them
+func (r *userRepository) FindContactByID(db *gorm.DB, id uint) (*models.User, error) {
+	var user models.User
+	result := db.
+		Select("id", "name", "email", "email_team_changes", "email_leave_reviews", "deactivated_at").
+		First(&user, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+func (r *userRepository) SearchUsers(db *gorm.DB, name *string, teamId *uint, limit, offset int) ([]models.User, int64, error) {
 	var users []models.User
 	query := db.Model(&models.User{})
 
 	result := query
 
 	if name != nil {
-		result = result.Where("name LIKE ?", "%"+*name+"%")
+		// LIKE is case-sensitive on PostgreSQL
+		result = result.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(*name)+"%")
 	}
 
 	if teamId != nil {
@@ -68,23 +129,64 @@ func (r *UserRepository) SearchUsers(db *gorm.DB, name *string, teamId *uint, li
 	return users, count, nil
 }
 
-func (r *UserRepository) CreateUser(db *gorm.DB, user *models.User) error {
+func (r *userRepository) ExistByID(db *gorm.DB, id uint) (bool, error) {
+	var count int64
+	result := db.Model(&models.User{}).
+		Where("id = ?", id).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+func (r *userRepository) CreateUser(db *gorm.DB, user *models.User) error {
 	if err := db.Create(user).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *UserRepository) CreateUserSkills(db *gorm.DB, userSkills []models.UserSkill) error {
+// CreateUsers inserts many users at once and sets their IDs
+func (r *userRepository) CreateUsers(db *gorm.DB, users []models.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	return db.CreateInBatches(&users, createBatchSize).Error
+}
+
+// CountByEmailDomain counts the users with an email address at the domain
+func (r *userRepository) CountByEmailDomain(db *gorm.DB, domain string) (int64, error) {
+	var count int64
+	result := db.Model(&models.User{}).
+		Where("email LIKE ?", "%@"+domain).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return count, nil
+}
+
+// UpdateCurrentTeamID moves the users to the team
+func (r *userRepository) UpdateCurrentTeamID(db *gorm.DB, userIDs []uint, teamID uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return db.Model(&models.User{}).
+		Where("id IN ?", userIDs).
+		Update("current_team_id", teamID).Error
+}
+
+func (r *userRepository) CreateUserSkills(db *gorm.DB, userSkills []models.UserSkill) error {
 	if len(userSkills) > 0 {
-		if err := db.Create(&userSkills).Error; err != nil {
+		if err := db.CreateInBatches(&userSkills, createBatchSize).Error; err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (r *UserRepository) UpdateUser(db *gorm.DB, user *models.User) error {
+func (r *userRepository) UpdateUser(db *gorm.DB, user *models.User) error {
 	return db.Model(&models.User{}).
 		Where("id = ?", user.ID).
 		Updates(map[string]interface{}{
@@ -93,11 +195,10 @@ func (r *UserRepository) UpdateUser(db *gorm.DB, user *models.User) error {
 			"birthday":        user.Birthday,
 			"current_team_id": user.CurrentTeamID,
 			"position_id":     user.PositionID,
-			"role":            user.Role,
 		}).Error
 }
 
-func (r *UserRepository) UpdateUserSkills(db *gorm.DB, userID uint, skills []models.UserSkill) error {
+func (r *userRepository) UpdateUserSkills(db *gorm.DB, userID uint, skills []models.UserSkill) error {
 	return db.Transaction(func(tx *gorm.DB) error {
 		// Delete existing skills
 		if err := tx.Where("user_id = ?", userID).Delete(&models.UserSkill{}).Error; err != nil {
@@ -115,8 +216,164 @@ func (r *UserRepository) UpdateUserSkills(db *gorm.DB, userID uint, skills []mod
 	})
 }
 
-func (r *UserRepository) UpdateUsersCurrentTeamToNullByTeamID(db *gorm.DB, teamID uint) error {
+func (r *userRepository) UpdateUsersCurrentTeamToNullByTeamID(db *gorm.DB, teamID uint) error {
 	return db.Model(&models.User{}).
 		Where("current_team_id = ?", teamID).
 		Update("current_team_id", nil).Error
 }
+
+// UpdatePreferences sets the given preference columns of the user
+func (r *userRepository) UpdatePreferences(db *gorm.DB, userID uint, preferences map[string]interface{}) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(preferences).Error
+}
+
+// UpdatePassword replaces the password hash and bumps the token version, access tokens issued before stop working
+func (r *userRepository) UpdatePassword(db *gorm.DB, id uint, hashedPassword string) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"password":      hashedPassword,
+			"token_version": gorm.Expr("token_version + 1"),
+		}).Error
+}
+
+// IncrementFailedLoginCount records a failed login of the user at the given time
+func (r *userRepository) IncrementFailedLoginCount(db *gorm.DB, id uint, failedAt time.Time) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"failed_login_count":   gorm.Expr("failed_login_count + 1"),
+			"last_failed_login_at": failedAt,
+		}).Error
+}
+
+// LockUntil locks the user out of logging in until the given time and starts a fresh failure count
+func (r *userRepository) LockUntil(db *gorm.DB, id uint, lockedUntil time.Time) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"failed_login_count":   0,
+			"last_failed_login_at": nil,
+			"locked_until":         lockedUntil,
+		}).Error
+}
+
+// ResetLoginFailures clears failed login tracking and any lockout of the user
+func (r *userRepository) ResetLoginFailures(db *gorm.DB, id uint) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"failed_login_count":   0,
+			"last_failed_login_at": nil,
+			"locked_until":         nil,
+		}).Error
+}
+
+// UpdateTwoFactorSecret stores the secret of a pending two-factor enrolment
+func (r *userRepository) UpdateTwoFactorSecret(db *gorm.DB, id uint, secret string) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Update("two_factor_secret", secret).Error
+}
+
+func (r *userRepository) EnableTwoFactor(db *gorm.DB, id uint, lastUsedStep int64) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"two_factor_enabled":        true,
+			"two_factor_last_used_step": lastUsedStep,
+		}).Error
+}
+
+func (r *userRepository) DisableTwoFactor(db *gorm.DB, id uint) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"two_factor_secret":         nil,
+			"two_factor_enabled":        false,
+			"two_factor_last_used_step": nil,
+		}).Error
+}
+
+// UpdateTwoFactorLastUsedStep moves the last used TOTP time step forward and reports false
+// when the step was already used, so each code is accepted only once
+func (r *userRepository) UpdateTwoFactorLastUsedStep(db *gorm.DB, id uint, step int64) (bool, error) {
+	result := db.Model(&models.User{}).
+		Where("id = ? AND (two_factor_last_used_step IS NULL OR two_factor_last_used_step < ?)", id, step).
+		Update("two_factor_last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *userRepository) UpdateTwoFactorRequired(db *gorm.DB, id uint, required bool) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Update("two_factor_required", required).Error
+}
+
+// LinkOIDCIdentity ties the user to their identity at the OpenID Connect provider
+func (r *userRepository) LinkOIDCIdentity(db *gorm.DB, id uint, issuer, subject string) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"oidc_issuer":  issuer,
+			"oidc_subject": subject,
+		}).Error
+}
+
+// FindAllForDirectorySync returns every user with the fields the LDAP synchronisation compares
+func (r *userRepository) FindAllForDirectorySync(db *gorm.DB) ([]models.User, error) {
+	var users []models.User
+	result := db.
+		Select("id", "name", "email", "birthday", "current_team_id", "position_id", "role", "ldap_uid", "deactivated_at").
+		Order("id ASC").
+		Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return users, nil
+}
+
+// LinkLDAPUID ties the user to their entry in the LDAP directory
+func (r *userRepository) LinkLDAPUID(db *gorm.DB, id uint, uid string) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Update("ldap_uid", uid).Error
+}
+
+// UpdateDeactivatedAt deactivates the user, or reactivates them when deactivatedAt is nil
+func (r *userRepository) UpdateDeactivatedAt(db *gorm.DB, id uint, deactivatedAt *time.Time) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Update("deactivated_at", deactivatedAt).Error
+}
+
+// FindAccessState returns the user with only the columns telling whether they may still use an earlier
+// sign-in: when they were deactivated and the version of their access tokens
+func (r *userRepository) FindAccessState(db *gorm.DB, id uint) (*models.User, error) {
+	var user models.User
+	if err := db.Select("id", "deactivated_at", "token_version").First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateCalendarFeedTokenHash replaces the secret of the user's calendar feeds, nil revokes them
+func (r *userRepository) UpdateCalendarFeedTokenHash(db *gorm.DB, id uint, tokenHash *string) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", id).
+		Update("calendar_feed_token_hash", tokenHash).Error
+}
+
+// FindByCalendarFeedTokenHash returns the user whose calendar feeds are opened with the token
+func (r *userRepository) FindByCalendarFeedTokenHash(db *gorm.DB, tokenHash string) (*models.User, error) {
+	var user models.User
+	if err := db.Select("id", "deactivated_at").Where("calendar_feed_token_hash = ?", tokenHash).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepository interface {
+	CreateDeliveries(db *gorm.DB, deliveries []models.WebhookDelivery) error
+	Create(db *gorm.DB, delivery *models.WebhookDelivery) error
+	Update(db *gorm.DB, delivery *models.WebhookDelivery) error
+	FindByID(db *gorm.DB, id uint) (*models.WebhookDelivery, error)
+	FindDue(db *gorm.DB, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	Claim(db *gorm.DB, delivery *models.WebhookDelivery, leaseUntil time.Time) (bool, error)
+	SearchBySubscriptionID(db *gorm.DB, subscriptionID uint, status *string, limit, offset int) ([]models.WebhookDelivery, int64, error)
+}
+
+type webhookDeliveryRepository struct {
+}
+
+func NewWebhookDeliveryRepository() WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{}
+}
+
+func (r *webhookDeliveryRepository) CreateDeliveries(db *gorm.DB, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return db.Create(&deliveries).Error
+}
+
+func (r *webhookDeliveryRepository) Create(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	return db.Create(delivery).Error
+}
+
+func (r *webhookDeliveryRepository) Update(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	return db.Save(delivery).Error
+}
+
+func (r *webhookDeliveryRepository) FindByID(db *gorm.DB, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	result := db.First(&delivery, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &delivery, nil
+}
+
+// FindDue returns pending deliveries whose next attempt is due, oldest first, with their subscription
+func (r *webhookDeliveryRepository) FindDue(db *gorm.DB, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	result := db.
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryStatusPending, now).
+		Order("next_attempt_at ASC, id ASC").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return deliveries, nil
+}
+
+// Claim moves the next attempt of a due delivery to leaseUntil, so that no other worker picks it up while it is sent.
+// It reports false when another worker claimed the delivery first.
+func (r *webhookDeliveryRepository) Claim(db *gorm.DB, delivery *models.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	result := db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, models.WebhookDeliveryStatusPending, delivery.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SearchBySubscriptionID returns the delivery log of the subscription, newest first
+func (r *webhookDeliveryRepository) SearchBySubscriptionID(db *gorm.DB, subscriptionID uint, status *string, limit, offset int) ([]models.WebhookDelivery, int64, error) {
+	query := db.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []models.WebhookDelivery
+	result := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return deliveries, count, nil
+}
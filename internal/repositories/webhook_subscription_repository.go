@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepository interface {
+	FindAll(db *gorm.DB) ([]models.WebhookSubscription, error)
+	FindActive(db *gorm.DB) ([]models.WebhookSubscription, error)
+	FindByID(db *gorm.DB, id uint) (*models.WebhookSubscription, error)
+	Create(db *gorm.DB, subscription *models.WebhookSubscription) error
+	Update(db *gorm.DB, subscription *models.WebhookSubscription) error
+	Delete(db *gorm.DB, id uint) error
+}
+
+type webhookSubscriptionRepository struct {
+}
+
+func NewWebhookSubscriptionRepository() WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{}
+}
+
+func (r *webhookSubscriptionRepository) FindAll(db *gorm.DB) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	result := db.Order("name ASC, id ASC").Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return subscriptions, nil
+}
+
+// FindActive returns the active subscriptions, the caller filters them by event type
+func (r *webhookSubscriptionRepository) FindActive(db *gorm.DB) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	result := db.Where("active = ?", true).Find(&subscriptions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookSubscriptionRepository) FindByID(db *gorm.DB, id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	result := db.First(&subscription, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &subscription, nil
+}
+
+func (r *webhookSubscriptionRepository) Create(db *gorm.DB, subscription *models.WebhookSubscription) error {
+	return db.Create(subscription).Error
+}
+
+func (r *webhookSubscriptionRepository) Update(db *gorm.DB, subscription *models.WebhookSubscription) error {
+	return db.Save(subscription).Error
+}
+
+// Delete removes the subscription, its deliveries go with it
+func (r *webhookSubscriptionRepository) Delete(db *gorm.DB, id uint) error {
+	return db.Delete(&models.WebhookSubscription{}, id).Error
+}
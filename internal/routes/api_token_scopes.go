@@ -0,0 +1,44 @@
+package routes
+
+import "trieu_mock_project_go/models"
+
+// apiTokenRouteScopes lists the routes a personal access token may call and the scope each one needs.
+// Routes missing here are closed to tokens, including token management and security settings,
+// so that a leaked token cannot mint new tokens or lock its owner out.
+var apiTokenRouteScopes = map[string]string{
+	"GET /api/profile":                             models.APITokenScopeProfileRead,
+	"GET /api/profile/promotion-readiness":         models.APITokenScopeProfileRead,
+	"GET /api/profile/:userId":                     models.APITokenScopeProfileRead,
+	"GET /api/profile/:userId/promotion-readiness": models.APITokenScopeProfileRead,
+	"GET /api/leaves":                              models.APITokenScopeProfileRead,
+	"GET /api/leaves/calendar.ics":                 models.APITokenScopeProfileRead,
+	"GET /api/timesheets":                          models.APITokenScopeProfileRead,
+	"GET /api/celebrations/upcoming":               models.APITokenScopeProfileRead,
+	"GET /api/notifications":                       models.APITokenScopeProfileRead,
+	"GET /api/teams":                               models.APITokenScopeTeamsRead,
+	"GET /api/teams/:id":                           models.APITokenScopeTeamsRead,
+	"GET /api/teams/:id/members":                   models.APITokenScopeTeamsRead,
+	"GET /api/teams/:id/leaves":                    models.APITokenScopeTeamsRead,
+	"GET /api/teams/:id/leaves.ics":                models.APITokenScopeTeamsRead,
+	"POST /admin/users":                            models.APITokenScopeAdminWrite,
+	"PUT /admin/users/:userId":                     models.APITokenScopeAdminWrite,
+	"DELETE /admin/users/:userId":                  models.APITokenScopeAdminWrite,
+	"POST /admin/users/:userId/unlock":             models.APITokenScopeAdminWrite,
+	"PUT /admin/users/:userId/2fa-requirement":     models.APITokenScopeAdminWrite,
+	"DELETE /admin/users/:userId/2fa":              models.APITokenScopeAdminWrite,
+	"POST /admin/positions":                        models.APITokenScopeAdminWrite,
+	"PUT /admin/positions/:positionId":             models.APITokenScopeAdminWrite,
+	"DELETE /admin/positions/:positionId":          models.APITokenScopeAdminWrite,
+	"POST /admin/career-tracks":                    models.APITokenScopeAdminWrite,
+	"PUT /admin/career-tracks/:careerTrackId":      models.APITokenScopeAdminWrite,
+	"DELETE /admin/career-tracks/:careerTrackId":   models.APITokenScopeAdminWrite,
+	"POST /admin/skills":                           models.APITokenScopeAdminWrite,
+	"PUT /admin/skills/:skillId":                   models.APITokenScopeAdminWrite,
+	"DELETE /admin/skills/:skillId":                models.APITokenScopeAdminWrite,
+	"POST /admin/teams":                            models.APITokenScopeAdminWrite,
+	"PUT /admin/teams/:teamId":                     models.APITokenScopeAdminWrite,
+	"DELETE /admin/teams/:teamId":                  models.APITokenScopeAdminWrite,
+	"POST /admin/teams/:teamId/members":            models.APITokenScopeAdminWrite,
+	"DELETE /admin/teams/:teamId/members/:userId":  models.APITokenScopeAdminWrite,
+	"POST /admin/directory-sync":                   models.APITokenScopeAdminWrite,
+}
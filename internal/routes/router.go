@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"trieu_mock_project_go/internal/bootstrap"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/middlewares"
+	"trieu_mock_project_go/internal/sessionstore"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter creates the engine serving the app, with its templates, static files, sessions and routes.
+// Templates and static files are read relative to the working directory.
+func NewRouter(cfg *config.Config, appContainer *bootstrap.AppContainer) (*gin.Engine, error) {
+	router := gin.New()
+	// c.ClientIP() only reads X-Forwarded-For when the request came through one of these proxies
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	router.Use(
+		middlewares.RequestIDMiddleware(),
+		middlewares.TracingMiddleware(),
+		middlewares.RequestLoggerMiddleware(),
+		middlewares.MetricsMiddleware(appContainer.Metrics),
+		middlewares.RecoveryMiddleware(),
+	)
+
+	setupHtmlTemplate(router)
+
+	router.Static("/static", "./static")
+
+	setupSessionConfiguration(router, cfg, appContainer.SessionBackend)
+
+	SetupRoutes(router, appContainer)
+	return router, nil
+}
+
+func setupHtmlTemplate(router *gin.Engine) {
+	router.SetFuncMap(template.FuncMap{
+		"add": func(a, b int) int { return a + b },
+		"sub": func(a, b int) int { return a - b },
+		"min": func(a, b int64) int64 {
+			if a < b {
+				return a
+			}
+			return b
+		},
+		"int64": func(v int) int64 {
+			return int64(v)
+		},
+		"iterate": func(start, end int) []int {
+			var items []int
+			for i := start; i <= end; i++ {
+				items = append(items, i)
+			}
+			return items
+		},
+	})
+
+	router.LoadHTMLGlob("templates/**/*")
+}
+
+func setupSessionConfiguration(router *gin.Engine, cfg *config.Config, backend sessionstore.Backend) {
+	store := sessionstore.NewStore(backend, []byte(cfg.SessionConfig.Secret))
+	store.Options(sessions.Options{
+		Path:     "/",
+		MaxAge:   cfg.SessionConfig.MaxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   cfg.SessionConfig.Secure,
+	})
+	router.Use(sessionstore.ClientIPMiddleware(), sessions.Sessions("trieu_mock_project_session", store))
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"trieu_mock_project_go/internal/bootstrap"
+	"trieu_mock_project_go/internal/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,31 +11,99 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 	// User endpoint
 	router.GET("/login", appContainer.AuthHandler.ShowLoginPage)
 	router.POST("/login", appContainer.AuthHandler.UserLogin)
+	router.POST("/login/2fa", appContainer.AuthHandler.UserLoginTwoFactor)
+	router.POST("/login/2fa/setup", appContainer.AuthHandler.UserLoginTwoFactorSetup)
+	router.POST("/login/2fa/enable", appContainer.AuthHandler.UserLoginTwoFactorEnable)
+	router.GET("/login/oidc", appContainer.AuthHandler.UserOIDCLogin)
+	router.GET("/login/oidc/callback", appContainer.AuthHandler.UserOIDCCallback)
+	router.POST("/login/oidc/complete", appContainer.AuthHandler.UserOIDCComplete)
+	router.GET("/forgot-password", appContainer.AuthHandler.ShowForgotPasswordPage)
+	router.POST("/forgot-password", appContainer.AuthHandler.ForgotPassword)
+	router.GET("/reset-password", appContainer.AuthHandler.ShowResetPasswordPage)
+	router.POST("/reset-password", appContainer.AuthHandler.ResetPassword)
+	router.GET("/.well-known/jwks.json", appContainer.AuthHandler.JWKS)
+
+	// Slash commands from the chat workspace, authenticated by their signature instead of a user session
+	router.POST("/integrations/chat/commands", appContainer.ChatCommandAuthMiddleware, appContainer.ChatHandler.HandleCommand)
+
+	// Prometheus metrics, scraped with the token of METRICS_TOKEN
+	router.GET("/metrics", appContainer.MetricsAuthMiddleware, gin.WrapH(appContainer.Metrics.Handler()))
+
+	// Probes of the orchestrator: liveness restarts a stuck process, readiness routes traffic to it
+	router.GET("/healthz", appContainer.HealthHandler.Liveness)
+	router.GET("/readyz", appContainer.HealthHandler.Readiness)
+
+	// Leave feeds for calendar clients, opened with the secret token of a user in the URL
+	router.GET("/calendar/leaves.ics", appContainer.CalendarFeedAuthMiddleware, appContainer.LeaveHandler.GetMyICalendar)
+	router.GET("/calendar/teams/:id/leaves.ics", appContainer.CalendarFeedAuthMiddleware, appContainer.LeaveHandler.GetTeamICalendar)
+
 	router.GET("/", appContainer.DashboardHandler.DashboardPageHandler)
 	router.GET("/profile", appContainer.UserProfileHandler.UserMyProfilePageHandler)
 	router.GET("/profile/:userId", appContainer.UserProfileHandler.UserUserProfilePageHandler)
 	router.GET("/teams", appContainer.TeamsHandler.TeamsPageHandler)
 	router.GET("/teams/:id", appContainer.TeamsHandler.TeamDetailsPageHandler)
+	router.GET("/teams/:id/calendar", appContainer.LeaveHandler.TeamCalendarPageHandler)
+	router.GET("/leaves", appContainer.LeaveHandler.LeavesPageHandler)
+	router.GET("/timesheet", appContainer.TimesheetHandler.TimesheetPageHandler)
 
-	// Normal user routes (JWT)
+	// Normal user routes (JWT or personal access token)
 	apiGroup := router.Group("/api")
-	apiGroup.Use(appContainer.JWTAuthMiddleware)
+	apiGroup.Use(appContainer.JWTAuthMiddleware, middlewares.APITokenScopeMiddleware(apiTokenRouteScopes))
 	{
 		apiGroup.GET("/profile", appContainer.UserProfileHandler.GetMyProfile)
+		apiGroup.GET("/profile/promotion-readiness", appContainer.UserProfileHandler.GetMyPromotionReadiness)
+		apiGroup.PUT("/profile/preferences", appContainer.UserProfileHandler.UpdateMyPreferences)
+		apiGroup.GET("/profile/2fa", appContainer.TwoFactorHandler.GetStatus)
+		apiGroup.POST("/profile/2fa/setup", appContainer.TwoFactorHandler.BeginSetup)
+		apiGroup.POST("/profile/2fa/enable", appContainer.TwoFactorHandler.Enable)
+		apiGroup.POST("/profile/2fa/disable", appContainer.TwoFactorHandler.Disable)
+		apiGroup.POST("/profile/2fa/recovery-codes", appContainer.TwoFactorHandler.RegenerateRecoveryCodes)
+		apiGroup.GET("/profile/tokens", appContainer.APITokenHandler.ListTokens)
+		apiGroup.POST("/profile/tokens", appContainer.APITokenHandler.CreateToken)
+		apiGroup.DELETE("/profile/tokens/:id", appContainer.APITokenHandler.RevokeToken)
 		apiGroup.GET("/profile/:userId", appContainer.UserProfileHandler.GetUserProfile)
+		apiGroup.GET("/profile/:userId/promotion-readiness", appContainer.UserProfileHandler.GetUserPromotionReadiness)
 		apiGroup.GET("/teams", appContainer.TeamsHandler.ListTeams)
 		apiGroup.GET("/teams/:id", appContainer.TeamsHandler.GetTeamDetails)
 		apiGroup.GET("/teams/:id/members", appContainer.TeamsHandler.GetTeamMembers)
+		apiGroup.GET("/teams/:id/leaves", appContainer.LeaveHandler.GetTeamCalendar)
+		apiGroup.GET("/teams/:id/leaves.ics", appContainer.LeaveHandler.GetTeamICalendar)
+		apiGroup.POST("/leaves", appContainer.LeaveHandler.CreateLeave)
+		apiGroup.GET("/leaves", appContainer.LeaveHandler.ListMyLeaves)
+		apiGroup.GET("/leaves/pending", appContainer.LeaveHandler.ListPendingLeaves)
+		apiGroup.GET("/leaves/calendar.ics", appContainer.LeaveHandler.GetMyICalendar)
+		apiGroup.POST("/leaves/calendar-feed", appContainer.LeaveHandler.CreateCalendarFeed)
+		apiGroup.DELETE("/leaves/calendar-feed", appContainer.LeaveHandler.RevokeCalendarFeed)
+		apiGroup.PUT("/leaves/:id/approve", appContainer.LeaveHandler.ApproveLeave)
+		apiGroup.PUT("/leaves/:id/reject", appContainer.LeaveHandler.RejectLeave)
+		apiGroup.PUT("/leaves/:id/cancel", appContainer.LeaveHandler.CancelLeave)
+		apiGroup.GET("/timesheets", appContainer.TimesheetHandler.GetTimesheet)
+		apiGroup.GET("/timesheets/projects", appContainer.TimesheetHandler.GetProjects)
+		apiGroup.GET("/timesheets/pending", appContainer.TimesheetHandler.ListPendingTimesheets)
+		apiGroup.PUT("/timesheets/entries", appContainer.TimesheetHandler.SaveTimeEntry)
+		apiGroup.DELETE("/timesheets/entries/:id", appContainer.TimesheetHandler.DeleteTimeEntry)
+		apiGroup.POST("/timesheets/submit", appContainer.TimesheetHandler.SubmitTimesheet)
+		apiGroup.PUT("/timesheets/:id/approve", appContainer.TimesheetHandler.ApproveTimesheet)
+		apiGroup.PUT("/timesheets/:id/reject", appContainer.TimesheetHandler.RejectTimesheet)
+		apiGroup.GET("/celebrations/upcoming", appContainer.DashboardHandler.GetUpcomingCelebrations)
+		apiGroup.GET("/notifications", appContainer.NotificationHandler.ListNotifications)
+		apiGroup.PUT("/notifications/:id/read", appContainer.NotificationHandler.MarkAsRead)
 	}
 
 	// Admin login flow
 	router.GET("/admin/login", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminShowLogin)
 	router.POST("/admin/login", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminLogin)
+	router.GET("/admin/login/2fa", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminShowTwoFactor)
+	router.POST("/admin/login/2fa", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminVerifyTwoFactor)
+	router.GET("/admin/login/2fa/setup", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminShowTwoFactorSetup)
+	router.POST("/admin/login/2fa/setup", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminCompleteTwoFactorSetup)
+	router.GET("/admin/login/oidc", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminOIDCLogin)
+	router.GET("/admin/login/oidc/callback", appContainer.CSRFMiddleware, appContainer.AdminAuthHandler.AdminOIDCCallback)
 	router.GET("/admin/logout", appContainer.AdminAuthHandler.AdminLogout)
 
-	// Admin routes (Session)
+	// Admin routes (Session, or personal access token with the admin:write scope)
 	adminGroup := router.Group("/admin")
-	adminGroup.Use(appContainer.AdminAuthMiddleware)
+	adminGroup.Use(appContainer.AdminAuthMiddleware, middlewares.APITokenScopeMiddleware(apiTokenRouteScopes))
 	{
 		// Admin dashboard
 		adminGroup.GET("/", appContainer.AdminDashboardHandler.AdminDashboardPage)
@@ -48,6 +117,9 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 		adminGroup.GET("/users/:userId/edit", appContainer.CSRFMiddleware, appContainer.AdminUserHandler.AdminUserEditPage)
 		adminGroup.PUT("/users/:userId", appContainer.CSRFMiddleware, appContainer.AdminUserHandler.UpdateUser)
 		adminGroup.DELETE("/users/:userId", appContainer.CSRFMiddleware, appContainer.AdminUserHandler.DeleteUser)
+		adminGroup.POST("/users/:userId/unlock", appContainer.CSRFMiddleware, appContainer.AdminUserHandler.UnlockUser)
+		adminGroup.PUT("/users/:userId/2fa-requirement", appContainer.CSRFMiddleware, appContainer.AdminUserHandler.UpdateTwoFactorRequirement)
+		adminGroup.DELETE("/users/:userId/2fa", appContainer.CSRFMiddleware, appContainer.AdminUserHandler.ResetTwoFactor)
 		// Admin position management
 		adminGroup.GET("/positions", appContainer.CSRFMiddleware, appContainer.AdminPositionHandler.ListPositionPage)
 		adminGroup.GET("/positions/partial/search", appContainer.AdminPositionHandler.PositionSearchPartial)
@@ -56,6 +128,14 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 		adminGroup.GET("/positions/:positionId/edit", appContainer.CSRFMiddleware, appContainer.AdminPositionHandler.EditPositionPage)
 		adminGroup.PUT("/positions/:positionId", appContainer.CSRFMiddleware, appContainer.AdminPositionHandler.UpdatePosition)
 		adminGroup.DELETE("/positions/:positionId", appContainer.CSRFMiddleware, appContainer.AdminPositionHandler.DeletePosition)
+		// Admin career track management
+		adminGroup.GET("/career-tracks", appContainer.CSRFMiddleware, appContainer.AdminCareerTrackHandler.ListCareerTrackPage)
+		adminGroup.GET("/career-tracks/partial/search", appContainer.AdminCareerTrackHandler.CareerTrackSearchPartial)
+		adminGroup.GET("/career-tracks/create", appContainer.CSRFMiddleware, appContainer.AdminCareerTrackHandler.CreateCareerTrackPage)
+		adminGroup.POST("/career-tracks", appContainer.CSRFMiddleware, appContainer.AdminCareerTrackHandler.CreateCareerTrack)
+		adminGroup.GET("/career-tracks/:careerTrackId/edit", appContainer.CSRFMiddleware, appContainer.AdminCareerTrackHandler.EditCareerTrackPage)
+		adminGroup.PUT("/career-tracks/:careerTrackId", appContainer.CSRFMiddleware, appContainer.AdminCareerTrackHandler.UpdateCareerTrack)
+		adminGroup.DELETE("/career-tracks/:careerTrackId", appContainer.CSRFMiddleware, appContainer.AdminCareerTrackHandler.DeleteCareerTrack)
 		// Admin skill management
 		adminGroup.GET("/skills", appContainer.CSRFMiddleware, appContainer.AdminSkillHandler.ListSkillPage)
 		adminGroup.GET("/skills/partial/search", appContainer.AdminSkillHandler.SkillSearchPartial)
@@ -75,5 +155,36 @@ func SetupRoutes(router *gin.Engine, appContainer *bootstrap.AppContainer) {
 		adminGroup.DELETE("/teams/:teamId", appContainer.CSRFMiddleware, appContainer.AdminTeamHandler.DeleteTeam)
 		adminGroup.POST("/teams/:teamId/members", appContainer.CSRFMiddleware, appContainer.AdminTeamHandler.AddMember)
 		adminGroup.DELETE("/teams/:teamId/members/:userId", appContainer.CSRFMiddleware, appContainer.AdminTeamHandler.RemoveMember)
+		// Admin reports
+		adminGroup.GET("/reports/promotions", appContainer.AdminReportHandler.PromotionReportPage)
+		adminGroup.GET("/reports/project-effort", appContainer.AdminReportHandler.ProjectEffortReportPage)
+		adminGroup.GET("/reports/project-effort.csv", appContainer.AdminReportHandler.ProjectEffortReportCSV)
+
+		// Admin directory sync
+		adminGroup.GET("/directory-sync", appContainer.CSRFMiddleware, appContainer.AdminDirectorySyncHandler.SyncRunsPage)
+		adminGroup.POST("/directory-sync", appContainer.CSRFMiddleware, appContainer.AdminDirectorySyncHandler.RunSync)
+		adminGroup.GET("/directory-sync/:runId", appContainer.AdminDirectorySyncHandler.SyncRunPage)
+		// Admin activity log
+		adminGroup.GET("/activity-log", appContainer.AdminActivityLogHandler.ActivityLogPage)
+		// Admin webhooks
+		adminGroup.GET("/webhooks", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.ListWebhookPage)
+		adminGroup.GET("/webhooks/create", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.CreateWebhookPage)
+		adminGroup.POST("/webhooks", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.CreateWebhook)
+		adminGroup.GET("/webhooks/:webhookId/edit", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.EditWebhookPage)
+		adminGroup.PUT("/webhooks/:webhookId", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.UpdateWebhook)
+		adminGroup.DELETE("/webhooks/:webhookId", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.DeleteWebhook)
+		adminGroup.GET("/webhooks/:webhookId/deliveries", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.DeliveriesPage)
+		adminGroup.POST("/webhooks/:webhookId/deliveries/:deliveryId/redeliver", appContainer.CSRFMiddleware, appContainer.AdminWebhookHandler.Redeliver)
+		// Admin security
+		adminGroup.GET("/security/login-attempts", appContainer.AdminSecurityHandler.LoginAttemptsPage)
+		adminGroup.GET("/security/sessions", appContainer.CSRFMiddleware, appContainer.AdminSecurityHandler.SessionsPage)
+		adminGroup.DELETE("/security/sessions/:sessionId", appContainer.CSRFMiddleware, appContainer.AdminSecurityHandler.RevokeSession)
+		adminGroup.POST("/security/sessions/revoke-others", appContainer.CSRFMiddleware, appContainer.AdminSecurityHandler.RevokeOtherSessions)
+		adminGroup.PUT("/security/password", appContainer.CSRFMiddleware, appContainer.AdminSecurityHandler.ChangePassword)
+		adminGroup.GET("/security/2fa", appContainer.CSRFMiddleware, appContainer.AdminSecurityHandler.TwoFactorPage)
+		adminGroup.POST("/security/2fa/setup", appContainer.CSRFMiddleware, appContainer.TwoFactorHandler.BeginSetup)
+		adminGroup.POST("/security/2fa/enable", appContainer.CSRFMiddleware, appContainer.TwoFactorHandler.Enable)
+		adminGroup.POST("/security/2fa/disable", appContainer.CSRFMiddleware, appContainer.TwoFactorHandler.Disable)
+		adminGroup.POST("/security/2fa/recovery-codes", appContainer.CSRFMiddleware, appContainer.TwoFactorHandler.RegenerateRecoveryCodes)
 	}
 }
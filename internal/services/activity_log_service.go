@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+// ActivityLogService keeps the activity log, an entry for every domain event
+type ActivityLogService interface {
+	SearchActivityLogs(c context.Context, req dtos.ActivityLogSearchRequest) (*dtos.ActivityLogSearchResponse, error)
+	SubscriberName() string
+	HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error
+}
+
+type activityLogService struct {
+	db                    *gorm.DB
+	activityLogRepository repositories.ActivityLogRepository
+	userRepository        repositories.UserRepository
+}
+
+func NewActivityLogService(db *gorm.DB, activityLogRepository repositories.ActivityLogRepository, userRepository repositories.UserRepository) ActivityLogService {
+	return &activityLogService{db: db, activityLogRepository: activityLogRepository, userRepository: userRepository}
+}
+
+func (s *activityLogService) SearchActivityLogs(c context.Context, req dtos.ActivityLogSearchRequest) (*dtos.ActivityLogSearchResponse, error) {
+	activityLogs, total, err := s.activityLogRepository.Search(s.db.WithContext(c), req.Action, req.Limit, req.Offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &dtos.ActivityLogSearchResponse{
+		ActivityLogs: helpers.MapActivityLogsToDtos(activityLogs),
+		Page: dtos.PaginationResponse{
+			Limit:  req.Limit,
+			Offset: req.Offset,
+			Total:  total,
+		},
+	}, nil
+}
+
+func (s *activityLogService) SubscriberName() string {
+	return "activity_log"
+}
+
+// HandleEvent logs the event against the user it is about
+func (s *activityLogService) HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error {
+	var userID uint
+	var description string
+	switch event.EventType {
+	case models.DomainEventUserCreated, models.DomainEventUserDeleted:
+		var data dtos.UserEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		userID = data.User.ID
+		description = fmt.Sprintf("User %s <%s> was created", data.User.Name, data.User.Email)
+		if event.EventType == models.DomainEventUserDeleted {
+			description = fmt.Sprintf("User %s <%s> was deleted", data.User.Name, data.User.Email)
+		}
+	case models.DomainEventTeamCreated, models.DomainEventTeamDeleted:
+		var data dtos.TeamEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		userID = data.LeaderID
+		description = fmt.Sprintf("Team %s was created", data.Team.Name)
+		if event.EventType == models.DomainEventTeamDeleted {
+			description = fmt.Sprintf("Team %s was deleted", data.Team.Name)
+		}
+	case models.DomainEventTeamMemberAdded, models.DomainEventTeamMemberRemoved:
+		var data dtos.TeamMemberEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		userID = data.User.ID
+		description = fmt.Sprintf("%s joined the team %s", data.User.Name, data.Team.Name)
+		if event.EventType == models.DomainEventTeamMemberRemoved {
+			description = fmt.Sprintf("%s left the team %s", data.User.Name, data.Team.Name)
+		}
+	case models.DomainEventTeamLeaderChanged:
+		var data dtos.TeamLeaderChangedEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		userID = data.Leader.ID
+		description = fmt.Sprintf("%s became the leader of the team %s", data.Leader.Name, data.Team.Name)
+	default:
+		return nil
+	}
+
+	activityLog := &models.ActivityLog{
+		EventID:     &event.EventID,
+		Action:      event.EventType,
+		Description: &description,
+		CreatedAt:   event.OccurredAt,
+	}
+	// The user may be gone by the time the event is dispatched, the entry is kept without them
+	exists, err := s.userRepository.ExistByID(tx, userID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		activityLog.UserID = &userID
+	}
+	return s.activityLogRepository.Create(tx, activityLog)
+}
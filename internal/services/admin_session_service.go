@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/sessionstore"
+)
+
+// AdminSessionService lists and revokes the server-side sessions of admins.
+// It works on the session store backend, which is either the database or process memory.
+type AdminSessionService interface {
+	ListSessions(c context.Context, userID uint, currentSessionID string) ([]dtos.AdminSession, error)
+	RevokeSession(c context.Context, userID, sessionID uint, currentSessionID string) error
+	RevokeOtherSessions(c context.Context, userID uint, currentSessionID string) (int64, error)
+	DeleteExpiredSessions(c context.Context, now time.Time) (int64, error)
+}
+
+type adminSessionService struct {
+	backend sessionstore.Backend
+}
+
+func NewAdminSessionService(backend sessionstore.Backend) AdminSessionService {
+	return &adminSessionService{backend: backend}
+}
+
+// ListSessions returns the active sessions of the user, currentSessionID is the session the request was made with
+func (s *adminSessionService) ListSessions(c context.Context, userID uint, currentSessionID string) ([]dtos.AdminSession, error) {
+	sessions, err := s.backend.FindByUserID(c, userID, time.Now())
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapAdminSessionsToDtos(sessions, sessionstore.HashToken(currentSessionID)), nil
+}
+
+// RevokeSession signs one of the user's other sessions out
+func (s *adminSessionService) RevokeSession(c context.Context, userID, sessionID uint, currentSessionID string) error {
+	current, err := s.backend.Find(c, sessionstore.HashToken(currentSessionID), time.Now())
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if current != nil && current.ID == sessionID {
+		return appErrors.ErrCannotRevokeCurrentSession
+	}
+
+	deleted, err := s.backend.DeleteByID(c, sessionID, userID)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if !deleted {
+		return appErrors.ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeOtherSessions signs the user out everywhere except the current session and returns how many sessions ended
+func (s *adminSessionService) RevokeOtherSessions(c context.Context, userID uint, currentSessionID string) (int64, error) {
+	revoked, err := s.backend.DeleteByUserID(c, userID, sessionstore.HashToken(currentSessionID))
+	if err != nil {
+		return 0, appErrors.ErrInternalServerError
+	}
+	return revoked, nil
+}
+
+func (s *adminSessionService) DeleteExpiredSessions(c context.Context, now time.Time) (int64, error) {
+	deleted, err := s.backend.DeleteExpired(c, now)
+	if err != nil {
+		return 0, appErrors.ErrInternalServerError
+	}
+	return deleted, nil
+}
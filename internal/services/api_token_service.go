@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// APITokenPrefix starts every personal access token, it tells them apart from JWTs
+	APITokenPrefix = "tmp_"
+
+	apiTokenRandomBytes   = 32
+	apiTokenDisplayLength = 12
+	maxAPITokensPerUser   = 20
+	// Last-used tracking is written at most this often per token to keep requests from writing on every call
+	apiTokenLastUsedInterval = time.Minute
+)
+
+// APITokenService manages the personal access tokens users create to call the API from scripts.
+// Only a SHA-256 hash of a token is stored, the token itself is shown once when it is created.
+type APITokenService interface {
+	ListTokens(c context.Context, userID uint) (*dtos.APITokenListResponse, error)
+	CreateToken(c context.Context, userID uint, req dtos.CreateAPITokenRequest) (*dtos.CreateAPITokenResponse, error)
+	RevokeToken(c context.Context, userID, tokenID uint) error
+	Authenticate(c context.Context, rawToken, ipAddress string) (*models.APIToken, error)
+}
+
+type apiTokenService struct {
+	db                 *gorm.DB
+	apiTokenRepository repositories.APITokenRepository
+	userRepository     repositories.UserRepository
+}
+
+func NewAPITokenService(
+	db *gorm.DB,
+	apiTokenRepository repositories.APITokenRepository,
+	userRepository repositories.UserRepository) APITokenService {
+	return &apiTokenService{
+		db:                 db,
+		apiTokenRepository: apiTokenRepository,
+		userRepository:     userRepository,
+	}
+}
+
+// IsAPIToken reports whether a bearer token is a personal access token rather than a JWT
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+func (s *apiTokenService) ListTokens(c context.Context, userID uint) (*dtos.APITokenListResponse, error) {
+	tokens, err := s.apiTokenRepository.FindByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &dtos.APITokenListResponse{Tokens: helpers.MapAPITokensToDtos(tokens)}, nil
+}
+
+func (s *apiTokenService) CreateToken(c context.Context, userID uint, req dtos.CreateAPITokenRequest) (*dtos.CreateAPITokenResponse, error) {
+	user, err := s.userRepository.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	scopes := uniqueScopes(req.Scopes)
+	for _, scope := range scopes {
+		if scope == models.APITokenScopeAdminWrite && user.Role != "admin" {
+			return nil, appErrors.ErrAPITokenScopeNotAllowed
+		}
+	}
+
+	count, err := s.apiTokenRepository.CountByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if count >= maxAPITokensPerUser {
+		return nil, appErrors.ErrAPITokenLimitReached
+	}
+
+	rawToken, err := generateAPIToken()
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	token := &models.APIToken{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		TokenPrefix: rawToken[:apiTokenDisplayLength],
+		TokenHash:   hashAPIToken(rawToken),
+		Scopes:      strings.Join(scopes, " "),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+	if err := s.apiTokenRepository.Create(s.db.WithContext(c), token); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.CreateAPITokenResponse{
+		Token:    rawToken,
+		APIToken: *helpers.MapAPITokenToDto(token),
+	}, nil
+}
+
+func (s *apiTokenService) RevokeToken(c context.Context, userID, tokenID uint) error {
+	deleted, err := s.apiTokenRepository.DeleteByIDAndUserID(s.db.WithContext(c), tokenID, userID)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if !deleted {
+		return appErrors.ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// Authenticate resolves a personal access token to the token and its user, recording when and from where it was used
+func (s *apiTokenService) Authenticate(c context.Context, rawToken, ipAddress string) (*models.APIToken, error) {
+	token, err := s.apiTokenRepository.FindByTokenHash(s.db.WithContext(c), hashAPIToken(rawToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrInvalidToken
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	now := time.Now()
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, appErrors.ErrInvalidToken
+	}
+	if token.User.DeactivatedAt != nil {
+		return nil, appErrors.ErrAccountDeactivated
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= apiTokenLastUsedInterval {
+		if err := s.apiTokenRepository.UpdateLastUsed(s.db.WithContext(c), token.ID, now, ipAddress); err != nil {
+			return nil, appErrors.ErrInternalServerError
+		}
+		token.LastUsedAt = &now
+		token.LastUsedIP = &ipAddress
+	}
+	return token, nil
+}
+
+func generateAPIToken() (string, error) {
+	raw := make([]byte, apiTokenRandomBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		unique = append(unique, scope)
+	}
+	return unique
+}
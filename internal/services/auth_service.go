@@ -2,8 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
 	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/metrics"
 	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/sessionstore"
 	"trieu_mock_project_go/models"
 
 	"golang.org/x/crypto/bcrypt"
@@ -11,33 +20,525 @@ import (
 	"gorm.io/gorm"
 )
 
-type AuthService struct {
-	db   *gorm.DB
-	repo *repositories.UserRepository
+// Number of recent login attempts shown on the admin user detail page
+const recentLoginAttemptsLimit = 10
+
+// dummyPasswordHash is compared against when the email is unknown, so that the response takes as long
+// as for a wrong password and does not tell which emails have an account
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hashed, err := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	if err != nil {
+		panic(fmt.Sprintf("hashing dummy password: %v", err))
+	}
+	return string(hashed)
+})
+
+type AuthService interface {
+	Login(c context.Context, email, password string, meta LoginMeta) (*LoginResult, error)
+	CompleteSSOLogin(c context.Context, user *models.User, meta LoginMeta) (*LoginResult, error)
+	RejectSSOLogin(c context.Context, email string, meta LoginMeta) error
+	CompleteSecondFactor(c context.Context, userID uint, code string, meta LoginMeta) (*models.User, error)
+	CompleteTwoFactorSetup(c context.Context, userID uint, code string, meta LoginMeta) (*models.User, []string, error)
+	VerifyPassword(plainPassword, hashedPassword string) bool
+	CheckActive(c context.Context, userID uint) error
+	CheckAccessToken(c context.Context, userID, tokenVersion uint) error
+	ChangePassword(c context.Context, userID uint, currentPassword, newPassword, currentSessionID string) (int64, error)
+	UnlockUser(c context.Context, userID uint) error
+	GetAccountLoginSecurity(c context.Context, userID uint) (*dtos.AccountLoginSecurity, error)
+	SearchLoginAttempts(c context.Context, query dtos.LoginAttemptSearchRequest) (*dtos.LoginAttemptSearchResponse, error)
+}
+
+type authService struct {
+	db                     *gorm.DB
+	repo                   repositories.UserRepository
+	loginAttemptRepository repositories.LoginAttemptRepository
+	notificationRepository repositories.NotificationRepository
+	apiTokenRepository     repositories.APITokenRepository
+	sessionBackend         sessionstore.Backend
+	twoFactorService       TwoFactorService
+	protection             config.LoginProtectionConfig
+	metrics                *metrics.Metrics
+}
+
+// Second factor a login still needs after the password check
+const (
+	SecondFactorVerify = "verify"
+	SecondFactorSetup  = "setup"
+)
+
+// LoginResult is the outcome of a password check, the login is complete when SecondFactor is empty
+type LoginResult struct {
+	User         *models.User
+	SecondFactor string
 }
 
-func NewAuthService(db *gorm.DB, repo *repositories.UserRepository) *AuthService {
-	return &AuthService{db: db, repo: repo}
+// LoginMeta describes where a login attempt comes from, it is recorded in the login audit
+type LoginMeta struct {
+	Flow      string
+	IPAddress string
+	UserAgent string
 }
 
-func (s *AuthService) Login(c context.Context, email, password string) (*models.User, error) {
+func NewAuthService(
+	db *gorm.DB,
+	repo repositories.UserRepository,
+	loginAttemptRepository repositories.LoginAttemptRepository,
+	notificationRepository repositories.NotificationRepository,
+	apiTokenRepository repositories.APITokenRepository,
+	sessionBackend sessionstore.Backend,
+	twoFactorService TwoFactorService,
+	protection config.LoginProtectionConfig,
+	metrics *metrics.Metrics) AuthService {
+	return &authService{
+		db:                     db,
+		repo:                   repo,
+		loginAttemptRepository: loginAttemptRepository,
+		notificationRepository: notificationRepository,
+		apiTokenRepository:     apiTokenRepository,
+		sessionBackend:         sessionBackend,
+		twoFactorService:       twoFactorService,
+		protection:             protection,
+		metrics:                metrics,
+	}
+}
+
+// Login verifies the credentials and records the attempt. Failed attempts are throttled per IP address,
+// delayed progressively per account and lock the account once MaxAccountFailures is reached.
+// When the account uses two-factor authentication the login only completes after CompleteSecondFactor,
+// or CompleteTwoFactorSetup when enrolment is required but has not happened yet.
+func (s *authService) Login(c context.Context, email, password string, meta LoginMeta) (*LoginResult, error) {
+	now := time.Now()
+
+	ipFailures, err := s.loginAttemptRepository.CountFailuresByIPSince(
+		s.db.WithContext(c),
+		meta.IPAddress,
+		now.Add(-s.protection.IPWindow),
+		[]string{models.LoginFailureInvalidCredentials, models.LoginFailureUnknownEmail, models.LoginFailureInvalidSecondFactor},
+	)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if ipFailures >= int64(s.protection.MaxIPFailures) {
+		return nil, s.rejectLogin(c, email, nil, meta, models.LoginFailureThrottled,
+			appErrors.NewRetryAfterError(appErrors.ErrTooManyLoginAttempts, s.protection.IPWindow))
+	}
+
 	user, err := s.repo.FindByEmail(s.db.WithContext(c), email)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.VerifyPassword(password, dummyPasswordHash())
+			return nil, s.rejectLogin(c, email, nil, meta, models.LoginFailureUnknownEmail, appErrors.ErrInvalidCredentials)
+		}
 		return nil, appErrors.ErrInternalServerError
 	}
 
-	if user == nil {
-		return nil, appErrors.ErrUserNotFound
+	if reason, throttleErr := s.accountThrottle(user, now); throttleErr != nil {
+		return nil, s.rejectLogin(c, email, user, meta, reason, throttleErr)
 	}
 
 	if !s.VerifyPassword(password, user.Password) {
-		return nil, appErrors.ErrInvalidCredentials
+		return nil, s.rejectFailedLogin(c, user, meta, models.LoginFailureInvalidCredentials, appErrors.ErrInvalidCredentials, now)
 	}
 
+	return s.startLogin(c, user, meta)
+}
+
+// CompleteSSOLogin signs in a user whose identity was confirmed by the single sign-on provider.
+// The second factor still applies, like after a password check.
+func (s *authService) CompleteSSOLogin(c context.Context, user *models.User, meta LoginMeta) (*LoginResult, error) {
+	return s.startLogin(c, user, meta)
+}
+
+// RejectSSOLogin audits a single sign-on of an identity that has no account here
+func (s *authService) RejectSSOLogin(c context.Context, email string, meta LoginMeta) error {
+	return s.rejectLogin(c, email, nil, meta, models.LoginFailureSSONoAccount, appErrors.ErrSSONoAccount)
+}
+
+// CompleteSecondFactor finishes a login that passed the password check with a TOTP or recovery code.
+// Wrong codes count against the account like wrong passwords.
+func (s *authService) CompleteSecondFactor(c context.Context, userID uint, code string, meta LoginMeta) (*models.User, error) {
+	user, err := s.findLoginUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason, throttleErr := s.accountThrottle(user, time.Now()); throttleErr != nil {
+		return nil, s.rejectLogin(c, user.Email, user, meta, reason, throttleErr)
+	}
+
+	if err := s.twoFactorService.Verify(c, user.ID, code); err != nil {
+		if err == appErrors.ErrInvalidTwoFactorCode {
+			return nil, s.rejectFailedLogin(c, user, meta, models.LoginFailureInvalidSecondFactor, err, time.Now())
+		}
+		return nil, err
+	}
+
+	if err := s.completeLogin(c, user, meta); err != nil {
+		return nil, err
+	}
 	return user, nil
 }
 
-func (s *AuthService) VerifyPassword(plainPassword, hashedPassword string) bool {
+// CompleteTwoFactorSetup finishes a login of an account that is required to use two-factor authentication
+// by confirming its enrolment, the recovery codes are returned to be shown once
+func (s *authService) CompleteTwoFactorSetup(c context.Context, userID uint, code string, meta LoginMeta) (*models.User, []string, error) {
+	user, err := s.findLoginUser(c, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if reason, throttleErr := s.accountThrottle(user, time.Now()); throttleErr != nil {
+		return nil, nil, s.rejectLogin(c, user.Email, user, meta, reason, throttleErr)
+	}
+
+	recoveryCodes, err := s.twoFactorService.Enable(c, user.ID, code)
+	if err != nil {
+		if err == appErrors.ErrInvalidTwoFactorCode {
+			return nil, nil, s.rejectFailedLogin(c, user, meta, models.LoginFailureInvalidSecondFactor, err, time.Now())
+		}
+		return nil, nil, err
+	}
+
+	if err := s.completeLogin(c, user, meta); err != nil {
+		return nil, nil, err
+	}
+	return user, recoveryCodes, nil
+}
+
+func (s *authService) VerifyPassword(plainPassword, hashedPassword string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
 	return err == nil
 }
+
+// CheckActive tells whether a user signed in earlier may still use their session,
+// it fails once the user was deactivated or deleted
+func (s *authService) CheckActive(c context.Context, userID uint) error {
+	_, err := s.findAccessState(c, userID)
+	return err
+}
+
+// CheckAccessToken tells whether a user may still use their access token, it also fails once they
+// changed their password since it was issued with tokenVersion
+func (s *authService) CheckAccessToken(c context.Context, userID, tokenVersion uint) error {
+	user, err := s.findAccessState(c, userID)
+	if err != nil {
+		return err
+	}
+	if user.TokenVersion != tokenVersion {
+		return appErrors.ErrInvalidToken
+	}
+	return nil
+}
+
+func (s *authService) findAccessState(c context.Context, userID uint) (*models.User, error) {
+	user, err := s.repo.FindAccessState(s.db.WithContext(c), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrInvalidToken
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	if user.DeactivatedAt != nil {
+		return nil, appErrors.ErrAccountDeactivated
+	}
+	return user, nil
+}
+
+// ChangePassword replaces the password of the user after checking the current one. The user is signed out
+// everywhere but in currentSessionID: their access tokens stop working, their API tokens and other admin
+// sessions are revoked in the same transaction. It returns how many sessions ended.
+func (s *authService) ChangePassword(c context.Context, userID uint, currentPassword, newPassword, currentSessionID string) (int64, error) {
+	user, err := s.repo.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, appErrors.ErrUserNotFound
+		}
+		return 0, appErrors.ErrInternalServerError
+	}
+	if !s.VerifyPassword(currentPassword, user.Password) {
+		return 0, appErrors.ErrIncorrectPassword
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, appErrors.ErrInternalServerError
+	}
+
+	var revoked int64
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.UpdatePassword(tx, userID, string(hashedPassword)); err != nil {
+			return err
+		}
+		revoked, err = revokeCredentials(c, tx, s.apiTokenRepository, s.sessionBackend, userID, currentSessionID)
+		return err
+	})
+	if err != nil {
+		return 0, appErrors.ErrInternalServerError
+	}
+	return revoked, nil
+}
+
+// revokeCredentials signs the user out after their password changed: their API tokens are deleted, and
+// their admin sessions except the one of keepSessionID (all of them when empty). It runs in the
+// transaction of the password change and returns how many sessions ended.
+func revokeCredentials(
+	c context.Context,
+	tx *gorm.DB,
+	apiTokenRepository repositories.APITokenRepository,
+	sessionBackend sessionstore.Backend,
+	userID uint,
+	keepSessionID string) (int64, error) {
+	if _, err := apiTokenRepository.DeleteByUserID(tx, userID); err != nil {
+		return 0, err
+	}
+	exceptTokenHash := ""
+	if keepSessionID != "" {
+		exceptTokenHash = sessionstore.HashToken(keepSessionID)
+	}
+	return sessionBackend.WithTx(tx).DeleteByUserID(c, userID, exceptTokenHash)
+}
+
+// UnlockUser lifts a lockout of the user and clears their failed login count
+func (s *authService) UnlockUser(c context.Context, userID uint) error {
+	if _, err := s.repo.FindByID(s.db.WithContext(c), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrUserNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+
+	if err := s.repo.ResetLoginFailures(s.db.WithContext(c), userID); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *authService) GetAccountLoginSecurity(c context.Context, userID uint) (*dtos.AccountLoginSecurity, error) {
+	user, err := s.repo.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	attempts, err := s.loginAttemptRepository.FindRecentByUserID(s.db.WithContext(c), userID, recentLoginAttemptsLimit)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.AccountLoginSecurity{
+		FailedLoginCount:  user.FailedLoginCount,
+		LastFailedLoginAt: user.LastFailedLoginAt,
+		LockedUntil:       user.LockedUntil,
+		IsLocked:          user.LockedUntil != nil && user.LockedUntil.After(time.Now()),
+		DeactivatedAt:     user.DeactivatedAt,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
+		TwoFactorRequired: user.TwoFactorRequired,
+		RecentAttempts:    helpers.MapLoginAttemptsToDtos(attempts),
+	}, nil
+}
+
+func (s *authService) SearchLoginAttempts(c context.Context, query dtos.LoginAttemptSearchRequest) (*dtos.LoginAttemptSearchResponse, error) {
+	var email, ipAddress *string
+	var success *bool
+	if query.Email != "" {
+		email = &query.Email
+	}
+	if query.IPAddress != "" {
+		ipAddress = &query.IPAddress
+	}
+	if query.Result != "" {
+		isSuccess := query.Result == "success"
+		success = &isSuccess
+	}
+
+	attempts, totalCount, err := s.loginAttemptRepository.SearchLoginAttempts(
+		s.db.WithContext(c), email, ipAddress, success, query.Limit, query.Offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.LoginAttemptSearchResponse{
+		Attempts: helpers.MapLoginAttemptsToDtos(attempts),
+		Page: dtos.PaginationResponse{
+			Limit:  query.Limit,
+			Offset: query.Offset,
+			Total:  totalCount,
+		},
+	}, nil
+}
+
+// recordFailedLogin counts a failed attempt against the account and locks it once the limit is reached.
+// The owner is notified about the lockout.
+func (s *authService) recordFailedLogin(c context.Context, user *models.User, meta LoginMeta, now time.Time) (bool, error) {
+	locked := false
+	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.IncrementFailedLoginCount(tx, user.ID, now); err != nil {
+			return err
+		}
+
+		// The row stays locked by the update until commit, so concurrent failures are counted one by one
+		current, err := s.repo.FindByEmail(tx, user.Email)
+		if err != nil {
+			return err
+		}
+		if current.FailedLoginCount < uint(s.protection.MaxAccountFailures) {
+			return nil
+		}
+
+		lockedUntil := now.Add(s.protection.LockoutDuration)
+		if err := s.repo.LockUntil(tx, user.ID, lockedUntil); err != nil {
+			return err
+		}
+		locked = true
+
+		notification := &models.Notification{
+			UserID: user.ID,
+			Title:  "Your account has been locked",
+			Content: fmt.Sprintf(
+				"Your account was locked until %s after %d failed login attempts, the last one from %s. If this was not you, contact an administrator.",
+				lockedUntil.Format("Jan 2 15:04"), current.FailedLoginCount, meta.IPAddress),
+		}
+		return s.notificationRepository.Create(tx, notification)
+	})
+	return locked, err
+}
+
+// startLogin continues a login once the user has proven who they are, deciding whether a second factor is needed
+func (s *authService) startLogin(c context.Context, user *models.User, meta LoginMeta) (*LoginResult, error) {
+	if user.DeactivatedAt != nil {
+		return nil, s.rejectLogin(c, user.Email, user, meta, models.LoginFailureAccountDeactivated, appErrors.ErrAccountDeactivated)
+	}
+	// A correct password on the admin flow does not reset the account state of a regular user
+	if meta.Flow == models.LoginFlowAdmin && user.Role != "admin" {
+		return nil, s.rejectLogin(c, user.Email, user, meta, models.LoginFailureNotAdmin, appErrors.ErrForbidden)
+	}
+
+	if user.TwoFactorEnabled {
+		return &LoginResult{User: user, SecondFactor: SecondFactorVerify}, nil
+	}
+	if s.twoFactorService.IsRequired(user) {
+		return &LoginResult{User: user, SecondFactor: SecondFactorSetup}, nil
+	}
+
+	if err := s.completeLogin(c, user, meta); err != nil {
+		return nil, err
+	}
+	return &LoginResult{User: user}, nil
+}
+
+// completeLogin clears the failed attempts of the account, tells the owner about them and audits the successful login
+func (s *authService) completeLogin(c context.Context, user *models.User, meta LoginMeta) error {
+	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if user.FailedLoginCount > 0 || user.LockedUntil != nil {
+			if err := s.repo.ResetLoginFailures(tx, user.ID); err != nil {
+				return err
+			}
+		}
+		if user.FailedLoginCount > 0 {
+			notification := &models.Notification{
+				UserID: user.ID,
+				Title:  "Failed login attempts on your account",
+				Content: fmt.Sprintf(
+					"There were %d failed login attempt(s) on your account before you signed in from %s. If this was not you, change your password.",
+					user.FailedLoginCount, meta.IPAddress),
+			}
+			if err := s.notificationRepository.Create(tx, notification); err != nil {
+				return err
+			}
+		}
+		return s.loginAttemptRepository.Create(tx, newLoginAttempt(user.Email, user, meta, nil))
+	})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	s.metrics.LoginSucceeded(meta.Flow)
+	return nil
+}
+
+// accountThrottle returns the error and audit reason when the account is locked or still inside its progressive delay
+func (s *authService) accountThrottle(user *models.User, now time.Time) (string, error) {
+	if user.LockedUntil != nil && user.LockedUntil.After(now) {
+		return models.LoginFailureAccountLocked,
+			appErrors.NewRetryAfterError(appErrors.ErrAccountLocked, user.LockedUntil.Sub(now))
+	}
+
+	if user.FailedLoginCount > 0 && user.LastFailedLoginAt != nil {
+		retryAt := user.LastFailedLoginAt.Add(s.loginDelay(user.FailedLoginCount))
+		if retryAt.After(now) {
+			return models.LoginFailureThrottled,
+				appErrors.NewRetryAfterError(appErrors.ErrTooManyLoginAttempts, retryAt.Sub(now))
+		}
+	}
+	return "", nil
+}
+
+// rejectFailedLogin counts a wrong password or second factor against the account and audits it
+func (s *authService) rejectFailedLogin(c context.Context, user *models.User, meta LoginMeta, reason string, loginErr error, now time.Time) error {
+	locked, err := s.recordFailedLogin(c, user, meta, now)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if locked {
+		loginErr = appErrors.NewRetryAfterError(appErrors.ErrAccountLocked, s.protection.LockoutDuration)
+	}
+	return s.rejectLogin(c, user.Email, user, meta, reason, loginErr)
+}
+
+// rejectLogin records a failed attempt in the login audit and returns the error for the caller
+func (s *authService) rejectLogin(c context.Context, email string, user *models.User, meta LoginMeta, reason string, loginErr error) error {
+	s.metrics.LoginFailed(meta.Flow, reason)
+	if err := s.loginAttemptRepository.Create(s.db.WithContext(c), newLoginAttempt(email, user, meta, &reason)); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return loginErr
+}
+
+func (s *authService) findLoginUser(c context.Context, userID uint) (*models.User, error) {
+	user, err := s.repo.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return user, nil
+}
+
+// loginDelay doubles BaseDelay for every failed attempt after the first, up to MaxDelay
+func (s *authService) loginDelay(failedCount uint) time.Duration {
+	delay := s.protection.BaseDelay
+	for i := uint(1); i < failedCount && delay < s.protection.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.protection.MaxDelay {
+		return s.protection.MaxDelay
+	}
+	return delay
+}
+
+func newLoginAttempt(email string, user *models.User, meta LoginMeta, failureReason *string) *models.LoginAttempt {
+	attempt := &models.LoginAttempt{
+		Flow:          meta.Flow,
+		Email:         truncate(email, 255),
+		IPAddress:     meta.IPAddress,
+		Success:       failureReason == nil,
+		FailureReason: failureReason,
+	}
+	if user != nil {
+		attempt.UserID = &user.ID
+	}
+	if meta.UserAgent != "" {
+		userAgent := truncate(meta.UserAgent, 255)
+		attempt.UserAgent = &userAgent
+	}
+	return attempt
+}
+
+func truncate(value string, maxLength int) string {
+	if len(value) > maxLength {
+		return value[:maxLength]
+	}
+	return value
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type CareerTrackService interface {
+	GetAllCareerTracksSummary(c context.Context) []dtos.CareerTrackSummary
+	SearchCareerTracks(c context.Context, limit, offset int) (*dtos.CareerTrackSearchResponse, error)
+	GetCareerTrackByID(c context.Context, id uint) (*dtos.CareerTrack, error)
+	CreateCareerTrack(c context.Context, req dtos.CreateOrUpdateCareerTrackRequest) error
+	UpdateCareerTrack(c context.Context, id uint, req dtos.CreateOrUpdateCareerTrackRequest) error
+	DeleteCareerTrack(c context.Context, id uint) error
+}
+
+type careerTrackService struct {
+	db                    *gorm.DB
+	careerTrackRepository repositories.CareerTrackRepository
+}
+
+func NewCareerTrackService(db *gorm.DB, careerTrackRepository repositories.CareerTrackRepository) CareerTrackService {
+	return &careerTrackService{db: db, careerTrackRepository: careerTrackRepository}
+}
+
+func (s *careerTrackService) GetAllCareerTracksSummary(c context.Context) []dtos.CareerTrackSummary {
+	careerTracks, err := s.careerTrackRepository.FindAllCareerTrackSummary(s.db.WithContext(c))
+	if err != nil {
+		return []dtos.CareerTrackSummary{}
+	}
+
+	return helpers.MapCareerTracksToCareerTrackSummaries(careerTracks)
+}
+
+func (s *careerTrackService) SearchCareerTracks(c context.Context, limit, offset int) (*dtos.CareerTrackSearchResponse, error) {
+	careerTracks, totalCount, err := s.careerTrackRepository.SearchCareerTracks(s.db.WithContext(c), limit, offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.CareerTrackSearchResponse{
+		CareerTracks: helpers.MapCareerTracksToCareerTrackDtos(careerTracks),
+		Page: dtos.PaginationResponse{
+			Limit:  limit,
+			Offset: offset,
+			Total:  totalCount,
+		},
+	}, nil
+}
+
+func (s *careerTrackService) GetCareerTrackByID(c context.Context, id uint) (*dtos.CareerTrack, error) {
+	careerTrack, err := s.careerTrackRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrCareerTrackNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return helpers.MapCareerTrackToCareerTrackDto(careerTrack), nil
+}
+
+func (s *careerTrackService) CreateCareerTrack(c context.Context, req dtos.CreateOrUpdateCareerTrackRequest) error {
+	careerTrack := &models.CareerTrack{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+
+	if err := s.careerTrackRepository.Create(s.db.WithContext(c), careerTrack); err != nil {
+		if appErrors.IsDuplicatedEntryError(err) {
+			return appErrors.ErrCareerTrackAlreadyExists
+		}
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *careerTrackService) UpdateCareerTrack(c context.Context, id uint, req dtos.CreateOrUpdateCareerTrackRequest) error {
+	currentCareerTrack, err := s.careerTrackRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.ErrCareerTrackNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+
+	currentCareerTrack.Name = strings.TrimSpace(req.Name)
+	currentCareerTrack.Description = req.Description
+
+	if err := s.careerTrackRepository.Update(s.db.WithContext(c), currentCareerTrack); err != nil {
+		if appErrors.IsDuplicatedEntryError(err) {
+			return appErrors.ErrCareerTrackAlreadyExists
+		}
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *careerTrackService) DeleteCareerTrack(c context.Context, id uint) error {
+	_, err := s.careerTrackRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.ErrCareerTrackNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+
+	existedPositionInTrack, err := s.careerTrackRepository.ExistsPositionsWithCareerTrackID(s.db.WithContext(c), id)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if existedPositionInTrack {
+		return appErrors.ErrCareerTrackInUse
+	}
+
+	if err := s.careerTrackRepository.Delete(s.db.WithContext(c), id); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return nil
+}
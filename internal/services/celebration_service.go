@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+	"trieu_mock_project_go/types"
+
+	"gorm.io/gorm"
+)
+
+type CelebrationService interface {
+	GetUpcomingCelebrations(c context.Context, userID uint, days int) (*dtos.UpcomingCelebrationsResponse, error)
+	SendCelebrationReminders(c context.Context, now time.Time) (int, error)
+}
+
+type celebrationService struct {
+	db                     *gorm.DB
+	teamMemberRepository   repositories.TeamMemberRepository
+	notificationRepository repositories.NotificationRepository
+	reminderDays           int
+}
+
+func NewCelebrationService(
+	db *gorm.DB,
+	teamMemberRepository repositories.TeamMemberRepository,
+	notificationRepository repositories.NotificationRepository,
+	reminderDays int) CelebrationService {
+	return &celebrationService{
+		db:                     db,
+		teamMemberRepository:   teamMemberRepository,
+		notificationRepository: notificationRepository,
+		reminderDays:           reminderDays,
+	}
+}
+
+// GetUpcomingCelebrations lists birthdays and work anniversaries in the user's current team within the next days
+func (s *celebrationService) GetUpcomingCelebrations(c context.Context, userID uint, days int) (*dtos.UpcomingCelebrationsResponse, error) {
+	response := &dtos.UpcomingCelebrationsResponse{Celebrations: []dtos.Celebration{}}
+
+	activeMember, err := s.teamMemberRepository.FindActiveMemberByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if activeMember == nil {
+		return response, nil
+	}
+
+	members, err := s.teamMemberRepository.FindAllActiveMembersByTeamID(s.db.WithContext(c), activeMember.TeamID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	celebrations, err := s.buildCelebrations(c, members, startOfDay(time.Now()), days)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	response.Celebrations = celebrations
+	return response, nil
+}
+
+// SendCelebrationReminders notifies every active team member about birthdays and work anniversaries
+// of their teammates within the reminder window. Reminders already sent are skipped, so it is safe to run repeatedly.
+func (s *celebrationService) SendCelebrationReminders(c context.Context, now time.Time) (int, error) {
+	members, err := s.teamMemberRepository.FindAllActiveMembers(s.db.WithContext(c))
+	if err != nil {
+		return 0, err
+	}
+
+	membersByTeam := make(map[uint][]models.TeamMember)
+	for _, member := range members {
+		membersByTeam[member.TeamID] = append(membersByTeam[member.TeamID], member)
+	}
+
+	today := startOfDay(now)
+	// A reminder for the same celebration is never repeated inside one reminder cycle
+	dedupeSince := today.AddDate(0, 0, -(s.reminderDays + 1))
+	sent := 0
+	for _, teamMembers := range membersByTeam {
+		celebrations, err := s.buildCelebrations(c, teamMembers, today, s.reminderDays)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, celebration := range celebrations {
+			title, content := celebrationNotificationText(celebration)
+			for _, recipient := range teamMembers {
+				if recipient.UserID == celebration.User.ID {
+					continue
+				}
+
+				exists, err := s.notificationRepository.ExistsByUserIDAndTitleSince(s.db.WithContext(c), recipient.UserID, title, dedupeSince)
+				if err != nil {
+					return sent, err
+				}
+				if exists {
+					continue
+				}
+
+				notification := &models.Notification{
+					UserID:  recipient.UserID,
+					Title:   title,
+					Content: content,
+				}
+				if err := s.notificationRepository.Create(s.db.WithContext(c), notification); err != nil {
+					return sent, err
+				}
+				sent++
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *celebrationService) buildCelebrations(c context.Context, members []models.TeamMember, today time.Time, days int) ([]dtos.Celebration, error) {
+	celebrations := make([]dtos.Celebration, 0)
+	if len(members) == 0 {
+		return celebrations, nil
+	}
+
+	userIDs := make([]uint, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	firstJoinedAt, err := s.teamMemberRepository.FindFirstJoinedAtByUserIDs(s.db.WithContext(c), userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	lastDay := today.AddDate(0, 0, days)
+	for _, member := range members {
+		user := member.User
+
+		if user.Birthday != nil && user.ShowBirthday {
+			next := nextAnniversary(*user.Birthday, today)
+			if !next.After(lastDay) {
+				celebrations = append(celebrations, dtos.Celebration{
+					Type:      dtos.CelebrationTypeBirthday,
+					User:      *helpers.MapUserToUserSummary(&user),
+					Date:      types.Date{Time: next},
+					DaysUntil: daysBetween(today, next),
+				})
+			}
+		}
+
+		if joinedAt, ok := firstJoinedAt[member.UserID]; ok {
+			next := nextAnniversary(joinedAt, today)
+			years := next.Year() - joinedAt.Year()
+			if years > 0 && !next.After(lastDay) {
+				celebrations = append(celebrations, dtos.Celebration{
+					Type:      dtos.CelebrationTypeWorkAnniversary,
+					User:      *helpers.MapUserToUserSummary(&user),
+					Date:      types.Date{Time: next},
+					DaysUntil: daysBetween(today, next),
+					Years:     years,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(celebrations, func(i, j int) bool {
+		return celebrations[i].DaysUntil < celebrations[j].DaysUntil
+	})
+	return celebrations, nil
+}
+
+func celebrationNotificationText(celebration dtos.Celebration) (string, string) {
+	date := celebration.Date.Format("Jan 2")
+	if celebration.Type == dtos.CelebrationTypeWorkAnniversary {
+		return fmt.Sprintf("Work anniversary: %s on %s", celebration.User.Name, date),
+			fmt.Sprintf("%s celebrates %d year(s) with us on %s.", celebration.User.Name, celebration.Years, date)
+	}
+	return fmt.Sprintf("Birthday: %s on %s", celebration.User.Name, date),
+		fmt.Sprintf("%s has a birthday on %s. Don't forget to wish them well!", celebration.User.Name, date)
+}
+
+// nextAnniversary returns the first occurrence of the month and day of date on or after today.
+// February 29 falls back to February 28 in non-leap years.
+func nextAnniversary(date time.Time, today time.Time) time.Time {
+	for year := today.Year(); ; year++ {
+		day := date.Day()
+		if date.Month() == time.February && day == 29 && !isLeapYear(year) {
+			day = 28
+		}
+		next := time.Date(year, date.Month(), day, 0, 0, 0, 0, today.Location())
+		if !next.Before(today) {
+			return next
+		}
+	}
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// localDate keeps the calendar day of a parsed date (UTC midnight) in the server location,
+// which is the location dates are written to the database in
+func localDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours()+12) / 24
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	chatBatchSize     = 50
+	maxChatRetryDelay = time.Hour
+)
+
+// ChatNotifier posts team changes to a Slack or Mattermost channel through its incoming webhook.
+// A message is queued in the transaction of the outbox event, the ChatDeliveryJob posts it and
+// retries it when that fails.
+type ChatNotifier interface {
+	PollInterval() time.Duration
+	SubscriberName() string
+	HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error
+	PostDue(c context.Context, now time.Time) (int, error)
+	DeleteFinishedMessages(c context.Context, now time.Time) (int64, error)
+}
+
+type chatNotifier struct {
+	db                    *gorm.DB
+	chatMessageRepository repositories.ChatMessageRepository
+	cfg                   config.ChatConfig
+	client                *http.Client
+}
+
+func NewChatNotifier(db *gorm.DB, chatMessageRepository repositories.ChatMessageRepository, cfg config.ChatConfig) ChatNotifier {
+	return &chatNotifier{
+		db:                    db,
+		chatMessageRepository: chatMessageRepository,
+		cfg:                   cfg,
+		client:                &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (s *chatNotifier) PollInterval() time.Duration {
+	return s.cfg.PollInterval
+}
+
+func (s *chatNotifier) SubscriberName() string {
+	return "chat"
+}
+
+// HandleEvent queues a message about team changes for the channel of the incoming webhook
+func (s *chatNotifier) HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error {
+	if s.cfg.WebhookURL == "" {
+		return nil
+	}
+	text, err := s.eventMessage(event)
+	if err != nil || text == "" {
+		return err
+	}
+	now := time.Now()
+	return s.chatMessageRepository.Create(tx, &models.ChatMessage{
+		EventID:       event.EventID,
+		Text:          text,
+		Status:        models.ChatMessageStatusPending,
+		NextAttemptAt: &now,
+	})
+}
+
+// PostDue posts the messages that are due and returns how many were attempted
+func (s *chatNotifier) PostDue(c context.Context, now time.Time) (int, error) {
+	messages, err := s.chatMessageRepository.FindDue(s.db.WithContext(c), now, chatBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return attemptClaimed(c, messages, func(message *models.ChatMessage) (bool, error) {
+		// Keep other instances away from the message for longer than posting it can take
+		return s.chatMessageRepository.Claim(s.db.WithContext(c), message, now.Add(2*s.cfg.Timeout))
+	}, func(message *models.ChatMessage) error {
+		return s.send(c, message)
+	})
+}
+
+// DeleteFinishedMessages removes the posted and failed messages queued longer ago than the retention
+func (s *chatNotifier) DeleteFinishedMessages(c context.Context, now time.Time) (int64, error) {
+	return s.chatMessageRepository.DeleteFinishedBefore(s.db.WithContext(c), now.Add(-s.cfg.Retention))
+}
+
+// send makes one attempt and records its outcome, only failing to record it is returned as an error
+func (s *chatNotifier) send(c context.Context, message *models.ChatMessage) error {
+	now := time.Now()
+	message.Attempts++
+	message.LastAttemptAt = &now
+	message.Error = nil
+
+	postErr := s.post(c, message.Text)
+	switch {
+	case postErr == nil:
+		message.Status = models.ChatMessageStatusSent
+		message.SentAt = &now
+		message.NextAttemptAt = nil
+	case message.Attempts >= s.cfg.MaxAttempts:
+		errMessage := postErr.Error()
+		message.Error = &errMessage
+		message.Status = models.ChatMessageStatusFailed
+		message.NextAttemptAt = nil
+	default:
+		errMessage := postErr.Error()
+		message.Error = &errMessage
+		nextAttemptAt := now.Add(retryDelay(s.cfg.RetryBaseDelay, maxChatRetryDelay, message.Attempts))
+		message.NextAttemptAt = &nextAttemptAt
+	}
+	return s.chatMessageRepository.Update(s.db.WithContext(c), message)
+}
+
+func (s *chatNotifier) eventMessage(event *models.OutboxEvent) (string, error) {
+	switch event.EventType {
+	case models.DomainEventTeamCreated, models.DomainEventTeamDeleted:
+		var data dtos.TeamEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return "", err
+		}
+		if event.EventType == models.DomainEventTeamDeleted {
+			return fmt.Sprintf("Team %s was deleted", data.Team.Name), nil
+		}
+		return fmt.Sprintf("New team %s was created %s", data.Team.Name, s.link(fmt.Sprintf("/teams/%d", data.Team.ID))), nil
+	case models.DomainEventTeamMemberAdded, models.DomainEventTeamMemberRemoved:
+		var data dtos.TeamMemberEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return "", err
+		}
+		if event.EventType == models.DomainEventTeamMemberRemoved {
+			return fmt.Sprintf("%s left the team %s", data.User.Name, data.Team.Name), nil
+		}
+		return fmt.Sprintf("%s joined the team %s %s", data.User.Name, data.Team.Name, s.link(fmt.Sprintf("/teams/%d", data.Team.ID))), nil
+	case models.DomainEventTeamLeaderChanged:
+		var data dtos.TeamLeaderChangedEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s is now the leader of the team %s", data.Leader.Name, data.Team.Name), nil
+	default:
+		return "", nil
+	}
+}
+
+func (s *chatNotifier) post(c context.Context, text string) error {
+	body, err := json.Marshal(dtos.ChatMessage{Text: text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(c, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("chat webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *chatNotifier) link(path string) string {
+	return s.cfg.BaseURL + path
+}
@@ -0,0 +1,80 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+func TestChatNotifier(t *testing.T) {
+	t.Run("team changes are queued in the event transaction and posted later", func(t *testing.T) {
+		app := apptest.New(t)
+		var posts, failures atomic.Int32
+		failures.Store(1)
+		webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			posts.Add(1)
+			if failures.Add(-1) >= 0 {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		t.Cleanup(webhook.Close)
+		notifier := services.NewChatNotifier(app.DB, repositories.NewChatMessageRepository(), config.ChatConfig{
+			WebhookURL:     webhook.URL,
+			Timeout:        time.Second,
+			MaxAttempts:    3,
+			RetryBaseDelay: time.Minute,
+			Retention:      24 * time.Hour,
+		})
+
+		payload, _ := json.Marshal(dtos.TeamEventData{Team: dtos.EventTeam{ID: 1, Name: "Atlas"}})
+		event := &models.OutboxEvent{EventID: "0b7e3c52-5d1f-4c1e-9a55-3f0c2f4f1a01", EventType: models.DomainEventTeamCreated, Payload: string(payload)}
+		if err := app.DB.Transaction(func(tx *gorm.DB) error { return notifier.HandleEvent(tx, event) }); err != nil {
+			t.Fatalf("handling the event: %v", err)
+		}
+		if got := posts.Load(); got != 0 {
+			t.Fatalf("%d posts while handling the event, want 0", got)
+		}
+
+		now := time.Now()
+		if _, err := notifier.PostDue(context.Background(), now); err != nil {
+			t.Fatalf("posting messages: %v", err)
+		}
+		var message models.ChatMessage
+		if err := app.DB.First(&message).Error; err != nil {
+			t.Fatalf("finding the message: %v", err)
+		}
+		if message.Status != models.ChatMessageStatusPending || message.Attempts != 1 || message.NextAttemptAt == nil {
+			t.Fatalf("message is %s after %d attempts, want pending with a retry", message.Status, message.Attempts)
+		}
+
+		if _, err := notifier.PostDue(context.Background(), *message.NextAttemptAt); err != nil {
+			t.Fatalf("posting messages: %v", err)
+		}
+		if err := app.DB.First(&message, message.ID).Error; err != nil {
+			t.Fatalf("reloading the message: %v", err)
+		}
+		if message.Status != models.ChatMessageStatusSent || posts.Load() != 2 {
+			t.Errorf("message is %s after %d posts, want sent after 2", message.Status, posts.Load())
+		}
+
+		deleted, err := notifier.DeleteFinishedMessages(context.Background(), now.Add(48*time.Hour))
+		if err != nil {
+			t.Fatalf("deleting finished messages: %v", err)
+		}
+		if deleted != 1 {
+			t.Errorf("deleted %d messages, want 1", deleted)
+		}
+	})
+}
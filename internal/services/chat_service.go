@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+)
+
+const (
+	chatMaxTeamMembers  = 50
+	chatMaxUserMatches  = 10
+	chatMaxSkillHolders = 20
+)
+
+const chatHelpText = `Ask about teams, people and skills:
+  team <name>     who is in a team, also "who is in <team>"
+  profile <name>  a person's position, team and skills, also "who is <name>"
+  skill <name>    who knows a skill, best first, also "who knows <skill>"
+  help            this message`
+
+// chatCommandPrefixes maps the ways of asking a question to the kind of question, longer phrasings
+// come before their prefixes so "who is in" is not read as "who is"
+var chatCommandPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{"who is in team ", "team"},
+	{"who is in ", "team"},
+	{"members of ", "team"},
+	{"team ", "team"},
+	{"who knows ", "skill"},
+	{"skill ", "skill"},
+	{"who is ", "profile"},
+	{"profile ", "profile"},
+}
+
+// ChatService answers slash commands from a Slack or Mattermost workspace. Answers are plain text,
+// which both render the same way.
+type ChatService interface {
+	HandleCommand(c context.Context, text string) string
+}
+
+type chatService struct {
+	teamsService TeamsService
+	userService  UserService
+	skillService SkillService
+	cfg          config.ChatConfig
+}
+
+func NewChatService(
+	teamsService TeamsService,
+	userService UserService,
+	skillService SkillService,
+	cfg config.ChatConfig) ChatService {
+	return &chatService{
+		teamsService: teamsService,
+		userService:  userService,
+		skillService: skillService,
+		cfg:          cfg,
+	}
+}
+
+// HandleCommand answers the text typed after the slash command
+func (s *chatService) HandleCommand(c context.Context, text string) string {
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "?"))
+	lower := strings.ToLower(text)
+	if lower == "" || lower == "help" {
+		return chatHelpText
+	}
+
+	for _, command := range chatCommandPrefixes {
+		if !strings.HasPrefix(lower, command.prefix) {
+			continue
+		}
+		query := strings.TrimSpace(text[len(command.prefix):])
+		if query == "" {
+			break
+		}
+		var (
+			answer string
+			err    error
+		)
+		switch command.kind {
+		case "team":
+			answer, err = s.answerTeam(c, query)
+		case "skill":
+			answer, err = s.answerSkill(c, query)
+		default:
+			answer, err = s.answerProfile(c, query)
+		}
+		if err != nil {
+			return "Something went wrong, please try again later."
+		}
+		return answer
+	}
+	return fmt.Sprintf("Sorry, I don't understand %q.\n\n%s", text, chatHelpText)
+}
+
+func (s *chatService) answerTeam(c context.Context, name string) (string, error) {
+	var matches []dtos.TeamSummary
+	for _, team := range s.teamsService.GetAllTeamsSummary(c) {
+		if strings.EqualFold(team.Name, name) {
+			matches = []dtos.TeamSummary{team}
+			break
+		}
+		if strings.Contains(strings.ToLower(team.Name), strings.ToLower(name)) {
+			matches = append(matches, team)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Sprintf("No team matches %q.", name), nil
+	case 1:
+	default:
+		names := make([]string, 0, len(matches))
+		for _, team := range matches {
+			names = append(names, team.Name)
+		}
+		return fmt.Sprintf("Several teams match %q: %s. Which one?", name, strings.Join(names, ", ")), nil
+	}
+
+	team, err := s.teamsService.GetTeamDetails(c, matches[0].ID)
+	if err != nil {
+		return "", err
+	}
+	members, err := s.teamsService.GetTeamMembers(c, team.ID, chatMaxTeamMembers, 0)
+	if err != nil {
+		return "", err
+	}
+
+	var answer strings.Builder
+	fmt.Fprintf(&answer, "Team %s (%d members)\n", team.Name, members.Page.Total)
+	fmt.Fprintf(&answer, "Leader: %s\n", team.Leader.Name)
+	for _, member := range members.Members {
+		fmt.Fprintf(&answer, "- %s <%s>\n", member.Name, member.Email)
+	}
+	if more := members.Page.Total - int64(len(members.Members)); more > 0 {
+		fmt.Fprintf(&answer, "...and %d more\n", more)
+	}
+	answer.WriteString(s.link(fmt.Sprintf("/teams/%d", team.ID)))
+	return answer.String(), nil
+}
+
+func (s *chatService) answerProfile(c context.Context, name string) (string, error) {
+	users, err := s.userService.SearchUsers(c, &name, nil, chatMaxUserMatches, 0)
+	if err != nil {
+		return "", err
+	}
+	var userID uint
+	for _, user := range users.Users {
+		if strings.EqualFold(user.Name, name) {
+			userID = user.ID
+			break
+		}
+	}
+	switch {
+	case userID != 0:
+	case len(users.Users) == 0:
+		return fmt.Sprintf("Nobody is called %q.", name), nil
+	case len(users.Users) == 1:
+		userID = users.Users[0].ID
+	default:
+		names := make([]string, 0, len(users.Users))
+		for _, user := range users.Users {
+			names = append(names, user.Name)
+		}
+		return fmt.Sprintf("Several people match %q: %s. Who do you mean?", name, strings.Join(names, ", ")), nil
+	}
+
+	profile, err := s.userService.GetPublicUserProfile(c, userID)
+	if err != nil {
+		return "", err
+	}
+
+	var answer strings.Builder
+	fmt.Fprintf(&answer, "%s <%s>\n", profile.Name, profile.Email)
+	if profile.Position.Name != "" {
+		fmt.Fprintf(&answer, "Position: %s\n", profile.Position.Name)
+	}
+	if profile.CurrentTeam != nil {
+		fmt.Fprintf(&answer, "Team: %s\n", profile.CurrentTeam.Name)
+	} else {
+		answer.WriteString("Team: none\n")
+	}
+	if len(profile.Skills) > 0 {
+		skills := make([]string, 0, len(profile.Skills))
+		for _, skill := range profile.Skills {
+			skills = append(skills, fmt.Sprintf("%s (level %d, %s)", skill.Name, skill.Level, chatYears(skill.UsedYearNumber)))
+		}
+		fmt.Fprintf(&answer, "Skills: %s\n", strings.Join(skills, ", "))
+	}
+	answer.WriteString(s.link(fmt.Sprintf("/profile/%d", profile.ID)))
+	return answer.String(), nil
+}
+
+func (s *chatService) answerSkill(c context.Context, name string) (string, error) {
+	holders, err := s.skillService.FindSkillHolders(c, name, chatMaxSkillHolders)
+	if err != nil {
+		return "", err
+	}
+	if len(holders) == 0 {
+		return fmt.Sprintf("Nobody has a skill matching %q.", name), nil
+	}
+
+	var answer strings.Builder
+	fmt.Fprintf(&answer, "People who know %s:\n", name)
+	for _, holder := range holders {
+		team := "no team"
+		if holder.CurrentTeam != nil {
+			team = holder.CurrentTeam.Name
+		}
+		fmt.Fprintf(&answer, "- %s, %s level %d, %s (%s)\n",
+			holder.User.Name, holder.Skill, holder.Level, chatYears(holder.UsedYearNumber), team)
+	}
+	return strings.TrimSuffix(answer.String(), "\n"), nil
+}
+
+func (s *chatService) link(path string) string {
+	return s.cfg.BaseURL + path
+}
+
+func chatYears(years int) string {
+	if years == 1 {
+		return "1 year"
+	}
+	return fmt.Sprintf("%d years", years)
+}
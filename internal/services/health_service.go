@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// healthCheckTimeout bounds each check, a probe must answer before the load balancer gives up on it
+const healthCheckTimeout = 2 * time.Second
+
+// HealthService tells whether the app is ready to serve requests: the database answers and its schema
+// is at the version of the last migration. A stopping app reports itself unavailable, so load balancers
+// stop sending it requests while the in-flight ones drain.
+type HealthService interface {
+	Readiness(c context.Context) *dtos.HealthResponse
+	MarkShuttingDown()
+}
+
+type healthService struct {
+	db                        *gorm.DB
+	schemaMigrationRepository repositories.SchemaMigrationRepository
+	// Version of the last migration shipped with the app, zero when the migrations could not be read
+	latestMigration uint
+	shuttingDown    atomic.Bool
+}
+
+func NewHealthService(db *gorm.DB, schemaMigrationRepository repositories.SchemaMigrationRepository, latestMigration uint) HealthService {
+	return &healthService{
+		db:                        db,
+		schemaMigrationRepository: schemaMigrationRepository,
+		latestMigration:           latestMigration,
+	}
+}
+
+// MarkShuttingDown makes the app unavailable until it exits
+func (s *healthService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s *healthService) Readiness(c context.Context) *dtos.HealthResponse {
+	if s.shuttingDown.Load() {
+		return &dtos.HealthResponse{
+			Status: dtos.HealthStatusUnavailable,
+			Checks: []dtos.HealthCheck{{Name: "server", Status: dtos.HealthStatusFailing, Message: "shutting down"}},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c, healthCheckTimeout)
+	defer cancel()
+
+	response := &dtos.HealthResponse{Status: dtos.HealthStatusOK}
+	database := s.checkDatabase(ctx)
+	response.Checks = append(response.Checks, database)
+	// The version can't be read from a database that does not answer
+	if database.Status == dtos.HealthStatusOK {
+		response.Checks = append(response.Checks, s.checkMigrations(ctx))
+	}
+	for _, check := range response.Checks {
+		if check.Status != dtos.HealthStatusOK {
+			response.Status = dtos.HealthStatusUnavailable
+		}
+	}
+	return response
+}
+
+// checkDatabase pings the database. Probes are not authenticated, the error is logged rather than answered.
+func (s *healthService) checkDatabase(ctx context.Context) dtos.HealthCheck {
+	check := dtos.HealthCheck{Name: "database", Status: dtos.HealthStatusOK}
+	sqlDB, err := s.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "Readiness check of the database failed", "error", err)
+		check.Status, check.Message = dtos.HealthStatusFailing, "database is unreachable"
+	}
+	return check
+}
+
+// checkMigrations compares the version of the schema to the last migration. A schema ahead of it is
+// fine: during a rolling deploy the new release migrates while the old one still serves requests.
+func (s *healthService) checkMigrations(ctx context.Context) dtos.HealthCheck {
+	check := dtos.HealthCheck{Name: "migrations", Status: dtos.HealthStatusFailing}
+	if s.latestMigration == 0 {
+		check.Message = "migrations of the app could not be read"
+		return check
+	}
+
+	version, dirty, err := s.schemaMigrationRepository.CurrentVersion(s.db.WithContext(ctx))
+	switch {
+	case err != nil:
+		slog.WarnContext(ctx, "Readiness check of the migrations failed", "error", err)
+		check.Message = "schema version could not be read"
+	case dirty:
+		check.Message = fmt.Sprintf("migration %d failed part way", version)
+	case version < s.latestMigration:
+		check.Message = fmt.Sprintf("schema is at version %d, migrations up to %d are pending", version, s.latestMigration)
+	default:
+		check.Status = dtos.HealthStatusOK
+	}
+	return check
+}
@@ -0,0 +1,652 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/ldap"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/sessionstore"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+// errLDAPSyncDryRun rolls back the transaction of a dry run
+var errLDAPSyncDryRun = errors.New("ldap sync dry run")
+
+// LDAPSyncService mirrors the users of the LDAP directory. Entries are matched to users by their
+// uid, then by email on the first sync. Departments are matched to teams and titles to positions by
+// name, and users linked to an entry that disappeared from the directory are deactivated.
+// Users that were never in the directory are left alone, and local admins are never linked by email.
+type LDAPSyncService interface {
+	ScheduleEnabled() bool
+	SyncInterval() time.Duration
+	Sync(c context.Context, trigger string, dryRun bool) (*dtos.LDAPSyncRun, error)
+	SearchRuns(c context.Context, query dtos.LDAPSyncRunSearchRequest) (*dtos.LDAPSyncRunSearchResponse, error)
+	GetRun(c context.Context, id uint) (*dtos.LDAPSyncRun, error)
+}
+
+type ldapSyncService struct {
+	db                            *gorm.DB
+	userRepository                repositories.UserRepository
+	teamsRepository               repositories.TeamsRepository
+	teamMemberRepository          repositories.TeamMemberRepository
+	positionRepository            repositories.PositionRepository
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository
+	ldapSyncRunRepository         repositories.LDAPSyncRunRepository
+	apiTokenRepository            repositories.APITokenRepository
+	sessionBackend                sessionstore.Backend
+	outboxService                 OutboxService
+	cfg                           config.LDAPConfig
+
+	// Only one sync runs at a time in this process
+	running sync.Mutex
+}
+
+// DirectoryUser is a user entry read from the directory
+type DirectoryUser struct {
+	DN         string
+	UID        string
+	Email      string
+	Name       string
+	Department string
+	Title      string
+}
+
+// ldapSyncState is what one sync knows about the users, teams and positions, keyed for matching
+type ldapSyncState struct {
+	usersByUID        map[string]*models.User
+	usersByEmail      map[string]*models.User
+	teamsByName       map[string]*models.Team
+	teamsByID         map[uint]*models.Team
+	positionsByName   map[string]*models.Position
+	seenUserIDs       map[uint]bool
+	createdUserIDs    map[uint]bool
+	deactivatedUserID []uint
+	changes           []dtos.LDAPSyncChange
+	now               time.Time
+}
+
+func NewLDAPSyncService(
+	db *gorm.DB,
+	userRepository repositories.UserRepository,
+	teamsRepository repositories.TeamsRepository,
+	teamMemberRepository repositories.TeamMemberRepository,
+	positionRepository repositories.PositionRepository,
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository,
+	ldapSyncRunRepository repositories.LDAPSyncRunRepository,
+	apiTokenRepository repositories.APITokenRepository,
+	sessionBackend sessionstore.Backend,
+	outboxService OutboxService,
+	cfg config.LDAPConfig) LDAPSyncService {
+	return &ldapSyncService{
+		db:                            db,
+		userRepository:                userRepository,
+		teamsRepository:               teamsRepository,
+		teamMemberRepository:          teamMemberRepository,
+		positionRepository:            positionRepository,
+		userPositionHistoryRepository: userPositionHistoryRepository,
+		ldapSyncRunRepository:         ldapSyncRunRepository,
+		apiTokenRepository:            apiTokenRepository,
+		sessionBackend:                sessionBackend,
+		outboxService:                 outboxService,
+		cfg:                           cfg,
+	}
+}
+
+// ScheduleEnabled tells whether the sync job should run
+func (s *ldapSyncService) ScheduleEnabled() bool {
+	return s.cfg.SyncEnabled
+}
+
+func (s *ldapSyncService) SyncInterval() time.Duration {
+	return s.cfg.SyncInterval
+}
+
+// Sync reads the directory and applies it to the users in one transaction, recording the report as a sync run.
+// A dry run reports what would change without saving it. When the sync fails the failed run is returned with
+// ErrLDAPSyncFailed, its Error says why.
+func (s *ldapSyncService) Sync(c context.Context, trigger string, dryRun bool) (*dtos.LDAPSyncRun, error) {
+	if !s.running.TryLock() {
+		return nil, appErrors.ErrLDAPSyncInProgress
+	}
+	defer s.running.Unlock()
+
+	run := &models.LDAPSyncRun{
+		Trigger:   trigger,
+		DryRun:    dryRun,
+		Status:    models.LDAPSyncStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.ldapSyncRunRepository.Create(s.db.WithContext(c), run); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	state, syncErr := s.sync(c, run, dryRun)
+	if syncErr == nil && !dryRun {
+		// Deactivated users are signed out of the admin panel right away
+		for _, userID := range state.deactivatedUserID {
+			if _, err := s.sessionBackend.DeleteByUserID(c, userID, ""); err != nil {
+				state.changes = append(state.changes, dtos.LDAPSyncChange{
+					UserID:  &userID,
+					Action:  dtos.LDAPSyncActionWarning,
+					Message: fmt.Sprintf("sessions could not be revoked: %v", err),
+				})
+			}
+		}
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = models.LDAPSyncStatusSucceeded
+	if syncErr != nil {
+		run.Status = models.LDAPSyncStatusFailed
+		message := syncErr.Error()
+		run.Error = &message
+	}
+	if state != nil {
+		s.countChanges(run, state.changes)
+		details, err := json.Marshal(state.changes)
+		if err == nil {
+			detailsJSON := string(details)
+			run.Details = &detailsJSON
+		}
+	}
+	if err := s.ldapSyncRunRepository.Update(s.db.WithContext(c), run); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	if syncErr != nil {
+		return helpers.MapLDAPSyncRunToDto(run), appErrors.ErrLDAPSyncFailed
+	}
+	return helpers.MapLDAPSyncRunToDto(run), nil
+}
+
+func (s *ldapSyncService) SearchRuns(c context.Context, query dtos.LDAPSyncRunSearchRequest) (*dtos.LDAPSyncRunSearchResponse, error) {
+	runs, totalCount, err := s.ldapSyncRunRepository.SearchRuns(s.db.WithContext(c), query.Limit, query.Offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.LDAPSyncRunSearchResponse{
+		Runs: helpers.MapLDAPSyncRunsToDtos(runs),
+		Page: dtos.PaginationResponse{
+			Limit:  query.Limit,
+			Offset: query.Offset,
+			Total:  totalCount,
+		},
+	}, nil
+}
+
+func (s *ldapSyncService) GetRun(c context.Context, id uint) (*dtos.LDAPSyncRun, error) {
+	run, err := s.ldapSyncRunRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrLDAPSyncRunNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapLDAPSyncRunToDto(run), nil
+}
+
+func (s *ldapSyncService) sync(c context.Context, run *models.LDAPSyncRun, dryRun bool) (*ldapSyncState, error) {
+	directoryUsers, err := s.fetchDirectoryUsers(c)
+	if err != nil {
+		return nil, err
+	}
+	run.DirectoryEntries = len(directoryUsers)
+	// An empty result is far more likely a wrong base DN or filter than a company without employees
+	if len(directoryUsers) == 0 {
+		return nil, fmt.Errorf("the directory returned no users, nobody was deactivated")
+	}
+
+	var state *ldapSyncState
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		state, err = s.loadState(tx)
+		if err != nil {
+			return err
+		}
+		for _, directoryUser := range directoryUsers {
+			if err := s.applyDirectoryUser(tx, state, directoryUser); err != nil {
+				return err
+			}
+		}
+		if err := s.deactivateMissingUsers(tx, state); err != nil {
+			return err
+		}
+		if dryRun {
+			return errLDAPSyncDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLDAPSyncDryRun) {
+		return nil, fmt.Errorf("applying the directory failed: %w", err)
+	}
+
+	if dryRun {
+		// Users created by a dry run were rolled back
+		for i := range state.changes {
+			if userID := state.changes[i].UserID; userID != nil && state.createdUserIDs[*userID] {
+				state.changes[i].UserID = nil
+			}
+		}
+	}
+	return state, nil
+}
+
+func (s *ldapSyncService) fetchDirectoryUsers(c context.Context) ([]DirectoryUser, error) {
+	conn, err := ldap.Dial(c, ldap.Config{
+		URL:                s.cfg.URL,
+		BindDN:             s.cfg.BindDN,
+		BindPassword:       s.cfg.BindPassword,
+		StartTLS:           s.cfg.StartTLS,
+		AllowPlaintextBind: s.cfg.AllowPlaintextBind,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	entries, err := conn.Search(c, s.cfg.BaseDN, s.cfg.UserFilter, []string{
+		s.cfg.UIDAttribute,
+		s.cfg.EmailAttribute,
+		s.cfg.NameAttribute,
+		s.cfg.DepartmentAttribute,
+		s.cfg.TitleAttribute,
+	}, s.cfg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	directoryUsers := make([]DirectoryUser, 0, len(entries))
+	for _, entry := range entries {
+		directoryUsers = append(directoryUsers, DirectoryUser{
+			DN:         entry.DN,
+			UID:        strings.TrimSpace(entry.Value(s.cfg.UIDAttribute)),
+			Email:      strings.TrimSpace(entry.Value(s.cfg.EmailAttribute)),
+			Name:       strings.TrimSpace(entry.Value(s.cfg.NameAttribute)),
+			Department: strings.TrimSpace(entry.Value(s.cfg.DepartmentAttribute)),
+			Title:      strings.TrimSpace(entry.Value(s.cfg.TitleAttribute)),
+		})
+	}
+	return directoryUsers, nil
+}
+
+func (s *ldapSyncService) loadState(tx *gorm.DB) (*ldapSyncState, error) {
+	users, err := s.userRepository.FindAllForDirectorySync(tx)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.teamsRepository.FindAllTeamsSummary(tx)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.positionRepository.FindAllPositionsSummary(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &ldapSyncState{
+		usersByUID:      make(map[string]*models.User),
+		usersByEmail:    make(map[string]*models.User, len(users)),
+		teamsByName:     make(map[string]*models.Team, len(teams)),
+		teamsByID:       make(map[uint]*models.Team, len(teams)),
+		positionsByName: make(map[string]*models.Position, len(positions)),
+		seenUserIDs:     make(map[uint]bool),
+		createdUserIDs:  make(map[uint]bool),
+		now:             time.Now(),
+	}
+	for i := range users {
+		if users[i].LDAPUID != nil {
+			state.usersByUID[*users[i].LDAPUID] = &users[i]
+		}
+		state.usersByEmail[strings.ToLower(users[i].Email)] = &users[i]
+	}
+	for i := range teams {
+		state.teamsByName[strings.ToLower(teams[i].Name)] = &teams[i]
+		state.teamsByID[teams[i].ID] = &teams[i]
+	}
+	for i := range positions {
+		state.positionsByName[strings.ToLower(positions[i].Name)] = &positions[i]
+	}
+	return state, nil
+}
+
+func (s *ldapSyncService) applyDirectoryUser(tx *gorm.DB, state *ldapSyncState, directoryUser DirectoryUser) error {
+	change := dtos.LDAPSyncChange{UID: directoryUser.UID, Email: directoryUser.Email}
+	if directoryUser.UID == "" || directoryUser.Email == "" {
+		change.UID = directoryUser.DN
+		change.Action = dtos.LDAPSyncActionSkipped
+		change.Message = fmt.Sprintf("entry has no %s or %s", s.cfg.UIDAttribute, s.cfg.EmailAttribute)
+		state.changes = append(state.changes, change)
+		return nil
+	}
+
+	user := state.usersByUID[directoryUser.UID]
+	if user != nil && state.seenUserIDs[user.ID] {
+		change.Action = dtos.LDAPSyncActionSkipped
+		change.Message = fmt.Sprintf("%s is used by more than one entry", s.cfg.UIDAttribute)
+		state.changes = append(state.changes, change)
+		return nil
+	}
+	if user == nil {
+		user = state.usersByEmail[strings.ToLower(directoryUser.Email)]
+		// An account linked to another entry is not taken over by a matching email
+		if user != nil && (user.LDAPUID != nil || state.seenUserIDs[user.ID]) {
+			change.UserID = &user.ID
+			change.Action = dtos.LDAPSyncActionSkipped
+			change.Message = "email belongs to a user linked to another directory entry"
+			state.changes = append(state.changes, change)
+			return nil
+		}
+		// A directory entry does not take over a local admin, who would be deactivated once the entry is removed
+		if user != nil && user.Role == "admin" {
+			change.UserID = &user.ID
+			change.Action = dtos.LDAPSyncActionSkipped
+			change.Message = "email belongs to a local admin, not linked"
+			state.changes = append(state.changes, change)
+			return nil
+		}
+	}
+
+	if user == nil {
+		return s.createUser(tx, state, directoryUser, change)
+	}
+	state.seenUserIDs[user.ID] = true
+	return s.updateUser(tx, state, user, directoryUser, change)
+}
+
+func (s *ldapSyncService) createUser(tx *gorm.DB, state *ldapSyncState, directoryUser DirectoryUser, change dtos.LDAPSyncChange) error {
+	// Directory users sign in with single sign-on or after an admin sets their password
+	password, err := unusablePassword()
+	if err != nil {
+		return err
+	}
+
+	name := directoryUser.Name
+	if name == "" {
+		name = strings.SplitN(directoryUser.Email, "@", 2)[0]
+	}
+	var notes []string
+	positionID := s.cfg.DefaultPositionID
+	if position, note := s.matchPosition(state, directoryUser.Title); position != nil {
+		positionID = position.ID
+	} else if note != "" {
+		notes = append(notes, note)
+	}
+	team, note := s.matchTeam(state, directoryUser.Department)
+	if note != "" {
+		notes = append(notes, note)
+	}
+
+	uid := directoryUser.UID
+	user := &models.User{
+		Name:       truncate(name, 255),
+		Email:      directoryUser.Email,
+		Password:   password,
+		PositionID: positionID,
+		Role:       "user",
+		LDAPUID:    &uid,
+	}
+	if team != nil {
+		user.CurrentTeamID = &team.ID
+	}
+	if err := s.userRepository.CreateUser(tx, user); err != nil {
+		return err
+	}
+	if err := recordPositionChange(tx, s.positionRepository, s.userPositionHistoryRepository, user, nil, state.now, 0); err != nil {
+		return err
+	}
+	if team != nil {
+		if err := s.teamMemberRepository.Create(tx, &models.TeamMember{
+			UserID:   user.ID,
+			TeamID:   team.ID,
+			JoinedAt: state.now,
+		}); err != nil {
+			return err
+		}
+	}
+	// Events of a dry run are rolled back with the rest of it
+	if err := s.outboxService.Record(tx, models.DomainEventUserCreated, dtos.UserEventData{
+		User: helpers.MapUserToEventUser(user),
+	}); err != nil {
+		return err
+	}
+	if team != nil {
+		if err := s.outboxService.Record(tx, models.DomainEventTeamMemberAdded, dtos.TeamMemberEventData{
+			Team: helpers.MapTeamToEventTeam(team),
+			User: helpers.MapUserToEventUser(user),
+		}); err != nil {
+			return err
+		}
+	}
+
+	state.usersByUID[uid] = user
+	state.usersByEmail[strings.ToLower(user.Email)] = user
+	state.seenUserIDs[user.ID] = true
+	state.createdUserIDs[user.ID] = true
+
+	change.UserID = &user.ID
+	change.Action = dtos.LDAPSyncActionCreated
+	change.Message = strings.Join(notes, "; ")
+	state.changes = append(state.changes, change)
+	return nil
+}
+
+func (s *ldapSyncService) updateUser(tx *gorm.DB, state *ldapSyncState, user *models.User, directoryUser DirectoryUser, change dtos.LDAPSyncChange) error {
+	change.UserID = &user.ID
+	var updates, notes []string
+	changed := false
+
+	if user.LDAPUID == nil {
+		if err := s.userRepository.LinkLDAPUID(tx, user.ID, directoryUser.UID); err != nil {
+			return err
+		}
+		uid := directoryUser.UID
+		user.LDAPUID = &uid
+		state.usersByUID[uid] = user
+		updates = append(updates, "linked to the directory entry")
+	}
+
+	reactivated := false
+	if user.DeactivatedAt != nil {
+		if err := s.userRepository.UpdateDeactivatedAt(tx, user.ID, nil); err != nil {
+			return err
+		}
+		user.DeactivatedAt = nil
+		reactivated = true
+	}
+
+	if directoryUser.Name != "" && directoryUser.Name != user.Name {
+		updates = append(updates, fmt.Sprintf("name %q -> %q", user.Name, directoryUser.Name))
+		user.Name = truncate(directoryUser.Name, 255)
+		changed = true
+	}
+
+	if !strings.EqualFold(directoryUser.Email, user.Email) {
+		if other := state.usersByEmail[strings.ToLower(directoryUser.Email)]; other != nil {
+			notes = append(notes, fmt.Sprintf("email %s is used by another user, not changed", directoryUser.Email))
+		} else {
+			updates = append(updates, fmt.Sprintf("email %s -> %s", user.Email, directoryUser.Email))
+			delete(state.usersByEmail, strings.ToLower(user.Email))
+			user.Email = directoryUser.Email
+			state.usersByEmail[strings.ToLower(user.Email)] = user
+			changed = true
+		}
+	}
+
+	position, note := s.matchPosition(state, directoryUser.Title)
+	if note != "" {
+		notes = append(notes, note)
+	}
+	if position != nil && position.ID != user.PositionID {
+		oldPositionID := user.PositionID
+		user.PositionID = position.ID
+		if err := recordPositionChange(tx, s.positionRepository, s.userPositionHistoryRepository, user, &oldPositionID, state.now, 0); err != nil {
+			return err
+		}
+		updates = append(updates, fmt.Sprintf("position -> %s", position.Name))
+		changed = true
+	}
+
+	team, note := s.matchTeam(state, directoryUser.Department)
+	if note != "" {
+		notes = append(notes, note)
+	}
+	if team != nil && (user.CurrentTeamID == nil || *user.CurrentTeamID != team.ID) {
+		moved, err := s.moveToTeam(tx, state, user, team)
+		if err != nil {
+			return err
+		}
+		if moved {
+			updates = append(updates, fmt.Sprintf("team -> %s", team.Name))
+			changed = true
+		} else {
+			notes = append(notes, fmt.Sprintf("leads a team, not moved to %s", team.Name))
+		}
+	}
+
+	if changed {
+		if err := s.userRepository.UpdateUser(tx, user); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case reactivated:
+		change.Action = dtos.LDAPSyncActionReactivated
+	case len(updates) > 0:
+		change.Action = dtos.LDAPSyncActionUpdated
+	case len(notes) > 0:
+		change.Action = dtos.LDAPSyncActionWarning
+	default:
+		return nil
+	}
+	change.Message = strings.Join(append(updates, notes...), "; ")
+	state.changes = append(state.changes, change)
+	return nil
+}
+
+// moveToTeam closes the current membership and opens one in the team, like TeamsService.AddMemberToTeam.
+// Team leaders are not moved.
+func (s *ldapSyncService) moveToTeam(tx *gorm.DB, state *ldapSyncState, user *models.User, team *models.Team) (bool, error) {
+	isLeader, err := s.teamsRepository.ExistByLeaderID(tx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	if isLeader {
+		return false, nil
+	}
+
+	activeTeamMember, err := s.teamMemberRepository.FindActiveMemberByUserID(tx, user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	if activeTeamMember != nil {
+		activeTeamMember.LeftAt = &state.now
+		if err := s.teamMemberRepository.Update(tx, activeTeamMember); err != nil {
+			return false, err
+		}
+		if previousTeam := state.teamsByID[activeTeamMember.TeamID]; previousTeam != nil {
+			if err := s.outboxService.Record(tx, models.DomainEventTeamMemberRemoved, dtos.TeamMemberEventData{
+				Team: helpers.MapTeamToEventTeam(previousTeam),
+				User: helpers.MapUserToEventUser(user),
+			}); err != nil {
+				return false, err
+			}
+		}
+	}
+	if err := s.teamMemberRepository.Create(tx, &models.TeamMember{
+		UserID:   user.ID,
+		TeamID:   team.ID,
+		JoinedAt: state.now,
+	}); err != nil {
+		return false, err
+	}
+	user.CurrentTeamID = &team.ID
+	if err := s.outboxService.Record(tx, models.DomainEventTeamMemberAdded, dtos.TeamMemberEventData{
+		Team: helpers.MapTeamToEventTeam(team),
+		User: helpers.MapUserToEventUser(user),
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// deactivateMissingUsers deactivates the users linked to an entry that is no longer in the directory and
+// revokes their personal access tokens, their admin sessions are revoked once the sync is committed
+func (s *ldapSyncService) deactivateMissingUsers(tx *gorm.DB, state *ldapSyncState) error {
+	var missingUIDs []string
+	for uid, user := range state.usersByUID {
+		if !state.seenUserIDs[user.ID] && user.DeactivatedAt == nil {
+			missingUIDs = append(missingUIDs, uid)
+		}
+	}
+	sort.Strings(missingUIDs)
+
+	for _, uid := range missingUIDs {
+		user := state.usersByUID[uid]
+		if err := s.userRepository.UpdateDeactivatedAt(tx, user.ID, &state.now); err != nil {
+			return err
+		}
+		if _, err := s.apiTokenRepository.DeleteByUserID(tx, user.ID); err != nil {
+			return err
+		}
+		state.deactivatedUserID = append(state.deactivatedUserID, user.ID)
+		state.changes = append(state.changes, dtos.LDAPSyncChange{
+			UID:     uid,
+			Email:   user.Email,
+			UserID:  &user.ID,
+			Action:  dtos.LDAPSyncActionDeactivated,
+			Message: "no longer in the directory",
+		})
+	}
+	return nil
+}
+
+func (s *ldapSyncService) matchPosition(state *ldapSyncState, title string) (*models.Position, string) {
+	if title == "" {
+		return nil, ""
+	}
+	if position := state.positionsByName[strings.ToLower(title)]; position != nil {
+		return position, ""
+	}
+	return nil, fmt.Sprintf("title %q matches no position", title)
+}
+
+func (s *ldapSyncService) matchTeam(state *ldapSyncState, department string) (*models.Team, string) {
+	if department == "" {
+		return nil, ""
+	}
+	if team := state.teamsByName[strings.ToLower(department)]; team != nil {
+		return team, ""
+	}
+	return nil, fmt.Sprintf("department %q matches no team", department)
+}
+
+func (s *ldapSyncService) countChanges(run *models.LDAPSyncRun, changes []dtos.LDAPSyncChange) {
+	for _, change := range changes {
+		switch change.Action {
+		case dtos.LDAPSyncActionCreated:
+			run.CreatedCount++
+		case dtos.LDAPSyncActionUpdated:
+			run.UpdatedCount++
+		case dtos.LDAPSyncActionDeactivated:
+			run.DeactivatedCount++
+		case dtos.LDAPSyncActionReactivated:
+			run.ReactivatedCount++
+		case dtos.LDAPSyncActionSkipped:
+			run.SkippedCount++
+		}
+	}
+}
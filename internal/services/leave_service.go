@@ -0,0 +1,434 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/utils"
+	"trieu_mock_project_go/models"
+	"trieu_mock_project_go/types"
+
+	"gorm.io/gorm"
+)
+
+const calendarFeedTokenRandomBytes = 32
+
+// Leaves shown in calendars and feeds; pending ones are marked as tentative
+var calendarLeaveStatuses = []string{models.LeaveStatusPending, models.LeaveStatusApproved}
+
+type LeaveService interface {
+	CreateLeave(c context.Context, userID uint, req dtos.CreateLeaveRequest) (*dtos.LeaveRequest, error)
+	ListMyLeaves(c context.Context, userID uint, limit, offset int) (*dtos.ListLeaveRequestsResponse, error)
+	ListPendingLeaves(c context.Context, leaderID uint) (*dtos.PendingLeaveRequestsResponse, error)
+	ApproveLeave(c context.Context, id, reviewerID uint, req dtos.ReviewLeaveRequest) error
+	RejectLeave(c context.Context, id, reviewerID uint, req dtos.ReviewLeaveRequest) error
+	CancelLeave(c context.Context, id, userID uint) error
+	GetTeamCalendar(c context.Context, teamID uint, from, to time.Time) (*dtos.TeamLeaveCalendarResponse, error)
+	GetUserICalendar(c context.Context, userID uint) (string, error)
+	GetTeamICalendar(c context.Context, teamID uint) (string, error)
+	CreateCalendarFeed(c context.Context, userID uint) (*dtos.CalendarFeedResponse, error)
+	RevokeCalendarFeed(c context.Context, userID uint) error
+	AuthenticateCalendarFeed(c context.Context, token string) (uint, error)
+}
+
+type leaveService struct {
+	db                     *gorm.DB
+	leaveRequestRepository repositories.LeaveRequestRepository
+	teamRepository         repositories.TeamsRepository
+	teamMemberRepository   repositories.TeamMemberRepository
+	userRepository         repositories.UserRepository
+	notificationRepository repositories.NotificationRepository
+	mailService            MailService
+}
+
+func NewLeaveService(
+	db *gorm.DB,
+	leaveRequestRepository repositories.LeaveRequestRepository,
+	teamRepository repositories.TeamsRepository,
+	teamMemberRepository repositories.TeamMemberRepository,
+	userRepository repositories.UserRepository,
+	notificationRepository repositories.NotificationRepository,
+	mailService MailService) LeaveService {
+	return &leaveService{
+		db:                     db,
+		leaveRequestRepository: leaveRequestRepository,
+		teamRepository:         teamRepository,
+		teamMemberRepository:   teamMemberRepository,
+		userRepository:         userRepository,
+		notificationRepository: notificationRepository,
+		mailService:            mailService,
+	}
+}
+
+// CreateLeave submits a leave request for the user's current team. The team leader is notified,
+// requests of the team leader are approved straight away since nobody else leads the team.
+func (s *leaveService) CreateLeave(c context.Context, userID uint, req dtos.CreateLeaveRequest) (*dtos.LeaveRequest, error) {
+	startDate := localDate(req.StartDate.Time)
+	endDate := localDate(req.EndDate.Time)
+	if endDate.Before(startDate) {
+		return nil, appErrors.ErrLeaveDateRangeInvalid
+	}
+	if startDate.Equal(endDate) && req.StartHalfDay && req.EndHalfDay {
+		return nil, appErrors.ErrLeaveHalfDayInvalid
+	}
+	if helpers.CountLeaveDays(startDate, endDate, req.StartHalfDay, req.EndHalfDay) <= 0 {
+		return nil, appErrors.ErrLeaveNoWorkingDays
+	}
+
+	activeMember, err := s.teamMemberRepository.FindActiveMemberByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if activeMember == nil {
+		return nil, appErrors.ErrLeaveUserNotInTeam
+	}
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), activeMember.TeamID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	leave := &models.LeaveRequest{
+		UserID:       userID,
+		TeamID:       team.ID,
+		Type:         req.Type,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		StartHalfDay: req.StartHalfDay,
+		EndHalfDay:   req.EndHalfDay,
+		Reason:       req.Reason,
+		Status:       models.LeaveStatusPending,
+	}
+	if team.LeaderID == userID {
+		now := time.Now()
+		leave.Status = models.LeaveStatusApproved
+		leave.ReviewerID = &userID
+		leave.ReviewedAt = &now
+	}
+
+	var created *models.LeaveRequest
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		overlaps, err := s.leaveRequestRepository.ExistsOverlapping(tx, userID, startDate, endDate)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if overlaps {
+			return appErrors.ErrLeaveOverlaps
+		}
+		if err := s.leaveRequestRepository.Create(tx, leave); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		created, err = s.leaveRequestRepository.FindByID(tx, leave.ID)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if created.Status != models.LeaveStatusPending {
+			return nil
+		}
+		notification := &models.Notification{
+			UserID: team.LeaderID,
+			Title:  fmt.Sprintf("Leave request from %s", created.User.Name),
+			Content: fmt.Sprintf("%s requested %s leave from %s to %s and is waiting for your approval.",
+				created.User.Name, created.Type, created.StartDate.Format("2006-01-02"), created.EndDate.Format("2006-01-02")),
+		}
+		if err := s.notificationRepository.Create(tx, notification); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.MapLeaveRequestToDto(created), nil
+}
+
+func (s *leaveService) ListMyLeaves(c context.Context, userID uint, limit, offset int) (*dtos.ListLeaveRequestsResponse, error) {
+	leaves, err := s.leaveRequestRepository.FindByUserID(s.db.WithContext(c), userID, limit, offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	totalCount, err := s.leaveRequestRepository.CountByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.ListLeaveRequestsResponse{
+		Leaves: helpers.MapLeaveRequestsToDtos(leaves),
+		Page: dtos.PaginationResponse{
+			Limit:  limit,
+			Offset: offset,
+			Total:  totalCount,
+		},
+	}, nil
+}
+
+// ListPendingLeaves lists the requests waiting for the user's approval as a team leader
+func (s *leaveService) ListPendingLeaves(c context.Context, leaderID uint) (*dtos.PendingLeaveRequestsResponse, error) {
+	leaves, err := s.leaveRequestRepository.FindPendingByLeaderID(s.db.WithContext(c), leaderID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.PendingLeaveRequestsResponse{Leaves: helpers.MapLeaveRequestsToDtos(leaves)}, nil
+}
+
+func (s *leaveService) ApproveLeave(c context.Context, id, reviewerID uint, req dtos.ReviewLeaveRequest) error {
+	return s.reviewLeave(c, id, reviewerID, models.LeaveStatusApproved, req.Note)
+}
+
+func (s *leaveService) RejectLeave(c context.Context, id, reviewerID uint, req dtos.ReviewLeaveRequest) error {
+	return s.reviewLeave(c, id, reviewerID, models.LeaveStatusRejected, req.Note)
+}
+
+// reviewLeave lets the leader of the requester's current team approve or reject a pending request,
+// a member who moved teams since asking is reviewed by their new leader
+func (s *leaveService) reviewLeave(c context.Context, id, reviewerID uint, status string, note *string) error {
+	leave, err := s.leaveRequestRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.ErrLeaveRequestNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+	if leave.UserID == reviewerID {
+		return appErrors.ErrForbidden
+	}
+	activeMember, err := s.teamMemberRepository.FindActiveMemberByUserID(s.db.WithContext(c), leave.UserID)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if activeMember == nil {
+		return appErrors.ErrForbidden
+	}
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), activeMember.TeamID)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if team.LeaderID != reviewerID {
+		return appErrors.ErrForbidden
+	}
+	if leave.Status != models.LeaveStatusPending {
+		return appErrors.ErrLeaveNotPending
+	}
+
+	now := time.Now()
+	leave.Status = status
+	leave.ReviewerID = &reviewerID
+	leave.ReviewNote = note
+	leave.ReviewedAt = &now
+
+	return s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.leaveRequestRepository.UpdateStatus(tx, leave); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		content := fmt.Sprintf("Your %s leave from %s to %s was %s.",
+			leave.Type, leave.StartDate.Format("2006-01-02"), leave.EndDate.Format("2006-01-02"), status)
+		if note != nil && *note != "" {
+			content += " Note: " + *note
+		}
+		notification := &models.Notification{
+			UserID:  leave.UserID,
+			Title:   fmt.Sprintf("Leave request %s", status),
+			Content: content,
+		}
+		if err := s.notificationRepository.Create(tx, notification); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if err := s.mailService.QueueLeaveReview(tx, leave); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		return nil
+	})
+}
+
+// CancelLeave withdraws a pending request or an approved leave that has not started yet
+func (s *leaveService) CancelLeave(c context.Context, id, userID uint) error {
+	leave, err := s.leaveRequestRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.ErrLeaveRequestNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+	if leave.UserID != userID {
+		return appErrors.ErrLeaveRequestNotFound
+	}
+
+	today := startOfDay(time.Now())
+	cancellable := leave.Status == models.LeaveStatusPending ||
+		(leave.Status == models.LeaveStatusApproved && leave.StartDate.After(today))
+	if !cancellable {
+		return appErrors.ErrLeaveCannotBeCancelled
+	}
+
+	leave.Status = models.LeaveStatusCancelled
+	if err := s.leaveRequestRepository.UpdateStatus(s.db.WithContext(c), leave); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+// GetTeamCalendar lists pending and approved leaves of the team overlapping the date range.
+// Reasons and review notes are private to the requester and the leader, so they are left out.
+func (s *leaveService) GetTeamCalendar(c context.Context, teamID uint, from, to time.Time) (*dtos.TeamLeaveCalendarResponse, error) {
+	from, to = localDate(from), localDate(to)
+	if to.Before(from) {
+		return nil, appErrors.ErrLeaveDateRangeInvalid
+	}
+	if to.Sub(from) > 366*24*time.Hour {
+		return nil, appErrors.ErrLeaveDateRangeTooLong
+	}
+	if _, err := s.teamRepository.FindByID(s.db.WithContext(c), teamID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrTeamNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	leaves, err := s.leaveRequestRepository.FindByTeamIDBetween(s.db.WithContext(c), teamID, from, to, calendarLeaveStatuses)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	leaveDtos := helpers.MapLeaveRequestsToDtos(leaves)
+	for i := range leaveDtos {
+		leaveDtos[i].Reason = nil
+		leaveDtos[i].ReviewNote = nil
+	}
+
+	return &dtos.TeamLeaveCalendarResponse{
+		TeamID: teamID,
+		From:   types.Date{Time: from},
+		To:     types.Date{Time: to},
+		Leaves: leaveDtos,
+	}, nil
+}
+
+// GetUserICalendar renders the user's pending and approved leaves as an iCalendar feed
+func (s *leaveService) GetUserICalendar(c context.Context, userID uint) (string, error) {
+	leaves, err := s.leaveRequestRepository.FindByUserIDAndStatuses(s.db.WithContext(c), userID, calendarLeaveStatuses)
+	if err != nil {
+		return "", appErrors.ErrInternalServerError
+	}
+
+	return utils.BuildICalendar("My leaves", buildLeaveICalEvents(leaves)), nil
+}
+
+// GetTeamICalendar renders the team's pending and approved leaves as an iCalendar feed
+func (s *leaveService) GetTeamICalendar(c context.Context, teamID uint) (string, error) {
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), teamID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", appErrors.ErrTeamNotFound
+		}
+		return "", appErrors.ErrInternalServerError
+	}
+
+	leaves, err := s.leaveRequestRepository.FindByTeamIDAndStatuses(s.db.WithContext(c), teamID, calendarLeaveStatuses)
+	if err != nil {
+		return "", appErrors.ErrInternalServerError
+	}
+
+	return utils.BuildICalendar(team.Name+" leaves", buildLeaveICalEvents(leaves)), nil
+}
+
+// CreateCalendarFeed gives the user a new secret for the feed URLs calendar clients subscribe to, the
+// URLs handed out before stop working. Only a SHA-256 hash of the token is stored, it is shown once.
+func (s *leaveService) CreateCalendarFeed(c context.Context, userID uint) (*dtos.CalendarFeedResponse, error) {
+	token, err := generateCalendarFeedToken()
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	tokenHash := hashCalendarFeedToken(token)
+	if err := s.userRepository.UpdateCalendarFeedTokenHash(s.db.WithContext(c), userID, &tokenHash); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	feed := &dtos.CalendarFeedResponse{
+		Token:      token,
+		LeavesPath: "/calendar/leaves.ics?token=" + token,
+	}
+	activeMember, err := s.teamMemberRepository.FindActiveMemberByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if activeMember != nil {
+		feed.TeamLeavesPath = fmt.Sprintf("/calendar/teams/%d/leaves.ics?token=%s", activeMember.TeamID, token)
+	}
+	return feed, nil
+}
+
+// RevokeCalendarFeed stops the feed URLs of the user from working
+func (s *leaveService) RevokeCalendarFeed(c context.Context, userID uint) error {
+	if err := s.userRepository.UpdateCalendarFeedTokenHash(s.db.WithContext(c), userID, nil); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+// AuthenticateCalendarFeed returns the user whose feed URLs carry the token, deactivated users have none
+func (s *leaveService) AuthenticateCalendarFeed(c context.Context, token string) (uint, error) {
+	if token == "" {
+		return 0, appErrors.ErrInvalidToken
+	}
+	user, err := s.userRepository.FindByCalendarFeedTokenHash(s.db.WithContext(c), hashCalendarFeedToken(token))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, appErrors.ErrInvalidToken
+		}
+		return 0, appErrors.ErrInternalServerError
+	}
+	if user.DeactivatedAt != nil {
+		return 0, appErrors.ErrInvalidToken
+	}
+	return user.ID, nil
+}
+
+func generateCalendarFeedToken() (string, error) {
+	raw := make([]byte, calendarFeedTokenRandomBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashCalendarFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func buildLeaveICalEvents(leaves []models.LeaveRequest) []utils.ICalEvent {
+	events := make([]utils.ICalEvent, 0, len(leaves))
+	for _, leave := range leaves {
+		summary := fmt.Sprintf("%s - %s leave", leave.User.Name, leave.Type)
+		if leave.Status == models.LeaveStatusPending {
+			summary += " (pending)"
+		}
+		description := fmt.Sprintf("%.1f working day(s)", helpers.CountLeaveDays(leave.StartDate, leave.EndDate, leave.StartHalfDay, leave.EndHalfDay))
+		if leave.StartHalfDay {
+			description += ", starts at noon"
+		}
+		if leave.EndHalfDay {
+			description += ", ends at noon"
+		}
+		events = append(events, utils.ICalEvent{
+			UID:         utils.ICalUID("leave", leave.ID),
+			Summary:     summary,
+			Description: description,
+			StartDate:   leave.StartDate,
+			EndDate:     leave.EndDate,
+			Tentative:   leave.Status == models.LeaveStatusPending,
+			UpdatedAt:   leave.UpdatedAt,
+		})
+	}
+	return events
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/mailer"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	emailBatchSize     = 50
+	maxEmailRetryDelay = 6 * time.Hour
+)
+
+// emailLayout is what every email template uses, see internal/mailer/templates/layout.html
+type emailLayout struct {
+	Subject     string
+	Name        string
+	ActionURL   string
+	ActionLabel string
+	// Link to the email preferences, set on the emails users can opt out of
+	PreferencesURL string
+}
+
+type welcomeEmail struct {
+	emailLayout
+	Email string
+}
+
+type passwordResetEmail struct {
+	emailLayout
+	ExpiresAt string
+}
+
+type teamChangeEmail struct {
+	emailLayout
+	Joined   bool
+	TeamName string
+}
+
+type leaveReviewEmail struct {
+	emailLayout
+	LeaveType    string
+	StartDate    string
+	EndDate      string
+	Status       string
+	ReviewerName string
+	Note         string
+}
+
+// MailService queues emails to users and sends them. An email is rendered and queued in the transaction
+// of the change it is about, the EmailDeliveryJob hands it to the mailer and retries it when that fails.
+// Welcome and password reset emails are always sent, the others follow the email preferences of the user.
+// The bodies, which may hold a reset link, are cleared once an email is sent or given up on, and the
+// emails themselves are deleted after the retention.
+type MailService interface {
+	PollInterval() time.Duration
+	SubscriberName() string
+	HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error
+	QueuePasswordReset(tx *gorm.DB, user *models.User, token string, expiresAt time.Time) error
+	QueueLeaveReview(tx *gorm.DB, leave *models.LeaveRequest) error
+	SendDue(c context.Context, now time.Time) (int, error)
+	DeleteFinishedEmails(c context.Context, now time.Time) (int64, error)
+}
+
+type mailService struct {
+	db                     *gorm.DB
+	emailMessageRepository repositories.EmailMessageRepository
+	userRepository         repositories.UserRepository
+	mailer                 mailer.Mailer
+	cfg                    config.MailConfig
+}
+
+func NewMailService(
+	db *gorm.DB,
+	emailMessageRepository repositories.EmailMessageRepository,
+	userRepository repositories.UserRepository,
+	mailer mailer.Mailer,
+	cfg config.MailConfig) MailService {
+	return &mailService{
+		db:                     db,
+		emailMessageRepository: emailMessageRepository,
+		userRepository:         userRepository,
+		mailer:                 mailer,
+		cfg:                    cfg,
+	}
+}
+
+func (s *mailService) PollInterval() time.Duration {
+	return s.cfg.PollInterval
+}
+
+func (s *mailService) SubscriberName() string {
+	return "email"
+}
+
+// HandleEvent welcomes new users and tells users about changes to their team membership
+func (s *mailService) HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error {
+	switch event.EventType {
+	case models.DomainEventUserCreated:
+		var data dtos.UserEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		user, err := s.findRecipient(tx, data.User.ID)
+		if err != nil || user == nil {
+			return err
+		}
+		return s.queueWelcome(tx, user)
+	case models.DomainEventTeamMemberAdded, models.DomainEventTeamMemberRemoved:
+		var data dtos.TeamMemberEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		user, err := s.findRecipient(tx, data.User.ID)
+		if err != nil || user == nil || !user.EmailTeamChanges {
+			return err
+		}
+		return s.queueTeamChange(tx, user, data.Team, event.EventType == models.DomainEventTeamMemberAdded)
+	default:
+		return nil
+	}
+}
+
+// QueuePasswordReset emails the link to reset the password with the token, tx must be the transaction creating the token
+func (s *mailService) QueuePasswordReset(tx *gorm.DB, user *models.User, token string, expiresAt time.Time) error {
+	subject := "Reset your password"
+	return s.queue(tx, user, models.EmailKindPasswordReset, subject, passwordResetEmail{
+		emailLayout: s.layout(user, subject, s.link("/reset-password?token="+url.QueryEscape(token)), "Choose a new password", false),
+		ExpiresAt:   expiresAt.Format("2006-01-02 15:04 MST"),
+	})
+}
+
+// QueueLeaveReview tells the requester that their leave was approved or rejected, unless they opted out
+func (s *mailService) QueueLeaveReview(tx *gorm.DB, leave *models.LeaveRequest) error {
+	user, err := s.findRecipient(tx, leave.UserID)
+	if err != nil || user == nil || !user.EmailLeaveReviews {
+		return err
+	}
+	reviewerName := "your team leader"
+	if leave.ReviewerID != nil {
+		reviewer, err := s.findRecipient(tx, *leave.ReviewerID)
+		if err != nil {
+			return err
+		}
+		if reviewer != nil {
+			reviewerName = reviewer.Name
+		}
+	}
+	note := ""
+	if leave.ReviewNote != nil {
+		note = *leave.ReviewNote
+	}
+
+	subject := fmt.Sprintf("Your leave request was %s", leave.Status)
+	return s.queue(tx, user, models.EmailKindLeaveReview, subject, leaveReviewEmail{
+		emailLayout:  s.layout(user, subject, s.link("/leaves"), "View my leaves", true),
+		LeaveType:    leave.Type,
+		StartDate:    leave.StartDate.Format("2006-01-02"),
+		EndDate:      leave.EndDate.Format("2006-01-02"),
+		Status:       leave.Status,
+		ReviewerName: reviewerName,
+		Note:         note,
+	})
+}
+
+// SendDue sends the emails that are due and returns how many were attempted. Password reset emails
+// still queued when their link expired are given up on instead.
+func (s *mailService) SendDue(c context.Context, now time.Time) (int, error) {
+	if _, err := s.emailMessageRepository.FailPendingBefore(s.db.WithContext(c), models.EmailKindPasswordReset,
+		now.Add(-s.cfg.PasswordResetTTL), "the reset link expired before the email was sent"); err != nil {
+		return 0, err
+	}
+
+	messages, err := s.emailMessageRepository.FindDue(s.db.WithContext(c), now, emailBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return attemptClaimed(c, messages, func(message *models.EmailMessage) (bool, error) {
+		// Keep other instances away from the email for longer than sending it can take
+		return s.emailMessageRepository.Claim(s.db.WithContext(c), message, now.Add(2*s.cfg.Timeout))
+	}, func(message *models.EmailMessage) error {
+		return s.send(c, message)
+	})
+}
+
+// send makes one attempt and records its outcome, only failing to record it is returned as an error
+func (s *mailService) send(c context.Context, message *models.EmailMessage) error {
+	now := time.Now()
+	message.Attempts++
+	message.LastAttemptAt = &now
+	message.Error = nil
+
+	sendCtx, cancel := context.WithTimeout(c, s.cfg.Timeout)
+	sendErr := s.mailer.Send(sendCtx, &mailer.Message{
+		From:     s.cfg.From,
+		To:       message.ToAddress,
+		Subject:  message.Subject,
+		HTMLBody: message.HTMLBody,
+		TextBody: message.TextBody,
+	})
+	cancel()
+
+	switch {
+	case sendErr == nil:
+		message.Status = models.EmailMessageStatusSent
+		message.SentAt = &now
+		message.NextAttemptAt = nil
+		message.HTMLBody, message.TextBody = "", ""
+	case message.Attempts >= s.cfg.MaxAttempts:
+		errMessage := sendErr.Error()
+		message.Error = &errMessage
+		message.Status = models.EmailMessageStatusFailed
+		message.NextAttemptAt = nil
+		message.HTMLBody, message.TextBody = "", ""
+	default:
+		errMessage := sendErr.Error()
+		message.Error = &errMessage
+		nextAttemptAt := now.Add(retryDelay(s.cfg.RetryBaseDelay, maxEmailRetryDelay, message.Attempts))
+		message.NextAttemptAt = &nextAttemptAt
+	}
+	return s.emailMessageRepository.Update(s.db.WithContext(c), message)
+}
+
+// DeleteFinishedEmails removes the sent and failed emails queued longer ago than the retention
+func (s *mailService) DeleteFinishedEmails(c context.Context, now time.Time) (int64, error) {
+	return s.emailMessageRepository.DeleteFinishedBefore(s.db.WithContext(c), now.Add(-s.cfg.Retention))
+}
+
+func (s *mailService) queueWelcome(tx *gorm.DB, user *models.User) error {
+	subject := "Welcome to Trieu Mock Project"
+	return s.queue(tx, user, models.EmailKindWelcome, subject, welcomeEmail{
+		emailLayout: s.layout(user, subject, s.link("/login"), "Sign in", false),
+		Email:       user.Email,
+	})
+}
+
+func (s *mailService) queueTeamChange(tx *gorm.DB, user *models.User, team dtos.EventTeam, joined bool) error {
+	subject := fmt.Sprintf("You left the team %s", team.Name)
+	actionURL, actionLabel := s.link("/teams"), "View teams"
+	if joined {
+		subject = fmt.Sprintf("You joined the team %s", team.Name)
+		actionURL, actionLabel = s.link(fmt.Sprintf("/teams/%d", team.ID)), "View your team"
+	}
+	return s.queue(tx, user, models.EmailKindTeamChange, subject, teamChangeEmail{
+		emailLayout: s.layout(user, subject, actionURL, actionLabel, true),
+		Joined:      joined,
+		TeamName:    team.Name,
+	})
+}
+
+// queue renders the email with the templates of its kind and adds it to the send queue
+func (s *mailService) queue(tx *gorm.DB, user *models.User, kind, subject string, data interface{}) error {
+	htmlBody, textBody, err := mailer.Render(kind, data)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return s.emailMessageRepository.Create(tx, &models.EmailMessage{
+		UserID:        &user.ID,
+		Kind:          kind,
+		ToAddress:     (&mail.Address{Name: user.Name, Address: user.Email}).String(),
+		Subject:       subject,
+		HTMLBody:      htmlBody,
+		TextBody:      textBody,
+		Status:        models.EmailMessageStatusPending,
+		NextAttemptAt: &now,
+	})
+}
+
+func (s *mailService) layout(user *models.User, subject, actionURL, actionLabel string, optional bool) emailLayout {
+	layout := emailLayout{
+		Subject:     subject,
+		Name:        user.Name,
+		ActionURL:   actionURL,
+		ActionLabel: actionLabel,
+	}
+	if optional {
+		layout.PreferencesURL = s.link("/profile")
+	}
+	return layout
+}
+
+func (s *mailService) link(path string) string {
+	return s.cfg.BaseURL + path
+}
+
+// findRecipient returns nil without an error when the user was deleted or deactivated since
+func (s *mailService) findRecipient(tx *gorm.DB, userID uint) (*models.User, error) {
+	user, err := s.userRepository.FindContactByID(tx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if user.DeactivatedAt != nil {
+		return nil, nil
+	}
+	return user, nil
+}
@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+)
+
+func TestMailPasswordReset(t *testing.T) {
+	t.Run("reset link is not kept once the email is sent or expired", func(t *testing.T) {
+		app := apptest.New(t)
+		user := app.CreateUser("Member", "member@example.com", "user")
+		mailService := app.Container.MailService
+
+		requestReset := func() *models.EmailMessage {
+			t.Helper()
+			app.NewClient().Post("/forgot-password", dtos.ForgotPasswordRequest{Email: user.Email}).ExpectStatus(http.StatusOK)
+			var message models.EmailMessage
+			if err := app.DB.Where("kind = ?", models.EmailKindPasswordReset).Order("id DESC").First(&message).Error; err != nil {
+				t.Fatalf("finding the reset email: %v", err)
+			}
+			if !strings.Contains(message.TextBody, "/reset-password?token=") {
+				t.Fatalf("queued reset email has no link: %q", message.TextBody)
+			}
+			return &message
+		}
+		reload := func(message *models.EmailMessage) {
+			t.Helper()
+			if err := app.DB.First(message, message.ID).Error; err != nil {
+				t.Fatalf("reloading the email: %v", err)
+			}
+			if message.HTMLBody != "" || message.TextBody != "" {
+				t.Errorf("%s email still has its body", message.Status)
+			}
+		}
+
+		sent := requestReset()
+		if _, err := mailService.SendDue(context.Background(), time.Now()); err != nil {
+			t.Fatalf("sending emails: %v", err)
+		}
+		reload(sent)
+		if sent.Status != models.EmailMessageStatusSent {
+			t.Errorf("email is %s, want sent", sent.Status)
+		}
+
+		// Queued while the mailer was down, the link has expired by now
+		expired := requestReset()
+		if _, err := mailService.SendDue(context.Background(), time.Now().Add(2*time.Hour)); err != nil {
+			t.Fatalf("sending emails: %v", err)
+		}
+		reload(expired)
+		if expired.Status != models.EmailMessageStatusFailed || expired.Attempts != 0 {
+			t.Errorf("email is %s after %d attempts, want failed without an attempt", expired.Status, expired.Attempts)
+		}
+
+		deleted, err := mailService.DeleteFinishedEmails(context.Background(), time.Now().Add(60*24*time.Hour))
+		if err != nil {
+			t.Fatalf("deleting finished emails: %v", err)
+		}
+		if deleted != 2 {
+			t.Errorf("deleted %d emails, want 2", deleted)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type NotificationService interface {
+	ListNotifications(c context.Context, userID uint, limit, offset int) (*dtos.ListNotificationsResponse, error)
+	MarkAsRead(c context.Context, id, userID uint) error
+	SubscriberName() string
+	HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error
+}
+
+type notificationService struct {
+	db                     *gorm.DB
+	notificationRepository repositories.NotificationRepository
+}
+
+func NewNotificationService(db *gorm.DB, notificationRepository repositories.NotificationRepository) NotificationService {
+	return &notificationService{db: db, notificationRepository: notificationRepository}
+}
+
+func (s *notificationService) ListNotifications(c context.Context, userID uint, limit, offset int) (*dtos.ListNotificationsResponse, error) {
+	notifications, err := s.notificationRepository.FindByUserID(s.db.WithContext(c), userID, limit, offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	totalCount, err := s.notificationRepository.CountByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	unreadCount, err := s.notificationRepository.CountUnreadByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.ListNotificationsResponse{
+		Notifications: helpers.MapNotificationsToDtos(notifications),
+		UnreadCount:   unreadCount,
+		Page: dtos.PaginationResponse{
+			Limit:  limit,
+			Offset: offset,
+			Total:  totalCount,
+		},
+	}, nil
+}
+
+func (s *notificationService) MarkAsRead(c context.Context, id, userID uint) error {
+	updated, err := s.notificationRepository.MarkAsRead(s.db.WithContext(c), id, userID)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if !updated {
+		return appErrors.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *notificationService) SubscriberName() string {
+	return "notifications"
+}
+
+// HandleEvent tells users about changes to their team membership
+func (s *notificationService) HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error {
+	var notification *models.Notification
+	switch event.EventType {
+	case models.DomainEventTeamMemberAdded, models.DomainEventTeamMemberRemoved:
+		var data dtos.TeamMemberEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		notification = &models.Notification{
+			UserID:  data.User.ID,
+			Title:   fmt.Sprintf("You joined %s", data.Team.Name),
+			Content: fmt.Sprintf("You were added to the team %s.", data.Team.Name),
+		}
+		if event.EventType == models.DomainEventTeamMemberRemoved {
+			notification.Title = fmt.Sprintf("You left %s", data.Team.Name)
+			notification.Content = fmt.Sprintf("You are no longer a member of the team %s.", data.Team.Name)
+		}
+	case models.DomainEventTeamLeaderChanged:
+		var data dtos.TeamLeaderChangedEventData
+		if err := json.Unmarshal([]byte(event.Payload), &data); err != nil {
+			return err
+		}
+		notification = &models.Notification{
+			UserID:  data.Leader.ID,
+			Title:   fmt.Sprintf("You lead %s", data.Team.Name),
+			Content: fmt.Sprintf("You are now the leader of the team %s.", data.Team.Name),
+		}
+	default:
+		return nil
+	}
+	return s.notificationRepository.Create(tx, notification)
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/oidc"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// OIDCService signs users in through an OpenID Connect provider. Identities are matched to users by
+// issuer and subject, then by email on the first sign-on, and unknown emails get an account when
+// AutoProvision is on.
+type OIDCService interface {
+	Enabled() bool
+	ProviderName() string
+	RedirectURI(callbackPath string) string
+	BeginLogin(c context.Context, redirectURI string) (*oidc.AuthRequest, string, error)
+	FinishLogin(c context.Context, code, redirectURI string, request *oidc.AuthRequest, meta LoginMeta) (*LoginResult, error)
+}
+
+type oidcService struct {
+	db                            *gorm.DB
+	userRepository                repositories.UserRepository
+	positionRepository            repositories.PositionRepository
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository
+	authService                   AuthService
+	client                        *oidc.Client
+	cfg                           config.OIDCConfig
+}
+
+// OIDCIdentity is the user as described by the claims of a verified ID token
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+func NewOIDCService(
+	db *gorm.DB,
+	userRepository repositories.UserRepository,
+	positionRepository repositories.PositionRepository,
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository,
+	authService AuthService,
+	cfg config.OIDCConfig) OIDCService {
+	return &oidcService{
+		db:                            db,
+		userRepository:                userRepository,
+		positionRepository:            positionRepository,
+		userPositionHistoryRepository: userPositionHistoryRepository,
+		authService:                   authService,
+		client: oidc.NewClient(oidc.Config{
+			IssuerURL:    cfg.IssuerURL,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Scopes:       cfg.Scopes,
+		}),
+		cfg: cfg,
+	}
+}
+
+func (s *oidcService) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+func (s *oidcService) ProviderName() string {
+	return s.cfg.ProviderName
+}
+
+// RedirectURI is the absolute callback URL for the path, it has to be registered at the provider
+func (s *oidcService) RedirectURI(callbackPath string) string {
+	return s.cfg.RedirectBaseURL + callbackPath
+}
+
+// BeginLogin returns the provider URL to send the browser to, the returned request has to be kept
+// server-side and handed to FinishLogin when the provider redirects back
+func (s *oidcService) BeginLogin(c context.Context, redirectURI string) (*oidc.AuthRequest, string, error) {
+	if !s.cfg.Enabled {
+		return nil, "", appErrors.ErrSSODisabled
+	}
+
+	request, err := oidc.NewAuthRequest()
+	if err != nil {
+		return nil, "", appErrors.ErrInternalServerError
+	}
+	authURL, err := s.client.AuthCodeURL(c, redirectURI, request)
+	if err != nil {
+		slog.ErrorContext(c, "OIDC login could not start", "error", err)
+		return nil, "", appErrors.ErrSSOFailed
+	}
+	return request, authURL, nil
+}
+
+// FinishLogin redeems the authorization code and signs in the user behind the verified identity
+func (s *oidcService) FinishLogin(c context.Context, code, redirectURI string, request *oidc.AuthRequest, meta LoginMeta) (*LoginResult, error) {
+	if !s.cfg.Enabled {
+		return nil, appErrors.ErrSSODisabled
+	}
+
+	token, err := s.client.Exchange(c, code, redirectURI, request.CodeVerifier)
+	if err != nil {
+		slog.WarnContext(c, "OIDC code exchange failed", "error", err)
+		return nil, appErrors.ErrSSOFailed
+	}
+	claims, err := s.client.VerifyIDToken(c, token.IDToken, request.Nonce)
+	if err != nil {
+		slog.WarnContext(c, "OIDC ID token rejected", "error", err)
+		return nil, appErrors.ErrSSOFailed
+	}
+
+	identity := s.identityFromClaims(claims)
+	if identity.Subject == "" {
+		return nil, appErrors.ErrSSOFailed
+	}
+
+	user, err := s.resolveUser(c, identity, meta)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrSSONoAccount) {
+			return nil, s.authService.RejectSSOLogin(c, identity.Email, meta)
+		}
+		return nil, err
+	}
+	return s.authService.CompleteSSOLogin(c, user, meta)
+}
+
+func (s *oidcService) identityFromClaims(claims map[string]interface{}) *OIDCIdentity {
+	identity := &OIDCIdentity{}
+	identity.Issuer, _ = claims["iss"].(string)
+	identity.Subject, _ = claims["sub"].(string)
+	email, _ := claims[s.cfg.EmailClaim].(string)
+	identity.Email = strings.TrimSpace(email)
+	name, _ := claims[s.cfg.NameClaim].(string)
+	identity.Name = strings.TrimSpace(name)
+
+	// An email is only trusted once the provider says it checked it, unless it is configured as a
+	// provider that never sends the claim and only hands out addresses it owns
+	verified, ok := claims["email_verified"].(bool)
+	identity.EmailVerified = verified || (!ok && s.cfg.TrustUnverifiedEmails)
+	return identity
+}
+
+// resolveUser finds the user of the identity, linking an existing account by email on its first
+// sign-on or creating one when AutoProvision is on. Accounts are never created or linked from the admin
+// login, and admin accounts are never linked by email: a provider issuing their address to someone else
+// must not hand over the admin.
+func (s *oidcService) resolveUser(c context.Context, identity *OIDCIdentity, meta LoginMeta) (*models.User, error) {
+	user, err := s.userRepository.FindByOIDCIdentity(s.db.WithContext(c), identity.Issuer, identity.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, appErrors.ErrSSONoAccount
+	}
+
+	if meta.Flow == models.LoginFlowAdmin {
+		return nil, appErrors.ErrSSONoAccount
+	}
+
+	user, err = s.userRepository.FindByEmail(s.db.WithContext(c), identity.Email)
+	if err == nil {
+		// An account already linked to another identity is not taken over by a matching email
+		if user.OIDCSubject != nil || user.Role == "admin" {
+			return nil, appErrors.ErrSSONoAccount
+		}
+		if err := s.userRepository.LinkOIDCIdentity(s.db.WithContext(c), user.ID, identity.Issuer, identity.Subject); err != nil {
+			return nil, appErrors.ErrInternalServerError
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	if !s.cfg.AutoProvision {
+		return nil, appErrors.ErrSSONoAccount
+	}
+	return s.provisionUser(c, identity)
+}
+
+func (s *oidcService) provisionUser(c context.Context, identity *OIDCIdentity) (*models.User, error) {
+	// The account signs in through the provider only, nobody knows this password
+	password, err := unusablePassword()
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	user := &models.User{
+		Name:        truncate(name, 255),
+		Email:       identity.Email,
+		Password:    password,
+		PositionID:  s.cfg.DefaultPositionID,
+		Role:        "user",
+		OIDCIssuer:  &identity.Issuer,
+		OIDCSubject: &identity.Subject,
+	}
+
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.userRepository.CreateUser(tx, user); err != nil {
+			return err
+		}
+		return recordPositionChange(tx, s.positionRepository, s.userPositionHistoryRepository, user, nil, time.Now(), 0)
+	})
+	if err != nil {
+		slog.ErrorContext(c, "OIDC user provisioning failed", "email", identity.Email, "error", err)
+		return nil, appErrors.ErrInternalServerError
+	}
+	return user, nil
+}
+
+func unusablePassword() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(base64.RawStdEncoding.EncodeToString(raw)), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
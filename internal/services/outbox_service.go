@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/utils"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	outboxBatchSize     = 100
+	outboxLease         = time.Minute
+	maxOutboxRetryDelay = time.Hour
+)
+
+// EventSubscriber handles the domain events dispatched from the outbox. An event is delivered at least once:
+// after a failure it is dispatched again to the subscribers that have not handled it yet. HandleEvent runs in
+// the transaction that marks the event handled, so writes made through tx happen once per event.
+type EventSubscriber interface {
+	// SubscriberName identifies the subscriber in the processed events, it must not change once events were handled
+	SubscriberName() string
+	HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error
+}
+
+// OutboxService records domain events in the transaction of the change they describe and dispatches them
+// to the subscribers once committed, so an event is neither lost when the process stops after the commit
+// nor published for a change that was rolled back.
+type OutboxService interface {
+	PollInterval() time.Duration
+	Record(tx *gorm.DB, eventType string, data interface{}) error
+	DispatchDue(c context.Context, now time.Time) (int, error)
+	DeleteDispatchedEvents(c context.Context, now time.Time) (int64, error)
+}
+
+type outboxService struct {
+	db                             *gorm.DB
+	outboxEventRepository          repositories.OutboxEventRepository
+	outboxProcessedEventRepository repositories.OutboxProcessedEventRepository
+	subscribers                    []EventSubscriber
+	cfg                            config.OutboxConfig
+}
+
+func NewOutboxService(
+	db *gorm.DB,
+	outboxEventRepository repositories.OutboxEventRepository,
+	outboxProcessedEventRepository repositories.OutboxProcessedEventRepository,
+	subscribers []EventSubscriber,
+	cfg config.OutboxConfig) OutboxService {
+	return &outboxService{
+		db:                             db,
+		outboxEventRepository:          outboxEventRepository,
+		outboxProcessedEventRepository: outboxProcessedEventRepository,
+		subscribers:                    subscribers,
+		cfg:                            cfg,
+	}
+}
+
+func (s *outboxService) PollInterval() time.Duration {
+	return s.cfg.PollInterval
+}
+
+// Record writes the event to the outbox, tx must be the transaction of the change the event describes
+func (s *outboxService) Record(tx *gorm.DB, eventType string, data interface{}) error {
+	eventID, err := utils.NewUUID()
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return s.outboxEventRepository.Create(tx, &models.OutboxEvent{
+		EventID:       eventID,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Status:        models.OutboxEventStatusPending,
+		NextAttemptAt: &now,
+		OccurredAt:    now,
+	})
+}
+
+// DispatchDue dispatches the events that are due and returns how many were attempted
+func (s *outboxService) DispatchDue(c context.Context, now time.Time) (int, error) {
+	events, err := s.outboxEventRepository.FindDue(s.db.WithContext(c), now, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return attemptClaimed(c, events, func(event *models.OutboxEvent) (bool, error) {
+		return s.outboxEventRepository.Claim(s.db.WithContext(c), event, now.Add(outboxLease))
+	}, func(event *models.OutboxEvent) error {
+		return s.dispatch(c, event)
+	})
+}
+
+// DeleteDispatchedEvents removes the events dispatched longer ago than the retention
+func (s *outboxService) DeleteDispatchedEvents(c context.Context, now time.Time) (int64, error) {
+	return s.outboxEventRepository.DeleteDispatchedBefore(s.db.WithContext(c), now.Add(-s.cfg.Retention))
+}
+
+// dispatch hands the event to every subscriber and records the outcome, only failing to record it is returned
+func (s *outboxService) dispatch(c context.Context, event *models.OutboxEvent) error {
+	var failures []string
+	for _, subscriber := range s.subscribers {
+		if err := s.deliver(c, subscriber, event); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", subscriber.SubscriberName(), err))
+		}
+	}
+
+	now := time.Now()
+	event.Attempts++
+	switch {
+	case len(failures) == 0:
+		event.Status = models.OutboxEventStatusDispatched
+		event.DispatchedAt = &now
+		event.NextAttemptAt = nil
+		event.LastError = nil
+	case event.Attempts >= s.cfg.MaxAttempts:
+		message := strings.Join(failures, "; ")
+		event.Status = models.OutboxEventStatusFailed
+		event.NextAttemptAt = nil
+		event.LastError = &message
+		slog.ErrorContext(c, "Outbox event failed", "event_id", event.EventID, "event_type", event.EventType, "attempts", event.Attempts, "error", message)
+	default:
+		message := strings.Join(failures, "; ")
+		nextAttemptAt := now.Add(retryDelay(s.cfg.RetryBaseDelay, maxOutboxRetryDelay, event.Attempts))
+		event.NextAttemptAt = &nextAttemptAt
+		event.LastError = &message
+	}
+	return s.outboxEventRepository.Update(s.db.WithContext(c), event)
+}
+
+// deliver hands the event to the subscriber unless it handled it before, keyed by subscriber and event ID
+func (s *outboxService) deliver(c context.Context, subscriber EventSubscriber, event *models.OutboxEvent) error {
+	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		processed, err := s.outboxProcessedEventRepository.Exists(tx, subscriber.SubscriberName(), event.EventID)
+		if err != nil || processed {
+			return err
+		}
+		if err := subscriber.HandleEvent(tx, event); err != nil {
+			return err
+		}
+		return s.outboxProcessedEventRepository.Create(tx, &models.OutboxProcessedEvent{
+			Subscriber: subscriber.SubscriberName(),
+			EventID:    event.EventID,
+		})
+	})
+	// Another dispatcher handled the event at the same time, its writes were kept and these rolled back
+	if err != nil && appErrors.IsDuplicatedEntryError(err) {
+		return nil
+	}
+	return err
+}
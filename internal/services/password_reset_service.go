@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/sessionstore"
+	"trieu_mock_project_go/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	passwordResetTokenRandomBytes = 32
+	// Reset emails sent to one account per hour, further requests are ignored
+	maxPasswordResetRequestsPerHour = 3
+)
+
+// PasswordResetService lets users who forgot their password choose a new one through an emailed link.
+// Only a SHA-256 hash of the token in the link is stored.
+type PasswordResetService interface {
+	RequestReset(c context.Context, email string) error
+	ResetPassword(c context.Context, token, newPassword string) error
+}
+
+type passwordResetService struct {
+	db                           *gorm.DB
+	userRepository               repositories.UserRepository
+	passwordResetTokenRepository repositories.PasswordResetTokenRepository
+	apiTokenRepository           repositories.APITokenRepository
+	sessionBackend               sessionstore.Backend
+	mailService                  MailService
+	tokenTTL                     time.Duration
+}
+
+func NewPasswordResetService(
+	db *gorm.DB,
+	userRepository repositories.UserRepository,
+	passwordResetTokenRepository repositories.PasswordResetTokenRepository,
+	apiTokenRepository repositories.APITokenRepository,
+	sessionBackend sessionstore.Backend,
+	mailService MailService,
+	tokenTTL time.Duration) PasswordResetService {
+	return &passwordResetService{
+		db:                           db,
+		userRepository:               userRepository,
+		passwordResetTokenRepository: passwordResetTokenRepository,
+		apiTokenRepository:           apiTokenRepository,
+		sessionBackend:               sessionBackend,
+		mailService:                  mailService,
+		tokenTTL:                     tokenTTL,
+	}
+}
+
+// RequestReset emails a reset link to the account with the email. It succeeds without sending anything
+// for unknown or deactivated accounts and once the hourly limit is reached, so the response does not
+// tell whether an account exists.
+func (s *passwordResetService) RequestReset(c context.Context, email string) error {
+	user, err := s.userRepository.FindByEmail(s.db.WithContext(c), email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return appErrors.ErrInternalServerError
+	}
+	if user.DeactivatedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	recent, err := s.passwordResetTokenRepository.CountByUserIDSince(s.db.WithContext(c), user.ID, now.Add(-time.Hour))
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if recent >= maxPasswordResetRequestsPerHour {
+		return nil
+	}
+
+	token, err := generatePasswordResetToken()
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	expiresAt := now.Add(s.tokenTTL)
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.passwordResetTokenRepository.Create(tx, &models.PasswordResetToken{
+			UserID:    user.ID,
+			TokenHash: hashPasswordResetToken(token),
+			ExpiresAt: expiresAt,
+		}); err != nil {
+			return err
+		}
+		return s.mailService.QueuePasswordReset(tx, user, token, expiresAt)
+	})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+// ResetPassword sets the new password of the account the token was sent to. Every reset link of the
+// account stops working and a lockout from failed logins is lifted, the user proved they own the email.
+// Whoever knew the old password is signed out: the access tokens of the account stop working, and its
+// admin sessions and API tokens are revoked in the same transaction, so the password never changes while
+// they stay valid.
+func (s *passwordResetService) ResetPassword(c context.Context, token, newPassword string) error {
+	resetToken, err := s.passwordResetTokenRepository.FindValidByTokenHash(s.db.WithContext(c), hashPasswordResetToken(token), time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return appErrors.ErrInvalidPasswordResetToken
+		}
+		return appErrors.ErrInternalServerError
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+
+	return s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		deleted, err := s.passwordResetTokenRepository.DeleteByUserID(tx, resetToken.UserID)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		// The link was used at the same time by another request
+		if deleted == 0 {
+			return appErrors.ErrInvalidPasswordResetToken
+		}
+		if err := s.userRepository.UpdatePassword(tx, resetToken.UserID, string(hashedPassword)); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if err := s.userRepository.ResetLoginFailures(tx, resetToken.UserID); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if _, err := revokeCredentials(c, tx, s.apiTokenRepository, s.sessionBackend, resetToken.UserID, ""); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		return nil
+	})
+}
+
+func generatePasswordResetToken() (string, error) {
+	raw := make([]byte, passwordResetTokenRandomBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
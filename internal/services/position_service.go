@@ -12,16 +12,27 @@ import (
 	"gorm.io/gorm"
 )
 
-type PositionService struct {
+type PositionService interface {
+	GetAllPositionsSummary(c context.Context) []dtos.PositionSummary
+	SearchPositions(c context.Context, limit, offset int) (*dtos.PositionSearchResponse, error)
+	GetPositionByID(c context.Context, id uint) (*dtos.Position, error)
+	CreatePosition(c context.Context, req dtos.CreateOrUpdatePositionRequest) error
+	UpdatePosition(c context.Context, id uint, req dtos.CreateOrUpdatePositionRequest) error
+	DeletePosition(c context.Context, id uint) error
+	GetPromotionReadiness(c context.Context, userID uint) (*dtos.PromotionReadiness, error)
+}
+
+type positionService struct {
 	db                 *gorm.DB
-	positionRepository *repositories.PositionRepository
+	positionRepository repositories.PositionRepository
+	userRepository     repositories.UserRepository
 }
 
-func NewPositionService(db *gorm.DB, positionRepository *repositories.PositionRepository) *PositionService {
-	return &PositionService{db: db, positionRepository: positionRepository}
+func NewPositionService(db *gorm.DB, positionRepository repositories.PositionRepository, userRepository repositories.UserRepository) PositionService {
+	return &positionService{db: db, positionRepository: positionRepository, userRepository: userRepository}
 }
 
-func (s *PositionService) GetAllPositionsSummary(c context.Context) []dtos.PositionSummary {
+func (s *positionService) GetAllPositionsSummary(c context.Context) []dtos.PositionSummary {
 	positions, err := s.positionRepository.FindAllPositionsSummary(s.db.WithContext(c))
 	if err != nil {
 		return []dtos.PositionSummary{}
@@ -30,7 +41,7 @@ func (s *PositionService) GetAllPositionsSummary(c context.Context) []dtos.Posit
 	return helpers.MapPositionsToPositionSummaries(positions)
 }
 
-func (s *PositionService) SearchPositions(c context.Context, limit, offset int) (*dtos.PositionSearchResponse, error) {
+func (s *positionService) SearchPositions(c context.Context, limit, offset int) (*dtos.PositionSearchResponse, error) {
 	positions, totalCount, err := s.positionRepository.SearchPositions(s.db.WithContext(c), limit, offset)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -46,7 +57,7 @@ func (s *PositionService) SearchPositions(c context.Context, limit, offset int)
 	}, nil
 }
 
-func (s *PositionService) GetPositionByID(c context.Context, id uint) (*dtos.Position, error) {
+func (s *positionService) GetPositionByID(c context.Context, id uint) (*dtos.Position, error) {
 	position, err := s.positionRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -58,22 +69,36 @@ func (s *PositionService) GetPositionByID(c context.Context, id uint) (*dtos.Pos
 	return helpers.MapPositionToPositionDto(position), nil
 }
 
-func (s *PositionService) CreatePosition(c context.Context, req dtos.CreateOrUpdatePositionRequest) error {
+func (s *positionService) CreatePosition(c context.Context, req dtos.CreateOrUpdatePositionRequest) error {
+	if err := validatePositionRequest(req); err != nil {
+		return err
+	}
+
 	position := &models.Position{
-		Name:         strings.TrimSpace(req.Name),
-		Abbreviation: strings.TrimSpace(req.Abbreviation),
+		Name:          strings.TrimSpace(req.Name),
+		Abbreviation:  strings.TrimSpace(req.Abbreviation),
+		CareerTrackID: req.CareerTrackID,
+		Grade:         req.Grade,
 	}
 
-	if err := s.positionRepository.Create(s.db.WithContext(c), position); err != nil {
-		if appErrors.IsDuplicatedEntryError(err) {
-			return appErrors.ErrPositionAlreadyExists
+	return s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.positionRepository.Create(tx, position); err != nil {
+			return mapPositionWriteError(err)
 		}
-		return appErrors.ErrInternalServerError
-	}
-	return nil
+
+		requiredSkills := buildPositionRequiredSkills(position.ID, req.RequiredSkills)
+		if err := s.positionRepository.UpdateRequiredSkills(tx, position.ID, requiredSkills); err != nil {
+			return mapRequiredSkillsWriteError(err)
+		}
+		return nil
+	})
 }
 
-func (s *PositionService) UpdatePosition(c context.Context, id uint, req dtos.CreateOrUpdatePositionRequest) error {
+func (s *positionService) UpdatePosition(c context.Context, id uint, req dtos.CreateOrUpdatePositionRequest) error {
+	if err := validatePositionRequest(req); err != nil {
+		return err
+	}
+
 	currentPosition, err := s.positionRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -85,17 +110,23 @@ func (s *PositionService) UpdatePosition(c context.Context, id uint, req dtos.Cr
 
 	currentPosition.Name = strings.TrimSpace(req.Name)
 	currentPosition.Abbreviation = strings.TrimSpace(req.Abbreviation)
+	currentPosition.CareerTrackID = req.CareerTrackID
+	currentPosition.Grade = req.Grade
 
-	if err := s.positionRepository.Update(s.db.WithContext(c), currentPosition); err != nil {
-		if appErrors.IsDuplicatedEntryError(err) {
-			return appErrors.ErrPositionAlreadyExists
+	return s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.positionRepository.Update(tx, currentPosition); err != nil {
+			return mapPositionWriteError(err)
 		}
-		return appErrors.ErrInternalServerError
-	}
-	return nil
+
+		requiredSkills := buildPositionRequiredSkills(id, req.RequiredSkills)
+		if err := s.positionRepository.UpdateRequiredSkills(tx, id, requiredSkills); err != nil {
+			return mapRequiredSkillsWriteError(err)
+		}
+		return nil
+	})
 }
 
-func (s *PositionService) DeletePosition(c context.Context, id uint) error {
+func (s *positionService) DeletePosition(c context.Context, id uint) error {
 	_, err := s.positionRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -112,9 +143,134 @@ func (s *PositionService) DeletePosition(c context.Context, id uint) error {
 		return appErrors.ErrPositionInUse
 	}
 
+	// Deleting the position would lose the promotions and moves recorded with it
+	existedHistoryUsePosition, err := s.positionRepository.ExistsHistoriesWithPositionID(s.db.WithContext(c), id)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if existedHistoryUsePosition {
+		return appErrors.ErrPositionInUse
+	}
+
 	if err := s.positionRepository.Delete(s.db.WithContext(c), id); err != nil {
+		// A user or history row referencing the position was written since the checks
+		if appErrors.IsForeignKeyViolationError(err) {
+			return appErrors.ErrPositionInUse
+		}
 		return appErrors.ErrInternalServerError
 	}
 
 	return nil
 }
+
+// GetPromotionReadiness compares the user's skills against the requirements of
+// the next position in the career track of their current position
+func (s *positionService) GetPromotionReadiness(c context.Context, userID uint) (*dtos.PromotionReadiness, error) {
+	user, err := s.userRepository.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	currentPosition, err := s.positionRepository.FindByID(s.db.WithContext(c), user.PositionID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	readiness := &dtos.PromotionReadiness{
+		UserID:          user.ID,
+		CurrentPosition: *helpers.MapPositionToPositionDto(currentPosition),
+		Requirements:    []dtos.SkillRequirementStatus{},
+	}
+
+	if currentPosition.CareerTrackID == nil || currentPosition.Grade == nil {
+		return readiness, nil
+	}
+
+	nextPosition, err := s.positionRepository.FindNextPositionInTrack(s.db.WithContext(c), *currentPosition.CareerTrackID, *currentPosition.Grade)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if nextPosition == nil {
+		return readiness, nil
+	}
+
+	userSkillLevels := make(map[uint]int, len(user.UserSkill))
+	for _, userSkill := range user.UserSkill {
+		userSkillLevels[userSkill.SkillID] = userSkill.Level
+	}
+
+	for _, requiredSkill := range nextPosition.RequiredSkills {
+		currentLevel := userSkillLevels[requiredSkill.SkillID]
+		isMet := currentLevel >= requiredSkill.MinLevel
+		if isMet {
+			readiness.MetCount++
+		}
+		readiness.Requirements = append(readiness.Requirements, dtos.SkillRequirementStatus{
+			SkillID:       requiredSkill.SkillID,
+			SkillName:     requiredSkill.Skill.Name,
+			RequiredLevel: requiredSkill.MinLevel,
+			CurrentLevel:  currentLevel,
+			IsMet:         isMet,
+		})
+	}
+
+	readiness.NextPosition = helpers.MapPositionToPositionDto(nextPosition)
+	readiness.TotalCount = len(readiness.Requirements)
+	readiness.IsReady = readiness.MetCount == readiness.TotalCount
+
+	return readiness, nil
+}
+
+func validatePositionRequest(req dtos.CreateOrUpdatePositionRequest) error {
+	if (req.CareerTrackID == nil) != (req.Grade == nil) {
+		return appErrors.ErrPositionCareerTrackGradeInvalid
+	}
+	skillIDs := make(map[uint]bool, len(req.RequiredSkills))
+	for _, requiredSkill := range req.RequiredSkills {
+		if skillIDs[requiredSkill.ID] {
+			return appErrors.ErrPositionRequiredSkillDuplicated
+		}
+		skillIDs[requiredSkill.ID] = true
+	}
+	return nil
+}
+
+func buildPositionRequiredSkills(positionID uint, reqs []dtos.UpdatePositionRequiredSkill) []models.PositionRequiredSkill {
+	requiredSkills := make([]models.PositionRequiredSkill, 0, len(reqs))
+	for _, req := range reqs {
+		requiredSkills = append(requiredSkills, models.PositionRequiredSkill{
+			PositionID: positionID,
+			SkillID:    req.ID,
+			MinLevel:   req.MinLevel,
+		})
+	}
+	return requiredSkills
+}
+
+func mapPositionWriteError(err error) error {
+	if appErrors.IsDuplicatedEntryErrorOnKey(err, "ux_positions_career_track_grade", "career_track_id", "grade") {
+		return appErrors.ErrPositionGradeAlreadyExists
+	}
+	if appErrors.IsDuplicatedEntryError(err) {
+		return appErrors.ErrPositionAlreadyExists
+	}
+	// The only foreign key of a position is its career track
+	if appErrors.IsForeignKeyViolationError(err) {
+		return appErrors.ErrPositionCareerTrackInvalid
+	}
+	return appErrors.ErrInternalServerError
+}
+
+func mapRequiredSkillsWriteError(err error) error {
+	// The position was written in the same transaction, a missing row can only be a skill
+	if appErrors.IsForeignKeyViolationError(err) {
+		return appErrors.ErrPositionRequiredSkillInvalid
+	}
+	if appErrors.IsDuplicatedEntryError(err) {
+		return appErrors.ErrPositionRequiredSkillDuplicated
+	}
+	return appErrors.ErrInternalServerError
+}
@@ -9,16 +9,20 @@ import (
 	"gorm.io/gorm"
 )
 
-type ProjectService struct {
+type ProjectService interface {
+	GetAllProjectSummary(c context.Context) []dtos.ProjectSummary
+}
+
+type projectService struct {
 	db                *gorm.DB
-	projectRepository *repositories.ProjectRepository
+	projectRepository repositories.ProjectRepository
 }
 
-func NewProjectService(db *gorm.DB, projectRepository *repositories.ProjectRepository) *ProjectService {
-	return &ProjectService{db: db, projectRepository: projectRepository}
+func NewProjectService(db *gorm.DB, projectRepository repositories.ProjectRepository) ProjectService {
+	return &projectService{db: db, projectRepository: projectRepository}
 }
 
-func (s *ProjectService) GetAllProjectSummary(c context.Context) []dtos.ProjectSummary {
+func (s *projectService) GetAllProjectSummary(c context.Context) []dtos.ProjectSummary {
 	projects, err := s.projectRepository.FindAllProjectSummary(s.db.WithContext(c))
 	if err != nil {
 		return []dtos.ProjectSummary{}
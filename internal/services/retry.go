@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// retryDelay is the wait before the next attempt after attempts failed ones: base after the first,
+// then twice as long each time, up to maxDelay
+func retryDelay(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// attemptClaimed runs attempt on each due item that claim wins for this worker, and returns how many were
+// attempted. Items another worker claimed first are skipped. It stops at the first error, which only
+// comes from failing to claim an item or to record an outcome, or when c is cancelled.
+func attemptClaimed[T any](c context.Context, items []T, claim func(*T) (bool, error), attempt func(*T) error) (int, error) {
+	attempted := 0
+	for i := range items {
+		if c.Err() != nil {
+			break
+		}
+		claimed, err := claim(&items[i])
+		if err != nil {
+			return attempted, err
+		}
+		if !claimed {
+			continue
+		}
+		if err := attempt(&items[i]); err != nil {
+			return attempted, err
+		}
+		attempted++
+	}
+	return attempted, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+// Reference data created by SeedService.SeedDefaults, and the names the demo dataset is built from
+
+type seedCareerTrack struct {
+	name        string
+	description string
+}
+
+type seedPosition struct {
+	name         string
+	abbreviation string
+	// Empty for positions outside of any career track
+	careerTrack string
+	grade       int
+}
+
+var seedCareerTracks = []seedCareerTrack{
+	{"Software Engineering", "Engineers building and running the products"},
+	{"Quality Assurance", "Engineers testing the products before and after release"},
+	{"Management", "Leaders of teams and of the engineering organisation"},
+}
+
+var seedPositions = []seedPosition{
+	{"Intern Software Engineer", "ISE", "Software Engineering", 1},
+	{"Junior Software Engineer", "JSE", "Software Engineering", 2},
+	{"Software Engineer", "SE", "Software Engineering", 3},
+	{"Senior Software Engineer", "SSE", "Software Engineering", 4},
+	{"Principal Software Engineer", "PSE", "Software Engineering", 5},
+	{"Junior QA Engineer", "JQA", "Quality Assurance", 1},
+	{"QA Engineer", "QA", "Quality Assurance", 2},
+	{"Senior QA Engineer", "SQA", "Quality Assurance", 3},
+	{"Team Lead", "TL", "Management", 1},
+	{"Engineering Manager", "EM", "Management", 2},
+	{"Director of Engineering", "DOE", "Management", 3},
+	{"Project Manager", "PM", "", 0},
+	{"Business Analyst", "BA", "", 0},
+	{"UI/UX Designer", "UX", "", 0},
+	{"DevOps Engineer", "DO", "", 0},
+}
+
+// The initial admin holds this position
+const seedAdminPosition = "Engineering Manager"
+
+var seedSkills = []string{
+	"Go", "Java", "Python", "JavaScript", "TypeScript", "React", "Vue.js", "Node.js", "PHP", "Ruby on Rails",
+	"SQL", "MySQL", "PostgreSQL", "Docker", "Kubernetes", "AWS", "Git", "Linux", "Testing", "Selenium",
+	"Figma", "Agile/Scrum", "English", "Japanese", "Communication",
+}
+
+var seedFirstNames = []string{
+	"An", "Binh", "Chi", "Dung", "Giang", "Hai", "Hanh", "Hieu", "Hoa", "Huong",
+	"Khanh", "Lan", "Linh", "Long", "Mai", "Minh", "Nam", "Ngoc", "Phong", "Phuong",
+	"Quan", "Son", "Tam", "Thao", "Trang", "Trieu", "Tuan", "Van", "Viet", "Yen",
+	"Alice", "Ben", "Chloe", "David", "Emma", "Hiro", "Kenji", "Lucas", "Maria", "Yuki",
+}
+
+var seedLastNames = []string{
+	"Nguyen", "Tran", "Le", "Pham", "Hoang", "Huynh", "Phan", "Vu", "Vo", "Dang",
+	"Bui", "Do", "Ho", "Ngo", "Duong", "Ly", "Dinh", "Lam", "Mai", "Trinh",
+	"Smith", "Garcia", "Muller", "Tanaka", "Sato", "Kim", "Park", "Rossi", "Martin", "Silva",
+}
+
+var seedTeamNames = []string{
+	"Atlas", "Borealis", "Comet", "Delta", "Eclipse", "Falcon", "Galaxy", "Horizon", "Ion", "Jupiter",
+	"Kepler", "Lumen", "Meteor", "Nebula", "Orion", "Pulsar", "Quasar", "Rover", "Saturn", "Titan",
+}
+
+var seedProjectWords = []string{
+	"Apollo", "Beacon", "Cascade", "Dynamo", "Ember", "Fusion", "Glacier", "Harbor", "Insight", "Jade",
+	"Keystone", "Lighthouse", "Mosaic", "Nova", "Onyx", "Prism", "Quartz", "Ripple", "Summit", "Tundra",
+}
+
+var seedProjectKinds = []string{"Portal", "Mobile App", "Platform", "Dashboard", "API", "Migration", "Analytics"}
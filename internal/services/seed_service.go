@@ -0,0 +1,571 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Demo users get an address at this reserved domain, which also tells whether the demo was seeded
+const seedDemoEmailDomain = "demo.example.com"
+
+// Second half of the state of the demo random generator, the seed is the first
+const seedDemoStream uint64 = 0x7e1e0
+
+// SeedService fills a database with what the app needs to be used: the default career tracks,
+// positions and skills, the first admin, and on request a demo dataset. Rows are written directly
+// without recording domain events, so seeding sends no emails, notifications or webhooks.
+type SeedService interface {
+	SeedDefaults(c context.Context, admin dtos.SeedAdmin) (*dtos.SeedReport, error)
+	SeedDemo(c context.Context, opts dtos.SeedDemoOptions) (*dtos.SeedDemoReport, error)
+}
+
+type seedService struct {
+	db                            *gorm.DB
+	userRepository                repositories.UserRepository
+	careerTrackRepository         repositories.CareerTrackRepository
+	positionRepository            repositories.PositionRepository
+	skillRepository               repositories.SkillRepository
+	teamsRepository               repositories.TeamsRepository
+	teamMemberRepository          repositories.TeamMemberRepository
+	projectRepository             repositories.ProjectRepository
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository
+}
+
+func NewSeedService(
+	db *gorm.DB,
+	userRepository repositories.UserRepository,
+	careerTrackRepository repositories.CareerTrackRepository,
+	positionRepository repositories.PositionRepository,
+	skillRepository repositories.SkillRepository,
+	teamsRepository repositories.TeamsRepository,
+	teamMemberRepository repositories.TeamMemberRepository,
+	projectRepository repositories.ProjectRepository,
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository) SeedService {
+	return &seedService{
+		db:                            db,
+		userRepository:                userRepository,
+		careerTrackRepository:         careerTrackRepository,
+		positionRepository:            positionRepository,
+		skillRepository:               skillRepository,
+		teamsRepository:               teamsRepository,
+		teamMemberRepository:          teamMemberRepository,
+		projectRepository:             projectRepository,
+		userPositionHistoryRepository: userPositionHistoryRepository,
+	}
+}
+
+// SeedDefaults creates the default career tracks, positions and skills missing by name, and the admin
+// unless a user with its email exists. Running it again creates nothing.
+func (s *seedService) SeedDefaults(c context.Context, admin dtos.SeedAdmin) (*dtos.SeedReport, error) {
+	report := &dtos.SeedReport{AdminEmail: admin.Email}
+	if admin.Password != "" && (len(admin.Password) < 8 || len(admin.Password) > 72) {
+		return nil, fmt.Errorf("the admin password must be 8 to 72 characters long")
+	}
+
+	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		careerTrackIDs, err := s.seedCareerTracks(tx, report)
+		if err != nil {
+			return err
+		}
+		positionIDs, err := s.seedPositions(tx, careerTrackIDs, report)
+		if err != nil {
+			return err
+		}
+		if err := s.seedSkills(tx, report); err != nil {
+			return err
+		}
+		return s.seedAdmin(tx, admin, positionIDs[seedAdminPosition], report)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *seedService) seedCareerTracks(tx *gorm.DB, report *dtos.SeedReport) (map[string]uint, error) {
+	existing, err := s.careerTrackRepository.FindAllCareerTrackSummary(tx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uint, len(existing))
+	for _, careerTrack := range existing {
+		ids[careerTrack.Name] = careerTrack.ID
+	}
+
+	for _, seed := range seedCareerTracks {
+		if _, ok := ids[seed.name]; ok {
+			continue
+		}
+		description := seed.description
+		careerTrack := &models.CareerTrack{Name: seed.name, Description: &description}
+		if err := s.careerTrackRepository.Create(tx, careerTrack); err != nil {
+			return nil, fmt.Errorf("creating career track %q: %w", seed.name, err)
+		}
+		ids[seed.name] = careerTrack.ID
+		report.CareerTracks++
+	}
+	return ids, nil
+}
+
+func (s *seedService) seedPositions(tx *gorm.DB, careerTrackIDs map[string]uint, report *dtos.SeedReport) (map[string]uint, error) {
+	existing, err := s.positionRepository.FindAllPositionsSummary(tx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uint, len(existing))
+	for _, position := range existing {
+		ids[position.Name] = position.ID
+	}
+
+	for _, seed := range seedPositions {
+		if _, ok := ids[seed.name]; ok {
+			continue
+		}
+		position := &models.Position{Name: seed.name, Abbreviation: seed.abbreviation}
+		if seed.careerTrack != "" {
+			careerTrackID, grade := careerTrackIDs[seed.careerTrack], seed.grade
+			position.CareerTrackID = &careerTrackID
+			position.Grade = &grade
+		}
+		if err := s.positionRepository.Create(tx, position); err != nil {
+			if appErrors.IsDuplicatedEntryErrorOnKey(err, "ux_positions_career_track_grade", "career_track_id", "grade") {
+				return nil, fmt.Errorf("creating position %q: another position of %s already has grade %d", seed.name, seed.careerTrack, seed.grade)
+			}
+			return nil, fmt.Errorf("creating position %q: %w", seed.name, err)
+		}
+		ids[seed.name] = position.ID
+		report.Positions++
+	}
+	return ids, nil
+}
+
+func (s *seedService) seedSkills(tx *gorm.DB, report *dtos.SeedReport) error {
+	existing, err := s.skillRepository.FindAllSkillSummary(tx)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(existing))
+	for _, skill := range existing {
+		names[skill.Name] = true
+	}
+
+	for _, name := range seedSkills {
+		if names[name] {
+			continue
+		}
+		if err := s.skillRepository.Create(tx, &models.Skill{Name: name}); err != nil {
+			return fmt.Errorf("creating skill %q: %w", name, err)
+		}
+		report.Skills++
+	}
+	return nil
+}
+
+func (s *seedService) seedAdmin(tx *gorm.DB, admin dtos.SeedAdmin, positionID uint, report *dtos.SeedReport) error {
+	if admin.Email == "" {
+		return nil
+	}
+	if _, err := s.userRepository.FindByEmail(tx, admin.Email); err == nil {
+		return nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	password := admin.Password
+	if password == "" {
+		raw := make([]byte, 12)
+		if _, err := rand.Read(raw); err != nil {
+			return err
+		}
+		password = base64.RawURLEncoding.EncodeToString(raw)
+		report.GeneratedPassword = password
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user := &models.User{
+		Name:       admin.Name,
+		Email:      admin.Email,
+		Password:   string(hashedPassword),
+		PositionID: positionID,
+		Role:       "admin",
+	}
+	if err := s.userRepository.CreateUser(tx, user); err != nil {
+		return fmt.Errorf("creating admin %s: %w", admin.Email, err)
+	}
+	if err := s.userPositionHistoryRepository.Create(tx, &models.UserPositionHistory{
+		UserID:        user.ID,
+		NewPositionID: positionID,
+		EffectiveDate: time.Now(),
+	}); err != nil {
+		return err
+	}
+	report.AdminCreated = true
+	return nil
+}
+
+// seedDemoUser is a demo user with the history that is generated for them. The CreatedAt of the user
+// is the day they were hired.
+type seedDemoUser struct {
+	user   models.User
+	skills []models.UserSkill
+	// Positions held, the first since hiring and the last until today
+	positionIDs []uint
+	promotedAt  time.Time
+	// Index of the current and of the previous team in the demo teams, -1 for none
+	team         int
+	joinedAt     time.Time
+	previousTeam int
+}
+
+type seedDemoTeam struct {
+	team    models.Team
+	members []int
+}
+
+// SeedDemo generates a realistic organisation of opts.Users people at the demo.example.com domain,
+// with teams, skills, projects, past team memberships and promotions. The default positions and
+// skills have to exist. It runs once, a database with demo users is left alone.
+func (s *seedService) SeedDemo(c context.Context, opts dtos.SeedDemoOptions) (*dtos.SeedDemoReport, error) {
+	if opts.Users < 1 {
+		return nil, fmt.Errorf("the demo needs at least one user")
+	}
+	if opts.Today.IsZero() {
+		return nil, fmt.Errorf("the demo needs the day its dates are relative to")
+	}
+	db := s.db.WithContext(c)
+
+	count, err := s.userRepository.CountByEmailDomain(db, seedDemoEmailDomain)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, appErrors.ErrDemoDataAlreadySeeded
+	}
+
+	positions, err := s.positionRepository.FindAllPositionsSummary(db)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.skillRepository.FindAllSkillSummary(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(positions) == 0 || len(skills) == 0 {
+		return nil, fmt.Errorf("the demo needs positions and skills, seed the defaults first")
+	}
+	existingTeams, err := s.teamsRepository.FindAllTeamsSummary(db)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every user shares the password, hashing it once keeps generation fast
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(opts.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := mathrand.New(mathrand.NewPCG(opts.Seed, seedDemoStream))
+	today := time.Date(opts.Today.Year(), opts.Today.Month(), opts.Today.Day(), 0, 0, 0, 0, time.UTC)
+	users := generateDemoUsers(rng, opts.Users, today, positions, skills, string(hashedPassword))
+	teams := generateDemoTeams(rng, users, today, existingTeams)
+
+	report := &dtos.SeedDemoReport{Users: len(users), Teams: len(teams)}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		rows := make([]models.User, len(users))
+		for i := range users {
+			rows[i] = users[i].user
+		}
+		if err := s.userRepository.CreateUsers(tx, rows); err != nil {
+			return fmt.Errorf("creating users: %w", err)
+		}
+		var userSkills []models.UserSkill
+		for i := range users {
+			users[i].user.ID = rows[i].ID
+			for _, skill := range users[i].skills {
+				skill.UserID = rows[i].ID
+				userSkills = append(userSkills, skill)
+			}
+		}
+		if err := s.userRepository.CreateUserSkills(tx, userSkills); err != nil {
+			return fmt.Errorf("creating user skills: %w", err)
+		}
+		report.UserSkills = len(userSkills)
+
+		for i := range teams {
+			teams[i].team.LeaderID = users[teams[i].members[0]].user.ID
+			if err := s.teamsRepository.Create(tx, &teams[i].team); err != nil {
+				return fmt.Errorf("creating team %q: %w", teams[i].team.Name, err)
+			}
+			memberIDs := make([]uint, 0, len(teams[i].members))
+			for _, member := range teams[i].members {
+				memberIDs = append(memberIDs, users[member].user.ID)
+			}
+			if err := s.userRepository.UpdateCurrentTeamID(tx, memberIDs, teams[i].team.ID); err != nil {
+				return err
+			}
+		}
+
+		memberships, histories := demoHistory(users, teams)
+		if err := s.teamMemberRepository.CreateMembers(tx, memberships); err != nil {
+			return fmt.Errorf("creating team memberships: %w", err)
+		}
+		if err := s.userPositionHistoryRepository.CreateHistories(tx, histories); err != nil {
+			return fmt.Errorf("creating position history: %w", err)
+		}
+		report.TeamMemberships = len(memberships)
+		report.PositionChanges = len(histories)
+
+		projects, err := s.createDemoProjects(tx, rng, users, teams, today)
+		report.Projects = projects
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func generateDemoUsers(
+	rng *mathrand.Rand,
+	count int,
+	today time.Time,
+	positions []models.Position,
+	skills []models.Skill,
+	hashedPassword string) []seedDemoUser {
+	// Order by ID, the database returns rows in no particular order and the demo must not depend on it
+	slices.SortFunc(positions, func(a, b models.Position) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(skills, func(a, b models.Skill) int { return cmp.Compare(a.ID, b.ID) })
+
+	// Career tracks with their positions by grade, and the positions outside of any track
+	var tracks [][]models.Position
+	var untracked []models.Position
+	trackIndexes := map[uint]int{}
+	for _, position := range positions {
+		if position.CareerTrackID == nil || position.Grade == nil {
+			untracked = append(untracked, position)
+			continue
+		}
+		index, ok := trackIndexes[*position.CareerTrackID]
+		if !ok {
+			index = len(tracks)
+			trackIndexes[*position.CareerTrackID] = index
+			tracks = append(tracks, nil)
+		}
+		tracks[index] = append(tracks[index], position)
+	}
+	for _, track := range tracks {
+		slices.SortFunc(track, func(a, b models.Position) int { return cmp.Compare(*a.Grade, *b.Grade) })
+	}
+
+	users := make([]seedDemoUser, count)
+	for i := range users {
+		first := seedFirstNames[rng.IntN(len(seedFirstNames))]
+		last := seedLastNames[rng.IntN(len(seedLastNames))]
+		hiredAt := today.AddDate(0, 0, -30-rng.IntN(6*365))
+		birthday := today.AddDate(-22-rng.IntN(38), 0, -rng.IntN(365))
+
+		demoUser := seedDemoUser{
+			user: models.User{
+				Name:      first + " " + last,
+				Email:     fmt.Sprintf("%s.%s.%d@%s", strings.ToLower(first), strings.ToLower(last), i+1, seedDemoEmailDomain),
+				Password:  hashedPassword,
+				Birthday:  &birthday,
+				Role:      "user",
+				CreatedAt: hiredAt,
+			},
+			team:         -1,
+			previousTeam: -1,
+		}
+
+		// Most people are on a career track, lower grades are more common; half of the people above
+		// the first grade who have been here for a year were promoted to it
+		if len(tracks) > 0 && (len(untracked) == 0 || rng.IntN(5) > 0) {
+			track := tracks[rng.IntN(len(tracks))]
+			if rng.IntN(2) == 0 {
+				track = tracks[0]
+			}
+			grade := min(rng.IntN(len(track)), rng.IntN(len(track)))
+			tenure := int(today.Sub(hiredAt).Hours() / 24)
+			if grade > 0 && tenure > 365 && rng.IntN(2) == 0 {
+				demoUser.positionIDs = append(demoUser.positionIDs, track[grade-1].ID)
+				demoUser.promotedAt = hiredAt.AddDate(0, 0, 180+rng.IntN(tenure-180))
+			}
+			demoUser.positionIDs = append(demoUser.positionIDs, track[grade].ID)
+		} else {
+			demoUser.positionIDs = append(demoUser.positionIDs, untracked[rng.IntN(len(untracked))].ID)
+		}
+		demoUser.user.PositionID = demoUser.positionIDs[len(demoUser.positionIDs)-1]
+
+		for _, index := range rng.Perm(len(skills))[:min(len(skills), 2+rng.IntN(5))] {
+			demoUser.skills = append(demoUser.skills, models.UserSkill{
+				SkillID:        skills[index].ID,
+				Level:          1 + rng.IntN(5),
+				UsedYearNumber: 1 + rng.IntN(10),
+			})
+		}
+		users[i] = demoUser
+	}
+	return users
+}
+
+// generateDemoTeams puts nine in ten users in teams of about fifteen, the first member leads the team.
+// A quarter of the members who have been here for a year were in another team before.
+func generateDemoTeams(rng *mathrand.Rand, users []seedDemoUser, today time.Time, existingTeams []models.Team) []seedDemoTeam {
+	taken := make(map[string]bool, len(existingTeams))
+	for _, team := range existingTeams {
+		taken[team.Name] = true
+	}
+
+	order := rng.Perm(len(users))
+	assigned := order[len(users)/10:]
+	teamCount := max(1, len(assigned)/15)
+	if len(assigned) == 0 {
+		return nil
+	}
+
+	teams := make([]seedDemoTeam, teamCount)
+	for i := range teams {
+		// Names are numbered once the list runs out or when a team already has the name
+		base := seedTeamNames[i%len(seedTeamNames)]
+		name := base
+		if round := i / len(seedTeamNames); round > 0 {
+			name = fmt.Sprintf("%s %d", base, round+1)
+		}
+		for n := 2; taken[name]; n++ {
+			name = fmt.Sprintf("%s %d", base, n)
+		}
+		taken[name] = true
+		description := fmt.Sprintf("The %s team", name)
+		teams[i].team = models.Team{Name: name, Description: &description}
+	}
+
+	for i, userIndex := range assigned {
+		team := i % teamCount
+		teams[team].members = append(teams[team].members, userIndex)
+
+		user := &users[userIndex]
+		tenure := int(today.Sub(user.user.CreatedAt).Hours()/24) + 1
+		user.team = team
+		user.joinedAt = user.user.CreatedAt.AddDate(0, 0, rng.IntN(tenure))
+		if teamCount > 1 && tenure > 365 && rng.IntN(4) == 0 {
+			user.previousTeam = (team + 1 + rng.IntN(teamCount-1)) % teamCount
+			user.joinedAt = user.user.CreatedAt.AddDate(0, 0, 90+rng.IntN(tenure-90))
+		}
+	}
+	return teams
+}
+
+// demoHistory returns the team memberships and position changes of the demo users
+func demoHistory(users []seedDemoUser, teams []seedDemoTeam) ([]models.TeamMember, []models.UserPositionHistory) {
+	var memberships []models.TeamMember
+	var histories []models.UserPositionHistory
+	for i := range users {
+		user := &users[i]
+		if user.previousTeam >= 0 {
+			leftAt := user.joinedAt
+			memberships = append(memberships, models.TeamMember{
+				UserID:   user.user.ID,
+				TeamID:   teams[user.previousTeam].team.ID,
+				JoinedAt: user.user.CreatedAt,
+				LeftAt:   &leftAt,
+			})
+		}
+		if user.team >= 0 {
+			memberships = append(memberships, models.TeamMember{
+				UserID:   user.user.ID,
+				TeamID:   teams[user.team].team.ID,
+				JoinedAt: user.joinedAt,
+			})
+		}
+
+		// The team of a position change is the team of the user on that day
+		teamAt := func(date time.Time) *uint {
+			switch {
+			case user.team >= 0 && !date.Before(user.joinedAt):
+				return &teams[user.team].team.ID
+			case user.previousTeam >= 0:
+				return &teams[user.previousTeam].team.ID
+			default:
+				return nil
+			}
+		}
+		histories = append(histories, models.UserPositionHistory{
+			UserID:        user.user.ID,
+			NewPositionID: user.positionIDs[0],
+			TeamID:        teamAt(user.user.CreatedAt),
+			EffectiveDate: user.user.CreatedAt,
+		})
+		if len(user.positionIDs) > 1 {
+			histories = append(histories, models.UserPositionHistory{
+				UserID:        user.user.ID,
+				OldPositionID: &user.positionIDs[0],
+				NewPositionID: user.positionIDs[1],
+				TeamID:        teamAt(user.promotedAt),
+				IsPromotion:   true,
+				EffectiveDate: user.promotedAt,
+			})
+		}
+	}
+	return memberships, histories
+}
+
+// createDemoProjects gives every team one to three projects, led by one of its members and staffed
+// from the team. Some projects are finished, the others are ongoing.
+func (s *seedService) createDemoProjects(tx *gorm.DB, rng *mathrand.Rand, users []seedDemoUser, teams []seedDemoTeam, today time.Time) (int, error) {
+	count := 0
+	for _, team := range teams {
+		for range 1 + rng.IntN(3) {
+			word := seedProjectWords[rng.IntN(len(seedProjectWords))]
+			kind := seedProjectKinds[rng.IntN(len(seedProjectKinds))]
+			startDate := today.AddDate(0, 0, -30-rng.IntN(700))
+			var endDate *time.Time
+			if rng.IntN(5) < 3 {
+				end := startDate.AddDate(0, 0, 90+rng.IntN(450))
+				endDate = &end
+			}
+
+			members := slices.Clone(team.members)
+			rng.Shuffle(len(members), func(i, j int) { members[i], members[j] = members[j], members[i] })
+			members = members[:min(len(members), 3+rng.IntN(6))]
+
+			count++
+			project := &models.Project{
+				Name:         word + " " + kind,
+				Abbreviation: fmt.Sprintf("%s-%d", strings.ToUpper(word[:3]), count),
+				StartDate:    &startDate,
+				EndDate:      endDate,
+				LeaderID:     users[members[0]].user.ID,
+				TeamID:       team.team.ID,
+			}
+			if err := s.projectRepository.Create(tx, project); err != nil {
+				return count, fmt.Errorf("creating project %q: %w", project.Name, err)
+			}
+			memberIDs := make([]uint, 0, len(members))
+			for _, member := range members {
+				memberIDs = append(memberIDs, users[member].user.ID)
+			}
+			if err := s.projectRepository.AddMembers(tx, project.ID, memberIDs); err != nil {
+				return count, fmt.Errorf("adding members to project %q: %w", project.Name, err)
+			}
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"trieu_mock_project_go/internal/apptest"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+// demoDataset lists what the demo generated, leaving out the timestamps the database sets on insert
+var demoDataset = []string{
+	"SELECT id, name, email, birthday, position_id, current_team_id, created_at FROM users WHERE email LIKE '%@demo.example.com' ORDER BY id",
+	"SELECT user_id, skill_id, level, used_year_number FROM user_skills ORDER BY user_id, skill_id",
+	"SELECT id, name, leader_id FROM teams ORDER BY id",
+	"SELECT user_id, team_id, joined_at, left_at FROM team_members ORDER BY id",
+	"SELECT user_id, old_position_id, new_position_id, team_id, is_promotion, effective_date FROM user_position_histories ORDER BY id",
+	"SELECT id, name, abbreviation, start_date, end_date, leader_id, team_id FROM projects ORDER BY id",
+	"SELECT project_id, user_id FROM project_members ORDER BY project_id, user_id",
+}
+
+func TestSeedDemo(t *testing.T) {
+	t.Run("same options give the same dataset", func(t *testing.T) {
+		opts := dtos.SeedDemoOptions{Seed: 42, Users: 40, Password: apptest.Password, Today: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+		first := apptest.New(t)
+		if _, err := first.Container.SeedService.SeedDemo(context.Background(), opts); err != nil {
+			t.Fatalf("seeding the demo: %v", err)
+		}
+		// Only the day matters, not the time it is generated at
+		opts.Today = opts.Today.Add(15 * time.Hour)
+		second := apptest.New(t)
+		if _, err := second.Container.SeedService.SeedDemo(context.Background(), opts); err != nil {
+			t.Fatalf("seeding the demo again: %v", err)
+		}
+
+		for _, query := range demoDataset {
+			want, got := dumpRows(t, first.DB, query), dumpRows(t, second.DB, query)
+			if len(want) == 0 {
+				t.Errorf("%s returned nothing", query)
+			}
+			if want != got {
+				t.Errorf("%s differs between the runs:\n%s\nwant:\n%s", query, got, want)
+			}
+		}
+	})
+
+	t.Run("dates are relative to the given day", func(t *testing.T) {
+		app := apptest.New(t)
+		today := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+		if _, err := app.Container.SeedService.SeedDemo(context.Background(), dtos.SeedDemoOptions{Seed: 1, Users: 20, Password: apptest.Password, Today: today}); err != nil {
+			t.Fatalf("seeding the demo: %v", err)
+		}
+
+		var latest models.User
+		if err := app.DB.Where("email LIKE ?", "%@demo.example.com").Order("created_at DESC").First(&latest).Error; err != nil {
+			t.Fatalf("finding the last hired demo user: %v", err)
+		}
+		if latest.CreatedAt.After(today) || latest.CreatedAt.Before(today.AddDate(-6, 0, -30)) {
+			t.Errorf("latest demo user hired on %s, want within six years before %s", latest.CreatedAt, today)
+		}
+	})
+}
+
+func dumpRows(t *testing.T, db *gorm.DB, query string) string {
+	t.Helper()
+	var rows []map[string]any
+	if err := db.Raw(query).Scan(&rows).Error; err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+	return fmt.Sprint(rows)
+}
@@ -0,0 +1,6 @@
+// Package services holds the business logic of the app. Each service is an interface implemented by an
+// unexported type, so handlers and other services can be tested against fakes and wrapped by decorators
+// such as the tracing ones of traced_services.go.
+package services
+
+//go:generate go run ../../cmd/tracegen -output traced_services.go
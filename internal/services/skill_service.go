@@ -12,16 +12,26 @@ import (
 	"gorm.io/gorm"
 )
 
-type SkillService struct {
+type SkillService interface {
+	GetAllSkillsSummary(c context.Context) []dtos.SkillSummary
+	SearchSkills(c context.Context, limit, offset int) (*dtos.SkillSearchResponse, error)
+	GetSkillByID(c context.Context, id uint) (*dtos.SkillSummary, error)
+	CreateSkill(c context.Context, req dtos.CreateOrUpdateSkillRequest) error
+	UpdateSkill(c context.Context, id uint, req dtos.CreateOrUpdateSkillRequest) error
+	DeleteSkill(c context.Context, id uint) error
+	FindSkillHolders(c context.Context, name string, limit int) ([]dtos.SkillHolder, error)
+}
+
+type skillService struct {
 	db              *gorm.DB
-	skillRepository *repositories.SkillRepository
+	skillRepository repositories.SkillRepository
 }
 
-func NewSkillService(db *gorm.DB, skillRepository *repositories.SkillRepository) *SkillService {
-	return &SkillService{db: db, skillRepository: skillRepository}
+func NewSkillService(db *gorm.DB, skillRepository repositories.SkillRepository) SkillService {
+	return &skillService{db: db, skillRepository: skillRepository}
 }
 
-func (s *SkillService) GetAllSkillsSummary(c context.Context) []dtos.SkillSummary {
+func (s *skillService) GetAllSkillsSummary(c context.Context) []dtos.SkillSummary {
 	skills, err := s.skillRepository.FindAllSkillSummary(s.db.WithContext(c))
 	if err != nil {
 		return []dtos.SkillSummary{}
@@ -30,7 +40,7 @@ func (s *SkillService) GetAllSkillsSummary(c context.Context) []dtos.SkillSummar
 	return helpers.MapSkillsToSkillSummaries(skills)
 }
 
-func (s *SkillService) SearchSkills(c context.Context, limit, offset int) (*dtos.SkillSearchResponse, error) {
+func (s *skillService) SearchSkills(c context.Context, limit, offset int) (*dtos.SkillSearchResponse, error) {
 	skills, totalCount, err := s.skillRepository.SearchSkills(s.db.WithContext(c), limit, offset)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -46,7 +56,7 @@ func (s *SkillService) SearchSkills(c context.Context, limit, offset int) (*dtos
 	}, nil
 }
 
-func (s *SkillService) GetSkillByID(c context.Context, id uint) (*dtos.SkillSummary, error) {
+func (s *skillService) GetSkillByID(c context.Context, id uint) (*dtos.SkillSummary, error) {
 	skill, err := s.skillRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -58,7 +68,7 @@ func (s *SkillService) GetSkillByID(c context.Context, id uint) (*dtos.SkillSumm
 	return helpers.MapSkillToSkillSummary(skill), nil
 }
 
-func (s *SkillService) CreateSkill(c context.Context, req dtos.CreateOrUpdateSkillRequest) error {
+func (s *skillService) CreateSkill(c context.Context, req dtos.CreateOrUpdateSkillRequest) error {
 	skill := &models.Skill{
 		Name: strings.TrimSpace(req.Name),
 	}
@@ -72,7 +82,7 @@ func (s *SkillService) CreateSkill(c context.Context, req dtos.CreateOrUpdateSki
 	return nil
 }
 
-func (s *SkillService) UpdateSkill(c context.Context, id uint, req dtos.CreateOrUpdateSkillRequest) error {
+func (s *skillService) UpdateSkill(c context.Context, id uint, req dtos.CreateOrUpdateSkillRequest) error {
 	currentSkill, err := s.skillRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -92,7 +102,7 @@ func (s *SkillService) UpdateSkill(c context.Context, id uint, req dtos.CreateOr
 	return nil
 }
 
-func (s *SkillService) DeleteSkill(c context.Context, id uint) error {
+func (s *skillService) DeleteSkill(c context.Context, id uint) error {
 	_, err := s.skillRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -115,3 +125,12 @@ func (s *SkillService) DeleteSkill(c context.Context, id uint) error {
 
 	return nil
 }
+
+// FindSkillHolders lists the users who know a skill whose name contains name, most experienced first
+func (s *skillService) FindSkillHolders(c context.Context, name string, limit int) ([]dtos.SkillHolder, error) {
+	userSkills, err := s.skillRepository.FindUserSkillsBySkillName(s.db.WithContext(c), name, limit)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapUserSkillsToSkillHolders(userSkills), nil
+}
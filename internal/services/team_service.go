@@ -12,18 +12,32 @@ import (
 	"gorm.io/gorm"
 )
 
-type TeamsService struct {
+type TeamsService interface {
+	ListTeams(c context.Context, limit, offset int) (*dtos.ListTeamsResponse, error)
+	GetTeamDetails(c context.Context, id uint) (*dtos.Team, error)
+	GetTeamMembers(c context.Context, teamID uint, limit, offset int) (*dtos.ListTeamMembersResponse, error)
+	GetTeamMemberHistory(c context.Context, teamID uint, limit, offset int) (*dtos.ListTeamMemberHistoryResponse, error)
+	GetAllTeamsSummary(c context.Context) []dtos.TeamSummary
+	CreateTeam(c context.Context, req dtos.CreateOrUpdateTeamRequest) error
+	UpdateTeam(c context.Context, id uint, req dtos.CreateOrUpdateTeamRequest) error
+	DeleteTeam(c context.Context, id uint) error
+	AddMemberToTeam(c context.Context, teamID uint, userID uint) error
+	RemoveMemberFromTeam(c context.Context, teamID uint, userID uint) error
+}
+
+type teamsService struct {
 	db                   *gorm.DB
-	teamRepository       *repositories.TeamsRepository
-	teamMemberRepository *repositories.TeamMemberRepository
-	userRepository       *repositories.UserRepository
+	teamRepository       repositories.TeamsRepository
+	teamMemberRepository repositories.TeamMemberRepository
+	userRepository       repositories.UserRepository
+	outboxService        OutboxService
 }
 
-func NewTeamsService(db *gorm.DB, teamRepository *repositories.TeamsRepository, teamMemberRepository *repositories.TeamMemberRepository, userRepository *repositories.UserRepository) *TeamsService {
-	return &TeamsService{db: db, teamRepository: teamRepository, teamMemberRepository: teamMemberRepository, userRepository: userRepository}
+func NewTeamsService(db *gorm.DB, teamRepository repositories.TeamsRepository, teamMemberRepository repositories.TeamMemberRepository, userRepository repositories.UserRepository, outboxService OutboxService) TeamsService {
+	return &teamsService{db: db, teamRepository: teamRepository, teamMemberRepository: teamMemberRepository, userRepository: userRepository, outboxService: outboxService}
 }
 
-func (s *TeamsService) ListTeams(c context.Context, limit, offset int) (*dtos.ListTeamsResponse, error) {
+func (s *teamsService) ListTeams(c context.Context, limit, offset int) (*dtos.ListTeamsResponse, error) {
 	teams, err := s.teamRepository.ListTeams(s.db.WithContext(c), limit, offset)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -48,7 +62,7 @@ func (s *TeamsService) ListTeams(c context.Context, limit, offset int) (*dtos.Li
 	return response, nil
 }
 
-func (s *TeamsService) GetTeamDetails(c context.Context, id uint) (*dtos.Team, error) {
+func (s *teamsService) GetTeamDetails(c context.Context, id uint) (*dtos.Team, error) {
 	team, err := s.teamRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -57,7 +71,7 @@ func (s *TeamsService) GetTeamDetails(c context.Context, id uint) (*dtos.Team, e
 	return helpers.MapTeamToTeamDto(team), nil
 }
 
-func (s *TeamsService) GetTeamMembers(c context.Context, teamID uint, limit, offset int) (*dtos.ListTeamMembersResponse, error) {
+func (s *teamsService) GetTeamMembers(c context.Context, teamID uint, limit, offset int) (*dtos.ListTeamMembersResponse, error) {
 	members, err := s.teamMemberRepository.FindActiveMembersByTeamID(s.db.WithContext(c), teamID, limit, offset)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -80,7 +94,7 @@ func (s *TeamsService) GetTeamMembers(c context.Context, teamID uint, limit, off
 	return response, nil
 }
 
-func (s *TeamsService) GetTeamMemberHistory(c context.Context, teamID uint, limit, offset int) (*dtos.ListTeamMemberHistoryResponse, error) {
+func (s *teamsService) GetTeamMemberHistory(c context.Context, teamID uint, limit, offset int) (*dtos.ListTeamMemberHistoryResponse, error) {
 	members, err := s.teamMemberRepository.FindTeamMembersByTeamID(s.db.WithContext(c), teamID, limit, offset)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -101,7 +115,7 @@ func (s *TeamsService) GetTeamMemberHistory(c context.Context, teamID uint, limi
 	}, nil
 }
 
-func (s *TeamsService) GetAllTeamsSummary(c context.Context) []dtos.TeamSummary {
+func (s *teamsService) GetAllTeamsSummary(c context.Context) []dtos.TeamSummary {
 	teams, err := s.teamRepository.FindAllTeamsSummary(s.db.WithContext(c))
 	if err != nil {
 		return []dtos.TeamSummary{}
@@ -110,7 +124,7 @@ func (s *TeamsService) GetAllTeamsSummary(c context.Context) []dtos.TeamSummary
 	return helpers.MapTeamsToTeamSummaries(teams)
 }
 
-func (s *TeamsService) CreateTeam(c context.Context, req dtos.CreateOrUpdateTeamRequest) error {
+func (s *teamsService) CreateTeam(c context.Context, req dtos.CreateOrUpdateTeamRequest) error {
 	leader, err := s.userRepository.FindByID(s.db.WithContext(c), req.LeaderID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -147,11 +161,24 @@ func (s *TeamsService) CreateTeam(c context.Context, req dtos.CreateOrUpdateTeam
 			}
 			return appErrors.ErrInternalServerError
 		}
+
+		if err := s.outboxService.Record(tx, models.DomainEventTeamCreated, dtos.TeamEventData{
+			Team:     helpers.MapTeamToEventTeam(team),
+			LeaderID: team.LeaderID,
+		}); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if err := s.outboxService.Record(tx, models.DomainEventTeamMemberAdded, dtos.TeamMemberEventData{
+			Team: helpers.MapTeamToEventTeam(team),
+			User: helpers.MapUserToEventUser(leader),
+		}); err != nil {
+			return appErrors.ErrInternalServerError
+		}
 		return nil
 	})
 }
 
-func (s *TeamsService) UpdateTeam(c context.Context, id uint, req dtos.CreateOrUpdateTeamRequest) error {
+func (s *teamsService) UpdateTeam(c context.Context, id uint, req dtos.CreateOrUpdateTeamRequest) error {
 	team, err := s.teamRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -164,6 +191,7 @@ func (s *TeamsService) UpdateTeam(c context.Context, id uint, req dtos.CreateOrU
 	team.Description = req.Description
 
 	if team.LeaderID != req.LeaderID {
+		previousLeaderID := team.LeaderID
 		team.LeaderID = req.LeaderID
 		return s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
 			if err := s.teamRepository.Update(tx, team); err != nil {
@@ -186,6 +214,14 @@ func (s *TeamsService) UpdateTeam(c context.Context, id uint, req dtos.CreateOrU
 				return appErrors.ErrInternalServerError
 			}
 
+			if err := s.outboxService.Record(tx, models.DomainEventTeamLeaderChanged, dtos.TeamLeaderChangedEventData{
+				Team:             helpers.MapTeamToEventTeam(team),
+				PreviousLeaderID: previousLeaderID,
+				Leader:           helpers.MapUserToEventUser(newLeader),
+			}); err != nil {
+				return appErrors.ErrInternalServerError
+			}
+
 			activeTeamMember, err := s.teamMemberRepository.FindActiveMemberByUserID(tx, req.LeaderID)
 			if err != nil && err != gorm.ErrRecordNotFound {
 				return appErrors.ErrInternalServerError
@@ -208,6 +244,12 @@ func (s *TeamsService) UpdateTeam(c context.Context, id uint, req dtos.CreateOrU
 			if err := s.teamMemberRepository.Create(tx, newMember); err != nil {
 				return appErrors.ErrInternalServerError
 			}
+			if err := s.outboxService.Record(tx, models.DomainEventTeamMemberAdded, dtos.TeamMemberEventData{
+				Team: helpers.MapTeamToEventTeam(team),
+				User: helpers.MapUserToEventUser(newLeader),
+			}); err != nil {
+				return appErrors.ErrInternalServerError
+			}
 
 			return nil
 		})
@@ -222,20 +264,28 @@ func (s *TeamsService) UpdateTeam(c context.Context, id uint, req dtos.CreateOrU
 	}
 }
 
-func (s *TeamsService) DeleteTeam(c context.Context, id uint) error {
-	if _, err := s.teamRepository.FindByID(s.db.WithContext(c), id); err != nil {
+func (s *teamsService) DeleteTeam(c context.Context, id uint) error {
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return appErrors.ErrTeamNotFound
 		}
 		return appErrors.ErrInternalServerError
 	}
-	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
 		// Set current_team_id = null for all users in this team
 		if err := s.userRepository.UpdateUsersCurrentTeamToNullByTeamID(tx, id); err != nil {
 			return err
 		}
 
-		return s.teamRepository.Delete(tx, id)
+		if err := s.teamRepository.Delete(tx, id); err != nil {
+			return err
+		}
+
+		return s.outboxService.Record(tx, models.DomainEventTeamDeleted, dtos.TeamEventData{
+			Team:     helpers.MapTeamToEventTeam(team),
+			LeaderID: team.LeaderID,
+		})
 	})
 	if err != nil {
 		return appErrors.ErrInternalServerError
@@ -243,8 +293,9 @@ func (s *TeamsService) DeleteTeam(c context.Context, id uint) error {
 	return nil
 }
 
-func (s *TeamsService) AddMemberToTeam(c context.Context, teamID uint, userID uint) error {
-	if _, err := s.teamRepository.FindByID(s.db.WithContext(c), teamID); err != nil {
+func (s *teamsService) AddMemberToTeam(c context.Context, teamID uint, userID uint) error {
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), teamID)
+	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return appErrors.ErrTeamNotFound
 		}
@@ -279,6 +330,12 @@ func (s *TeamsService) AddMemberToTeam(c context.Context, teamID uint, userID ui
 			if err := s.teamMemberRepository.Update(tx, activeTeamMember); err != nil {
 				return appErrors.ErrInternalServerError
 			}
+			if err := s.outboxService.Record(tx, models.DomainEventTeamMemberRemoved, dtos.TeamMemberEventData{
+				Team: helpers.MapTeamToEventTeam(activeTeam),
+				User: helpers.MapUserToEventUser(user),
+			}); err != nil {
+				return appErrors.ErrInternalServerError
+			}
 		}
 		// Add new team member record
 		newMember := &models.TeamMember{
@@ -295,11 +352,18 @@ func (s *TeamsService) AddMemberToTeam(c context.Context, teamID uint, userID ui
 		if err := s.userRepository.UpdateUser(tx, user); err != nil {
 			return appErrors.ErrInternalServerError
 		}
+
+		if err := s.outboxService.Record(tx, models.DomainEventTeamMemberAdded, dtos.TeamMemberEventData{
+			Team: helpers.MapTeamToEventTeam(team),
+			User: helpers.MapUserToEventUser(user),
+		}); err != nil {
+			return appErrors.ErrInternalServerError
+		}
 		return nil
 	})
 }
 
-func (s *TeamsService) RemoveMemberFromTeam(c context.Context, teamID uint, userID uint) error {
+func (s *teamsService) RemoveMemberFromTeam(c context.Context, teamID uint, userID uint) error {
 	team, err := s.teamRepository.FindByID(s.db.WithContext(c), teamID)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -340,6 +404,13 @@ func (s *TeamsService) RemoveMemberFromTeam(c context.Context, teamID uint, user
 		if err := s.userRepository.UpdateUser(tx, user); err != nil {
 			return appErrors.ErrInternalServerError
 		}
+
+		if err := s.outboxService.Record(tx, models.DomainEventTeamMemberRemoved, dtos.TeamMemberEventData{
+			Team: helpers.MapTeamToEventTeam(team),
+			User: helpers.MapUserToEventUser(user),
+		}); err != nil {
+			return appErrors.ErrInternalServerError
+		}
 		return nil
 	})
 }
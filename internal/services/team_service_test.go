@@ -0,0 +1,198 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/fakes"
+	"trieu_mock_project_go/internal/services"
+	"trieu_mock_project_go/models"
+)
+
+type teamsServiceTest struct {
+	store   *fakes.Store
+	users   *fakes.UserRepository
+	teams   *fakes.TeamsRepository
+	members *fakes.TeamMemberRepository
+	service services.TeamsService
+}
+
+func newTeamsServiceTest() *teamsServiceTest {
+	store := fakes.NewStore()
+	test := &teamsServiceTest{
+		store:   store,
+		users:   fakes.NewUserRepository(store),
+		teams:   fakes.NewTeamsRepository(store),
+		members: fakes.NewTeamMemberRepository(store),
+	}
+	test.service = services.NewTeamsService(store.DB(), test.teams, test.members, test.users, fakes.NewOutboxService(store))
+	return test
+}
+
+func (test *teamsServiceTest) user(t *testing.T, name string) *models.User {
+	t.Helper()
+	user := &models.User{Name: name, Email: name + "@example.com", Role: "user"}
+	if err := test.users.CreateUser(nil, user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+// team creates a team led by leader, who is an active member of it
+func (test *teamsServiceTest) team(t *testing.T, name string, leader *models.User) *models.Team {
+	t.Helper()
+	team := &models.Team{Name: name, LeaderID: leader.ID}
+	if err := test.teams.Create(nil, team); err != nil {
+		t.Fatal(err)
+	}
+	test.join(t, leader, team)
+	return team
+}
+
+func (test *teamsServiceTest) join(t *testing.T, user *models.User, team *models.Team) {
+	t.Helper()
+	if err := test.members.Create(nil, &models.TeamMember{UserID: user.ID, TeamID: team.ID}); err != nil {
+		t.Fatal(err)
+	}
+	user.CurrentTeamID = &team.ID
+	if err := test.users.UpdateUser(nil, user); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTeamsServiceUpdateTeam(t *testing.T) {
+	t.Run("renames without changing the leader", func(t *testing.T) {
+		test := newTeamsServiceTest()
+		leader := test.user(t, "leader")
+		team := test.team(t, "Atlas", leader)
+		description := "Platform team"
+
+		err := test.service.UpdateTeam(context.Background(), team.ID, dtos.CreateOrUpdateTeamRequest{
+			Name:        "Borealis",
+			Description: &description,
+			LeaderID:    leader.ID,
+		})
+		if err != nil {
+			t.Fatalf("UpdateTeam: %v", err)
+		}
+
+		updated := test.store.Teams[team.ID]
+		if updated.Name != "Borealis" || updated.Description == nil || *updated.Description != description {
+			t.Errorf("team is %q %v, want Borealis %q", updated.Name, updated.Description, description)
+		}
+		if len(test.store.Events) != 0 {
+			t.Errorf("recorded %v, want no events", test.store.EventTypes())
+		}
+	})
+
+	t.Run("rejects a missing team and a taken name", func(t *testing.T) {
+		test := newTeamsServiceTest()
+		leader := test.user(t, "leader")
+		team := test.team(t, "Atlas", leader)
+		test.team(t, "Borealis", test.user(t, "other"))
+
+		err := test.service.UpdateTeam(context.Background(), team.ID+100, dtos.CreateOrUpdateTeamRequest{Name: "Comet", LeaderID: leader.ID})
+		if !errors.Is(err, appErrors.ErrTeamNotFound) {
+			t.Errorf("missing team: got %v, want %v", err, appErrors.ErrTeamNotFound)
+		}
+
+		err = test.service.UpdateTeam(context.Background(), team.ID, dtos.CreateOrUpdateTeamRequest{Name: "Borealis", LeaderID: leader.ID})
+		if !errors.Is(err, appErrors.ErrTeamAlreadyExists) {
+			t.Errorf("taken name: got %v, want %v", err, appErrors.ErrTeamAlreadyExists)
+		}
+		if name := test.store.Teams[team.ID].Name; name != "Atlas" {
+			t.Errorf("team renamed to %q", name)
+		}
+	})
+
+	t.Run("new leader without a team joins it", func(t *testing.T) {
+		test := newTeamsServiceTest()
+		team := test.team(t, "Atlas", test.user(t, "leader"))
+		newLeader := test.user(t, "new-leader")
+
+		err := test.service.UpdateTeam(context.Background(), team.ID, dtos.CreateOrUpdateTeamRequest{Name: "Atlas", LeaderID: newLeader.ID})
+		if err != nil {
+			t.Fatalf("UpdateTeam: %v", err)
+		}
+
+		if leaderID := test.store.Teams[team.ID].LeaderID; leaderID != newLeader.ID {
+			t.Errorf("team led by %d, want %d", leaderID, newLeader.ID)
+		}
+		if current := test.store.Users[newLeader.ID].CurrentTeamID; current == nil || *current != team.ID {
+			t.Errorf("new leader is in team %v, want %d", current, team.ID)
+		}
+		membership, _ := test.members.FindActiveMemberByUserID(nil, newLeader.ID)
+		if membership == nil || membership.TeamID != team.ID {
+			t.Errorf("new leader has active membership %+v, want one in team %d", membership, team.ID)
+		}
+		want := []string{models.DomainEventTeamLeaderChanged, models.DomainEventTeamMemberAdded}
+		if got := test.store.EventTypes(); !slices.Equal(got, want) {
+			t.Errorf("recorded %v, want %v", got, want)
+		}
+	})
+
+	t.Run("new leader from the team keeps their membership", func(t *testing.T) {
+		test := newTeamsServiceTest()
+		team := test.team(t, "Atlas", test.user(t, "leader"))
+		member := test.user(t, "member")
+		test.join(t, member, team)
+		memberships := len(test.store.TeamMembers)
+
+		err := test.service.UpdateTeam(context.Background(), team.ID, dtos.CreateOrUpdateTeamRequest{Name: "Atlas", LeaderID: member.ID})
+		if err != nil {
+			t.Fatalf("UpdateTeam: %v", err)
+		}
+
+		if leaderID := test.store.Teams[team.ID].LeaderID; leaderID != member.ID {
+			t.Errorf("team led by %d, want %d", leaderID, member.ID)
+		}
+		if len(test.store.TeamMembers) != memberships {
+			t.Errorf("%d memberships, want %d", len(test.store.TeamMembers), memberships)
+		}
+		want := []string{models.DomainEventTeamLeaderChanged}
+		if got := test.store.EventTypes(); !slices.Equal(got, want) {
+			t.Errorf("recorded %v, want %v", got, want)
+		}
+	})
+
+	t.Run("new leader from another team is rejected and nothing changes", func(t *testing.T) {
+		test := newTeamsServiceTest()
+		leader := test.user(t, "leader")
+		team := test.team(t, "Atlas", leader)
+		otherTeam := test.team(t, "Borealis", test.user(t, "other-leader"))
+		member := test.user(t, "member")
+		test.join(t, member, otherTeam)
+
+		err := test.service.UpdateTeam(context.Background(), team.ID, dtos.CreateOrUpdateTeamRequest{Name: "Atlas", LeaderID: member.ID})
+		if !errors.Is(err, appErrors.ErrTeamLeaderAlreadyInAnotherTeam) {
+			t.Fatalf("got %v, want %v", err, appErrors.ErrTeamLeaderAlreadyInAnotherTeam)
+		}
+
+		if leaderID := test.store.Teams[team.ID].LeaderID; leaderID != leader.ID {
+			t.Errorf("team led by %d, want %d", leaderID, leader.ID)
+		}
+		if current := test.store.Users[member.ID].CurrentTeamID; current == nil || *current != otherTeam.ID {
+			t.Errorf("member is in team %v, want %d", current, otherTeam.ID)
+		}
+		if len(test.store.Events) != 0 {
+			t.Errorf("recorded %v, want no events", test.store.EventTypes())
+		}
+	})
+
+	t.Run("missing new leader is rejected", func(t *testing.T) {
+		test := newTeamsServiceTest()
+		leader := test.user(t, "leader")
+		team := test.team(t, "Atlas", leader)
+
+		err := test.service.UpdateTeam(context.Background(), team.ID, dtos.CreateOrUpdateTeamRequest{Name: "Atlas", LeaderID: leader.ID + 100})
+		if !errors.Is(err, appErrors.ErrUserNotFound) {
+			t.Fatalf("got %v, want %v", err, appErrors.ErrUserNotFound)
+		}
+		if leaderID := test.store.Teams[team.ID].LeaderID; leaderID != leader.ID {
+			t.Errorf("team led by %d, want %d", leaderID, leader.ID)
+		}
+	})
+}
@@ -0,0 +1,424 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const maxDailyHours = 24
+
+type TimesheetService interface {
+	GetProjectsForTimesheet(c context.Context, userID uint) (*dtos.TimesheetProjectsResponse, error)
+	GetTimesheet(c context.Context, userID uint, day time.Time) (*dtos.Timesheet, error)
+	SaveTimeEntry(c context.Context, userID uint, req dtos.SaveTimeEntryRequest) (*dtos.Timesheet, error)
+	DeleteTimeEntry(c context.Context, userID, entryID uint) error
+	SubmitTimesheet(c context.Context, userID uint, req dtos.SubmitTimesheetRequest) (*dtos.Timesheet, error)
+	ListPendingTimesheets(c context.Context, leaderID uint) (*dtos.PendingTimesheetsResponse, error)
+	ApproveTimesheet(c context.Context, id, reviewerID uint, req dtos.ReviewTimesheetRequest) error
+	RejectTimesheet(c context.Context, id, reviewerID uint, req dtos.ReviewTimesheetRequest) error
+	GetProjectEffortReport(c context.Context, projectID uint, from, to time.Time) (*dtos.ProjectEffortReport, error)
+}
+
+type timesheetService struct {
+	db                     *gorm.DB
+	timesheetRepository    repositories.TimesheetRepository
+	timeEntryRepository    repositories.TimeEntryRepository
+	projectRepository      repositories.ProjectRepository
+	userRepository         repositories.UserRepository
+	teamRepository         repositories.TeamsRepository
+	notificationRepository repositories.NotificationRepository
+}
+
+func NewTimesheetService(
+	db *gorm.DB,
+	timesheetRepository repositories.TimesheetRepository,
+	timeEntryRepository repositories.TimeEntryRepository,
+	projectRepository repositories.ProjectRepository,
+	userRepository repositories.UserRepository,
+	teamRepository repositories.TeamsRepository,
+	notificationRepository repositories.NotificationRepository) TimesheetService {
+	return &timesheetService{
+		db:                     db,
+		timesheetRepository:    timesheetRepository,
+		timeEntryRepository:    timeEntryRepository,
+		projectRepository:      projectRepository,
+		userRepository:         userRepository,
+		teamRepository:         teamRepository,
+		notificationRepository: notificationRepository,
+	}
+}
+
+// GetProjectsForTimesheet lists the projects the user is a member of and can log time to
+func (s *timesheetService) GetProjectsForTimesheet(c context.Context, userID uint) (*dtos.TimesheetProjectsResponse, error) {
+	projects, err := s.projectRepository.FindByMemberID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.TimesheetProjectsResponse{Projects: helpers.MapProjectsToProjectSummaries(projects)}, nil
+}
+
+// GetTimesheet returns the user's timesheet of the week containing day, or an empty draft when nothing is logged yet
+func (s *timesheetService) GetTimesheet(c context.Context, userID uint, day time.Time) (*dtos.Timesheet, error) {
+	weekStart := startOfWeek(localDate(day))
+	timesheet, err := s.timesheetRepository.FindByUserIDAndWeekStart(s.db.WithContext(c), userID, weekStart)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if timesheet != nil {
+		return helpers.MapTimesheetToDto(timesheet), nil
+	}
+
+	user, err := s.userRepository.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapTimesheetToDto(&models.Timesheet{
+		User:      *user,
+		WeekStart: weekStart,
+		Status:    models.TimesheetStatusDraft,
+	}), nil
+}
+
+// SaveTimeEntry creates or replaces the user's entry for a project and day.
+// Users can only log to projects they are members of, within the project dates and up to today.
+func (s *timesheetService) SaveTimeEntry(c context.Context, userID uint, req dtos.SaveTimeEntryRequest) (*dtos.Timesheet, error) {
+	workDate := localDate(req.WorkDate.Time)
+	if workDate.After(startOfDay(time.Now())) {
+		return nil, appErrors.ErrTimeEntryFutureDate
+	}
+
+	project, err := s.projectRepository.FindByID(s.db.WithContext(c), req.ProjectID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrProjectNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	isMember, err := s.projectRepository.IsMember(s.db.WithContext(c), project.ID, userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if !isMember {
+		return nil, appErrors.ErrTimeEntryNotProjectMember
+	}
+	if (project.StartDate != nil && workDate.Before(localDate(*project.StartDate))) ||
+		(project.EndDate != nil && workDate.After(localDate(*project.EndDate))) {
+		return nil, appErrors.ErrTimeEntryOutsideProjectDates
+	}
+
+	weekStart := startOfWeek(workDate)
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		timesheet, err := s.findOrCreateTimesheet(tx, userID, weekStart)
+		if err != nil {
+			return err
+		}
+		if !timesheet.IsEditable() {
+			return appErrors.ErrTimesheetNotEditable
+		}
+
+		entry, err := s.timeEntryRepository.FindByUserIDProjectIDAndDate(tx, userID, project.ID, workDate)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		var excludeID uint
+		if entry != nil {
+			excludeID = entry.ID
+		}
+		loggedHours, err := s.timeEntryRepository.SumHoursByUserIDAndDate(tx, userID, workDate, excludeID)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if loggedHours+req.Hours > maxDailyHours {
+			return appErrors.ErrTimeEntryDailyHoursExceeded
+		}
+
+		if entry != nil {
+			entry.Hours = req.Hours
+			entry.Description = req.Description
+			if err := s.timeEntryRepository.Update(tx, entry); err != nil {
+				return appErrors.ErrInternalServerError
+			}
+			return nil
+		}
+		entry = &models.TimeEntry{
+			TimesheetID: timesheet.ID,
+			UserID:      userID,
+			ProjectID:   project.ID,
+			WorkDate:    workDate,
+			Hours:       req.Hours,
+			Description: req.Description,
+		}
+		if err := s.timeEntryRepository.Create(tx, entry); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTimesheet(c, userID, weekStart)
+}
+
+func (s *timesheetService) DeleteTimeEntry(c context.Context, userID, entryID uint) error {
+	entry, err := s.timeEntryRepository.FindByID(s.db.WithContext(c), entryID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.ErrTimeEntryNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+	if entry.UserID != userID {
+		return appErrors.ErrTimeEntryNotFound
+	}
+	if !entry.Timesheet.IsEditable() {
+		return appErrors.ErrTimesheetNotEditable
+	}
+
+	if err := s.timeEntryRepository.Delete(s.db.WithContext(c), entry.ID); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+// SubmitTimesheet sends the week to the leader of the user's current team for approval.
+// Timesheets of the team leader are approved straight away since nobody else leads the team.
+func (s *timesheetService) SubmitTimesheet(c context.Context, userID uint, req dtos.SubmitTimesheetRequest) (*dtos.Timesheet, error) {
+	weekStart := startOfWeek(localDate(req.WeekStart.Time))
+	timesheet, err := s.timesheetRepository.FindByUserIDAndWeekStart(s.db.WithContext(c), userID, weekStart)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if timesheet == nil || len(timesheet.Entries) == 0 {
+		return nil, appErrors.ErrTimesheetEmpty
+	}
+	if !timesheet.IsEditable() {
+		return nil, appErrors.ErrTimesheetNotEditable
+	}
+	if timesheet.User.CurrentTeamID == nil {
+		return nil, appErrors.ErrTimesheetUserNotInTeam
+	}
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), *timesheet.User.CurrentTeamID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	now := time.Now()
+	timesheet.Status = models.TimesheetStatusSubmitted
+	timesheet.SubmittedAt = &now
+	timesheet.ReviewerID = nil
+	timesheet.ReviewNote = nil
+	timesheet.ReviewedAt = nil
+	if team.LeaderID == userID {
+		timesheet.Status = models.TimesheetStatusApproved
+		timesheet.ReviewerID = &userID
+		timesheet.ReviewedAt = &now
+	}
+
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.timesheetRepository.UpdateStatus(tx, timesheet); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if timesheet.Status != models.TimesheetStatusSubmitted {
+			return nil
+		}
+		notification := &models.Notification{
+			UserID: team.LeaderID,
+			Title:  fmt.Sprintf("Timesheet from %s", timesheet.User.Name),
+			Content: fmt.Sprintf("%s submitted the timesheet of the week starting %s and is waiting for your approval.",
+				timesheet.User.Name, weekStart.Format("2006-01-02")),
+		}
+		if err := s.notificationRepository.Create(tx, notification); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTimesheet(c, userID, weekStart)
+}
+
+// ListPendingTimesheets lists the submitted timesheets waiting for the user's approval as a team leader
+func (s *timesheetService) ListPendingTimesheets(c context.Context, leaderID uint) (*dtos.PendingTimesheetsResponse, error) {
+	timesheets, err := s.timesheetRepository.FindSubmittedByLeaderID(s.db.WithContext(c), leaderID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.PendingTimesheetsResponse{Timesheets: helpers.MapTimesheetsToDtos(timesheets)}, nil
+}
+
+func (s *timesheetService) ApproveTimesheet(c context.Context, id, reviewerID uint, req dtos.ReviewTimesheetRequest) error {
+	return s.reviewTimesheet(c, id, reviewerID, models.TimesheetStatusApproved, req.Note)
+}
+
+func (s *timesheetService) RejectTimesheet(c context.Context, id, reviewerID uint, req dtos.ReviewTimesheetRequest) error {
+	return s.reviewTimesheet(c, id, reviewerID, models.TimesheetStatusRejected, req.Note)
+}
+
+// reviewTimesheet lets the leader of the user's current team approve or reject a submitted timesheet.
+// A rejected timesheet becomes editable again and can be resubmitted.
+func (s *timesheetService) reviewTimesheet(c context.Context, id, reviewerID uint, status string, note *string) error {
+	timesheet, err := s.timesheetRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return appErrors.ErrTimesheetNotFound
+		}
+		return appErrors.ErrInternalServerError
+	}
+	if timesheet.UserID == reviewerID || timesheet.User.CurrentTeamID == nil {
+		return appErrors.ErrForbidden
+	}
+	team, err := s.teamRepository.FindByID(s.db.WithContext(c), *timesheet.User.CurrentTeamID)
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if team.LeaderID != reviewerID {
+		return appErrors.ErrForbidden
+	}
+	if timesheet.Status != models.TimesheetStatusSubmitted {
+		return appErrors.ErrTimesheetNotSubmitted
+	}
+
+	now := time.Now()
+	timesheet.Status = status
+	timesheet.ReviewerID = &reviewerID
+	timesheet.ReviewNote = note
+	timesheet.ReviewedAt = &now
+
+	return s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.timesheetRepository.UpdateStatus(tx, timesheet); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		content := fmt.Sprintf("Your timesheet of the week starting %s was %s.", timesheet.WeekStart.Format("2006-01-02"), status)
+		if note != nil && *note != "" {
+			content += " Note: " + *note
+		}
+		notification := &models.Notification{
+			UserID:  timesheet.UserID,
+			Title:   fmt.Sprintf("Timesheet %s", status),
+			Content: content,
+		}
+		if err := s.notificationRepository.Create(tx, notification); err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		return nil
+	})
+}
+
+// GetProjectEffortReport sums approved hours logged to the project in [from, to], per member and per week
+func (s *timesheetService) GetProjectEffortReport(c context.Context, projectID uint, from, to time.Time) (*dtos.ProjectEffortReport, error) {
+	project, err := s.projectRepository.FindByID(s.db.WithContext(c), projectID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrProjectNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	entries, err := s.timeEntryRepository.FindApprovedByProjectIDBetween(s.db.WithContext(c), projectID, localDate(from), localDate(to))
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	type rowKey struct {
+		userID    uint
+		weekStart string
+	}
+	rowsByKey := make(map[rowKey]*dtos.ProjectEffortRow)
+	membersByID := make(map[uint]*dtos.ProjectEffortMemberTotal)
+	memberDays := make(map[uint]map[string]bool)
+	rows := make([]*dtos.ProjectEffortRow, 0)
+	members := make([]*dtos.ProjectEffortMemberTotal, 0)
+	totalHours := 0.0
+	for _, entry := range entries {
+		key := rowKey{userID: entry.UserID, weekStart: startOfWeek(entry.WorkDate).Format("2006-01-02")}
+		row, ok := rowsByKey[key]
+		if !ok {
+			row = &dtos.ProjectEffortRow{UserID: entry.UserID, UserName: entry.User.Name, UserEmail: entry.User.Email, WeekStart: key.weekStart}
+			rowsByKey[key] = row
+			rows = append(rows, row)
+		}
+		row.Hours += entry.Hours
+
+		member, ok := membersByID[entry.UserID]
+		if !ok {
+			member = &dtos.ProjectEffortMemberTotal{UserID: entry.UserID, UserName: entry.User.Name, UserEmail: entry.User.Email}
+			membersByID[entry.UserID] = member
+			memberDays[entry.UserID] = make(map[string]bool)
+			members = append(members, member)
+		}
+		member.Hours += entry.Hours
+		memberDays[entry.UserID][entry.WorkDate.Format("2006-01-02")] = true
+
+		totalHours += entry.Hours
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].WeekStart != rows[j].WeekStart {
+			return rows[i].WeekStart < rows[j].WeekStart
+		}
+		return rows[i].UserName < rows[j].UserName
+	})
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].UserName < members[j].UserName
+	})
+
+	report := &dtos.ProjectEffortReport{
+		Project:    *helpers.MapProjectToProjectSummary(project),
+		From:       from,
+		To:         to,
+		Rows:       make([]dtos.ProjectEffortRow, 0, len(rows)),
+		Members:    make([]dtos.ProjectEffortMemberTotal, 0, len(members)),
+		TotalHours: totalHours,
+	}
+	for _, row := range rows {
+		report.Rows = append(report.Rows, *row)
+	}
+	for _, member := range members {
+		member.Days = len(memberDays[member.UserID])
+		report.Members = append(report.Members, *member)
+	}
+	return report, nil
+}
+
+func (s *timesheetService) findOrCreateTimesheet(tx *gorm.DB, userID uint, weekStart time.Time) (*models.Timesheet, error) {
+	timesheet, err := s.timesheetRepository.FindByUserIDAndWeekStart(tx, userID, weekStart)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if timesheet != nil {
+		return timesheet, nil
+	}
+
+	timesheet = &models.Timesheet{
+		UserID:    userID,
+		WeekStart: weekStart,
+		Status:    models.TimesheetStatusDraft,
+	}
+	if err := s.timesheetRepository.Create(tx, timesheet); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return timesheet, nil
+}
+
+// startOfWeek returns the Monday of the week containing day
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return startOfDay(day).AddDate(0, 0, -offset)
+}
@@ -0,0 +1,1158 @@
+// Code generated by cmd/tracegen. DO NOT EDIT.
+
+package services
+
+import (
+	"context"
+	"time"
+	"trieu_mock_project_go/internal/dtos"
+	"trieu_mock_project_go/internal/oidc"
+	"trieu_mock_project_go/internal/tracing"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+// TraceAPITokenService runs the methods of next in spans
+func TraceAPITokenService(next APITokenService) APITokenService {
+	return &tracedAPITokenService{next: next}
+}
+
+type tracedAPITokenService struct {
+	next APITokenService
+}
+
+func (s *tracedAPITokenService) ListTokens(ctx context.Context, p1 uint) (*dtos.APITokenListResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "APITokenService.ListTokens")
+	r0, err := s.next.ListTokens(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAPITokenService) CreateToken(ctx context.Context, p1 uint, p2 dtos.CreateAPITokenRequest) (*dtos.CreateAPITokenResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "APITokenService.CreateToken")
+	r0, err := s.next.CreateToken(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAPITokenService) RevokeToken(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "APITokenService.RevokeToken")
+	err := s.next.RevokeToken(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedAPITokenService) Authenticate(ctx context.Context, p1 string, p2 string) (*models.APIToken, error) {
+	ctx, span := tracing.StartSpan(ctx, "APITokenService.Authenticate")
+	r0, err := s.next.Authenticate(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceActivityLogService runs the methods of next in spans
+func TraceActivityLogService(next ActivityLogService) ActivityLogService {
+	return &tracedActivityLogService{next: next}
+}
+
+type tracedActivityLogService struct {
+	next ActivityLogService
+}
+
+func (s *tracedActivityLogService) SearchActivityLogs(ctx context.Context, p1 dtos.ActivityLogSearchRequest) (*dtos.ActivityLogSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "ActivityLogService.SearchActivityLogs")
+	r0, err := s.next.SearchActivityLogs(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedActivityLogService) SubscriberName() string {
+	return s.next.SubscriberName()
+}
+
+func (s *tracedActivityLogService) HandleEvent(tx *gorm.DB, p1 *models.OutboxEvent) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "ActivityLogService.HandleEvent")
+	tx = tx.WithContext(ctx)
+	err := s.next.HandleEvent(tx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TraceAdminSessionService runs the methods of next in spans
+func TraceAdminSessionService(next AdminSessionService) AdminSessionService {
+	return &tracedAdminSessionService{next: next}
+}
+
+type tracedAdminSessionService struct {
+	next AdminSessionService
+}
+
+func (s *tracedAdminSessionService) ListSessions(ctx context.Context, p1 uint, p2 string) ([]dtos.AdminSession, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminSessionService.ListSessions")
+	r0, err := s.next.ListSessions(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAdminSessionService) RevokeSession(ctx context.Context, p1 uint, p2 uint, p3 string) error {
+	ctx, span := tracing.StartSpan(ctx, "AdminSessionService.RevokeSession")
+	err := s.next.RevokeSession(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedAdminSessionService) RevokeOtherSessions(ctx context.Context, p1 uint, p2 string) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminSessionService.RevokeOtherSessions")
+	r0, err := s.next.RevokeOtherSessions(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAdminSessionService) DeleteExpiredSessions(ctx context.Context, p1 time.Time) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "AdminSessionService.DeleteExpiredSessions")
+	r0, err := s.next.DeleteExpiredSessions(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceAuthService runs the methods of next in spans
+func TraceAuthService(next AuthService) AuthService {
+	return &tracedAuthService{next: next}
+}
+
+type tracedAuthService struct {
+	next AuthService
+}
+
+func (s *tracedAuthService) Login(ctx context.Context, p1 string, p2 string, p3 LoginMeta) (*LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.Login")
+	r0, err := s.next.Login(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAuthService) CompleteSSOLogin(ctx context.Context, p1 *models.User, p2 LoginMeta) (*LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.CompleteSSOLogin")
+	r0, err := s.next.CompleteSSOLogin(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAuthService) RejectSSOLogin(ctx context.Context, p1 string, p2 LoginMeta) error {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.RejectSSOLogin")
+	err := s.next.RejectSSOLogin(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedAuthService) CompleteSecondFactor(ctx context.Context, p1 uint, p2 string, p3 LoginMeta) (*models.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.CompleteSecondFactor")
+	r0, err := s.next.CompleteSecondFactor(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAuthService) CompleteTwoFactorSetup(ctx context.Context, p1 uint, p2 string, p3 LoginMeta) (*models.User, []string, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.CompleteTwoFactorSetup")
+	r0, r1, err := s.next.CompleteTwoFactorSetup(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, r1, err
+}
+
+func (s *tracedAuthService) VerifyPassword(p0 string, p1 string) bool {
+	return s.next.VerifyPassword(p0, p1)
+}
+
+func (s *tracedAuthService) CheckActive(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.CheckActive")
+	err := s.next.CheckActive(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedAuthService) CheckAccessToken(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.CheckAccessToken")
+	err := s.next.CheckAccessToken(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedAuthService) ChangePassword(ctx context.Context, p1 uint, p2 string, p3 string, p4 string) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.ChangePassword")
+	r0, err := s.next.ChangePassword(ctx, p1, p2, p3, p4)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAuthService) UnlockUser(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.UnlockUser")
+	err := s.next.UnlockUser(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedAuthService) GetAccountLoginSecurity(ctx context.Context, p1 uint) (*dtos.AccountLoginSecurity, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.GetAccountLoginSecurity")
+	r0, err := s.next.GetAccountLoginSecurity(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedAuthService) SearchLoginAttempts(ctx context.Context, p1 dtos.LoginAttemptSearchRequest) (*dtos.LoginAttemptSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "AuthService.SearchLoginAttempts")
+	r0, err := s.next.SearchLoginAttempts(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceCareerTrackService runs the methods of next in spans
+func TraceCareerTrackService(next CareerTrackService) CareerTrackService {
+	return &tracedCareerTrackService{next: next}
+}
+
+type tracedCareerTrackService struct {
+	next CareerTrackService
+}
+
+func (s *tracedCareerTrackService) GetAllCareerTracksSummary(ctx context.Context) []dtos.CareerTrackSummary {
+	ctx, span := tracing.StartSpan(ctx, "CareerTrackService.GetAllCareerTracksSummary")
+	r0 := s.next.GetAllCareerTracksSummary(ctx)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+func (s *tracedCareerTrackService) SearchCareerTracks(ctx context.Context, p1 int, p2 int) (*dtos.CareerTrackSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "CareerTrackService.SearchCareerTracks")
+	r0, err := s.next.SearchCareerTracks(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedCareerTrackService) GetCareerTrackByID(ctx context.Context, p1 uint) (*dtos.CareerTrack, error) {
+	ctx, span := tracing.StartSpan(ctx, "CareerTrackService.GetCareerTrackByID")
+	r0, err := s.next.GetCareerTrackByID(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedCareerTrackService) CreateCareerTrack(ctx context.Context, p1 dtos.CreateOrUpdateCareerTrackRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "CareerTrackService.CreateCareerTrack")
+	err := s.next.CreateCareerTrack(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedCareerTrackService) UpdateCareerTrack(ctx context.Context, p1 uint, p2 dtos.CreateOrUpdateCareerTrackRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "CareerTrackService.UpdateCareerTrack")
+	err := s.next.UpdateCareerTrack(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedCareerTrackService) DeleteCareerTrack(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "CareerTrackService.DeleteCareerTrack")
+	err := s.next.DeleteCareerTrack(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TraceCelebrationService runs the methods of next in spans
+func TraceCelebrationService(next CelebrationService) CelebrationService {
+	return &tracedCelebrationService{next: next}
+}
+
+type tracedCelebrationService struct {
+	next CelebrationService
+}
+
+func (s *tracedCelebrationService) GetUpcomingCelebrations(ctx context.Context, p1 uint, p2 int) (*dtos.UpcomingCelebrationsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "CelebrationService.GetUpcomingCelebrations")
+	r0, err := s.next.GetUpcomingCelebrations(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedCelebrationService) SendCelebrationReminders(ctx context.Context, p1 time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "CelebrationService.SendCelebrationReminders")
+	r0, err := s.next.SendCelebrationReminders(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceChatService runs the methods of next in spans
+func TraceChatService(next ChatService) ChatService {
+	return &tracedChatService{next: next}
+}
+
+type tracedChatService struct {
+	next ChatService
+}
+
+func (s *tracedChatService) HandleCommand(ctx context.Context, p1 string) string {
+	ctx, span := tracing.StartSpan(ctx, "ChatService.HandleCommand")
+	r0 := s.next.HandleCommand(ctx, p1)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+// TraceHealthService runs the methods of next in spans
+func TraceHealthService(next HealthService) HealthService {
+	return &tracedHealthService{next: next}
+}
+
+type tracedHealthService struct {
+	next HealthService
+}
+
+func (s *tracedHealthService) Readiness(ctx context.Context) *dtos.HealthResponse {
+	ctx, span := tracing.StartSpan(ctx, "HealthService.Readiness")
+	r0 := s.next.Readiness(ctx)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+func (s *tracedHealthService) MarkShuttingDown() {
+	s.next.MarkShuttingDown()
+}
+
+// TraceLDAPSyncService runs the methods of next in spans
+func TraceLDAPSyncService(next LDAPSyncService) LDAPSyncService {
+	return &tracedLDAPSyncService{next: next}
+}
+
+type tracedLDAPSyncService struct {
+	next LDAPSyncService
+}
+
+func (s *tracedLDAPSyncService) ScheduleEnabled() bool {
+	return s.next.ScheduleEnabled()
+}
+
+func (s *tracedLDAPSyncService) SyncInterval() time.Duration {
+	return s.next.SyncInterval()
+}
+
+func (s *tracedLDAPSyncService) Sync(ctx context.Context, p1 string, p2 bool) (*dtos.LDAPSyncRun, error) {
+	ctx, span := tracing.StartSpan(ctx, "LDAPSyncService.Sync")
+	r0, err := s.next.Sync(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLDAPSyncService) SearchRuns(ctx context.Context, p1 dtos.LDAPSyncRunSearchRequest) (*dtos.LDAPSyncRunSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "LDAPSyncService.SearchRuns")
+	r0, err := s.next.SearchRuns(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLDAPSyncService) GetRun(ctx context.Context, p1 uint) (*dtos.LDAPSyncRun, error) {
+	ctx, span := tracing.StartSpan(ctx, "LDAPSyncService.GetRun")
+	r0, err := s.next.GetRun(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceLeaveService runs the methods of next in spans
+func TraceLeaveService(next LeaveService) LeaveService {
+	return &tracedLeaveService{next: next}
+}
+
+type tracedLeaveService struct {
+	next LeaveService
+}
+
+func (s *tracedLeaveService) CreateLeave(ctx context.Context, p1 uint, p2 dtos.CreateLeaveRequest) (*dtos.LeaveRequest, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.CreateLeave")
+	r0, err := s.next.CreateLeave(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) ListMyLeaves(ctx context.Context, p1 uint, p2 int, p3 int) (*dtos.ListLeaveRequestsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.ListMyLeaves")
+	r0, err := s.next.ListMyLeaves(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) ListPendingLeaves(ctx context.Context, p1 uint) (*dtos.PendingLeaveRequestsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.ListPendingLeaves")
+	r0, err := s.next.ListPendingLeaves(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) ApproveLeave(ctx context.Context, p1 uint, p2 uint, p3 dtos.ReviewLeaveRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.ApproveLeave")
+	err := s.next.ApproveLeave(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedLeaveService) RejectLeave(ctx context.Context, p1 uint, p2 uint, p3 dtos.ReviewLeaveRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.RejectLeave")
+	err := s.next.RejectLeave(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedLeaveService) CancelLeave(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.CancelLeave")
+	err := s.next.CancelLeave(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedLeaveService) GetTeamCalendar(ctx context.Context, p1 uint, p2 time.Time, p3 time.Time) (*dtos.TeamLeaveCalendarResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.GetTeamCalendar")
+	r0, err := s.next.GetTeamCalendar(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) GetUserICalendar(ctx context.Context, p1 uint) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.GetUserICalendar")
+	r0, err := s.next.GetUserICalendar(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) GetTeamICalendar(ctx context.Context, p1 uint) (string, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.GetTeamICalendar")
+	r0, err := s.next.GetTeamICalendar(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) CreateCalendarFeed(ctx context.Context, p1 uint) (*dtos.CalendarFeedResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.CreateCalendarFeed")
+	r0, err := s.next.CreateCalendarFeed(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedLeaveService) RevokeCalendarFeed(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.RevokeCalendarFeed")
+	err := s.next.RevokeCalendarFeed(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedLeaveService) AuthenticateCalendarFeed(ctx context.Context, p1 string) (uint, error) {
+	ctx, span := tracing.StartSpan(ctx, "LeaveService.AuthenticateCalendarFeed")
+	r0, err := s.next.AuthenticateCalendarFeed(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceMailService runs the methods of next in spans
+func TraceMailService(next MailService) MailService {
+	return &tracedMailService{next: next}
+}
+
+type tracedMailService struct {
+	next MailService
+}
+
+func (s *tracedMailService) PollInterval() time.Duration {
+	return s.next.PollInterval()
+}
+
+func (s *tracedMailService) SubscriberName() string {
+	return s.next.SubscriberName()
+}
+
+func (s *tracedMailService) HandleEvent(tx *gorm.DB, p1 *models.OutboxEvent) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "MailService.HandleEvent")
+	tx = tx.WithContext(ctx)
+	err := s.next.HandleEvent(tx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedMailService) QueuePasswordReset(tx *gorm.DB, p1 *models.User, p2 string, p3 time.Time) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "MailService.QueuePasswordReset")
+	tx = tx.WithContext(ctx)
+	err := s.next.QueuePasswordReset(tx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedMailService) QueueLeaveReview(tx *gorm.DB, p1 *models.LeaveRequest) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "MailService.QueueLeaveReview")
+	tx = tx.WithContext(ctx)
+	err := s.next.QueueLeaveReview(tx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedMailService) SendDue(ctx context.Context, p1 time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "MailService.SendDue")
+	r0, err := s.next.SendDue(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedMailService) DeleteFinishedEmails(ctx context.Context, p1 time.Time) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "MailService.DeleteFinishedEmails")
+	r0, err := s.next.DeleteFinishedEmails(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceNotificationService runs the methods of next in spans
+func TraceNotificationService(next NotificationService) NotificationService {
+	return &tracedNotificationService{next: next}
+}
+
+type tracedNotificationService struct {
+	next NotificationService
+}
+
+func (s *tracedNotificationService) ListNotifications(ctx context.Context, p1 uint, p2 int, p3 int) (*dtos.ListNotificationsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "NotificationService.ListNotifications")
+	r0, err := s.next.ListNotifications(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedNotificationService) MarkAsRead(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "NotificationService.MarkAsRead")
+	err := s.next.MarkAsRead(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedNotificationService) SubscriberName() string {
+	return s.next.SubscriberName()
+}
+
+func (s *tracedNotificationService) HandleEvent(tx *gorm.DB, p1 *models.OutboxEvent) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "NotificationService.HandleEvent")
+	tx = tx.WithContext(ctx)
+	err := s.next.HandleEvent(tx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TraceOIDCService runs the methods of next in spans
+func TraceOIDCService(next OIDCService) OIDCService {
+	return &tracedOIDCService{next: next}
+}
+
+type tracedOIDCService struct {
+	next OIDCService
+}
+
+func (s *tracedOIDCService) Enabled() bool {
+	return s.next.Enabled()
+}
+
+func (s *tracedOIDCService) ProviderName() string {
+	return s.next.ProviderName()
+}
+
+func (s *tracedOIDCService) RedirectURI(p0 string) string {
+	return s.next.RedirectURI(p0)
+}
+
+func (s *tracedOIDCService) BeginLogin(ctx context.Context, p1 string) (*oidc.AuthRequest, string, error) {
+	ctx, span := tracing.StartSpan(ctx, "OIDCService.BeginLogin")
+	r0, r1, err := s.next.BeginLogin(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, r1, err
+}
+
+func (s *tracedOIDCService) FinishLogin(ctx context.Context, p1 string, p2 string, p3 *oidc.AuthRequest, p4 LoginMeta) (*LoginResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "OIDCService.FinishLogin")
+	r0, err := s.next.FinishLogin(ctx, p1, p2, p3, p4)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceOutboxService runs the methods of next in spans
+func TraceOutboxService(next OutboxService) OutboxService {
+	return &tracedOutboxService{next: next}
+}
+
+type tracedOutboxService struct {
+	next OutboxService
+}
+
+func (s *tracedOutboxService) PollInterval() time.Duration {
+	return s.next.PollInterval()
+}
+
+func (s *tracedOutboxService) Record(tx *gorm.DB, p1 string, p2 interface{}) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "OutboxService.Record")
+	tx = tx.WithContext(ctx)
+	err := s.next.Record(tx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedOutboxService) DispatchDue(ctx context.Context, p1 time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "OutboxService.DispatchDue")
+	r0, err := s.next.DispatchDue(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedOutboxService) DeleteDispatchedEvents(ctx context.Context, p1 time.Time) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "OutboxService.DeleteDispatchedEvents")
+	r0, err := s.next.DeleteDispatchedEvents(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TracePasswordResetService runs the methods of next in spans
+func TracePasswordResetService(next PasswordResetService) PasswordResetService {
+	return &tracedPasswordResetService{next: next}
+}
+
+type tracedPasswordResetService struct {
+	next PasswordResetService
+}
+
+func (s *tracedPasswordResetService) RequestReset(ctx context.Context, p1 string) error {
+	ctx, span := tracing.StartSpan(ctx, "PasswordResetService.RequestReset")
+	err := s.next.RequestReset(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedPasswordResetService) ResetPassword(ctx context.Context, p1 string, p2 string) error {
+	ctx, span := tracing.StartSpan(ctx, "PasswordResetService.ResetPassword")
+	err := s.next.ResetPassword(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TracePositionService runs the methods of next in spans
+func TracePositionService(next PositionService) PositionService {
+	return &tracedPositionService{next: next}
+}
+
+type tracedPositionService struct {
+	next PositionService
+}
+
+func (s *tracedPositionService) GetAllPositionsSummary(ctx context.Context) []dtos.PositionSummary {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.GetAllPositionsSummary")
+	r0 := s.next.GetAllPositionsSummary(ctx)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+func (s *tracedPositionService) SearchPositions(ctx context.Context, p1 int, p2 int) (*dtos.PositionSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.SearchPositions")
+	r0, err := s.next.SearchPositions(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedPositionService) GetPositionByID(ctx context.Context, p1 uint) (*dtos.Position, error) {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.GetPositionByID")
+	r0, err := s.next.GetPositionByID(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedPositionService) CreatePosition(ctx context.Context, p1 dtos.CreateOrUpdatePositionRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.CreatePosition")
+	err := s.next.CreatePosition(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedPositionService) UpdatePosition(ctx context.Context, p1 uint, p2 dtos.CreateOrUpdatePositionRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.UpdatePosition")
+	err := s.next.UpdatePosition(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedPositionService) DeletePosition(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.DeletePosition")
+	err := s.next.DeletePosition(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedPositionService) GetPromotionReadiness(ctx context.Context, p1 uint) (*dtos.PromotionReadiness, error) {
+	ctx, span := tracing.StartSpan(ctx, "PositionService.GetPromotionReadiness")
+	r0, err := s.next.GetPromotionReadiness(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceProjectService runs the methods of next in spans
+func TraceProjectService(next ProjectService) ProjectService {
+	return &tracedProjectService{next: next}
+}
+
+type tracedProjectService struct {
+	next ProjectService
+}
+
+func (s *tracedProjectService) GetAllProjectSummary(ctx context.Context) []dtos.ProjectSummary {
+	ctx, span := tracing.StartSpan(ctx, "ProjectService.GetAllProjectSummary")
+	r0 := s.next.GetAllProjectSummary(ctx)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+// TraceSeedService runs the methods of next in spans
+func TraceSeedService(next SeedService) SeedService {
+	return &tracedSeedService{next: next}
+}
+
+type tracedSeedService struct {
+	next SeedService
+}
+
+func (s *tracedSeedService) SeedDefaults(ctx context.Context, p1 dtos.SeedAdmin) (*dtos.SeedReport, error) {
+	ctx, span := tracing.StartSpan(ctx, "SeedService.SeedDefaults")
+	r0, err := s.next.SeedDefaults(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedSeedService) SeedDemo(ctx context.Context, p1 dtos.SeedDemoOptions) (*dtos.SeedDemoReport, error) {
+	ctx, span := tracing.StartSpan(ctx, "SeedService.SeedDemo")
+	r0, err := s.next.SeedDemo(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceSkillService runs the methods of next in spans
+func TraceSkillService(next SkillService) SkillService {
+	return &tracedSkillService{next: next}
+}
+
+type tracedSkillService struct {
+	next SkillService
+}
+
+func (s *tracedSkillService) GetAllSkillsSummary(ctx context.Context) []dtos.SkillSummary {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.GetAllSkillsSummary")
+	r0 := s.next.GetAllSkillsSummary(ctx)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+func (s *tracedSkillService) SearchSkills(ctx context.Context, p1 int, p2 int) (*dtos.SkillSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.SearchSkills")
+	r0, err := s.next.SearchSkills(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedSkillService) GetSkillByID(ctx context.Context, p1 uint) (*dtos.SkillSummary, error) {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.GetSkillByID")
+	r0, err := s.next.GetSkillByID(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedSkillService) CreateSkill(ctx context.Context, p1 dtos.CreateOrUpdateSkillRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.CreateSkill")
+	err := s.next.CreateSkill(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedSkillService) UpdateSkill(ctx context.Context, p1 uint, p2 dtos.CreateOrUpdateSkillRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.UpdateSkill")
+	err := s.next.UpdateSkill(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedSkillService) DeleteSkill(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.DeleteSkill")
+	err := s.next.DeleteSkill(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedSkillService) FindSkillHolders(ctx context.Context, p1 string, p2 int) ([]dtos.SkillHolder, error) {
+	ctx, span := tracing.StartSpan(ctx, "SkillService.FindSkillHolders")
+	r0, err := s.next.FindSkillHolders(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceTeamsService runs the methods of next in spans
+func TraceTeamsService(next TeamsService) TeamsService {
+	return &tracedTeamsService{next: next}
+}
+
+type tracedTeamsService struct {
+	next TeamsService
+}
+
+func (s *tracedTeamsService) ListTeams(ctx context.Context, p1 int, p2 int) (*dtos.ListTeamsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.ListTeams")
+	r0, err := s.next.ListTeams(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTeamsService) GetTeamDetails(ctx context.Context, p1 uint) (*dtos.Team, error) {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.GetTeamDetails")
+	r0, err := s.next.GetTeamDetails(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTeamsService) GetTeamMembers(ctx context.Context, p1 uint, p2 int, p3 int) (*dtos.ListTeamMembersResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.GetTeamMembers")
+	r0, err := s.next.GetTeamMembers(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTeamsService) GetTeamMemberHistory(ctx context.Context, p1 uint, p2 int, p3 int) (*dtos.ListTeamMemberHistoryResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.GetTeamMemberHistory")
+	r0, err := s.next.GetTeamMemberHistory(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTeamsService) GetAllTeamsSummary(ctx context.Context) []dtos.TeamSummary {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.GetAllTeamsSummary")
+	r0 := s.next.GetAllTeamsSummary(ctx)
+	tracing.EndSpan(span, nil)
+	return r0
+}
+
+func (s *tracedTeamsService) CreateTeam(ctx context.Context, p1 dtos.CreateOrUpdateTeamRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.CreateTeam")
+	err := s.next.CreateTeam(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTeamsService) UpdateTeam(ctx context.Context, p1 uint, p2 dtos.CreateOrUpdateTeamRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.UpdateTeam")
+	err := s.next.UpdateTeam(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTeamsService) DeleteTeam(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.DeleteTeam")
+	err := s.next.DeleteTeam(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTeamsService) AddMemberToTeam(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.AddMemberToTeam")
+	err := s.next.AddMemberToTeam(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTeamsService) RemoveMemberFromTeam(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "TeamsService.RemoveMemberFromTeam")
+	err := s.next.RemoveMemberFromTeam(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TraceTimesheetService runs the methods of next in spans
+func TraceTimesheetService(next TimesheetService) TimesheetService {
+	return &tracedTimesheetService{next: next}
+}
+
+type tracedTimesheetService struct {
+	next TimesheetService
+}
+
+func (s *tracedTimesheetService) GetProjectsForTimesheet(ctx context.Context, p1 uint) (*dtos.TimesheetProjectsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.GetProjectsForTimesheet")
+	r0, err := s.next.GetProjectsForTimesheet(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTimesheetService) GetTimesheet(ctx context.Context, p1 uint, p2 time.Time) (*dtos.Timesheet, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.GetTimesheet")
+	r0, err := s.next.GetTimesheet(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTimesheetService) SaveTimeEntry(ctx context.Context, p1 uint, p2 dtos.SaveTimeEntryRequest) (*dtos.Timesheet, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.SaveTimeEntry")
+	r0, err := s.next.SaveTimeEntry(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTimesheetService) DeleteTimeEntry(ctx context.Context, p1 uint, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.DeleteTimeEntry")
+	err := s.next.DeleteTimeEntry(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTimesheetService) SubmitTimesheet(ctx context.Context, p1 uint, p2 dtos.SubmitTimesheetRequest) (*dtos.Timesheet, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.SubmitTimesheet")
+	r0, err := s.next.SubmitTimesheet(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTimesheetService) ListPendingTimesheets(ctx context.Context, p1 uint) (*dtos.PendingTimesheetsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.ListPendingTimesheets")
+	r0, err := s.next.ListPendingTimesheets(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTimesheetService) ApproveTimesheet(ctx context.Context, p1 uint, p2 uint, p3 dtos.ReviewTimesheetRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.ApproveTimesheet")
+	err := s.next.ApproveTimesheet(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTimesheetService) RejectTimesheet(ctx context.Context, p1 uint, p2 uint, p3 dtos.ReviewTimesheetRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.RejectTimesheet")
+	err := s.next.RejectTimesheet(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTimesheetService) GetProjectEffortReport(ctx context.Context, p1 uint, p2 time.Time, p3 time.Time) (*dtos.ProjectEffortReport, error) {
+	ctx, span := tracing.StartSpan(ctx, "TimesheetService.GetProjectEffortReport")
+	r0, err := s.next.GetProjectEffortReport(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceTwoFactorService runs the methods of next in spans
+func TraceTwoFactorService(next TwoFactorService) TwoFactorService {
+	return &tracedTwoFactorService{next: next}
+}
+
+type tracedTwoFactorService struct {
+	next TwoFactorService
+}
+
+func (s *tracedTwoFactorService) IsRequired(p0 *models.User) bool {
+	return s.next.IsRequired(p0)
+}
+
+func (s *tracedTwoFactorService) GetStatus(ctx context.Context, p1 uint) (*dtos.TwoFactorStatus, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.GetStatus")
+	r0, err := s.next.GetStatus(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTwoFactorService) BeginSetup(ctx context.Context, p1 uint) (*dtos.TwoFactorSetupResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.BeginSetup")
+	r0, err := s.next.BeginSetup(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTwoFactorService) Enable(ctx context.Context, p1 uint, p2 string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Enable")
+	r0, err := s.next.Enable(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTwoFactorService) Disable(ctx context.Context, p1 uint, p2 string) error {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Disable")
+	err := s.next.Disable(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTwoFactorService) Reset(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Reset")
+	err := s.next.Reset(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, p1 uint, p2 string) ([]string, error) {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.RegenerateRecoveryCodes")
+	r0, err := s.next.RegenerateRecoveryCodes(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedTwoFactorService) SetRequired(ctx context.Context, p1 uint, p2 bool) error {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.SetRequired")
+	err := s.next.SetRequired(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedTwoFactorService) Verify(ctx context.Context, p1 uint, p2 string) error {
+	ctx, span := tracing.StartSpan(ctx, "TwoFactorService.Verify")
+	err := s.next.Verify(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TraceUserService runs the methods of next in spans
+func TraceUserService(next UserService) UserService {
+	return &tracedUserService{next: next}
+}
+
+type tracedUserService struct {
+	next UserService
+}
+
+func (s *tracedUserService) GetUserProfile(ctx context.Context, p1 uint) (*dtos.UserProfile, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.GetUserProfile")
+	r0, err := s.next.GetUserProfile(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedUserService) GetPublicUserProfile(ctx context.Context, p1 uint) (*dtos.UserProfile, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.GetPublicUserProfile")
+	r0, err := s.next.GetPublicUserProfile(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedUserService) UpdateProfilePreferences(ctx context.Context, p1 uint, p2 dtos.UpdateProfilePreferencesRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "UserService.UpdateProfilePreferences")
+	err := s.next.UpdateProfilePreferences(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedUserService) SearchUsers(ctx context.Context, p1 *string, p2 *uint, p3 int, p4 int) (*dtos.UserSearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.SearchUsers")
+	r0, err := s.next.SearchUsers(ctx, p1, p2, p3, p4)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedUserService) CreateUser(ctx context.Context, p1 dtos.CreateOrUpdateUserRequest, p2 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "UserService.CreateUser")
+	err := s.next.CreateUser(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedUserService) UpdateUser(ctx context.Context, p1 uint, p2 dtos.CreateOrUpdateUserRequest, p3 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "UserService.UpdateUser")
+	err := s.next.UpdateUser(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedUserService) DeleteUser(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "UserService.DeleteUser")
+	err := s.next.DeleteUser(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedUserService) GetUserPositionHistory(ctx context.Context, p1 uint) ([]dtos.UserPositionHistory, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.GetUserPositionHistory")
+	r0, err := s.next.GetUserPositionHistory(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedUserService) GetPromotionReport(ctx context.Context, p1 time.Time, p2 time.Time, p3 string) (*dtos.PromotionReport, error) {
+	ctx, span := tracing.StartSpan(ctx, "UserService.GetPromotionReport")
+	r0, err := s.next.GetPromotionReport(ctx, p1, p2, p3)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+// TraceWebhookService runs the methods of next in spans
+func TraceWebhookService(next WebhookService) WebhookService {
+	return &tracedWebhookService{next: next}
+}
+
+type tracedWebhookService struct {
+	next WebhookService
+}
+
+func (s *tracedWebhookService) PollInterval() time.Duration {
+	return s.next.PollInterval()
+}
+
+func (s *tracedWebhookService) ListSubscriptions(ctx context.Context) ([]dtos.WebhookSubscription, error) {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.ListSubscriptions")
+	r0, err := s.next.ListSubscriptions(ctx)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedWebhookService) GetSubscription(ctx context.Context, p1 uint) (*dtos.WebhookSubscription, error) {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.GetSubscription")
+	r0, err := s.next.GetSubscription(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedWebhookService) CreateSubscription(ctx context.Context, p1 dtos.CreateOrUpdateWebhookSubscriptionRequest) (*dtos.WebhookSubscription, error) {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.CreateSubscription")
+	r0, err := s.next.CreateSubscription(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedWebhookService) UpdateSubscription(ctx context.Context, p1 uint, p2 dtos.CreateOrUpdateWebhookSubscriptionRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.UpdateSubscription")
+	err := s.next.UpdateSubscription(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedWebhookService) DeleteSubscription(ctx context.Context, p1 uint) error {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.DeleteSubscription")
+	err := s.next.DeleteSubscription(ctx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedWebhookService) SearchDeliveries(ctx context.Context, p1 uint, p2 dtos.WebhookDeliverySearchRequest) (*dtos.WebhookDeliverySearchResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.SearchDeliveries")
+	r0, err := s.next.SearchDeliveries(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedWebhookService) Redeliver(ctx context.Context, p1 uint, p2 uint) (*dtos.WebhookDelivery, error) {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.Redeliver")
+	r0, err := s.next.Redeliver(ctx, p1, p2)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
+
+func (s *tracedWebhookService) SubscriberName() string {
+	return s.next.SubscriberName()
+}
+
+func (s *tracedWebhookService) HandleEvent(tx *gorm.DB, p1 *models.OutboxEvent) error {
+	ctx, span := tracing.StartSpan(tx.Statement.Context, "WebhookService.HandleEvent")
+	tx = tx.WithContext(ctx)
+	err := s.next.HandleEvent(tx, p1)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (s *tracedWebhookService) DeliverDue(ctx context.Context, p1 time.Time) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "WebhookService.DeliverDue")
+	r0, err := s.next.DeliverDue(ctx, p1)
+	tracing.EndSpan(span, err)
+	return r0, err
+}
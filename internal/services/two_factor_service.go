@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/internal/utils"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 10
+
+type TwoFactorService interface {
+	IsRequired(user *models.User) bool
+	GetStatus(c context.Context, userID uint) (*dtos.TwoFactorStatus, error)
+	BeginSetup(c context.Context, userID uint) (*dtos.TwoFactorSetupResponse, error)
+	Enable(c context.Context, userID uint, code string) ([]string, error)
+	Disable(c context.Context, userID uint, code string) error
+	Reset(c context.Context, userID uint) error
+	RegenerateRecoveryCodes(c context.Context, userID uint, code string) ([]string, error)
+	SetRequired(c context.Context, userID uint, required bool) error
+	Verify(c context.Context, userID uint, code string) error
+}
+
+type twoFactorService struct {
+	db                         *gorm.DB
+	userRepository             repositories.UserRepository
+	userRecoveryCodeRepository repositories.UserRecoveryCodeRepository
+	cfg                        config.TwoFactorConfig
+}
+
+func NewTwoFactorService(
+	db *gorm.DB,
+	userRepository repositories.UserRepository,
+	userRecoveryCodeRepository repositories.UserRecoveryCodeRepository,
+	cfg config.TwoFactorConfig) TwoFactorService {
+	return &twoFactorService{
+		db:                         db,
+		userRepository:             userRepository,
+		userRecoveryCodeRepository: userRecoveryCodeRepository,
+		cfg:                        cfg,
+	}
+}
+
+// IsRequired reports whether the user must use two-factor authentication, either set by an admin
+// or because every admin is required to
+func (s *twoFactorService) IsRequired(user *models.User) bool {
+	return user.TwoFactorRequired || (s.cfg.RequiredForAdmins && user.Role == "admin")
+}
+
+func (s *twoFactorService) GetStatus(c context.Context, userID uint) (*dtos.TwoFactorStatus, error) {
+	user, err := s.findUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dtos.TwoFactorStatus{
+		Enabled:  user.TwoFactorEnabled,
+		Required: s.IsRequired(user),
+	}
+	if user.TwoFactorEnabled {
+		remaining, err := s.userRecoveryCodeRepository.CountUnusedByUserID(s.db.WithContext(c), userID)
+		if err != nil {
+			return nil, appErrors.ErrInternalServerError
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+	return status, nil
+}
+
+// BeginSetup generates a new secret for the user, enrolment completes once Enable confirms a code from it
+func (s *twoFactorService) BeginSetup(c context.Context, userID uint) (*dtos.TwoFactorSetupResponse, error) {
+	user, err := s.findUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, appErrors.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	if err := s.userRepository.UpdateTwoFactorSecret(s.db.WithContext(c), userID, secret); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return &dtos.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.cfg.Issuer, user.Email, secret),
+	}, nil
+}
+
+// Enable confirms the pending enrolment with a code from the authenticator app and returns the recovery codes,
+// which are only ever shown this once
+func (s *twoFactorService) Enable(c context.Context, userID uint, code string) ([]string, error) {
+	user, err := s.findUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, appErrors.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TwoFactorSecret == nil {
+		return nil, appErrors.ErrTwoFactorSetupNotStarted
+	}
+
+	step, ok := utils.ValidateTOTP(*user.TwoFactorSecret, code, time.Now())
+	if !ok {
+		return nil, appErrors.ErrInvalidTwoFactorCode
+	}
+
+	var recoveryCodes []string
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.userRepository.EnableTwoFactor(tx, userID, step); err != nil {
+			return err
+		}
+		codes, err := s.replaceRecoveryCodes(tx, userID)
+		if err != nil {
+			return err
+		}
+		recoveryCodes = codes
+		return nil
+	})
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return recoveryCodes, nil
+}
+
+// Disable turns two-factor authentication off after checking a current code, unless it is required for the user
+func (s *twoFactorService) Disable(c context.Context, userID uint, code string) error {
+	user, err := s.findUser(c, userID)
+	if err != nil {
+		return err
+	}
+	if s.IsRequired(user) {
+		return appErrors.ErrTwoFactorRequired
+	}
+	if err := s.verify(c, user, code); err != nil {
+		return err
+	}
+
+	return s.Reset(c, userID)
+}
+
+// Reset removes the enrolment and recovery codes of the user, admins use it when a user lost their device
+func (s *twoFactorService) Reset(c context.Context, userID uint) error {
+	if _, err := s.findUser(c, userID); err != nil {
+		return err
+	}
+
+	err := s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := s.userRepository.DisableTwoFactor(tx, userID); err != nil {
+			return err
+		}
+		return s.userRecoveryCodeRepository.DeleteByUserID(tx, userID)
+	})
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes invalidates all previous recovery codes of the user after checking a current code
+func (s *twoFactorService) RegenerateRecoveryCodes(c context.Context, userID uint, code string) ([]string, error) {
+	user, err := s.findUser(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(c, user, code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := s.replaceRecoveryCodes(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return recoveryCodes, nil
+}
+
+func (s *twoFactorService) SetRequired(c context.Context, userID uint, required bool) error {
+	if _, err := s.findUser(c, userID); err != nil {
+		return err
+	}
+
+	if err := s.userRepository.UpdateTwoFactorRequired(s.db.WithContext(c), userID, required); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+// Verify checks a TOTP code, or consumes a recovery code, of a user with two-factor authentication enabled
+func (s *twoFactorService) Verify(c context.Context, userID uint, code string) error {
+	user, err := s.findUser(c, userID)
+	if err != nil {
+		return err
+	}
+	return s.verify(c, user, code)
+}
+
+func (s *twoFactorService) verify(c context.Context, user *models.User, code string) error {
+	if !user.TwoFactorEnabled || user.TwoFactorSecret == nil {
+		return appErrors.ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := utils.ValidateTOTP(*user.TwoFactorSecret, code, time.Now()); ok {
+		accepted, err := s.userRepository.UpdateTwoFactorLastUsedStep(s.db.WithContext(c), user.ID, step)
+		if err != nil {
+			return appErrors.ErrInternalServerError
+		}
+		if !accepted {
+			return appErrors.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := s.userRecoveryCodeRepository.MarkUsed(s.db.WithContext(c), user.ID, utils.HashRecoveryCode(code), time.Now())
+	if err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	if !used {
+		return appErrors.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (s *twoFactorService) replaceRecoveryCodes(db *gorm.DB, userID uint) ([]string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]models.UserRecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		recoveryCodes = append(recoveryCodes, models.UserRecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashRecoveryCode(code),
+		})
+	}
+	if err := s.userRecoveryCodeRepository.ReplaceByUserID(db, userID, recoveryCodes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) findUser(c context.Context, userID uint) (*models.User, error) {
+	user, err := s.userRepository.FindByID(s.db.WithContext(c), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return user, nil
+}
@@ -2,27 +2,58 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 	"trieu_mock_project_go/helpers"
 	"trieu_mock_project_go/internal/dtos"
 	appErrors "trieu_mock_project_go/internal/errors"
 	"trieu_mock_project_go/internal/repositories"
 	"trieu_mock_project_go/models"
+	"trieu_mock_project_go/types"
 
 	"gorm.io/gorm"
 )
 
-type UserService struct {
-	db             *gorm.DB
-	userRepository *repositories.UserRepository
-	teamRepository *repositories.TeamsRepository
+type UserService interface {
+	GetUserProfile(c context.Context, id uint) (*dtos.UserProfile, error)
+	GetPublicUserProfile(c context.Context, id uint) (*dtos.UserProfile, error)
+	UpdateProfilePreferences(c context.Context, id uint, req dtos.UpdateProfilePreferencesRequest) error
+	SearchUsers(c context.Context, name *string, teamId *uint, limit, offset int) (*dtos.UserSearchResponse, error)
+	CreateUser(c context.Context, req dtos.CreateOrUpdateUserRequest, actorID uint) error
+	UpdateUser(c context.Context, id uint, req dtos.CreateOrUpdateUserRequest, actorID uint) error
+	DeleteUser(c context.Context, id uint) error
+	GetUserPositionHistory(c context.Context, userID uint) ([]dtos.UserPositionHistory, error)
+	GetPromotionReport(c context.Context, from, to time.Time, period string) (*dtos.PromotionReport, error)
 }
 
-func NewUserService(db *gorm.DB, userRepository *repositories.UserRepository, teamRepository *repositories.TeamsRepository) *UserService {
-	return &UserService{db: db, userRepository: userRepository, teamRepository: teamRepository}
+type userService struct {
+	db                            *gorm.DB
+	userRepository                repositories.UserRepository
+	teamRepository                repositories.TeamsRepository
+	positionRepository            repositories.PositionRepository
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository
+	outboxService                 OutboxService
 }
 
-func (s *UserService) GetUserProfile(c context.Context, id uint) (*dtos.UserProfile, error) {
+func NewUserService(
+	db *gorm.DB,
+	userRepository repositories.UserRepository,
+	teamRepository repositories.TeamsRepository,
+	positionRepository repositories.PositionRepository,
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository,
+	outboxService OutboxService) UserService {
+	return &userService{
+		db:                            db,
+		userRepository:                userRepository,
+		teamRepository:                teamRepository,
+		positionRepository:            positionRepository,
+		userPositionHistoryRepository: userPositionHistoryRepository,
+		outboxService:                 outboxService,
+	}
+}
+
+func (s *userService) GetUserProfile(c context.Context, id uint) (*dtos.UserProfile, error) {
 	user, err := s.userRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -33,7 +64,39 @@ func (s *UserService) GetUserProfile(c context.Context, id uint) (*dtos.UserProf
 	return userProfile, nil
 }
 
-func (s *UserService) SearchUsers(c context.Context, name *string, teamId *uint, limit, offset int) (*dtos.UserSearchResponse, error) {
+// GetPublicUserProfile returns the profile as seen by other users, hiding the birthday when the user opted out
+func (s *userService) GetPublicUserProfile(c context.Context, id uint) (*dtos.UserProfile, error) {
+	userProfile, err := s.GetUserProfile(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !userProfile.ShowBirthday {
+		userProfile.Birthday = nil
+	}
+	userProfile.EmailTeamChanges = nil
+	userProfile.EmailLeaveReviews = nil
+	return userProfile, nil
+}
+
+func (s *userService) UpdateProfilePreferences(c context.Context, id uint, req dtos.UpdateProfilePreferencesRequest) error {
+	preferences := map[string]interface{}{}
+	if req.ShowBirthday != nil {
+		preferences["show_birthday"] = *req.ShowBirthday
+	}
+	if req.EmailTeamChanges != nil {
+		preferences["email_team_changes"] = *req.EmailTeamChanges
+	}
+	if req.EmailLeaveReviews != nil {
+		preferences["email_leave_reviews"] = *req.EmailLeaveReviews
+	}
+	if err := s.userRepository.UpdatePreferences(s.db.WithContext(c), id, preferences); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *userService) SearchUsers(c context.Context, name *string, teamId *uint, limit, offset int) (*dtos.UserSearchResponse, error) {
 	users, totalCount, err := s.userRepository.SearchUsers(s.db.WithContext(c), name, teamId, limit, offset)
 	if err != nil {
 		return nil, appErrors.ErrInternalServerError
@@ -51,7 +114,7 @@ func (s *UserService) SearchUsers(c context.Context, name *string, teamId *uint,
 	return response, nil
 }
 
-func (s *UserService) CreateUser(c context.Context, req dtos.CreateOrUpdateUserRequest) error {
+func (s *userService) CreateUser(c context.Context, req dtos.CreateOrUpdateUserRequest, actorID uint) error {
 	existedUser, err := s.userRepository.FindByEmail(s.db.WithContext(c), req.Email)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		return appErrors.ErrInternalServerError
@@ -89,7 +152,17 @@ func (s *UserService) CreateUser(c context.Context, req dtos.CreateOrUpdateUserR
 			})
 		}
 
-		return s.userRepository.CreateUserSkills(tx, userSkills)
+		if err := s.userRepository.CreateUserSkills(tx, userSkills); err != nil {
+			return err
+		}
+
+		if err := recordPositionChange(tx, s.positionRepository, s.userPositionHistoryRepository, user, nil, positionEffectiveDate(req.PositionEffectiveDate), actorID); err != nil {
+			return err
+		}
+
+		return s.outboxService.Record(tx, models.DomainEventUserCreated, dtos.UserEventData{
+			User: helpers.MapUserToEventUser(user),
+		})
 	})
 
 	if err != nil {
@@ -99,7 +172,7 @@ func (s *UserService) CreateUser(c context.Context, req dtos.CreateOrUpdateUserR
 	return nil
 }
 
-func (s *UserService) UpdateUser(c context.Context, id uint, req dtos.CreateOrUpdateUserRequest) error {
+func (s *userService) UpdateUser(c context.Context, id uint, req dtos.CreateOrUpdateUserRequest, actorID uint) error {
 	var birthday *time.Time
 	if req.Birthday != nil && !req.Birthday.Time.IsZero() {
 		birthday = &req.Birthday.Time
@@ -142,11 +215,17 @@ func (s *UserService) UpdateUser(c context.Context, id uint, req dtos.CreateOrUp
 		})
 	}
 
-	err = s.db.Transaction(func(tx *gorm.DB) error {
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
 		if err := s.userRepository.UpdateUser(tx, user); err != nil {
 			return err
 		}
-		return s.userRepository.UpdateUserSkills(tx, id, userSkills)
+		if err := s.userRepository.UpdateUserSkills(tx, id, userSkills); err != nil {
+			return err
+		}
+		if currentUser.PositionID == req.PositionID {
+			return nil
+		}
+		return recordPositionChange(tx, s.positionRepository, s.userPositionHistoryRepository, user, &currentUser.PositionID, positionEffectiveDate(req.PositionEffectiveDate), actorID)
 	})
 	if err != nil {
 		return appErrors.ErrInternalServerError
@@ -154,8 +233,8 @@ func (s *UserService) UpdateUser(c context.Context, id uint, req dtos.CreateOrUp
 	return nil
 }
 
-func (s *UserService) DeleteUser(c context.Context, id uint) error {
-	_, err := s.userRepository.FindByID(s.db.WithContext(c), id)
+func (s *userService) DeleteUser(c context.Context, id uint) error {
+	user, err := s.userRepository.FindByID(s.db.WithContext(c), id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return appErrors.ErrUserNotFound
@@ -171,9 +250,137 @@ func (s *UserService) DeleteUser(c context.Context, id uint) error {
 		return appErrors.ErrCannotDeleteUserBeingTeamLeader
 	}
 
-	if err := s.db.WithContext(c).Delete(&models.User{}, id).Error; err != nil {
+	err = s.db.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.User{}, id).Error; err != nil {
+			return err
+		}
+
+		return s.outboxService.Record(tx, models.DomainEventUserDeleted, dtos.UserEventData{
+			User: helpers.MapUserToEventUser(user),
+		})
+	})
+	if err != nil {
 		return appErrors.ErrInternalServerError
 	}
 
 	return nil
 }
+
+func (s *userService) GetUserPositionHistory(c context.Context, userID uint) ([]dtos.UserPositionHistory, error) {
+	histories, err := s.userPositionHistoryRepository.FindByUserID(s.db.WithContext(c), userID)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	return helpers.MapUserPositionHistoriesToDtos(histories), nil
+}
+
+// GetPromotionReport counts promotions with an effective date in [from, to],
+// grouped by period (month, quarter or year) and by the team the user was in at the time
+func (s *userService) GetPromotionReport(c context.Context, from, to time.Time, period string) (*dtos.PromotionReport, error) {
+	promotions, err := s.userPositionHistoryRepository.FindPromotionsBetween(s.db.WithContext(c), from, to)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+
+	type rowKey struct {
+		period string
+		teamID uint
+	}
+	rowsByKey := make(map[rowKey]*dtos.PromotionReportRow)
+	rows := make([]*dtos.PromotionReportRow, 0)
+	for _, promotion := range promotions {
+		key := rowKey{period: formatReportPeriod(promotion.EffectiveDate, period)}
+		teamName := "No Team"
+		if promotion.Team != nil {
+			key.teamID = promotion.Team.ID
+			teamName = promotion.Team.Name
+		}
+
+		row, ok := rowsByKey[key]
+		if !ok {
+			row = &dtos.PromotionReportRow{Period: key.period, TeamID: promotion.TeamID, TeamName: teamName}
+			rowsByKey[key] = row
+			rows = append(rows, row)
+		}
+		row.Count++
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Period != rows[j].Period {
+			return rows[i].Period < rows[j].Period
+		}
+		return rows[i].TeamName < rows[j].TeamName
+	})
+
+	report := &dtos.PromotionReport{
+		From:   from,
+		To:     to,
+		Period: period,
+		Rows:   make([]dtos.PromotionReportRow, 0, len(rows)),
+		Total:  len(promotions),
+	}
+	for _, row := range rows {
+		report.Rows = append(report.Rows, *row)
+	}
+	return report, nil
+}
+
+// recordPositionChange appends a position history entry for the user's current PositionID, changed by
+// actorID (0 when the change comes from a sync or a sign-in rather than an admin).
+// A change counts as a promotion when both positions are in the same career track and the grade increases
+func recordPositionChange(
+	tx *gorm.DB,
+	positionRepository repositories.PositionRepository,
+	userPositionHistoryRepository repositories.UserPositionHistoryRepository,
+	user *models.User,
+	oldPositionID *uint,
+	effectiveDate time.Time,
+	actorID uint) error {
+	history := &models.UserPositionHistory{
+		UserID:        user.ID,
+		OldPositionID: oldPositionID,
+		NewPositionID: user.PositionID,
+		TeamID:        user.CurrentTeamID,
+		EffectiveDate: effectiveDate,
+	}
+	if actorID != 0 {
+		history.ChangedByID = &actorID
+	}
+
+	if oldPositionID != nil {
+		oldPosition, err := positionRepository.FindByID(tx, *oldPositionID)
+		if err != nil {
+			return err
+		}
+		newPosition, err := positionRepository.FindByID(tx, user.PositionID)
+		if err != nil {
+			return err
+		}
+		history.IsPromotion = oldPosition.CareerTrackID != nil && newPosition.CareerTrackID != nil &&
+			*oldPosition.CareerTrackID == *newPosition.CareerTrackID &&
+			oldPosition.Grade != nil && newPosition.Grade != nil &&
+			*newPosition.Grade > *oldPosition.Grade
+	}
+
+	return userPositionHistoryRepository.Create(tx, history)
+}
+
+// positionEffectiveDate is the date a position change of an admin request takes effect, today when unset
+func positionEffectiveDate(effectiveDate *types.Date) time.Time {
+	if effectiveDate != nil && !effectiveDate.Time.IsZero() {
+		return effectiveDate.Time
+	}
+	return time.Now()
+}
+
+func formatReportPeriod(date time.Time, period string) string {
+	switch period {
+	case "year":
+		return date.Format("2006")
+	case "quarter":
+		return fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())-1)/3+1)
+	default:
+		return date.Format("2006-01")
+	}
+}
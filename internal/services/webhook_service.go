@@ -0,0 +1,366 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"trieu_mock_project_go/helpers"
+	"trieu_mock_project_go/internal/config"
+	"trieu_mock_project_go/internal/dtos"
+	appErrors "trieu_mock_project_go/internal/errors"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookSecretPrefix   = "whsec_"
+	webhookBatchSize      = 50
+	maxWebhookRetryDelay  = 6 * time.Hour
+	maxWebhookResponseLog = 1024
+)
+
+// WebhookService manages the webhook subscriptions and delivers organisation events to them.
+// Events are queued as one delivery per subscription and sent by the WebhookDeliveryJob, so a slow
+// or unreachable endpoint never holds up the request that caused the event.
+type WebhookService interface {
+	PollInterval() time.Duration
+	ListSubscriptions(c context.Context) ([]dtos.WebhookSubscription, error)
+	GetSubscription(c context.Context, id uint) (*dtos.WebhookSubscription, error)
+	CreateSubscription(c context.Context, req dtos.CreateOrUpdateWebhookSubscriptionRequest) (*dtos.WebhookSubscription, error)
+	UpdateSubscription(c context.Context, id uint, req dtos.CreateOrUpdateWebhookSubscriptionRequest) error
+	DeleteSubscription(c context.Context, id uint) error
+	SearchDeliveries(c context.Context, subscriptionID uint, req dtos.WebhookDeliverySearchRequest) (*dtos.WebhookDeliverySearchResponse, error)
+	Redeliver(c context.Context, subscriptionID, deliveryID uint) (*dtos.WebhookDelivery, error)
+	SubscriberName() string
+	HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error
+	DeliverDue(c context.Context, now time.Time) (int, error)
+}
+
+type webhookService struct {
+	db                            *gorm.DB
+	webhookSubscriptionRepository repositories.WebhookSubscriptionRepository
+	webhookDeliveryRepository     repositories.WebhookDeliveryRepository
+	cfg                           config.WebhookConfig
+	client                        *http.Client
+}
+
+func NewWebhookService(
+	db *gorm.DB,
+	webhookSubscriptionRepository repositories.WebhookSubscriptionRepository,
+	webhookDeliveryRepository repositories.WebhookDeliveryRepository,
+	cfg config.WebhookConfig) WebhookService {
+	return &webhookService{
+		db:                            db,
+		webhookSubscriptionRepository: webhookSubscriptionRepository,
+		webhookDeliveryRepository:     webhookDeliveryRepository,
+		cfg:                           cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// A redirect is reported as a failed delivery rather than followed, the payload is only signed for the registered URL
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *webhookService) PollInterval() time.Duration {
+	return s.cfg.PollInterval
+}
+
+func (s *webhookService) ListSubscriptions(c context.Context) ([]dtos.WebhookSubscription, error) {
+	subscriptions, err := s.webhookSubscriptionRepository.FindAll(s.db.WithContext(c))
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapWebhookSubscriptionsToDtos(subscriptions), nil
+}
+
+func (s *webhookService) GetSubscription(c context.Context, id uint) (*dtos.WebhookSubscription, error) {
+	subscription, err := s.findSubscription(c, id)
+	if err != nil {
+		return nil, err
+	}
+	return helpers.MapWebhookSubscriptionToDto(subscription), nil
+}
+
+// CreateSubscription registers an endpoint, a secret is generated when none is given
+func (s *webhookService) CreateSubscription(c context.Context, req dtos.CreateOrUpdateWebhookSubscriptionRequest) (*dtos.WebhookSubscription, error) {
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, appErrors.ErrInternalServerError
+		}
+		secret = generated
+	}
+
+	subscription := &models.WebhookSubscription{
+		Name:       strings.TrimSpace(req.Name),
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: strings.Join(uniqueEventTypes(req.EventTypes), " "),
+		Active:     req.Active,
+	}
+	if err := s.webhookSubscriptionRepository.Create(s.db.WithContext(c), subscription); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapWebhookSubscriptionToDto(subscription), nil
+}
+
+// UpdateSubscription changes the endpoint, an empty secret keeps the current one
+func (s *webhookService) UpdateSubscription(c context.Context, id uint, req dtos.CreateOrUpdateWebhookSubscriptionRequest) error {
+	subscription, err := s.findSubscription(c, id)
+	if err != nil {
+		return err
+	}
+
+	subscription.Name = strings.TrimSpace(req.Name)
+	subscription.URL = req.URL
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+	}
+	subscription.EventTypes = strings.Join(uniqueEventTypes(req.EventTypes), " ")
+	subscription.Active = req.Active
+	if err := s.webhookSubscriptionRepository.Update(s.db.WithContext(c), subscription); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *webhookService) DeleteSubscription(c context.Context, id uint) error {
+	if _, err := s.findSubscription(c, id); err != nil {
+		return err
+	}
+	if err := s.webhookSubscriptionRepository.Delete(s.db.WithContext(c), id); err != nil {
+		return appErrors.ErrInternalServerError
+	}
+	return nil
+}
+
+func (s *webhookService) SearchDeliveries(c context.Context, subscriptionID uint, req dtos.WebhookDeliverySearchRequest) (*dtos.WebhookDeliverySearchResponse, error) {
+	deliveries, total, err := s.webhookDeliveryRepository.SearchBySubscriptionID(s.db.WithContext(c), subscriptionID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return &dtos.WebhookDeliverySearchResponse{
+		Deliveries: helpers.MapWebhookDeliveriesToDtos(deliveries),
+		Page: dtos.PaginationResponse{
+			Limit:  req.Limit,
+			Offset: req.Offset,
+			Total:  total,
+		},
+	}, nil
+}
+
+// Redeliver queues the event of a delivery again as a new delivery, the original stays in the log.
+// The event ID is kept so that the receiver can recognise an event it already processed.
+func (s *webhookService) Redeliver(c context.Context, subscriptionID, deliveryID uint) (*dtos.WebhookDelivery, error) {
+	original, err := s.webhookDeliveryRepository.FindByID(s.db.WithContext(c), deliveryID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrWebhookDeliveryNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	if original.SubscriptionID != subscriptionID {
+		return nil, appErrors.ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now()
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         models.WebhookDeliveryStatusPending,
+		NextAttemptAt:  &now,
+	}
+	if err := s.webhookDeliveryRepository.Create(s.db.WithContext(c), delivery); err != nil {
+		return nil, appErrors.ErrInternalServerError
+	}
+	return helpers.MapWebhookDeliveryToDto(delivery), nil
+}
+
+func (s *webhookService) SubscriberName() string {
+	return "webhooks"
+}
+
+// HandleEvent queues the domain event for every active subscription to its type. The outbox event ID
+// is sent as the webhook ID, receivers use it to recognise an event delivered more than once.
+func (s *webhookService) HandleEvent(tx *gorm.DB, event *models.OutboxEvent) error {
+	subscriptions, err := s.webhookSubscriptionRepository.FindActive(tx)
+	if err != nil {
+		return err
+	}
+	subscriptions = slices.DeleteFunc(subscriptions, func(subscription models.WebhookSubscription) bool {
+		return !slices.Contains(subscription.EventTypeList(), event.EventType)
+	})
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(dtos.WebhookEvent{
+		ID:        event.EventID,
+		Type:      event.EventType,
+		CreatedAt: event.OccurredAt.UTC(),
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.EventID,
+			EventType:      event.EventType,
+			Payload:        string(payload),
+			Status:         models.WebhookDeliveryStatusPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	return s.webhookDeliveryRepository.CreateDeliveries(tx, deliveries)
+}
+
+// DeliverDue sends the deliveries that are due and returns how many were attempted
+func (s *webhookService) DeliverDue(c context.Context, now time.Time) (int, error) {
+	deliveries, err := s.webhookDeliveryRepository.FindDue(s.db.WithContext(c), now, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return attemptClaimed(c, deliveries, func(delivery *models.WebhookDelivery) (bool, error) {
+		// Keep other instances away from the delivery for longer than sending it can take
+		return s.webhookDeliveryRepository.Claim(s.db.WithContext(c), delivery, now.Add(2*s.cfg.Timeout))
+	}, func(delivery *models.WebhookDelivery) error {
+		return s.deliver(c, delivery)
+	})
+}
+
+// deliver makes one attempt and records its outcome, only failing to record it is returned as an error
+func (s *webhookService) deliver(c context.Context, delivery *models.WebhookDelivery) error {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = nil
+	delivery.Error = nil
+
+	var sendErr error
+	if !delivery.Subscription.Active {
+		sendErr = fmt.Errorf("subscription is disabled")
+	} else {
+		sendErr = s.send(c, delivery, now)
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookDeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+	case delivery.Attempts >= s.cfg.MaxAttempts || !delivery.Subscription.Active:
+		message := sendErr.Error()
+		delivery.Error = &message
+		delivery.Status = models.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+	default:
+		message := sendErr.Error()
+		delivery.Error = &message
+		nextAttemptAt := now.Add(retryDelay(s.cfg.RetryBaseDelay, maxWebhookRetryDelay, delivery.Attempts))
+		delivery.NextAttemptAt = &nextAttemptAt
+	}
+
+	// The delivery is saved on its own, Save would otherwise write the preloaded subscription too
+	subscription := delivery.Subscription
+	delivery.Subscription = models.WebhookSubscription{}
+	err := s.webhookDeliveryRepository.Update(s.db.WithContext(c), delivery)
+	delivery.Subscription = subscription
+	return err
+}
+
+func (s *webhookService) send(c context.Context, delivery *models.WebhookDelivery, now time.Time) error {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req, err := http.NewRequestWithContext(c, http.MethodPost, delivery.Subscription.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "trieu-mock-project-webhooks")
+	req.Header.Set("X-Webhook-Id", delivery.EventID)
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+signWebhookPayload(delivery.Subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseLog))
+	status := resp.StatusCode
+	delivery.ResponseStatus = &status
+	if len(body) > 0 {
+		responseBody := strings.ToValidUTF8(string(body), "")
+		delivery.ResponseBody = &responseBody
+	}
+	if status < 200 || status > 299 {
+		return fmt.Errorf("endpoint responded with status %d", status)
+	}
+	return nil
+}
+
+func (s *webhookService) findSubscription(c context.Context, id uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.webhookSubscriptionRepository.FindByID(s.db.WithContext(c), id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, appErrors.ErrWebhookSubscriptionNotFound
+		}
+		return nil, appErrors.ErrInternalServerError
+	}
+	return subscription, nil
+}
+
+// signWebhookPayload is the HMAC-SHA256 of "<timestamp>.<payload>", receivers recompute it with the
+// subscription secret and reject old timestamps to stop replays
+func signWebhookPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateWebhookSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func uniqueEventTypes(eventTypes []string) []string {
+	// Kept in the order of models.DomainEventTypes so that listings read the same for every subscription
+	unique := make([]string, 0, len(eventTypes))
+	for _, eventType := range models.DomainEventTypes {
+		if slices.Contains(eventTypes, eventType) {
+			unique = append(unique, eventType)
+		}
+	}
+	return unique
+}
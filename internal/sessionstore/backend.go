@@ -0,0 +1,25 @@
+package sessionstore
+
+import (
+	"context"
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+// Backend persists admin sessions. Sessions are keyed by the SHA-256 hash of their token,
+// Find returns nil without an error when the session does not exist or has expired
+type Backend interface {
+	// WithTx returns the backend running its queries in the transaction, so sessions are revoked together
+	// with the change that requires it. The memory backend is not transactional and returns itself.
+	WithTx(tx *gorm.DB) Backend
+	Find(ctx context.Context, tokenHash string, now time.Time) (*models.AdminSession, error)
+	Save(ctx context.Context, session *models.AdminSession) error
+	Touch(ctx context.Context, tokenHash string, lastSeenAt, expiresAt time.Time) error
+	Delete(ctx context.Context, tokenHash string) error
+	FindByUserID(ctx context.Context, userID uint, now time.Time) ([]models.AdminSession, error)
+	DeleteByID(ctx context.Context, id, userID uint) (bool, error)
+	DeleteByUserID(ctx context.Context, userID uint, exceptTokenHash string) (int64, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,64 @@
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"time"
+	"trieu_mock_project_go/internal/repositories"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type databaseBackend struct {
+	db   *gorm.DB
+	repo repositories.AdminSessionRepository
+}
+
+// NewDatabaseBackend stores sessions in the admin_sessions table
+func NewDatabaseBackend(db *gorm.DB, repo repositories.AdminSessionRepository) Backend {
+	return &databaseBackend{db: db, repo: repo}
+}
+
+func (b *databaseBackend) WithTx(tx *gorm.DB) Backend {
+	return &databaseBackend{db: tx, repo: b.repo}
+}
+
+func (b *databaseBackend) Find(ctx context.Context, tokenHash string, now time.Time) (*models.AdminSession, error) {
+	session, err := b.repo.FindActiveByTokenHash(b.db.WithContext(ctx), tokenHash, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+func (b *databaseBackend) Save(ctx context.Context, session *models.AdminSession) error {
+	return b.repo.Save(b.db.WithContext(ctx), session)
+}
+
+func (b *databaseBackend) Touch(ctx context.Context, tokenHash string, lastSeenAt, expiresAt time.Time) error {
+	return b.repo.Touch(b.db.WithContext(ctx), tokenHash, lastSeenAt, expiresAt)
+}
+
+func (b *databaseBackend) Delete(ctx context.Context, tokenHash string) error {
+	return b.repo.DeleteByTokenHash(b.db.WithContext(ctx), tokenHash)
+}
+
+func (b *databaseBackend) FindByUserID(ctx context.Context, userID uint, now time.Time) ([]models.AdminSession, error) {
+	return b.repo.FindActiveByUserID(b.db.WithContext(ctx), userID, now)
+}
+
+func (b *databaseBackend) DeleteByID(ctx context.Context, id, userID uint) (bool, error) {
+	return b.repo.DeleteByIDAndUserID(b.db.WithContext(ctx), id, userID)
+}
+
+func (b *databaseBackend) DeleteByUserID(ctx context.Context, userID uint, exceptTokenHash string) (int64, error) {
+	return b.repo.DeleteByUserIDExcept(b.db.WithContext(ctx), userID, exceptTokenHash)
+}
+
+func (b *databaseBackend) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return b.repo.DeleteExpired(b.db.WithContext(ctx), now)
+}
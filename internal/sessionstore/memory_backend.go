@@ -0,0 +1,133 @@
+package sessionstore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"trieu_mock_project_go/models"
+
+	"gorm.io/gorm"
+)
+
+type memoryBackend struct {
+	mu       sync.Mutex
+	nextID   uint
+	sessions map[string]models.AdminSession
+}
+
+// NewMemoryBackend keeps sessions in process memory, they are lost on restart and not shared
+// between instances, so it is meant for tests and local development
+func NewMemoryBackend() Backend {
+	return &memoryBackend{sessions: make(map[string]models.AdminSession)}
+}
+
+func (b *memoryBackend) WithTx(_ *gorm.DB) Backend {
+	return b
+}
+
+func (b *memoryBackend) Find(_ context.Context, tokenHash string, now time.Time) (*models.AdminSession, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	session, ok := b.sessions[tokenHash]
+	if !ok || !session.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	session.Data = append([]byte(nil), session.Data...)
+	return &session, nil
+}
+
+func (b *memoryBackend) Save(_ context.Context, session *models.AdminSession) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if session.ID == 0 {
+		b.nextID++
+		session.ID = b.nextID
+	}
+	stored := *session
+	stored.Data = append([]byte(nil), session.Data...)
+	b.sessions[session.TokenHash] = stored
+	return nil
+}
+
+func (b *memoryBackend) Touch(_ context.Context, tokenHash string, lastSeenAt, expiresAt time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if session, ok := b.sessions[tokenHash]; ok {
+		session.LastSeenAt = lastSeenAt
+		session.ExpiresAt = expiresAt
+		b.sessions[tokenHash] = session
+	}
+	return nil
+}
+
+func (b *memoryBackend) Delete(_ context.Context, tokenHash string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.sessions, tokenHash)
+	return nil
+}
+
+func (b *memoryBackend) FindByUserID(_ context.Context, userID uint, now time.Time) ([]models.AdminSession, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var sessions []models.AdminSession
+	for _, session := range b.sessions {
+		if session.UserID != nil && *session.UserID == userID && session.ExpiresAt.After(now) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].LastSeenAt.Equal(sessions[j].LastSeenAt) {
+			return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+		}
+		return sessions[i].ID > sessions[j].ID
+	})
+	return sessions, nil
+}
+
+func (b *memoryBackend) DeleteByID(_ context.Context, id, userID uint) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for tokenHash, session := range b.sessions {
+		if session.ID == id && session.UserID != nil && *session.UserID == userID {
+			delete(b.sessions, tokenHash)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (b *memoryBackend) DeleteByUserID(_ context.Context, userID uint, exceptTokenHash string) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var deleted int64
+	for tokenHash, session := range b.sessions {
+		if tokenHash != exceptTokenHash && session.UserID != nil && *session.UserID == userID {
+			delete(b.sessions, tokenHash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (b *memoryBackend) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var deleted int64
+	for tokenHash, session := range b.sessions {
+		if !session.ExpiresAt.After(now) {
+			delete(b.sessions, tokenHash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
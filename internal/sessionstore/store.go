@@ -0,0 +1,230 @@
+package sessionstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"time"
+	"trieu_mock_project_go/models"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+)
+
+const (
+	// Session value holding the signed in admin, sessions are listed and revoked per user through it
+	userIDKey = "user_id"
+	// Lifetime of a session when the cookie has no Max-Age
+	defaultLifetime = 24 * time.Hour
+	// Activity is written back at most this often so every request does not cost a write
+	touchInterval = time.Minute
+)
+
+// Store is a gin session store that keeps session values on the server. The cookie only
+// carries a signed random token, so a session ends for good once it is deleted from the backend.
+type Store struct {
+	backend Backend
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+}
+
+// NewStore signs the session token with the key pairs, like cookie.NewStore does with the values
+func NewStore(backend Backend, keyPairs ...[]byte) *Store {
+	return &Store{
+		backend: backend,
+		codecs:  securecookie.CodecsFromPairs(keyPairs...),
+		options: &gsessions.Options{Path: "/", MaxAge: int(defaultLifetime.Seconds())},
+	}
+}
+
+// HashToken returns the key a session token is stored under
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Store) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+}
+
+func (s *Store) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+// New loads the session of the request, an invalid, expired or revoked token starts an empty session
+func (s *Store) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	options := *s.options
+	session.Options = &options
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	var token string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &token, s.codecs...); err != nil {
+		return session, nil
+	}
+
+	now := time.Now()
+	record, err := s.backend.Find(r.Context(), HashToken(token), now)
+	if err != nil {
+		return session, err
+	}
+	if record == nil {
+		return session, nil
+	}
+	if err := gob.NewDecoder(bytes.NewReader(record.Data)).Decode(&session.Values); err != nil {
+		return session, nil
+	}
+
+	session.ID = token
+	session.IsNew = false
+	if now.Sub(record.LastSeenAt) >= touchInterval {
+		if err := s.backend.Touch(r.Context(), record.TokenHash, now, now.Add(s.lifetime(session))); err != nil {
+			return session, err
+		}
+	}
+	return session, nil
+}
+
+// Save writes the session to the backend and sets the token cookie. A negative MaxAge deletes the session.
+func (s *Store) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.backend.Delete(r.Context(), HashToken(session.ID)); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	now := time.Now()
+	userID := sessionUserID(session)
+	var existing *models.AdminSession
+	if session.ID != "" {
+		found, err := s.backend.Find(r.Context(), HashToken(session.ID), now)
+		if err != nil {
+			return err
+		}
+		existing = found
+	}
+	// A new token is issued whenever the signed in user changes, so a token planted
+	// in the browser before login is worthless afterwards
+	if existing != nil && !sameUser(existing.UserID, userID) {
+		if err := s.backend.Delete(r.Context(), existing.TokenHash); err != nil {
+			return err
+		}
+		existing = nil
+	}
+	if existing == nil {
+		token, err := generateToken()
+		if err != nil {
+			return err
+		}
+		session.ID = token
+	}
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(session.Values); err != nil {
+		return err
+	}
+
+	record := &models.AdminSession{
+		TokenHash:  HashToken(session.ID),
+		UserID:     userID,
+		Data:       data.Bytes(),
+		IPAddress:  truncate(clientIP(r), 45),
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.lifetime(session)),
+	}
+	if userAgent := truncate(r.UserAgent(), 255); userAgent != "" {
+		record.UserAgent = &userAgent
+	}
+	if existing != nil {
+		record.ID = existing.ID
+		record.CreatedAt = existing.CreatedAt
+	}
+	if err := s.backend.Save(r.Context(), record); err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+func (s *Store) lifetime(session *gsessions.Session) time.Duration {
+	if session.Options.MaxAge > 0 {
+		return time.Duration(session.Options.MaxAge) * time.Second
+	}
+	return defaultLifetime
+}
+
+func sessionUserID(session *gsessions.Session) *uint {
+	if userID, ok := session.Values[userIDKey].(uint); ok {
+		return &userID
+	}
+	return nil
+}
+
+func sameUser(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func generateToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+type clientIPKey struct{}
+
+// ClientIPMiddleware passes the IP gin resolved for the request, under its trusted proxies, to the store,
+// which only sees the raw request. It runs before the sessions middleware, so sessions record the same IP
+// as the login attempts.
+func ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), clientIPKey{}, c.ClientIP()))
+		c.Next()
+	}
+}
+
+// clientIP returns the IP set by ClientIPMiddleware, the peer address when it did not run. Proxy headers
+// are never read here, they can be forged.
+func clientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok && ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func truncate(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+	return value
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const querySpanKey = "tracing:query_span"
+
+// InstrumentDB runs every statement of db in a span, a child of the span of the context the query runs
+// with. The SQL is recorded with its placeholders, never with the values.
+func InstrumentDB(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startQuerySpan("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endQuerySpan),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startQuerySpan("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endQuerySpan),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startQuerySpan("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endQuerySpan),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startQuerySpan("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endQuerySpan),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startQuerySpan("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endQuerySpan),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startQuerySpan("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endQuerySpan),
+	)
+}
+
+func startQuerySpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		name := "db." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		_, span := Tracer().Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(dbSystem(db.Dialector.Name()), semconv.DBOperationName(operation)),
+		)
+		db.InstanceSet(querySpanKey, span)
+	}
+}
+
+func endQuerySpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(querySpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(semconv.DBQueryText(db.Statement.SQL.String()))
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	if db.Statement.RowsAffected >= 0 {
+		span.SetAttributes(attribute.Int64("db.rows_affected", db.Statement.RowsAffected))
+	}
+	// A missing record is answered to the client, it is not a failure of the query
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func dbSystem(dialector string) attribute.KeyValue {
+	switch strings.ToLower(dialector) {
+	case "mysql":
+		return semconv.DBSystemNameMySQL
+	case "postgres":
+		return semconv.DBSystemNamePostgreSQL
+	case "sqlite":
+		return semconv.DBSystemNameSQLite
+	default:
+		return semconv.DBSystemNameKey.String(dialector)
+	}
+}
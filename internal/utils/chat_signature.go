@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Slash commands older than this are rejected, so a captured request cannot be replayed later
+const chatRequestMaxAge = 5 * time.Minute
+
+// SignChatRequest returns the Slack request signature "v0=<hex HMAC-SHA256>" of the timestamp and raw body
+func SignChatRequest(signingSecret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyChatRequestSignature checks the X-Slack-Signature of a request sent at the X-Slack-Request-Timestamp
+func VerifyChatRequestSignature(signingSecret, timestamp string, body []byte, signature string, now time.Time) bool {
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := now.Sub(time.Unix(sentAt, 0))
+	if age > chatRequestMaxAge || age < -chatRequestMaxAge {
+		return false
+	}
+	return hmac.Equal([]byte(SignChatRequest(signingSecret, timestamp, body)), []byte(signature))
+}
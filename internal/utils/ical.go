@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ICalEvent is an all-day event of an iCalendar feed, EndDate is inclusive
+type ICalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	StartDate   time.Time
+	EndDate     time.Time
+	Tentative   bool
+	UpdatedAt   time.Time
+}
+
+// BuildICalendar renders the events as an RFC 5545 VCALENDAR document
+func BuildICalendar(calendarName string, events []ICalEvent) string {
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//trieu_mock_project_go//Leave Calendar//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(calendarName))
+	for _, event := range events {
+		status := "CONFIRMED"
+		if event.Tentative {
+			status = "TENTATIVE"
+		}
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+event.UpdatedAt.UTC().Format("20060102T150405Z"))
+		writeICalLine(&b, "DTSTART;VALUE=DATE:"+event.StartDate.Format("20060102"))
+		// DTEND of an all-day event is exclusive
+		writeICalLine(&b, "DTEND;VALUE=DATE:"+event.EndDate.AddDate(0, 0, 1).Format("20060102"))
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		writeICalLine(&b, "STATUS:"+status)
+		writeICalLine(&b, "TRANSP:TRANSPARENT")
+		writeICalLine(&b, "END:VEVENT")
+	}
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// writeICalLine folds content lines longer than 75 octets and terminates them with CRLF. A continuation
+// line starts with a space, which counts towards its 75 octets.
+func writeICalLine(b *strings.Builder, line string) {
+	maxLineLength := 75
+	for len(line) > maxLineLength {
+		cut := maxLineLength
+		// Do not split a multi-byte UTF-8 character
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		maxLineLength = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func escapeICalText(text string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(text)
+}
+
+// ICalUID builds a globally unique identifier for a record
+func ICalUID(kind string, id uint) string {
+	return fmt.Sprintf("%s-%d@trieu-mock-project", kind, id)
+}
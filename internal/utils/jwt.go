@@ -2,51 +2,122 @@ package utils
 
 import (
 	"time"
-	"trieu_mock_project_go/internal/config"
 	appErrors "trieu_mock_project_go/internal/errors"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Purposes of short-lived challenge tokens issued between the password check and the second factor
+const (
+	TwoFactorPurposeVerify = "2fa_verify"
+	TwoFactorPurposeSetup  = "2fa_setup"
+)
+
+const twoFactorChallengeTTL = 5 * time.Minute
+
+// Token types set in the typ header, a challenge token is never accepted where an access token is expected
+// and the other way around, even though both are signed with the keys published in the JWKS
+const (
+	accessTokenType             = "at+jwt"
+	twoFactorChallengeTokenType = "2fa-challenge+jwt"
+)
+
+// twoFactorChallengeAudience is the aud claim of challenge tokens, only the login endpoints accept them
+const twoFactorChallengeAudience = "2fa-challenge"
+
 type JWTClaims struct {
 	UserID uint   `json:"user_id"`
 	Email  string `json:"email"`
+	// Purpose is empty for access tokens
+	Purpose string `json:"purpose,omitempty"`
+	// TokenVersion of the user when the access token was issued, see models.User
+	TokenVersion uint `json:"ver,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateJWTToken generates a JWT token with user claims
-func GenerateJWTToken(userID uint, email string) (string, error) {
-	cfg := config.LoadConfig()
+// GenerateJWTToken generates a JWT token with user claims, signed with the configured key
+func GenerateJWTToken(userID uint, email string, tokenVersion uint) (string, error) {
+	keys, err := getJWTKeys()
+	if err != nil {
+		return "", err
+	}
 
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:       userID,
+		Email:        email,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    keys.issuer,
+			Audience:  jwt.ClaimStrings{keys.audience},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(cfg.JWT.Secret))
+	return keys.sign(claims, accessTokenType)
+}
+
+// GenerateTwoFactorChallengeToken generates a token that only proves the password check passed,
+// it cannot be used as an access token
+func GenerateTwoFactorChallengeToken(userID uint, email, purpose string) (string, error) {
+	keys, err := getJWTKeys()
 	if err != nil {
 		return "", err
 	}
 
-	return tokenString, nil
+	claims := JWTClaims{
+		UserID:  userID,
+		Email:   email,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    keys.issuer,
+			Audience:  jwt.ClaimStrings{twoFactorChallengeAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(twoFactorChallengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	return keys.sign(claims, twoFactorChallengeTokenType)
 }
 
-// ParseJWTToken parses and validates JWT token, returns claims
+// ParseJWTToken parses and validates an access token, returns claims
 func ParseJWTToken(tokenString string) (*JWTClaims, error) {
-	cfg := config.LoadConfig()
+	keys, err := getJWTKeys()
+	if err != nil {
+		return nil, appErrors.ErrInvalidToken
+	}
+	claims, err := parseToken(keys, tokenString, accessTokenType, keys.audience)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, appErrors.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// ParseTwoFactorChallengeToken parses a challenge token and checks it was issued for the purpose
+func ParseTwoFactorChallengeToken(tokenString, purpose string) (*JWTClaims, error) {
+	keys, err := getJWTKeys()
+	if err != nil {
+		return nil, appErrors.ErrInvalidTwoFactorChallenge
+	}
+	claims, err := parseToken(keys, tokenString, twoFactorChallengeTokenType, twoFactorChallengeAudience)
+	if err != nil || claims.Purpose != purpose {
+		return nil, appErrors.ErrInvalidTwoFactorChallenge
+	}
+	return claims, nil
+}
 
+// parseToken verifies the signature, the typ header and the iss, aud and exp claims of a token
+func parseToken(keys *jwtKeySet, tokenString, typ, audience string) (*JWTClaims, error) {
 	claims := &JWTClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, appErrors.ErrUnexpectedSigningMethod
+		if tokenType, _ := token.Header["typ"].(string); tokenType != typ {
+			return nil, appErrors.ErrInvalidToken
 		}
-		return []byte(cfg.JWT.Secret), nil
-	})
+		return keys.verificationKey(token)
+	}, jwt.WithIssuer(keys.issuer), jwt.WithAudience(audience), jwt.WithExpirationRequired())
 
 	if err != nil {
 		return nil, appErrors.ErrInvalidToken
@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"trieu_mock_project_go/internal/config"
+	appErrors "trieu_mock_project_go/internal/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms accepted in JWT_ALGORITHM
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+const minRSAKeyBits = 2048
+
+// JSONWebKey is the public half of a verification key as published in the JWKS, RFC 7517
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// jwtKeySet holds the key new tokens are signed with and, for asymmetric algorithms,
+// every public key tokens are still accepted from, indexed by key ID. Issuer and audience are the
+// claims access tokens are issued with.
+type jwtKeySet struct {
+	method           jwt.SigningMethod
+	signingKey       interface{}
+	signingKeyID     string
+	verificationKeys map[string]crypto.PublicKey
+	jwks             JSONWebKeySet
+	issuer           string
+	audience         string
+}
+
+var (
+	jwtKeysOnce sync.Once
+	jwtKeys     *jwtKeySet
+	jwtKeysErr  error
+)
+
+// LoadJWTKeys reads the configured signing and verification keys, the server calls it on start so that
+// a missing or invalid key file stops it instead of failing every login
+func LoadJWTKeys() error {
+	_, err := getJWTKeys()
+	return err
+}
+
+// JWKS returns the public keys tokens are verified with, empty when tokens are signed with a shared secret
+func JWKS() (*JSONWebKeySet, error) {
+	keys, err := getJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	return &keys.jwks, nil
+}
+
+func getJWTKeys() (*jwtKeySet, error) {
+	jwtKeysOnce.Do(func() {
+		jwtKeys, jwtKeysErr = loadJWTKeySet(config.LoadConfig().JWT)
+	})
+	return jwtKeys, jwtKeysErr
+}
+
+func loadJWTKeySet(cfg config.JWTConfig) (*jwtKeySet, error) {
+	keys := &jwtKeySet{
+		verificationKeys: make(map[string]crypto.PublicKey),
+		jwks:             JSONWebKeySet{Keys: []JSONWebKey{}},
+		issuer:           cfg.Issuer,
+		audience:         cfg.Audience,
+	}
+
+	switch cfg.Algorithm {
+	case JWTAlgorithmHS256:
+		keys.method = jwt.SigningMethodHS256
+		keys.signingKey = []byte(cfg.Secret)
+		return keys, nil
+	case JWTAlgorithmRS256:
+		keys.method = jwt.SigningMethodRS256
+	case JWTAlgorithmEdDSA:
+		keys.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q, use HS256, RS256 or EdDSA", cfg.Algorithm)
+	}
+
+	if cfg.SigningKeyFile == "" {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY_FILE is required for %s", cfg.Algorithm)
+	}
+	privateKey, err := readPrivateKey(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var publicKey crypto.PublicKey
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if cfg.Algorithm != JWTAlgorithmRS256 {
+			return nil, fmt.Errorf("%s holds an RSA key but JWT_ALGORITHM is %s", cfg.SigningKeyFile, cfg.Algorithm)
+		}
+		publicKey = key.Public()
+	case ed25519.PrivateKey:
+		if cfg.Algorithm != JWTAlgorithmEdDSA {
+			return nil, fmt.Errorf("%s holds an Ed25519 key but JWT_ALGORITHM is %s", cfg.SigningKeyFile, cfg.Algorithm)
+		}
+		publicKey = key.Public()
+	default:
+		return nil, fmt.Errorf("%s: only RSA and Ed25519 keys are supported", cfg.SigningKeyFile)
+	}
+	keys.signingKey = privateKey
+	if keys.signingKeyID, err = keys.addVerificationKey(publicKey); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.SigningKeyFile, err)
+	}
+
+	// Keys that were rotated out keep verifying the tokens they signed until those expire
+	for _, path := range cfg.VerificationKeyFiles {
+		publicKey, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := keys.addVerificationKey(publicKey); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return keys, nil
+}
+
+// addVerificationKey publishes the key and returns its ID, the RFC 7638 thumbprint,
+// which stays the same wherever the key is loaded from
+func (k *jwtKeySet) addVerificationKey(publicKey crypto.PublicKey) (string, error) {
+	var jwk JSONWebKey
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return "", fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		jwk = JSONWebKey{
+			Kty: "RSA",
+			Alg: JWTAlgorithmRS256,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		jwk = JSONWebKey{
+			Kty: "OKP",
+			Alg: JWTAlgorithmEdDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	default:
+		return "", fmt.Errorf("only RSA and Ed25519 keys are supported")
+	}
+	jwk.Use = "sig"
+	jwk.Kid = jwkThumbprint(jwk)
+
+	if _, exists := k.verificationKeys[jwk.Kid]; !exists {
+		k.verificationKeys[jwk.Kid] = publicKey
+		k.jwks.Keys = append(k.jwks.Keys, jwk)
+	}
+	return jwk.Kid, nil
+}
+
+// verificationKey returns the key a token is checked with, it must match the algorithm of the token
+func (k *jwtKeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	if k.method == jwt.SigningMethodHS256 {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, appErrors.ErrUnexpectedSigningMethod
+		}
+		return k.signingKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	publicKey, ok := k.verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		if token.Method != jwt.SigningMethodRS256 {
+			return nil, appErrors.ErrUnexpectedSigningMethod
+		}
+	case ed25519.PublicKey:
+		if token.Method != jwt.SigningMethodEdDSA {
+			return nil, appErrors.ErrUnexpectedSigningMethod
+		}
+	}
+	return publicKey, nil
+}
+
+// sign signs the claims, typ is the token type set in the header
+func (k *jwtKeySet) sign(claims jwt.Claims, typ string) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["typ"] = typ
+	if k.signingKeyID != "" {
+		token.Header["kid"] = k.signingKeyID
+	}
+	return token.SignedString(k.signingKey)
+}
+
+// jwkThumbprint hashes the required members of the key in lexical order, RFC 7638
+func jwkThumbprint(jwk JSONWebKey) string {
+	var members map[string]string
+	if jwk.Kty == "RSA" {
+		members = map[string]string{"e": jwk.E, "kty": jwk.Kty, "n": jwk.N}
+	} else {
+		members = map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X}
+	}
+	// encoding/json writes map keys sorted and without whitespace, as the thumbprint requires
+	encoded, _ := json.Marshal(members)
+	sum := sha256.Sum256(encoded)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func readPrivateKey(path string) (crypto.PrivateKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return key, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%s: expected a PRIVATE KEY or RSA PRIVATE KEY block, got %s", path, block.Type)
+	}
+}
+
+// readPublicKey reads a public key, or the public half of a private key file
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return key, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return key, nil
+	}
+
+	privateKey, err := readPrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: only RSA and Ed25519 keys are supported", path)
+	}
+	return signer.Public(), nil
+}
+
+func readPEMBlock(path string) (*pem.Block, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,13 @@
+-- users and teams reference each other, break the cycle before dropping the tables
+ALTER TABLE `users` DROP FOREIGN KEY `fk_users_current_team_id`;
+
+DROP TABLE IF EXISTS `notifications`;
+DROP TABLE IF EXISTS `activity_logs`;
+DROP TABLE IF EXISTS `project_members`;
+DROP TABLE IF EXISTS `projects`;
+DROP TABLE IF EXISTS `user_skills`;
+DROP TABLE IF EXISTS `skills`;
+DROP TABLE IF EXISTS `team_members`;
+DROP TABLE IF EXISTS `teams`;
+DROP TABLE IF EXISTS `users`;
+DROP TABLE IF EXISTS `positions`;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS `position_required_skills`;
+
+ALTER TABLE `positions`
+  DROP FOREIGN KEY `fk_positions_career_track_id`;
+
+ALTER TABLE `positions`
+  DROP INDEX `ux_positions_career_track_grade`,
+  DROP COLUMN `grade`,
+  DROP COLUMN `career_track_id`;
+
+DROP TABLE IF EXISTS `career_tracks`;
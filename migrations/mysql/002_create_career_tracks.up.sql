@@ -0,0 +1,28 @@
+-- Create career_tracks table
+CREATE TABLE IF NOT EXISTS `career_tracks` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `name` varchar(255) NOT NULL UNIQUE KEY,
+  `description` text NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Group positions into career tracks with ordered grades
+ALTER TABLE `positions`
+  ADD COLUMN `career_track_id` int unsigned NULL AFTER `abbreviation`,
+  ADD COLUMN `grade` int NULL AFTER `career_track_id`,
+  ADD CONSTRAINT `fk_positions_career_track_id` FOREIGN KEY (`career_track_id`) REFERENCES `career_tracks` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  ADD UNIQUE KEY `ux_positions_career_track_grade` (`career_track_id`, `grade`);
+
+-- Create position_required_skills table
+CREATE TABLE IF NOT EXISTS `position_required_skills` (
+  `position_id` int unsigned NOT NULL,
+  `skill_id` int unsigned NOT NULL,
+  `min_level` int NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`position_id`, `skill_id`),
+  CONSTRAINT `fk_position_required_skills_position_id` FOREIGN KEY (`position_id`) REFERENCES `positions` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_position_required_skills_skill_id` FOREIGN KEY (`skill_id`) REFERENCES `skills` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  KEY `idx_position_required_skills_skill_id` (`skill_id`)
+);
@@ -0,0 +1,19 @@
+-- Create user_position_histories table
+CREATE TABLE IF NOT EXISTS `user_position_histories` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` int unsigned NOT NULL,
+  `old_position_id` int unsigned NULL,
+  `new_position_id` int unsigned NOT NULL,
+  `team_id` int unsigned NULL,
+  `is_promotion` boolean NOT NULL DEFAULT FALSE,
+  `effective_date` date NOT NULL,
+  `changed_by_id` int unsigned NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_user_position_histories_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_user_position_histories_old_position_id` FOREIGN KEY (`old_position_id`) REFERENCES `positions` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT `fk_user_position_histories_new_position_id` FOREIGN KEY (`new_position_id`) REFERENCES `positions` (`id`) ON DELETE RESTRICT ON UPDATE CASCADE,
+  CONSTRAINT `fk_user_position_histories_team_id` FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT `fk_user_position_histories_changed_by_id` FOREIGN KEY (`changed_by_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  KEY `idx_user_position_histories_user_id` (`user_id`),
+  KEY `idx_user_position_histories_effective_date` (`effective_date`)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE `users`
+  DROP INDEX `idx_users_calendar_feed_token_hash`,
+  DROP COLUMN `calendar_feed_token_hash`;
+
+DROP TABLE IF EXISTS `leave_requests`;
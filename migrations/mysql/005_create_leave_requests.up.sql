@@ -0,0 +1,29 @@
+-- Create leave_requests table
+CREATE TABLE IF NOT EXISTS `leave_requests` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` int unsigned NOT NULL,
+  `team_id` int unsigned NOT NULL,
+  `type` enum('annual','sick','unpaid','other') NOT NULL,
+  `start_date` date NOT NULL,
+  `end_date` date NOT NULL,
+  `start_half_day` boolean NOT NULL DEFAULT FALSE,
+  `end_half_day` boolean NOT NULL DEFAULT FALSE,
+  `reason` text NULL,
+  `status` enum('pending','approved','rejected','cancelled') NOT NULL DEFAULT 'pending',
+  `reviewer_id` int unsigned NULL,
+  `review_note` text NULL,
+  `reviewed_at` timestamp NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_leave_requests_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_leave_requests_team_id` FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_leave_requests_reviewer_id` FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  KEY `idx_leave_requests_user_id_dates` (`user_id`, `start_date`, `end_date`),
+  KEY `idx_leave_requests_team_id_dates` (`team_id`, `start_date`, `end_date`),
+  KEY `idx_leave_requests_status` (`status`)
+);
+
+-- Secret of the calendar feed URLs of the user, calendar clients subscribe with it instead of a Bearer token
+ALTER TABLE `users`
+  ADD COLUMN `calendar_feed_token_hash` char(64) NULL AFTER `role`,
+  ADD UNIQUE KEY `idx_users_calendar_feed_token_hash` (`calendar_feed_token_hash`);
@@ -0,0 +1,35 @@
+-- Create timesheets table, one per user per week (week_start is a Monday)
+CREATE TABLE IF NOT EXISTS `timesheets` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` int unsigned NOT NULL,
+  `week_start` date NOT NULL,
+  `status` enum('draft','submitted','approved','rejected') NOT NULL DEFAULT 'draft',
+  `submitted_at` timestamp NULL,
+  `reviewer_id` int unsigned NULL,
+  `review_note` text NULL,
+  `reviewed_at` timestamp NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY `ux_timesheets_user_id_week_start` (`user_id`, `week_start`),
+  CONSTRAINT `fk_timesheets_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_timesheets_reviewer_id` FOREIGN KEY (`reviewer_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  KEY `idx_timesheets_status` (`status`)
+);
+
+-- Create time_entries table, one per user per project per day
+CREATE TABLE IF NOT EXISTS `time_entries` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `timesheet_id` int unsigned NOT NULL,
+  `user_id` int unsigned NOT NULL,
+  `project_id` int unsigned NOT NULL,
+  `work_date` date NOT NULL,
+  `hours` decimal(4,2) NOT NULL,
+  `description` text NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY `ux_time_entries_user_id_project_id_work_date` (`user_id`, `project_id`, `work_date`),
+  CONSTRAINT `fk_time_entries_timesheet_id` FOREIGN KEY (`timesheet_id`) REFERENCES `timesheets` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_time_entries_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_time_entries_project_id` FOREIGN KEY (`project_id`) REFERENCES `projects` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  KEY `idx_time_entries_project_id_work_date` (`project_id`, `work_date`)
+);
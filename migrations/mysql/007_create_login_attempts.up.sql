@@ -0,0 +1,23 @@
+-- Track failed logins and lockouts per account
+ALTER TABLE `users`
+  ADD COLUMN `failed_login_count` int unsigned NOT NULL DEFAULT 0 AFTER `role`,
+  ADD COLUMN `last_failed_login_at` timestamp NULL AFTER `failed_login_count`,
+  ADD COLUMN `locked_until` timestamp NULL AFTER `last_failed_login_at`;
+
+-- Create login_attempts table, the audit of every login on both the user and admin flows
+CREATE TABLE IF NOT EXISTS `login_attempts` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `flow` enum('user','admin') NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `user_id` int unsigned NULL,
+  `ip_address` varchar(45) NOT NULL,
+  `user_agent` varchar(255) NULL,
+  `success` boolean NOT NULL,
+  `failure_reason` varchar(50) NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_login_attempts_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  KEY `idx_login_attempts_ip_address_created_at` (`ip_address`, `created_at`),
+  KEY `idx_login_attempts_email_created_at` (`email`, `created_at`),
+  KEY `idx_login_attempts_user_id_created_at` (`user_id`, `created_at`),
+  KEY `idx_login_attempts_created_at` (`created_at`)
+);
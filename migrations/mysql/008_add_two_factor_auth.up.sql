@@ -0,0 +1,17 @@
+-- TOTP two-factor authentication state per account
+ALTER TABLE `users`
+  ADD COLUMN `two_factor_secret` varchar(64) NULL AFTER `locked_until`,
+  ADD COLUMN `two_factor_enabled` boolean NOT NULL DEFAULT false AFTER `two_factor_secret`,
+  ADD COLUMN `two_factor_required` boolean NOT NULL DEFAULT false AFTER `two_factor_enabled`,
+  ADD COLUMN `two_factor_last_used_step` bigint NULL AFTER `two_factor_required`;
+
+-- Create user_recovery_codes table, one-time codes replacing a TOTP code when the device is lost
+CREATE TABLE IF NOT EXISTS `user_recovery_codes` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` int unsigned NOT NULL,
+  `code_hash` char(64) NOT NULL,
+  `used_at` timestamp NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_user_recovery_codes_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  UNIQUE KEY `idx_user_recovery_codes_user_id_code_hash` (`user_id`, `code_hash`)
+);
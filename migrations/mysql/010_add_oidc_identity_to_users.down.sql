@@ -0,0 +1,4 @@
+ALTER TABLE `users`
+  DROP INDEX `idx_users_oidc_issuer_subject`,
+  DROP COLUMN `oidc_subject`,
+  DROP COLUMN `oidc_issuer`;
@@ -0,0 +1,5 @@
+-- Link users to their identity at the OpenID Connect provider, the subject is only unique per issuer
+ALTER TABLE `users`
+  ADD COLUMN `oidc_issuer` varchar(255) NULL AFTER `two_factor_last_used_step`,
+  ADD COLUMN `oidc_subject` varchar(255) NULL AFTER `oidc_issuer`,
+  ADD UNIQUE KEY `idx_users_oidc_issuer_subject` (`oidc_issuer`, `oidc_subject`);
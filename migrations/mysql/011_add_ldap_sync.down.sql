@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS `ldap_sync_runs`;
+
+ALTER TABLE `users`
+  DROP INDEX `idx_users_ldap_uid`,
+  DROP COLUMN `deactivated_at`,
+  DROP COLUMN `ldap_uid`;
@@ -0,0 +1,24 @@
+-- Link users to their LDAP directory entry, users removed from the directory are deactivated rather than deleted
+ALTER TABLE `users`
+  ADD COLUMN `ldap_uid` varchar(255) NULL AFTER `oidc_subject`,
+  ADD COLUMN `deactivated_at` timestamp NULL AFTER `ldap_uid`,
+  ADD UNIQUE KEY `idx_users_ldap_uid` (`ldap_uid`);
+
+-- Create ldap_sync_runs table, the report of every directory synchronisation
+CREATE TABLE IF NOT EXISTS `ldap_sync_runs` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `trigger` enum('schedule','cli','admin') NOT NULL,
+  `dry_run` boolean NOT NULL DEFAULT false,
+  `status` enum('running','succeeded','failed') NOT NULL,
+  `directory_entries` int unsigned NOT NULL DEFAULT 0,
+  `created_count` int unsigned NOT NULL DEFAULT 0,
+  `updated_count` int unsigned NOT NULL DEFAULT 0,
+  `deactivated_count` int unsigned NOT NULL DEFAULT 0,
+  `reactivated_count` int unsigned NOT NULL DEFAULT 0,
+  `skipped_count` int unsigned NOT NULL DEFAULT 0,
+  `error` text NULL,
+  `details` json NULL,
+  `started_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `finished_at` timestamp NULL,
+  KEY `idx_ldap_sync_runs_started_at` (`started_at`)
+);
@@ -0,0 +1,33 @@
+-- Create webhook_subscriptions table, endpoints admins register to be told about organisation events.
+-- event_types is a space-separated list, the secret signs every payload sent to the endpoint.
+CREATE TABLE IF NOT EXISTS `webhook_subscriptions` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `name` varchar(100) NOT NULL,
+  `url` varchar(2048) NOT NULL,
+  `secret` varchar(255) NOT NULL,
+  `event_types` varchar(500) NOT NULL,
+  `active` boolean NOT NULL DEFAULT true,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create webhook_deliveries table, the delivery queue and log. A pending delivery is sent once
+-- next_attempt_at has passed and retried with exponential backoff until it succeeds or runs out of attempts.
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `subscription_id` int unsigned NOT NULL,
+  `event_id` char(36) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `payload` json NOT NULL,
+  `status` enum('pending','succeeded','failed') NOT NULL DEFAULT 'pending',
+  `attempts` int unsigned NOT NULL DEFAULT 0,
+  `next_attempt_at` timestamp NULL,
+  `last_attempt_at` timestamp NULL,
+  `response_status` int NULL,
+  `response_body` text NULL,
+  `error` text NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_webhook_deliveries_subscription_id` FOREIGN KEY (`subscription_id`) REFERENCES `webhook_subscriptions` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  KEY `idx_webhook_deliveries_due` (`status`, `next_attempt_at`),
+  KEY `idx_webhook_deliveries_subscription_id` (`subscription_id`, `created_at`)
+);
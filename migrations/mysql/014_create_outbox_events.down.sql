@@ -0,0 +1,15 @@
+-- Entries about deleted users cannot be kept once user_id is required again
+ALTER TABLE `activity_logs`
+  DROP FOREIGN KEY `fk_activity_logs_user_id`;
+
+DELETE FROM `activity_logs` WHERE `user_id` IS NULL;
+
+ALTER TABLE `activity_logs`
+  DROP INDEX `idx_activity_logs_action`,
+  DROP INDEX `idx_activity_logs_event_id`,
+  DROP COLUMN `event_id`,
+  MODIFY `user_id` int unsigned NOT NULL,
+  ADD CONSTRAINT `fk_activity_logs_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+DROP TABLE IF EXISTS `outbox_processed_events`;
+DROP TABLE IF EXISTS `outbox_events`;
@@ -0,0 +1,40 @@
+-- Create outbox_events table, domain events written in the transaction of the change they describe
+-- and dispatched to the in-process subscribers afterwards. event_id is the idempotency key of the event.
+CREATE TABLE IF NOT EXISTS `outbox_events` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `event_id` char(36) NOT NULL,
+  `event_type` varchar(50) NOT NULL,
+  `payload` json NOT NULL,
+  `status` enum('pending','dispatched','failed') NOT NULL DEFAULT 'pending',
+  `attempts` int unsigned NOT NULL DEFAULT 0,
+  `next_attempt_at` timestamp NULL,
+  `last_error` text NULL,
+  `occurred_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `dispatched_at` timestamp NULL,
+  UNIQUE KEY `idx_outbox_events_event_id` (`event_id`),
+  KEY `idx_outbox_events_due` (`status`, `next_attempt_at`),
+  KEY `idx_outbox_events_dispatched_at` (`dispatched_at`)
+);
+
+-- Create outbox_processed_events table, the events each subscriber has handled. An event dispatched
+-- again after a partial failure is skipped by the subscribers that already handled it.
+CREATE TABLE IF NOT EXISTS `outbox_processed_events` (
+  `subscriber` varchar(50) NOT NULL,
+  `event_id` char(36) NOT NULL,
+  `processed_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`subscriber`, `event_id`),
+  CONSTRAINT `fk_outbox_processed_events_event_id` FOREIGN KEY (`event_id`) REFERENCES `outbox_events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  KEY `idx_outbox_processed_events_event_id` (`event_id`)
+);
+
+-- The activity log is written from domain events. Entries outlive the users they are about,
+-- and event_id keeps an event from being logged twice.
+ALTER TABLE `activity_logs`
+  DROP FOREIGN KEY `fk_activity_logs_user_id`;
+
+ALTER TABLE `activity_logs`
+  MODIFY `user_id` int unsigned NULL,
+  ADD COLUMN `event_id` char(36) NULL AFTER `id`,
+  ADD UNIQUE KEY `idx_activity_logs_event_id` (`event_id`),
+  ADD KEY `idx_activity_logs_action` (`action`, `created_at`),
+  ADD CONSTRAINT `fk_activity_logs_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS `password_reset_tokens`;
+DROP TABLE IF EXISTS `email_messages`;
+
+ALTER TABLE `users`
+  DROP COLUMN `email_leave_reviews`,
+  DROP COLUMN `email_team_changes`;
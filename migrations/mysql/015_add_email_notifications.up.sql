@@ -0,0 +1,38 @@
+-- Email preferences of users, welcome and password reset emails are always sent
+ALTER TABLE `users`
+  ADD COLUMN `email_team_changes` boolean NOT NULL DEFAULT true AFTER `show_birthday`,
+  ADD COLUMN `email_leave_reviews` boolean NOT NULL DEFAULT true AFTER `email_team_changes`;
+
+-- Create email_messages table, the send queue and log of emails. The bodies are rendered when an email
+-- is queued, a pending email is sent once next_attempt_at has passed and retried with exponential backoff.
+CREATE TABLE IF NOT EXISTS `email_messages` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` int unsigned NULL,
+  `kind` varchar(50) NOT NULL,
+  `to_address` varchar(255) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `html_body` mediumtext NOT NULL,
+  `text_body` mediumtext NOT NULL,
+  `status` enum('pending','sent','failed') NOT NULL DEFAULT 'pending',
+  `attempts` int unsigned NOT NULL DEFAULT 0,
+  `next_attempt_at` timestamp NULL,
+  `last_attempt_at` timestamp NULL,
+  `error` text NULL,
+  `sent_at` timestamp NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_email_messages_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  KEY `idx_email_messages_due` (`status`, `next_attempt_at`)
+);
+
+-- Create password_reset_tokens table. Only the SHA-256 hash of a token is stored, the token itself
+-- is only in the emailed link; a token is used once and every token of the user is dropped on reset.
+CREATE TABLE IF NOT EXISTS `password_reset_tokens` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `user_id` int unsigned NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `expires_at` timestamp NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT `fk_password_reset_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  UNIQUE KEY `idx_password_reset_tokens_token_hash` (`token_hash`),
+  KEY `idx_password_reset_tokens_user_id` (`user_id`, `created_at`)
+);
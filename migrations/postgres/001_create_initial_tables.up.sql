@@ -0,0 +1,131 @@
+-- Create positions table
+CREATE TABLE IF NOT EXISTS positions (
+  id serial PRIMARY KEY,
+  name varchar(255) NOT NULL UNIQUE,
+  abbreviation varchar(50) NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+  id serial PRIMARY KEY,
+  name varchar(255) NOT NULL,
+  email varchar(255) NOT NULL UNIQUE,
+  password varchar(255) NOT NULL,
+  birthday date NULL,
+  current_team_id integer NULL,
+  position_id integer NOT NULL,
+  role varchar(10) NOT NULL DEFAULT 'user' CHECK (role IN ('admin', 'user')),
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_users_position_id FOREIGN KEY (position_id) REFERENCES positions (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+-- Create teams table
+CREATE TABLE IF NOT EXISTS teams (
+  id serial PRIMARY KEY,
+  name varchar(255) NOT NULL UNIQUE,
+  description text NULL,
+  leader_id integer NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_teams_leader_id FOREIGN KEY (leader_id) REFERENCES users (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX idx_leader_id ON teams (leader_id);
+
+-- Add foreign key for users.current_team_id
+ALTER TABLE users ADD CONSTRAINT fk_users_current_team_id FOREIGN KEY (current_team_id) REFERENCES teams (id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- Create team_members table
+CREATE TABLE IF NOT EXISTS team_members (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  team_id integer NOT NULL,
+  joined_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  left_at timestamptz NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_team_members_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_team_members_team_id FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_team_members_team_id ON team_members (team_id);
+-- A user is an active member of one team at most
+CREATE UNIQUE INDEX ux_active_user_in_team
+  ON team_members (user_id)
+  WHERE left_at IS NULL;
+
+-- Create skills table
+CREATE TABLE IF NOT EXISTS skills (
+  id serial PRIMARY KEY,
+  name varchar(255) NOT NULL UNIQUE,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create user_skills table
+CREATE TABLE IF NOT EXISTS user_skills (
+  user_id integer NOT NULL,
+  skill_id integer NOT NULL,
+  level integer NOT NULL,
+  used_year_number integer NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, skill_id),
+  CONSTRAINT fk_user_skills_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_user_skills_skill_id FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_user_skills_skill_id ON user_skills (skill_id);
+
+-- Create projects table
+CREATE TABLE IF NOT EXISTS projects (
+  id serial PRIMARY KEY,
+  name varchar(255) NOT NULL,
+  abbreviation varchar(50) NOT NULL,
+  start_date date NULL,
+  end_date date NULL,
+  leader_id integer NOT NULL,
+  team_id integer NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_projects_leader_id FOREIGN KEY (leader_id) REFERENCES users (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+  CONSTRAINT fk_projects_team_id FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX idx_projects_leader_id ON projects (leader_id);
+CREATE INDEX idx_projects_team_id ON projects (team_id);
+
+-- Create project_members table
+CREATE TABLE IF NOT EXISTS project_members (
+  project_id integer NOT NULL,
+  user_id integer NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (project_id, user_id),
+  CONSTRAINT fk_project_members_project_id FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_project_members_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_project_members_user_id ON project_members (user_id);
+
+-- Create activity_logs table
+CREATE TABLE IF NOT EXISTS activity_logs (
+  id serial PRIMARY KEY,
+  action varchar(255) NOT NULL,
+  user_id integer NOT NULL,
+  description text NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_activity_logs_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+CREATE INDEX idx_activity_logs_user_id ON activity_logs (user_id);
+CREATE INDEX idx_activity_logs_created_at ON activity_logs (created_at);
+
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  title varchar(255) NOT NULL,
+  content text NOT NULL,
+  is_read boolean NOT NULL DEFAULT FALSE,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_notifications_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_notifications_user_id ON notifications (user_id);
+CREATE INDEX idx_notifications_is_read ON notifications (is_read);
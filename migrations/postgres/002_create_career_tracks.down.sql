@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS position_required_skills;
+
+-- Dropping the columns drops their constraints
+ALTER TABLE positions
+  DROP COLUMN grade,
+  DROP COLUMN career_track_id;
+
+DROP TABLE IF EXISTS career_tracks;
@@ -0,0 +1,19 @@
+-- Create user_position_histories table
+CREATE TABLE IF NOT EXISTS user_position_histories (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  old_position_id integer NULL,
+  new_position_id integer NOT NULL,
+  team_id integer NULL,
+  is_promotion boolean NOT NULL DEFAULT FALSE,
+  effective_date date NOT NULL,
+  changed_by_id integer NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_user_position_histories_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_user_position_histories_old_position_id FOREIGN KEY (old_position_id) REFERENCES positions (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT fk_user_position_histories_new_position_id FOREIGN KEY (new_position_id) REFERENCES positions (id) ON DELETE RESTRICT ON UPDATE CASCADE,
+  CONSTRAINT fk_user_position_histories_team_id FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT fk_user_position_histories_changed_by_id FOREIGN KEY (changed_by_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX idx_user_position_histories_user_id ON user_position_histories (user_id);
+CREATE INDEX idx_user_position_histories_effective_date ON user_position_histories (effective_date);
@@ -0,0 +1,3 @@
+-- Allow users to opt out of showing their birthday
+ALTER TABLE users
+  ADD COLUMN show_birthday boolean NOT NULL DEFAULT TRUE;
@@ -0,0 +1,29 @@
+-- Create leave_requests table
+CREATE TABLE IF NOT EXISTS leave_requests (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  team_id integer NOT NULL,
+  type varchar(10) NOT NULL CHECK (type IN ('annual', 'sick', 'unpaid', 'other')),
+  start_date date NOT NULL,
+  end_date date NOT NULL,
+  start_half_day boolean NOT NULL DEFAULT FALSE,
+  end_half_day boolean NOT NULL DEFAULT FALSE,
+  reason text NULL,
+  status varchar(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled')),
+  reviewer_id integer NULL,
+  review_note text NULL,
+  reviewed_at timestamptz NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_leave_requests_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_leave_requests_team_id FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_leave_requests_reviewer_id FOREIGN KEY (reviewer_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX idx_leave_requests_user_id_dates ON leave_requests (user_id, start_date, end_date);
+CREATE INDEX idx_leave_requests_team_id_dates ON leave_requests (team_id, start_date, end_date);
+CREATE INDEX idx_leave_requests_status ON leave_requests (status);
+
+-- Secret of the calendar feed URLs of the user, calendar clients subscribe with it instead of a Bearer token
+ALTER TABLE users
+  ADD COLUMN calendar_feed_token_hash char(64) NULL,
+  ADD CONSTRAINT idx_users_calendar_feed_token_hash UNIQUE (calendar_feed_token_hash);
@@ -0,0 +1,35 @@
+-- Create timesheets table, one per user per week (week_start is a Monday)
+CREATE TABLE IF NOT EXISTS timesheets (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  week_start date NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'submitted', 'approved', 'rejected')),
+  submitted_at timestamptz NULL,
+  reviewer_id integer NULL,
+  review_note text NULL,
+  reviewed_at timestamptz NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT ux_timesheets_user_id_week_start UNIQUE (user_id, week_start),
+  CONSTRAINT fk_timesheets_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_timesheets_reviewer_id FOREIGN KEY (reviewer_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX idx_timesheets_status ON timesheets (status);
+
+-- Create time_entries table, one per user per project per day
+CREATE TABLE IF NOT EXISTS time_entries (
+  id serial PRIMARY KEY,
+  timesheet_id integer NOT NULL,
+  user_id integer NOT NULL,
+  project_id integer NOT NULL,
+  work_date date NOT NULL,
+  hours decimal(4,2) NOT NULL,
+  description text NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT ux_time_entries_user_id_project_id_work_date UNIQUE (user_id, project_id, work_date),
+  CONSTRAINT fk_time_entries_timesheet_id FOREIGN KEY (timesheet_id) REFERENCES timesheets (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_time_entries_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_time_entries_project_id FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_time_entries_project_id_work_date ON time_entries (project_id, work_date);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE users
+  DROP COLUMN two_factor_last_used_step,
+  DROP COLUMN two_factor_required,
+  DROP COLUMN two_factor_enabled,
+  DROP COLUMN two_factor_secret;
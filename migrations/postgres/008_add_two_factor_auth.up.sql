@@ -0,0 +1,17 @@
+-- TOTP two-factor authentication state per account
+ALTER TABLE users
+  ADD COLUMN two_factor_secret varchar(64) NULL,
+  ADD COLUMN two_factor_enabled boolean NOT NULL DEFAULT false,
+  ADD COLUMN two_factor_required boolean NOT NULL DEFAULT false,
+  ADD COLUMN two_factor_last_used_step bigint NULL;
+
+-- Create user_recovery_codes table, one-time codes replacing a TOTP code when the device is lost
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  code_hash char(64) NOT NULL,
+  used_at timestamptz NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_user_recovery_codes_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT idx_user_recovery_codes_user_id_code_hash UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE users
+  DROP CONSTRAINT idx_users_oidc_issuer_subject,
+  DROP COLUMN oidc_subject,
+  DROP COLUMN oidc_issuer;
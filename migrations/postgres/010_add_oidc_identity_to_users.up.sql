@@ -0,0 +1,5 @@
+-- Link users to their identity at the OpenID Connect provider, the subject is only unique per issuer
+ALTER TABLE users
+  ADD COLUMN oidc_issuer varchar(255) NULL,
+  ADD COLUMN oidc_subject varchar(255) NULL,
+  ADD CONSTRAINT idx_users_oidc_issuer_subject UNIQUE (oidc_issuer, oidc_subject);
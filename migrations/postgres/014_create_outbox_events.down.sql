@@ -0,0 +1,15 @@
+-- Entries about deleted users cannot be kept once user_id is required again
+ALTER TABLE activity_logs
+  DROP CONSTRAINT fk_activity_logs_user_id;
+
+DELETE FROM activity_logs WHERE user_id IS NULL;
+
+DROP INDEX IF EXISTS idx_activity_logs_action;
+
+ALTER TABLE activity_logs
+  DROP COLUMN event_id,
+  ALTER COLUMN user_id SET NOT NULL,
+  ADD CONSTRAINT fk_activity_logs_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+DROP TABLE IF EXISTS outbox_processed_events;
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,38 @@
+-- Email preferences of users, welcome and password reset emails are always sent
+ALTER TABLE users
+  ADD COLUMN email_team_changes boolean NOT NULL DEFAULT true,
+  ADD COLUMN email_leave_reviews boolean NOT NULL DEFAULT true;
+
+-- Create email_messages table, the send queue and log of emails. The bodies are rendered when an email
+-- is queued, a pending email is sent once next_attempt_at has passed and retried with exponential backoff.
+CREATE TABLE IF NOT EXISTS email_messages (
+  id serial PRIMARY KEY,
+  user_id integer NULL,
+  kind varchar(50) NOT NULL,
+  to_address varchar(255) NOT NULL,
+  subject varchar(255) NOT NULL,
+  html_body text NOT NULL,
+  text_body text NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamptz NULL,
+  last_attempt_at timestamptz NULL,
+  error text NULL,
+  sent_at timestamptz NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_email_messages_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX idx_email_messages_due ON email_messages (status, next_attempt_at);
+
+-- Create password_reset_tokens table. Only the SHA-256 hash of a token is stored, the token itself
+-- is only in the emailed link; a token is used once and every token of the user is dropped on reset.
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+  id serial PRIMARY KEY,
+  user_id integer NOT NULL,
+  token_hash char(64) NOT NULL,
+  expires_at timestamptz NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_password_reset_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT idx_password_reset_tokens_token_hash UNIQUE (token_hash)
+);
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id, created_at);
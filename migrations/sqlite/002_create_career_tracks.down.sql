@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS position_required_skills;
+
+-- A column cannot be dropped while an index uses it
+DROP INDEX IF EXISTS ux_positions_career_track_grade;
+ALTER TABLE positions
+  DROP COLUMN grade;
+ALTER TABLE positions
+  DROP COLUMN career_track_id;
+
+DROP TABLE IF EXISTS career_tracks;
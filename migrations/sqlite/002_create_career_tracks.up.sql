@@ -0,0 +1,28 @@
+-- Create career_tracks table
+CREATE TABLE IF NOT EXISTS career_tracks (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  name varchar(255) NOT NULL UNIQUE,
+  description text NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Group positions into career tracks with ordered grades
+ALTER TABLE positions
+  ADD COLUMN career_track_id integer NULL REFERENCES career_tracks (id) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE positions
+  ADD COLUMN grade integer NULL;
+CREATE UNIQUE INDEX ux_positions_career_track_grade ON positions (career_track_id, grade);
+
+-- Create position_required_skills table
+CREATE TABLE IF NOT EXISTS position_required_skills (
+  position_id integer NOT NULL,
+  skill_id integer NOT NULL,
+  min_level integer NOT NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (position_id, skill_id),
+  CONSTRAINT fk_position_required_skills_position_id FOREIGN KEY (position_id) REFERENCES positions (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT fk_position_required_skills_skill_id FOREIGN KEY (skill_id) REFERENCES skills (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_position_required_skills_skill_id ON position_required_skills (skill_id);
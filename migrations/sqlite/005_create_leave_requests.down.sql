@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_calendar_feed_token_hash;
+ALTER TABLE users
+  DROP COLUMN calendar_feed_token_hash;
+
+DROP TABLE IF EXISTS leave_requests;
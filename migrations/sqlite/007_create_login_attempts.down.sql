@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS login_attempts;
+
+ALTER TABLE users
+  DROP COLUMN locked_until;
+ALTER TABLE users
+  DROP COLUMN last_failed_login_at;
+ALTER TABLE users
+  DROP COLUMN failed_login_count;
@@ -0,0 +1,25 @@
+-- Track failed logins and lockouts per account
+ALTER TABLE users
+  ADD COLUMN failed_login_count integer NOT NULL DEFAULT 0;
+ALTER TABLE users
+  ADD COLUMN last_failed_login_at timestamp NULL;
+ALTER TABLE users
+  ADD COLUMN locked_until timestamp NULL;
+
+-- Create login_attempts table, the audit of every login on both the user and admin flows
+CREATE TABLE IF NOT EXISTS login_attempts (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  flow varchar(10) NOT NULL CHECK (flow IN ('user', 'admin')),
+  email varchar(255) NOT NULL,
+  user_id integer NULL,
+  ip_address varchar(45) NOT NULL,
+  user_agent varchar(255) NULL,
+  success boolean NOT NULL,
+  failure_reason varchar(50) NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_login_attempts_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX idx_login_attempts_ip_address_created_at ON login_attempts (ip_address, created_at);
+CREATE INDEX idx_login_attempts_email_created_at ON login_attempts (email, created_at);
+CREATE INDEX idx_login_attempts_user_id_created_at ON login_attempts (user_id, created_at);
+CREATE INDEX idx_login_attempts_created_at ON login_attempts (created_at);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE users
+  DROP COLUMN two_factor_last_used_step;
+ALTER TABLE users
+  DROP COLUMN two_factor_required;
+ALTER TABLE users
+  DROP COLUMN two_factor_enabled;
+ALTER TABLE users
+  DROP COLUMN two_factor_secret;
@@ -0,0 +1,18 @@
+-- Create admin_sessions table, the server-side store behind the admin session cookie.
+-- The cookie only carries a signed random token, the table keeps its SHA-256 hash so a
+-- leaked table cannot be replayed as cookies
+CREATE TABLE IF NOT EXISTS admin_sessions (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  token_hash char(64) NOT NULL,
+  user_id integer NULL,
+  data blob NOT NULL,
+  ip_address varchar(45) NOT NULL,
+  user_agent varchar(255) NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_seen_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_at timestamp NOT NULL,
+  CONSTRAINT fk_admin_sessions_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT idx_admin_sessions_token_hash UNIQUE (token_hash)
+);
+CREATE INDEX idx_admin_sessions_user_id_expires_at ON admin_sessions (user_id, expires_at);
+CREATE INDEX idx_admin_sessions_expires_at ON admin_sessions (expires_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_oidc_issuer_subject;
+ALTER TABLE users
+  DROP COLUMN oidc_subject;
+ALTER TABLE users
+  DROP COLUMN oidc_issuer;
@@ -0,0 +1,6 @@
+-- Link users to their identity at the OpenID Connect provider, the subject is only unique per issuer
+ALTER TABLE users
+  ADD COLUMN oidc_issuer varchar(255) NULL;
+ALTER TABLE users
+  ADD COLUMN oidc_subject varchar(255) NULL;
+CREATE UNIQUE INDEX idx_users_oidc_issuer_subject ON users (oidc_issuer, oidc_subject);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS ldap_sync_runs;
+
+DROP INDEX IF EXISTS idx_users_ldap_uid;
+ALTER TABLE users
+  DROP COLUMN deactivated_at;
+ALTER TABLE users
+  DROP COLUMN ldap_uid;
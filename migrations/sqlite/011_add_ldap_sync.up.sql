@@ -0,0 +1,25 @@
+-- Link users to their LDAP directory entry, users removed from the directory are deactivated rather than deleted
+ALTER TABLE users
+  ADD COLUMN ldap_uid varchar(255) NULL;
+ALTER TABLE users
+  ADD COLUMN deactivated_at timestamp NULL;
+CREATE UNIQUE INDEX idx_users_ldap_uid ON users (ldap_uid);
+
+-- Create ldap_sync_runs table, the report of every directory synchronisation
+CREATE TABLE IF NOT EXISTS ldap_sync_runs (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  "trigger" varchar(10) NOT NULL CHECK ("trigger" IN ('schedule', 'cli', 'admin')),
+  dry_run boolean NOT NULL DEFAULT false,
+  status varchar(10) NOT NULL CHECK (status IN ('running', 'succeeded', 'failed')),
+  directory_entries integer NOT NULL DEFAULT 0,
+  created_count integer NOT NULL DEFAULT 0,
+  updated_count integer NOT NULL DEFAULT 0,
+  deactivated_count integer NOT NULL DEFAULT 0,
+  reactivated_count integer NOT NULL DEFAULT 0,
+  skipped_count integer NOT NULL DEFAULT 0,
+  error text NULL,
+  details text NULL,
+  started_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  finished_at timestamp NULL
+);
+CREATE INDEX idx_ldap_sync_runs_started_at ON ldap_sync_runs (started_at);
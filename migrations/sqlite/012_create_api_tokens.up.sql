@@ -0,0 +1,17 @@
+-- Create api_tokens table, personal access tokens users create for scripts and integrations.
+-- Only the SHA-256 hash of a token is stored, the prefix identifies it in listings.
+CREATE TABLE IF NOT EXISTS api_tokens (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  user_id integer NOT NULL,
+  name varchar(100) NOT NULL,
+  token_prefix varchar(16) NOT NULL,
+  token_hash char(64) NOT NULL,
+  scopes varchar(255) NOT NULL,
+  expires_at timestamp NULL,
+  last_used_at timestamp NULL,
+  last_used_ip varchar(45) NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_api_tokens_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT idx_api_tokens_token_hash UNIQUE (token_hash)
+);
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id);
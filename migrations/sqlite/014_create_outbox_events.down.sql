@@ -0,0 +1,19 @@
+-- SQLite cannot change a column or its foreign key in place, so the table is rebuilt.
+-- Entries about deleted users cannot be kept once user_id is required again.
+CREATE TABLE activity_logs_old (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  action varchar(255) NOT NULL,
+  user_id integer NOT NULL,
+  description text NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_activity_logs_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT ON UPDATE CASCADE
+);
+INSERT INTO activity_logs_old (id, action, user_id, description, created_at)
+  SELECT id, action, user_id, description, created_at FROM activity_logs WHERE user_id IS NOT NULL;
+DROP TABLE activity_logs;
+ALTER TABLE activity_logs_old RENAME TO activity_logs;
+CREATE INDEX idx_activity_logs_user_id ON activity_logs (user_id);
+CREATE INDEX idx_activity_logs_created_at ON activity_logs (created_at);
+
+DROP TABLE IF EXISTS outbox_processed_events;
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,49 @@
+-- Create outbox_events table, domain events written in the transaction of the change they describe
+-- and dispatched to the in-process subscribers afterwards. event_id is the idempotency key of the event.
+CREATE TABLE IF NOT EXISTS outbox_events (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  event_id char(36) NOT NULL,
+  event_type varchar(50) NOT NULL,
+  payload text NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dispatched', 'failed')),
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamp NULL,
+  last_error text NULL,
+  occurred_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  dispatched_at timestamp NULL,
+  CONSTRAINT idx_outbox_events_event_id UNIQUE (event_id)
+);
+CREATE INDEX idx_outbox_events_due ON outbox_events (status, next_attempt_at);
+CREATE INDEX idx_outbox_events_dispatched_at ON outbox_events (dispatched_at);
+
+-- Create outbox_processed_events table, the events each subscriber has handled. An event dispatched
+-- again after a partial failure is skipped by the subscribers that already handled it.
+CREATE TABLE IF NOT EXISTS outbox_processed_events (
+  subscriber varchar(50) NOT NULL,
+  event_id char(36) NOT NULL,
+  processed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (subscriber, event_id),
+  CONSTRAINT fk_outbox_processed_events_event_id FOREIGN KEY (event_id) REFERENCES outbox_events (event_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX idx_outbox_processed_events_event_id ON outbox_processed_events (event_id);
+
+-- The activity log is written from domain events. Entries outlive the users they are about,
+-- and event_id keeps an event from being logged twice.
+-- SQLite cannot change a column or its foreign key in place, so the table is rebuilt
+CREATE TABLE activity_logs_new (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  event_id char(36) NULL,
+  action varchar(255) NOT NULL,
+  user_id integer NULL,
+  description text NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_activity_logs_user_id FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+INSERT INTO activity_logs_new (id, action, user_id, description, created_at)
+  SELECT id, action, user_id, description, created_at FROM activity_logs;
+DROP TABLE activity_logs;
+ALTER TABLE activity_logs_new RENAME TO activity_logs;
+CREATE INDEX idx_activity_logs_user_id ON activity_logs (user_id);
+CREATE INDEX idx_activity_logs_created_at ON activity_logs (created_at);
+CREATE UNIQUE INDEX idx_activity_logs_event_id ON activity_logs (event_id);
+CREATE INDEX idx_activity_logs_action ON activity_logs (action, created_at);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS email_messages;
+
+ALTER TABLE users
+  DROP COLUMN email_leave_reviews;
+ALTER TABLE users
+  DROP COLUMN email_team_changes;
@@ -0,0 +1,3 @@
+-- Version of the access tokens of the user, bumped when the password changes so that older tokens are rejected
+ALTER TABLE users
+  ADD COLUMN token_version integer NOT NULL DEFAULT 0;
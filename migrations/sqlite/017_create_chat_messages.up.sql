@@ -0,0 +1,16 @@
+-- Create chat_messages table, the queue of messages posted to the incoming webhook of the chat workspace.
+-- A message is queued in the transaction of the outbox event it is about and posted once next_attempt_at
+-- has passed, failed posts are retried with exponential backoff.
+CREATE TABLE IF NOT EXISTS chat_messages (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  event_id char(36) NOT NULL,
+  text text NOT NULL,
+  status varchar(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamp NULL,
+  last_attempt_at timestamp NULL,
+  error text NULL,
+  sent_at timestamp NULL,
+  created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_chat_messages_due ON chat_messages (status, next_attempt_at);
@@ -3,12 +3,14 @@ package models
 import "time"
 
 type ActivityLog struct {
-	ID          uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	ID uint `gorm:"column:id;primaryKey;type:int unsigned"`
+	// Domain event the entry was written from
+	EventID     *string   `gorm:"column:event_id;type:char(36);uniqueIndex:idx_activity_logs_event_id"`
 	Action      string    `gorm:"column:action;type:varchar(255);not null"`
-	UserID      uint      `gorm:"column:user_id;type:int unsigned;not null"`
+	UserID      *uint     `gorm:"column:user_id;type:int unsigned"`
 	Description *string   `gorm:"column:description;type:text"`
 	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
 
 	// Relationships
-	User User `gorm:"foreignKey:UserID;references:ID"`
+	User *User `gorm:"foreignKey:UserID;references:ID"`
 }
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// AdminSession is a server-side admin panel session. UserID is empty until the admin signs in,
+// the session already exists before that to hold the CSRF salt and a pending second factor
+type AdminSession struct {
+	ID         uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	TokenHash  string    `gorm:"column:token_hash;type:char(64);not null;uniqueIndex:idx_admin_sessions_token_hash"`
+	UserID     *uint     `gorm:"column:user_id;type:int unsigned"`
+	Data       []byte    `gorm:"column:data;type:blob;not null"`
+	IPAddress  string    `gorm:"column:ip_address;type:varchar(45);not null"`
+	UserAgent  *string   `gorm:"column:user_agent;type:varchar(255)"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp;not null"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at;type:timestamp;not null"`
+	ExpiresAt  time.Time `gorm:"column:expires_at;type:timestamp;not null"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;references:ID"`
+}
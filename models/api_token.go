@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Scopes of an APIToken, a login token from /login can do everything the user can
+const (
+	APITokenScopeProfileRead = "profile:read"
+	APITokenScopeTeamsRead   = "teams:read"
+	APITokenScopeAdminWrite  = "admin:write"
+)
+
+// APIToken is a personal access token a user created for scripts and integrations
+type APIToken struct {
+	ID          uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID      uint       `gorm:"column:user_id;type:int unsigned;not null"`
+	Name        string     `gorm:"column:name;type:varchar(100);not null"`
+	TokenPrefix string     `gorm:"column:token_prefix;type:varchar(16);not null"`
+	TokenHash   string     `gorm:"column:token_hash;type:char(64);not null;uniqueIndex:idx_api_tokens_token_hash"`
+	Scopes      string     `gorm:"column:scopes;type:varchar(255);not null"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at;type:timestamp"`
+	LastUsedAt  *time.Time `gorm:"column:last_used_at;type:timestamp"`
+	LastUsedIP  *string    `gorm:"column:last_used_ip;type:varchar(45)"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// ScopeList splits the space-separated scopes
+func (t *APIToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
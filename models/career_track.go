@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type CareerTrack struct {
+	ID          uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	Name        string    `gorm:"column:name;type:varchar(255);not null"`
+	Description *string   `gorm:"column:description;type:text"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+
+	// Relationships
+	Positions []Position `gorm:"foreignKey:CareerTrackID;references:ID"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	ChatMessageStatusPending = "pending"
+	ChatMessageStatusSent    = "sent"
+	ChatMessageStatusFailed  = "failed"
+)
+
+// ChatMessage is one message queued for, or posted to, the incoming webhook of the chat workspace
+type ChatMessage struct {
+	ID            uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	EventID       string     `gorm:"column:event_id;type:char(36);not null"`
+	Text          string     `gorm:"column:text;type:text;not null"`
+	Status        string     `gorm:"column:status;type:enum('pending','sent','failed');default:'pending';not null"`
+	Attempts      int        `gorm:"column:attempts;type:int unsigned;default:0;not null"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at;type:timestamp"`
+	LastAttemptAt *time.Time `gorm:"column:last_attempt_at;type:timestamp"`
+	Error         *string    `gorm:"column:error;type:text"`
+	SentAt        *time.Time `gorm:"column:sent_at;type:timestamp"`
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+const (
+	EmailMessageStatusPending = "pending"
+	EmailMessageStatusSent    = "sent"
+	EmailMessageStatusFailed  = "failed"
+)
+
+// Kinds of email, each has templates of the same name in internal/mailer/templates
+const (
+	EmailKindWelcome       = "welcome"
+	EmailKindPasswordReset = "password_reset"
+	EmailKindTeamChange    = "team_change"
+	EmailKindLeaveReview   = "leave_review"
+)
+
+// EmailMessage is one email queued for, or handed to, the mailer
+type EmailMessage struct {
+	ID            uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID        *uint      `gorm:"column:user_id;type:int unsigned"`
+	Kind          string     `gorm:"column:kind;type:varchar(50);not null"`
+	ToAddress     string     `gorm:"column:to_address;type:varchar(255);not null"`
+	Subject       string     `gorm:"column:subject;type:varchar(255);not null"`
+	HTMLBody      string     `gorm:"column:html_body;type:mediumtext;not null"`
+	TextBody      string     `gorm:"column:text_body;type:mediumtext;not null"`
+	Status        string     `gorm:"column:status;type:enum('pending','sent','failed');default:'pending';not null"`
+	Attempts      int        `gorm:"column:attempts;type:int unsigned;default:0;not null"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at;type:timestamp"`
+	LastAttemptAt *time.Time `gorm:"column:last_attempt_at;type:timestamp"`
+	Error         *string    `gorm:"column:error;type:text"`
+	SentAt        *time.Time `gorm:"column:sent_at;type:timestamp"`
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+}
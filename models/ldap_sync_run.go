@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	LDAPSyncTriggerSchedule = "schedule"
+	LDAPSyncTriggerCLI      = "cli"
+	LDAPSyncTriggerAdmin    = "admin"
+
+	LDAPSyncStatusRunning   = "running"
+	LDAPSyncStatusSucceeded = "succeeded"
+	LDAPSyncStatusFailed    = "failed"
+)
+
+// LDAPSyncRun is the report of one synchronisation of users from the LDAP directory
+type LDAPSyncRun struct {
+	ID               uint    `gorm:"column:id;primaryKey;type:int unsigned"`
+	Trigger          string  `gorm:"column:trigger;type:enum('schedule','cli','admin');not null"`
+	DryRun           bool    `gorm:"column:dry_run;type:boolean;default:false;not null"`
+	Status           string  `gorm:"column:status;type:enum('running','succeeded','failed');not null"`
+	DirectoryEntries int     `gorm:"column:directory_entries;type:int unsigned;default:0;not null"`
+	CreatedCount     int     `gorm:"column:created_count;type:int unsigned;default:0;not null"`
+	UpdatedCount     int     `gorm:"column:updated_count;type:int unsigned;default:0;not null"`
+	DeactivatedCount int     `gorm:"column:deactivated_count;type:int unsigned;default:0;not null"`
+	ReactivatedCount int     `gorm:"column:reactivated_count;type:int unsigned;default:0;not null"`
+	SkippedCount     int     `gorm:"column:skipped_count;type:int unsigned;default:0;not null"`
+	Error            *string `gorm:"column:error;type:text"`
+	// JSON array of the changes made to each user, see dtos.LDAPSyncChange
+	Details    *string    `gorm:"column:details;type:json"`
+	StartedAt  time.Time  `gorm:"column:started_at;type:timestamp;not null"`
+	FinishedAt *time.Time `gorm:"column:finished_at;type:timestamp"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+const (
+	LeaveTypeAnnual = "annual"
+	LeaveTypeSick   = "sick"
+	LeaveTypeUnpaid = "unpaid"
+	LeaveTypeOther  = "other"
+
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+)
+
+// LeaveRequest is an absence of a user between StartDate and EndDate (inclusive).
+// StartHalfDay means the leave starts at noon, EndHalfDay means it ends at noon.
+type LeaveRequest struct {
+	ID           uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID       uint       `gorm:"column:user_id;type:int unsigned;not null"`
+	TeamID       uint       `gorm:"column:team_id;type:int unsigned;not null"`
+	Type         string     `gorm:"column:type;type:enum('annual','sick','unpaid','other');not null"`
+	StartDate    time.Time  `gorm:"column:start_date;type:date;not null"`
+	EndDate      time.Time  `gorm:"column:end_date;type:date;not null"`
+	StartHalfDay bool       `gorm:"column:start_half_day;type:boolean;default:false;not null"`
+	EndHalfDay   bool       `gorm:"column:end_half_day;type:boolean;default:false;not null"`
+	Reason       *string    `gorm:"column:reason;type:text"`
+	Status       string     `gorm:"column:status;type:enum('pending','approved','rejected','cancelled');default:'pending';not null"`
+	ReviewerID   *uint      `gorm:"column:reviewer_id;type:int unsigned"`
+	ReviewNote   *string    `gorm:"column:review_note;type:text"`
+	ReviewedAt   *time.Time `gorm:"column:reviewed_at;type:timestamp"`
+	CreatedAt    time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+
+	// Relationships
+	User     User  `gorm:"foreignKey:UserID;references:ID"`
+	Team     Team  `gorm:"foreignKey:TeamID;references:ID"`
+	Reviewer *User `gorm:"foreignKey:ReviewerID;references:ID"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+const (
+	LoginFlowUser  = "user"
+	LoginFlowAdmin = "admin"
+
+	LoginFailureInvalidCredentials  = "invalid_credentials"
+	LoginFailureUnknownEmail        = "unknown_email"
+	LoginFailureNotAdmin            = "not_admin"
+	LoginFailureAccountLocked       = "account_locked"
+	LoginFailureThrottled           = "throttled"
+	LoginFailureInvalidSecondFactor = "invalid_second_factor"
+	LoginFailureSSONoAccount        = "sso_no_account"
+	LoginFailureAccountDeactivated  = "account_deactivated"
+)
+
+type LoginAttempt struct {
+	ID            uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	Flow          string    `gorm:"column:flow;type:enum('user','admin');not null"`
+	Email         string    `gorm:"column:email;type:varchar(255);not null"`
+	UserID        *uint     `gorm:"column:user_id;type:int unsigned"`
+	IPAddress     string    `gorm:"column:ip_address;type:varchar(45);not null"`
+	UserAgent     *string   `gorm:"column:user_agent;type:varchar(255)"`
+	Success       bool      `gorm:"column:success;type:boolean;not null"`
+	FailureReason *string   `gorm:"column:failure_reason;type:varchar(50)"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;references:ID"`
+}
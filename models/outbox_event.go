@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Domain events, recorded in the outbox in the same transaction as the change they describe
+const (
+	DomainEventUserCreated       = "user.created"
+	DomainEventUserDeleted       = "user.deleted"
+	DomainEventTeamCreated       = "team.created"
+	DomainEventTeamDeleted       = "team.deleted"
+	DomainEventTeamMemberAdded   = "team.member_added"
+	DomainEventTeamMemberRemoved = "team.member_removed"
+	DomainEventTeamLeaderChanged = "team.leader_changed"
+)
+
+var DomainEventTypes = []string{
+	DomainEventUserCreated,
+	DomainEventUserDeleted,
+	DomainEventTeamCreated,
+	DomainEventTeamDeleted,
+	DomainEventTeamMemberAdded,
+	DomainEventTeamMemberRemoved,
+	DomainEventTeamLeaderChanged,
+}
+
+const (
+	OutboxEventStatusPending    = "pending"
+	OutboxEventStatusDispatched = "dispatched"
+	OutboxEventStatusFailed     = "failed"
+)
+
+type OutboxEvent struct {
+	ID            uint64     `gorm:"column:id;primaryKey;type:bigint unsigned"`
+	EventID       string     `gorm:"column:event_id;type:char(36);not null;uniqueIndex:idx_outbox_events_event_id"`
+	EventType     string     `gorm:"column:event_type;type:varchar(50);not null"`
+	Payload       string     `gorm:"column:payload;type:json;not null"`
+	Status        string     `gorm:"column:status;type:enum('pending','dispatched','failed');default:'pending';not null"`
+	Attempts      int        `gorm:"column:attempts;type:int unsigned;default:0;not null"`
+	NextAttemptAt *time.Time `gorm:"column:next_attempt_at;type:timestamp"`
+	LastError     *string    `gorm:"column:last_error;type:text"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at;type:timestamp;not null"`
+	DispatchedAt  *time.Time `gorm:"column:dispatched_at;type:timestamp"`
+}
+
+// OutboxProcessedEvent records that a subscriber handled an event
+type OutboxProcessedEvent struct {
+	Subscriber  string    `gorm:"column:subscriber;primaryKey;type:varchar(50)"`
+	EventID     string    `gorm:"column:event_id;primaryKey;type:char(36)"`
+	ProcessedAt time.Time `gorm:"column:processed_at;type:timestamp;autoCreateTime;not null"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PasswordResetToken lets the user choose a new password through the link emailed to them
+type PasswordResetToken struct {
+	ID        uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID    uint      `gorm:"column:user_id;type:int unsigned;not null"`
+	TokenHash string    `gorm:"column:token_hash;type:char(64);not null;uniqueIndex:idx_password_reset_tokens_token_hash"`
+	ExpiresAt time.Time `gorm:"column:expires_at;type:timestamp;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+}
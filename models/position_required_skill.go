@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type PositionRequiredSkill struct {
+	PositionID uint      `gorm:"column:position_id;primaryKey;type:int unsigned;not null"`
+	SkillID    uint      `gorm:"column:skill_id;primaryKey;type:int unsigned;not null"`
+	MinLevel   int       `gorm:"column:min_level;type:int;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+
+	// Relationships
+	Position Position `gorm:"foreignKey:PositionID;references:ID"`
+	Skill    Skill    `gorm:"foreignKey:SkillID;references:ID"`
+}
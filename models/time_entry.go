@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type TimeEntry struct {
+	ID          uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	TimesheetID uint      `gorm:"column:timesheet_id;type:int unsigned;not null"`
+	UserID      uint      `gorm:"column:user_id;type:int unsigned;not null"`
+	ProjectID   uint      `gorm:"column:project_id;type:int unsigned;not null"`
+	WorkDate    time.Time `gorm:"column:work_date;type:date;not null"`
+	Hours       float64   `gorm:"column:hours;type:decimal(4,2);not null"`
+	Description *string   `gorm:"column:description;type:text"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+
+	// Relationships
+	Timesheet Timesheet `gorm:"foreignKey:TimesheetID;references:ID"`
+	User      User      `gorm:"foreignKey:UserID;references:ID"`
+	Project   Project   `gorm:"foreignKey:ProjectID;references:ID"`
+}
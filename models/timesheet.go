@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	TimesheetStatusDraft     = "draft"
+	TimesheetStatusSubmitted = "submitted"
+	TimesheetStatusApproved  = "approved"
+	TimesheetStatusRejected  = "rejected"
+)
+
+// Timesheet groups the time entries of a user for the week starting on WeekStart (a Monday)
+type Timesheet struct {
+	ID          uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID      uint       `gorm:"column:user_id;type:int unsigned;not null"`
+	WeekStart   time.Time  `gorm:"column:week_start;type:date;not null"`
+	Status      string     `gorm:"column:status;type:enum('draft','submitted','approved','rejected');default:'draft';not null"`
+	SubmittedAt *time.Time `gorm:"column:submitted_at;type:timestamp"`
+	ReviewerID  *uint      `gorm:"column:reviewer_id;type:int unsigned"`
+	ReviewNote  *string    `gorm:"column:review_note;type:text"`
+	ReviewedAt  *time.Time `gorm:"column:reviewed_at;type:timestamp"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+
+	// Relationships
+	User     User        `gorm:"foreignKey:UserID;references:ID"`
+	Reviewer *User       `gorm:"foreignKey:ReviewerID;references:ID"`
+	Entries  []TimeEntry `gorm:"foreignKey:TimesheetID;references:ID"`
+}
+
+// IsEditable reports whether entries of the timesheet can still be changed
+func (t *Timesheet) IsEditable() bool {
+	return t.Status == TimesheetStatusDraft || t.Status == TimesheetStatusRejected
+}
@@ -10,11 +10,34 @@ type User struct {
 	Email         string     `gorm:"column:email;type:varchar(255);not null;uniqueIndex:idx_email"`
 	Password      string     `gorm:"column:password;type:varchar(255);not null"`
 	Birthday      *time.Time `gorm:"column:birthday;type:date"`
+	ShowBirthday  bool       `gorm:"column:show_birthday;type:boolean;default:true;not null"`
 	CurrentTeamID *uint      `gorm:"column:current_team_id;type:int unsigned"`
 	PositionID    uint       `gorm:"column:position_id;type:int unsigned;not null"`
 	Role          string     `gorm:"column:role;type:enum('admin','user');default:'user';not null"`
-	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
-	UpdatedAt     time.Time  `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+	// Optional emails the user receives, see MailService
+	EmailTeamChanges  bool `gorm:"column:email_team_changes;type:boolean;default:true;not null"`
+	EmailLeaveReviews bool `gorm:"column:email_leave_reviews;type:boolean;default:true;not null"`
+	// Brute-force protection state, see AuthService.Login
+	FailedLoginCount  uint       `gorm:"column:failed_login_count;type:int unsigned;default:0;not null"`
+	LastFailedLoginAt *time.Time `gorm:"column:last_failed_login_at;type:timestamp"`
+	LockedUntil       *time.Time `gorm:"column:locked_until;type:timestamp"`
+	// Carried by access tokens, bumped on every password change so that tokens issued before are rejected
+	TokenVersion uint `gorm:"column:token_version;type:int unsigned;default:0;not null"`
+	// TOTP two-factor authentication, the secret is kept while enrolment is pending
+	TwoFactorSecret       *string `gorm:"column:two_factor_secret;type:varchar(64)"`
+	TwoFactorEnabled      bool    `gorm:"column:two_factor_enabled;type:boolean;default:false;not null"`
+	TwoFactorRequired     bool    `gorm:"column:two_factor_required;type:boolean;default:false;not null"`
+	TwoFactorLastUsedStep *int64  `gorm:"column:two_factor_last_used_step;type:bigint"`
+	// Identity at the OpenID Connect provider, set on the first single sign-on
+	OIDCIssuer  *string `gorm:"column:oidc_issuer;type:varchar(255);uniqueIndex:idx_users_oidc_issuer_subject"`
+	OIDCSubject *string `gorm:"column:oidc_subject;type:varchar(255);uniqueIndex:idx_users_oidc_issuer_subject"`
+	// Entry of the user in the LDAP directory, see LDAPSyncService. Deactivated users cannot sign in.
+	LDAPUID       *string    `gorm:"column:ldap_uid;type:varchar(255);uniqueIndex:idx_users_ldap_uid"`
+	DeactivatedAt *time.Time `gorm:"column:deactivated_at;type:timestamp"`
+	// SHA-256 hash of the secret in the calendar feed URLs of the user, see LeaveService.CreateCalendarFeed
+	CalendarFeedTokenHash *string   `gorm:"column:calendar_feed_token_hash;type:char(64);uniqueIndex:idx_users_calendar_feed_token_hash"`
+	CreatedAt             time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt             time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
 
 	// Relationships
 	CurrentTeam   *Team          `gorm:"foreignKey:CurrentTeamID;references:ID"`
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+type UserPositionHistory struct {
+	ID            uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID        uint      `gorm:"column:user_id;type:int unsigned;not null"`
+	OldPositionID *uint     `gorm:"column:old_position_id;type:int unsigned"`
+	NewPositionID uint      `gorm:"column:new_position_id;type:int unsigned;not null"`
+	TeamID        *uint     `gorm:"column:team_id;type:int unsigned"`
+	IsPromotion   bool      `gorm:"column:is_promotion;type:boolean;default:false;not null"`
+	EffectiveDate time.Time `gorm:"column:effective_date;type:date;not null"`
+	ChangedByID   *uint     `gorm:"column:changed_by_id;type:int unsigned"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+
+	// Relationships
+	User        User      `gorm:"foreignKey:UserID;references:ID"`
+	OldPosition *Position `gorm:"foreignKey:OldPositionID;references:ID"`
+	NewPosition Position  `gorm:"foreignKey:NewPositionID;references:ID"`
+	Team        *Team     `gorm:"foreignKey:TeamID;references:ID"`
+	ChangedBy   *User     `gorm:"foreignKey:ChangedByID;references:ID"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type UserRecoveryCode struct {
+	ID        uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	UserID    uint       `gorm:"column:user_id;type:int unsigned;not null"`
+	CodeHash  string     `gorm:"column:code_hash;type:char(64);not null"`
+	UsedAt    *time.Time `gorm:"column:used_at;type:timestamp"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// WebhookSubscription is an endpoint that receives the events it subscribed to
+type WebhookSubscription struct {
+	ID         uint      `gorm:"column:id;primaryKey;type:int unsigned"`
+	Name       string    `gorm:"column:name;type:varchar(100);not null"`
+	URL        string    `gorm:"column:url;type:varchar(2048);not null"`
+	Secret     string    `gorm:"column:secret;type:varchar(255);not null"`
+	EventTypes string    `gorm:"column:event_types;type:varchar(500);not null"`
+	Active     bool      `gorm:"column:active;type:boolean;default:true;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime;not null"`
+}
+
+// EventTypeList splits the space-separated event types
+func (s *WebhookSubscription) EventTypeList() []string {
+	return strings.Fields(s.EventTypes)
+}
+
+// WebhookDelivery is one event queued for, or sent to, a subscription
+type WebhookDelivery struct {
+	ID             uint       `gorm:"column:id;primaryKey;type:int unsigned"`
+	SubscriptionID uint       `gorm:"column:subscription_id;type:int unsigned;not null"`
+	EventID        string     `gorm:"column:event_id;type:char(36);not null"`
+	EventType      string     `gorm:"column:event_type;type:varchar(50);not null"`
+	Payload        string     `gorm:"column:payload;type:json;not null"`
+	Status         string     `gorm:"column:status;type:enum('pending','succeeded','failed');default:'pending';not null"`
+	Attempts       int        `gorm:"column:attempts;type:int unsigned;default:0;not null"`
+	NextAttemptAt  *time.Time `gorm:"column:next_attempt_at;type:timestamp"`
+	LastAttemptAt  *time.Time `gorm:"column:last_attempt_at;type:timestamp"`
+	ResponseStatus *int       `gorm:"column:response_status;type:int"`
+	ResponseBody   *string    `gorm:"column:response_body;type:text"`
+	Error          *string    `gorm:"column:error;type:text"`
+	CreatedAt      time.Time  `gorm:"column:created_at;type:timestamp;autoCreateTime;not null"`
+
+	// Relationships
+	Subscription WebhookSubscription `gorm:"foreignKey:SubscriptionID;references:ID"`
+}
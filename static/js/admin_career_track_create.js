@@ -0,0 +1,29 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const createCareerTrackBtn = document.getElementById("createCareerTrackBtn");
+  const createCareerTrackForm = document.getElementById(
+    "createCareerTrackForm"
+  );
+  if (createCareerTrackBtn) {
+    createCareerTrackBtn.addEventListener("click", async function () {
+      if (!createCareerTrackForm.checkValidity()) {
+        createCareerTrackForm.reportValidity();
+        return;
+      }
+      const formData = new FormData(createCareerTrackForm);
+      const data = {
+        name: formData.get("name"),
+        description: formData.get("description") || null,
+      };
+      try {
+        const response = await AdminCareerTrackService.createCareerTrack(data);
+        Toast.success(response.message || "Career track created successfully");
+        setTimeout(() => {
+          window.location.href = "/admin/career-tracks";
+        }, 1500);
+      } catch (error) {
+        console.error("Error creating career track:", error);
+        Toast.error(error.message || "Failed to create career track");
+      }
+    });
+  }
+});
@@ -0,0 +1,34 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const updateCareerTrackBtn = document.getElementById("updateCareerTrackBtn");
+  const editCareerTrackForm = document.getElementById("editCareerTrackForm");
+
+  if (!updateCareerTrackBtn) return;
+
+  updateCareerTrackBtn.addEventListener("click", async function () {
+    if (!editCareerTrackForm.checkValidity()) {
+      editCareerTrackForm.reportValidity();
+      return;
+    }
+
+    const careerTrackId = editCareerTrackForm.getAttribute("data-id");
+    const formData = new FormData(editCareerTrackForm);
+    const data = {
+      name: formData.get("name"),
+      description: formData.get("description").trim() || null,
+    };
+
+    try {
+      const response = await AdminCareerTrackService.updateCareerTrack(
+        careerTrackId,
+        data
+      );
+      Toast.success(response.message || "Career track updated successfully");
+      setTimeout(() => {
+        window.location.href = "/admin/career-tracks";
+      }, 1500);
+    } catch (error) {
+      console.error("Error updating career track:", error);
+      Toast.error(error.message || "Failed to update career track");
+    }
+  });
+});
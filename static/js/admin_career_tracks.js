@@ -0,0 +1,77 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const careerTrackListContainer = document.getElementById("careerTrackListContainer");
+  const loadingTemplate = document.getElementById("loadingTemplate");
+
+  async function loadCareerTracks(offset = 0) {
+    const limit = 10;
+
+    // Show loading spinner
+    careerTrackListContainer.innerHTML = loadingTemplate.innerHTML;
+
+    try {
+      const html = await AdminCareerTrackService.searchCareerTracks({
+        limit,
+        offset,
+      });
+      careerTrackListContainer.innerHTML = html;
+      attachEvents();
+    } catch (error) {
+      console.error("Error loading career tracks:", error);
+      Toast.error("Failed to load career tracks list");
+      careerTrackListContainer.innerHTML =
+        '<div class="alert alert-danger">Failed to load career tracks.</div>';
+    }
+  }
+
+  function attachEvents() {
+    // Pagination events
+    const paginationLinks = careerTrackListContainer.querySelectorAll(".page-link");
+    paginationLinks.forEach((link) => {
+      link.addEventListener("click", function (e) {
+        e.preventDefault();
+        const offsetAttr = this.getAttribute("data-offset");
+        if (offsetAttr !== null) {
+          const offset = parseInt(offsetAttr, 10);
+          if (!Number.isNaN(offset) && offset >= 0) {
+            loadCareerTracks(offset);
+          }
+        }
+      });
+    });
+
+    // Delete events
+    const deleteBtns = careerTrackListContainer.querySelectorAll(".delete-career-track-btn");
+    deleteBtns.forEach((btn) => {
+      btn.addEventListener("click", async function () {
+        const id = this.getAttribute("data-id");
+        const name = this.getAttribute("data-name");
+
+        if (
+          confirm(
+            `Are you sure you want to delete career track "${escapeForDialog(name)}"?`
+          )
+        ) {
+          try {
+            const response = await AdminCareerTrackService.deleteCareerTrack(id);
+            Toast.success(response.message || "Career track deleted successfully");
+            loadCareerTracks(0);
+          } catch (error) {
+            console.error("Error deleting career track:", error);
+            Toast.error(error.message || "Failed to delete career track");
+          }
+        }
+      });
+    });
+  }
+
+  // Initial load
+  loadCareerTracks(0);
+});
+
+function escapeForDialog(str) {
+  return str
+    .replace(/\\/g, "\\\\")
+    .replace(/"/g, '\\"')
+    .replace(/\n/g, "\\n")
+    .replace(/\r/g, "\\r");
+}
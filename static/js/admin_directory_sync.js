@@ -0,0 +1,33 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const buttons = document.querySelectorAll(".run-sync-btn");
+  buttons.forEach((button) => {
+    button.addEventListener("click", async function () {
+      const dryRun = button.dataset.dryRun === "true";
+      if (
+        !dryRun &&
+        !confirm(
+          "Synchronise users from the directory now? Users no longer in the directory will be deactivated."
+        )
+      ) {
+        return;
+      }
+
+      buttons.forEach((b) => (b.disabled = true));
+      try {
+        const run = await AdminDirectorySyncService.run(dryRun);
+        Toast.success(
+          `${dryRun ? "Dry run" : "Sync"} finished: ${run.created_count} created, ${run.updated_count} updated, ${run.deactivated_count} deactivated`
+        );
+        setTimeout(() => {
+          window.location.href = `/admin/directory-sync/${run.id}`;
+        }, 1000);
+      } catch (error) {
+        console.error("Error running directory sync:", error);
+        Toast.error(error.message || "Failed to synchronise the directory");
+        setTimeout(() => {
+          window.location.reload();
+        }, 2000);
+      }
+    });
+  });
+});
@@ -1,6 +1,14 @@
 document.addEventListener("DOMContentLoaded", function () {
   const createPositionBtn = document.getElementById("createPositionBtn");
   const createPositionForm = document.getElementById("createPositionForm");
+
+  // Initialize Required Skill Manager
+  RequiredSkillManager.init({
+    skillsListId: "requiredSkillsList",
+    newSkillSelectId: "newRequiredSkillSelect",
+    addSkillBtnId: "addRequiredSkillBtn",
+  });
+
   if (createPositionBtn) {
     createPositionBtn.addEventListener("click", async function () {
       if (!createPositionForm.checkValidity()) {
@@ -11,6 +19,11 @@ document.addEventListener("DOMContentLoaded", function () {
       const data = {
         name: formData.get("name"),
         abbreviation: formData.get("abbreviation"),
+        career_track_id: formData.get("career_track_id")
+          ? parseInt(formData.get("career_track_id"))
+          : null,
+        grade: formData.get("grade") ? parseInt(formData.get("grade")) : null,
+        required_skills: RequiredSkillManager.getRequiredSkills(),
       };
       try {
         const response = await AdminPositionService.createPosition(data);
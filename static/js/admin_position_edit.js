@@ -4,6 +4,13 @@ document.addEventListener("DOMContentLoaded", function () {
 
   if (!updatePositionBtn) return;
 
+  // Initialize Required Skill Manager
+  RequiredSkillManager.init({
+    skillsListId: "requiredSkillsList",
+    newSkillSelectId: "newRequiredSkillSelect",
+    addSkillBtnId: "addRequiredSkillBtn",
+  });
+
   updatePositionBtn.addEventListener("click", async function () {
     if (!editPositionForm.checkValidity()) {
       editPositionForm.reportValidity();
@@ -15,6 +22,11 @@ document.addEventListener("DOMContentLoaded", function () {
     const data = {
       name: formData.get("name"),
       abbreviation: formData.get("abbreviation"),
+      career_track_id: formData.get("career_track_id")
+        ? parseInt(formData.get("career_track_id"))
+        : null,
+      grade: formData.get("grade") ? parseInt(formData.get("grade")) : null,
+      required_skills: RequiredSkillManager.getRequiredSkills(),
     };
 
     try {
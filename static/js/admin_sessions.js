@@ -0,0 +1,69 @@
+document.addEventListener("DOMContentLoaded", function () {
+  document.querySelectorAll(".revoke-session-btn").forEach((button) => {
+    button.addEventListener("click", async function () {
+      if (!confirm("Sign this session out?")) {
+        return;
+      }
+
+      try {
+        const response = await AdminSessionService.revoke(
+          button.dataset.sessionId
+        );
+        Toast.success(response.message || "Session revoked");
+        button.closest("tr").remove();
+      } catch (error) {
+        console.error("Error revoking session:", error);
+        Toast.error(error.message || "Failed to revoke session");
+      }
+    });
+  });
+
+  const revokeOthersBtn = document.getElementById("revokeOtherSessionsBtn");
+  if (revokeOthersBtn) {
+    revokeOthersBtn.addEventListener("click", async function () {
+      if (!confirm("Sign out of every session except this one?")) {
+        return;
+      }
+
+      try {
+        const response = await AdminSessionService.revokeOthers();
+        Toast.success(response.message || "Other sessions signed out");
+        setTimeout(() => {
+          window.location.reload();
+        }, 1000);
+      } catch (error) {
+        console.error("Error revoking sessions:", error);
+        Toast.error(error.message || "Failed to revoke sessions");
+      }
+    });
+  }
+
+  const changePasswordForm = document.getElementById("changePasswordForm");
+  if (changePasswordForm) {
+    changePasswordForm.addEventListener("submit", async function (e) {
+      e.preventDefault();
+
+      const currentPassword = document.getElementById("currentPassword").value;
+      const newPassword = document.getElementById("newPassword").value;
+      const confirmPassword = document.getElementById("confirmPassword").value;
+      if (newPassword !== confirmPassword) {
+        Toast.error("New passwords do not match");
+        return;
+      }
+
+      try {
+        const response = await AdminSessionService.changePassword(
+          currentPassword,
+          newPassword
+        );
+        Toast.success(response.message || "Password changed");
+        setTimeout(() => {
+          window.location.reload();
+        }, 1000);
+      } catch (error) {
+        console.error("Error changing password:", error);
+        Toast.error(error.message || "Failed to change password");
+      }
+    });
+  }
+});
@@ -0,0 +1,101 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const setupBtn = document.getElementById("setupTwoFactorBtn");
+  const enableBtn = document.getElementById("enableTwoFactorBtn");
+  const disableBtn = document.getElementById("disableTwoFactorBtn");
+  const regenerateBtn = document.getElementById("regenerateRecoveryCodesBtn");
+  const codeInput = document.getElementById("twoFactorCode");
+
+  if (setupBtn) {
+    setupBtn.addEventListener("click", async function () {
+      try {
+        const setup = await AdminTwoFactorService.beginSetup();
+        TotpQrCode.render(
+          document.getElementById("totpQrCode"),
+          setup.provisioning_uri
+        );
+        document.getElementById("totpSecret").textContent = setup.secret;
+        document.getElementById("twoFactorSetup").classList.remove("d-none");
+        setupBtn.classList.add("d-none");
+      } catch (error) {
+        console.error("Error starting two-factor setup:", error);
+        Toast.error(error.message || "Failed to start two-factor setup");
+      }
+    });
+  }
+
+  if (enableBtn) {
+    enableBtn.addEventListener("click", async function () {
+      try {
+        const response = await AdminTwoFactorService.enable(
+          codeInput.value.trim()
+        );
+        document.getElementById("twoFactorSetup").classList.add("d-none");
+        showRecoveryCodes(response.recovery_codes);
+        Toast.success("Two-factor authentication enabled");
+      } catch (error) {
+        console.error("Error enabling two-factor authentication:", error);
+        Toast.error(error.message || "Failed to enable two-factor authentication");
+      }
+    });
+  }
+
+  if (regenerateBtn) {
+    regenerateBtn.addEventListener("click", async function () {
+      if (
+        !confirm(
+          "Regenerating recovery codes invalidates all previous codes. Continue?"
+        )
+      ) {
+        return;
+      }
+
+      try {
+        const response = await AdminTwoFactorService.regenerateRecoveryCodes(
+          codeInput.value.trim()
+        );
+        showRecoveryCodes(response.recovery_codes);
+        Toast.success("Recovery codes regenerated");
+      } catch (error) {
+        console.error("Error regenerating recovery codes:", error);
+        Toast.error(error.message || "Failed to regenerate recovery codes");
+      }
+    });
+  }
+
+  if (disableBtn) {
+    disableBtn.addEventListener("click", async function () {
+      if (!confirm("Disable two-factor authentication for your account?")) {
+        return;
+      }
+
+      try {
+        const response = await AdminTwoFactorService.disable(
+          codeInput.value.trim()
+        );
+        Toast.success(
+          response.message || "Two-factor authentication disabled"
+        );
+        setTimeout(() => {
+          window.location.reload();
+        }, 1000);
+      } catch (error) {
+        console.error("Error disabling two-factor authentication:", error);
+        Toast.error(
+          error.message || "Failed to disable two-factor authentication"
+        );
+      }
+    });
+  }
+});
+
+function showRecoveryCodes(codes) {
+  const list = document.getElementById("recoveryCodesList");
+  list.innerHTML = "";
+  codes.forEach((code) => {
+    const item = document.createElement("li");
+    item.className = "list-group-item";
+    item.textContent = code;
+    list.appendChild(item);
+  });
+  document.getElementById("recoveryCodes").classList.remove("d-none");
+}
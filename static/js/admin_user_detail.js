@@ -26,6 +26,71 @@ document.addEventListener("DOMContentLoaded", function () {
       }
     });
   }
+
+  const unlockUserBtn = document.getElementById("unlockUserBtn");
+
+  if (unlockUserBtn) {
+    unlockUserBtn.addEventListener("click", async function () {
+      try {
+        const response = await AdminUserService.unlockUser(this.dataset.userId);
+        Toast.success(response.message || "User unlocked successfully");
+        setTimeout(() => {
+          window.location.reload();
+        }, 1000);
+      } catch (error) {
+        console.error("Error unlocking user:", error);
+        Toast.error(error.message || "Failed to unlock user");
+      }
+    });
+  }
+
+  const twoFactorRequiredSwitch = document.getElementById(
+    "twoFactorRequiredSwitch"
+  );
+
+  if (twoFactorRequiredSwitch) {
+    twoFactorRequiredSwitch.addEventListener("change", async function () {
+      const required = this.checked;
+      try {
+        const response = await AdminUserService.updateTwoFactorRequirement(
+          this.dataset.userId,
+          required
+        );
+        Toast.success(response.message || "Two-factor requirement updated");
+      } catch (error) {
+        console.error("Error updating two-factor requirement:", error);
+        this.checked = !required;
+        Toast.error(error.message || "Failed to update two-factor requirement");
+      }
+    });
+  }
+
+  const resetTwoFactorBtn = document.getElementById("resetTwoFactorBtn");
+
+  if (resetTwoFactorBtn) {
+    resetTwoFactorBtn.addEventListener("click", async function () {
+      if (
+        !confirm(
+          "Reset two-factor authentication for this user? They will have to enrol again."
+        )
+      ) {
+        return;
+      }
+
+      try {
+        const response = await AdminUserService.resetTwoFactor(
+          this.dataset.userId
+        );
+        Toast.success(response.message || "Two-factor authentication reset");
+        setTimeout(() => {
+          window.location.reload();
+        }, 1000);
+      } catch (error) {
+        console.error("Error resetting two-factor authentication:", error);
+        Toast.error(error.message || "Failed to reset two-factor authentication");
+      }
+    });
+  }
 });
 
 function escapeForDialog(str) {
@@ -22,6 +22,7 @@ document.addEventListener("DOMContentLoaded", function () {
         ? parseInt(formData.get("team_id"))
         : null,
       skills: SkillManager.getSelectedSkills(),
+      position_effective_date: formData.get("position_effective_date") || null,
     };
 
     try {
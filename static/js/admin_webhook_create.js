@@ -0,0 +1,40 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const createWebhookBtn = document.getElementById("createWebhookBtn");
+  const createWebhookForm = document.getElementById("createWebhookForm");
+
+  createWebhookBtn.addEventListener("click", async function () {
+    if (!createWebhookForm.checkValidity()) {
+      createWebhookForm.reportValidity();
+      return;
+    }
+
+    const eventTypes = Array.from(
+      createWebhookForm.querySelectorAll(".event-type-input:checked")
+    ).map((input) => input.value);
+    if (eventTypes.length === 0) {
+      Toast.error("Select at least one event");
+      return;
+    }
+
+    const formData = new FormData(createWebhookForm);
+    const data = {
+      name: formData.get("name"),
+      url: formData.get("url"),
+      secret: formData.get("secret"),
+      event_types: eventTypes,
+      active: document.getElementById("active").checked,
+    };
+
+    try {
+      const webhook = await AdminWebhookService.createWebhook(data);
+      Toast.success("Webhook created successfully");
+      createWebhookForm.classList.add("d-none");
+      document.getElementById("webhookSecretValue").textContent =
+        webhook.secret;
+      document.getElementById("webhookSecretResult").classList.remove("d-none");
+    } catch (error) {
+      console.error("Error creating webhook:", error);
+      Toast.error(error.message || "Failed to create webhook");
+    }
+  });
+});
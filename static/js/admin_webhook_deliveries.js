@@ -0,0 +1,21 @@
+document.addEventListener("DOMContentLoaded", function () {
+  document.querySelectorAll(".redeliver-btn").forEach((button) => {
+    button.addEventListener("click", async function () {
+      button.disabled = true;
+      try {
+        const response = await AdminWebhookService.redeliver(
+          button.dataset.webhookId,
+          button.dataset.deliveryId
+        );
+        Toast.success(response.message || "Redelivery queued successfully");
+        setTimeout(() => {
+          window.location.reload();
+        }, 1000);
+      } catch (error) {
+        console.error("Error queueing redelivery:", error);
+        Toast.error(error.message || "Failed to queue the redelivery");
+        button.disabled = false;
+      }
+    });
+  });
+});
@@ -0,0 +1,50 @@
+document.addEventListener("DOMContentLoaded", function () {
+  const updateWebhookBtn = document.getElementById("updateWebhookBtn");
+  const editWebhookForm = document.getElementById("editWebhookForm");
+
+  if (!updateWebhookBtn) return;
+
+  const toggleSecretBtn = document.getElementById("toggleSecretBtn");
+  const currentSecret = document.getElementById("currentSecret");
+  toggleSecretBtn.addEventListener("click", function () {
+    const hidden = currentSecret.type === "password";
+    currentSecret.type = hidden ? "text" : "password";
+    toggleSecretBtn.textContent = hidden ? "Hide" : "Show";
+  });
+
+  updateWebhookBtn.addEventListener("click", async function () {
+    if (!editWebhookForm.checkValidity()) {
+      editWebhookForm.reportValidity();
+      return;
+    }
+
+    const eventTypes = Array.from(
+      editWebhookForm.querySelectorAll(".event-type-input:checked")
+    ).map((input) => input.value);
+    if (eventTypes.length === 0) {
+      Toast.error("Select at least one event");
+      return;
+    }
+
+    const webhookId = editWebhookForm.getAttribute("data-id");
+    const formData = new FormData(editWebhookForm);
+    const data = {
+      name: formData.get("name"),
+      url: formData.get("url"),
+      secret: formData.get("secret"),
+      event_types: eventTypes,
+      active: document.getElementById("active").checked,
+    };
+
+    try {
+      const response = await AdminWebhookService.updateWebhook(webhookId, data);
+      Toast.success(response.message || "Webhook updated successfully");
+      setTimeout(() => {
+        window.location.href = "/admin/webhooks";
+      }, 1500);
+    } catch (error) {
+      console.error("Error updating webhook:", error);
+      Toast.error(error.message || "Failed to update webhook");
+    }
+  });
+});
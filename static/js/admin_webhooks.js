@@ -0,0 +1,22 @@
+document.addEventListener("DOMContentLoaded", function () {
+  document.querySelectorAll(".delete-webhook-btn").forEach((button) => {
+    button.addEventListener("click", async function () {
+      if (
+        !confirm("Delete this webhook? Its delivery log is deleted as well.")
+      ) {
+        return;
+      }
+
+      try {
+        const response = await AdminWebhookService.deleteWebhook(
+          button.dataset.webhookId
+        );
+        Toast.success(response.message || "Webhook deleted successfully");
+        button.closest("tr").remove();
+      } catch (error) {
+        console.error("Error deleting webhook:", error);
+        Toast.error(error.message || "Failed to delete webhook");
+      }
+    });
+  });
+});
@@ -0,0 +1,60 @@
+$(document).ready(function () {
+  if (!AuthService.isAuthenticated()) return;
+
+  loadUpcomingCelebrations();
+});
+
+/**
+ * Fetch and display upcoming celebrations
+ */
+async function loadUpcomingCelebrations() {
+  try {
+    const data = await CelebrationService.getUpcomingCelebrations();
+    renderCelebrations(data.celebrations || []);
+  } catch (error) {
+    console.error("Error fetching celebrations:", error);
+    if (error.status !== 401) {
+      $("#upcoming-celebrations").html(
+        '<li class="list-group-item text-danger">Failed to load celebrations</li>'
+      );
+    }
+  }
+}
+
+/**
+ * Render celebrations list
+ * @param {Array} celebrations
+ */
+function renderCelebrations(celebrations) {
+  if (celebrations.length === 0) {
+    $("#upcoming-celebrations").html(
+      '<li class="list-group-item text-muted">No upcoming celebrations in your team</li>'
+    );
+    return;
+  }
+
+  let html = "";
+  celebrations.forEach((celebration) => {
+    const date = new Date(celebration.date).toLocaleDateString();
+    const when =
+      celebration.days_until === 0
+        ? "Today"
+        : celebration.days_until === 1
+          ? "Tomorrow"
+          : `In ${celebration.days_until} days`;
+    const label =
+      celebration.type === "birthday"
+        ? "Birthday"
+        : `${celebration.years} year work anniversary`;
+    html += `
+      <li class="list-group-item d-flex justify-content-between align-items-center">
+        <div>
+          <a href="/profile/${celebration.user.id}" class="fw-bold text-decoration-none">${celebration.user.name}</a>
+          <div class="small text-muted">${label} &middot; ${date}</div>
+        </div>
+        <span class="badge bg-warning text-dark">${when}</span>
+      </li>
+    `;
+  });
+  $("#upcoming-celebrations").html(html);
+}
@@ -0,0 +1,29 @@
+$(document).ready(function () {
+  $("#forgotPasswordForm").on("submit", async function (e) {
+    e.preventDefault();
+
+    const email = $("#email").val();
+    const $submitBtn = $("#submitBtn");
+    const $errorAlert = $("#errorAlert");
+    const $successAlert = $("#successAlert");
+
+    $errorAlert.addClass("d-none");
+    $successAlert.addClass("d-none");
+    $submitBtn.prop("disabled", true);
+
+    try {
+      const result = await AuthService.requestPasswordReset(email);
+      $("#successMessage").text(result.message);
+      $successAlert.removeClass("d-none");
+      $("#forgotPasswordForm").addClass("d-none");
+    } catch (error) {
+      const errorMsg =
+        error.responseJSON?.message ||
+        error.message ||
+        "Failed to request a password reset";
+      $("#errorMessage").text(errorMsg);
+      $errorAlert.removeClass("d-none");
+      $submitBtn.prop("disabled", false);
+    }
+  });
+});
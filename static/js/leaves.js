@@ -0,0 +1,297 @@
+let currentOffset = 0;
+const limit = 10;
+
+const LEAVE_STATUS_BADGES = {
+  pending: "bg-warning text-dark",
+  approved: "bg-success",
+  rejected: "bg-danger",
+  cancelled: "bg-secondary",
+};
+
+$(document).ready(function () {
+  if (!AuthService.isAuthenticated()) return;
+
+  loadMyLeaves(currentOffset);
+  loadPendingLeaves();
+
+  $("#leave-form").on("submit", submitLeaveRequest);
+  $("#download-my-ics").on("click", async function () {
+    try {
+      await LeaveService.downloadMyICalendar();
+    } catch (error) {
+      console.error("Error downloading calendar:", error);
+      if (error.status !== 401) {
+        alert("Failed to download calendar.");
+      }
+    }
+  });
+  $("#subscribe-my-ics").on("click", subscribeToCalendar);
+});
+
+/**
+ * Create the secret feed URL of the user's leaves and show it to paste into a calendar client
+ */
+async function subscribeToCalendar() {
+  if (!confirm("Create a new calendar subscription URL? URLs created before stop working.")) return;
+  try {
+    const feed = await LeaveService.createCalendarFeed();
+    prompt("Subscribe to this URL in your calendar app, it is shown only once:", window.location.origin + feed.leaves_path);
+  } catch (error) {
+    console.error("Error creating calendar feed:", error);
+    if (error.status !== 401) {
+      alert("Failed to create calendar subscription.");
+    }
+  }
+}
+
+/**
+ * Format leave date range including half days
+ * @param {Object} leave
+ * @returns {string}
+ */
+function formatLeaveDates(leave) {
+  const start = new Date(leave.start_date).toLocaleDateString();
+  const end = new Date(leave.end_date).toLocaleDateString();
+  const startSuffix = leave.start_half_day ? " (PM)" : "";
+  const endSuffix = leave.end_half_day ? " (AM)" : "";
+  if (leave.start_date === leave.end_date) {
+    return `${start}${startSuffix}${endSuffix}`;
+  }
+  return `${start}${startSuffix} - ${end}${endSuffix}`;
+}
+
+/**
+ * Escape user provided text before injecting into HTML
+ * @param {string} text
+ * @returns {string}
+ */
+function escapeHtml(text) {
+  return $("<div>").text(text || "").html();
+}
+
+/**
+ * Submit the leave request form
+ * @param {Event} event
+ */
+async function submitLeaveRequest(event) {
+  event.preventDefault();
+  $("#leave-form-error").addClass("d-none");
+
+  const reason = $("#leave-reason").val().trim();
+  const data = {
+    type: $("#leave-type").val(),
+    start_date: $("#leave-start-date").val(),
+    end_date: $("#leave-end-date").val(),
+    start_half_day: $("#leave-start-half-day").is(":checked"),
+    end_half_day: $("#leave-end-half-day").is(":checked"),
+    reason: reason || null,
+  };
+
+  try {
+    await LeaveService.createLeave(data);
+    $("#leave-form")[0].reset();
+    loadMyLeaves(0);
+  } catch (error) {
+    console.error("Error creating leave request:", error);
+    if (error.status !== 401) {
+      const message =
+        error.responseJSON && error.responseJSON.message
+          ? error.responseJSON.message
+          : "Failed to submit leave request.";
+      $("#leave-form-error").text(message).removeClass("d-none");
+    }
+  }
+}
+
+/**
+ * Fetch and display current user's leave requests
+ * @param {number} offset
+ */
+async function loadMyLeaves(offset) {
+  try {
+    const response = await LeaveService.listMyLeaves(limit, offset);
+    updateMyLeavesTable(response.leaves);
+    updatePagination(response.page);
+    currentOffset = offset;
+  } catch (error) {
+    console.error("Error fetching leave requests:", error);
+    if (error.status !== 401) {
+      alert("Failed to load leave requests.");
+    }
+  }
+}
+
+/**
+ * Update my leaves table
+ * @param {Array} leaves
+ */
+function updateMyLeavesTable(leaves) {
+  const tbody = $("#my-leaves");
+  if (!leaves || leaves.length === 0) {
+    tbody.html(
+      '<tr><td colspan="6" class="text-center text-muted">No leave requests yet</td></tr>'
+    );
+    return;
+  }
+
+  const today = new Date().toISOString().slice(0, 10);
+  let html = "";
+  leaves.forEach((leave) => {
+    const cancellable =
+      leave.status === "pending" ||
+      (leave.status === "approved" && leave.start_date > today);
+    html += `
+      <tr>
+        <td class="text-capitalize">${leave.type}</td>
+        <td>${formatLeaveDates(leave)}</td>
+        <td>${leave.days}</td>
+        <td>
+          <span class="badge ${LEAVE_STATUS_BADGES[leave.status]} text-capitalize">${leave.status}</span>
+          ${leave.review_note ? `<div class="small text-muted">${escapeHtml(leave.review_note)}</div>` : ""}
+        </td>
+        <td>${leave.reviewer ? leave.reviewer.name : "-"}</td>
+        <td class="text-end">
+          ${
+            cancellable
+              ? `<button class="btn btn-sm btn-outline-danger" onclick="cancelLeave(${leave.id})">Cancel</button>`
+              : ""
+          }
+        </td>
+      </tr>
+    `;
+  });
+  tbody.html(html);
+}
+
+/**
+ * Fetch and display leave requests waiting for approval
+ */
+async function loadPendingLeaves() {
+  try {
+    const response = await LeaveService.listPendingLeaves();
+    updatePendingLeavesTable(response.leaves);
+  } catch (error) {
+    console.error("Error fetching pending leave requests:", error);
+  }
+}
+
+/**
+ * Update pending approvals table, hidden when there is nothing to review
+ * @param {Array} leaves
+ */
+function updatePendingLeavesTable(leaves) {
+  if (!leaves || leaves.length === 0) {
+    $("#pending-card").addClass("d-none");
+    return;
+  }
+
+  let html = "";
+  leaves.forEach((leave) => {
+    html += `
+      <tr>
+        <td><a href="/profile/${leave.user.id}" class="text-decoration-none">${leave.user.name}</a></td>
+        <td class="text-capitalize">${leave.type}</td>
+        <td>${formatLeaveDates(leave)}</td>
+        <td>${leave.days}</td>
+        <td class="small">${escapeHtml(leave.reason)}</td>
+        <td class="text-end text-nowrap">
+          <button class="btn btn-sm btn-success" onclick="reviewLeave(${leave.id}, true)">Approve</button>
+          <button class="btn btn-sm btn-outline-danger" onclick="reviewLeave(${leave.id}, false)">Reject</button>
+        </td>
+      </tr>
+    `;
+  });
+  $("#pending-leaves").html(html);
+  $("#pending-card").removeClass("d-none");
+}
+
+/**
+ * Approve or reject a leave request
+ * @param {number} id
+ * @param {boolean} approve
+ */
+async function reviewLeave(id, approve) {
+  const note = prompt(approve ? "Approval note (optional)" : "Rejection reason (optional)");
+  if (note === null) return;
+
+  try {
+    if (approve) {
+      await LeaveService.approveLeave(id, note);
+    } else {
+      await LeaveService.rejectLeave(id, note);
+    }
+    loadPendingLeaves();
+  } catch (error) {
+    console.error("Error reviewing leave request:", error);
+    if (error.status !== 401) {
+      alert(
+        error.responseJSON && error.responseJSON.message
+          ? error.responseJSON.message
+          : "Failed to review leave request."
+      );
+    }
+  }
+}
+
+/**
+ * Cancel own leave request
+ * @param {number} id
+ */
+async function cancelLeave(id) {
+  if (!confirm("Cancel this leave request?")) return;
+
+  try {
+    await LeaveService.cancelLeave(id);
+    loadMyLeaves(currentOffset);
+  } catch (error) {
+    console.error("Error cancelling leave request:", error);
+    if (error.status !== 401) {
+      alert(
+        error.responseJSON && error.responseJSON.message
+          ? error.responseJSON.message
+          : "Failed to cancel leave request."
+      );
+    }
+  }
+}
+
+/**
+ * Update pagination controls
+ * @param {Object} pageInfo
+ */
+function updatePagination(pageInfo) {
+  const pagination = $("#leaves-pagination");
+  const totalPages = Math.ceil(pageInfo.total / pageInfo.limit);
+  const currentPage = Math.floor(pageInfo.offset / pageInfo.limit) + 1;
+
+  if (totalPages <= 1) {
+    pagination.html("");
+    return;
+  }
+
+  let html = `
+    <li class="page-item ${currentPage === 1 ? "disabled" : ""}">
+      <a class="page-link" href="javascript:void(0)" onclick="loadMyLeaves(${
+        (currentPage - 2) * limit
+      })">Previous</a>
+    </li>
+  `;
+  for (let i = 1; i <= totalPages; i++) {
+    html += `
+      <li class="page-item ${i === currentPage ? "active" : ""}">
+        <a class="page-link" href="javascript:void(0)" onclick="loadMyLeaves(${
+          (i - 1) * limit
+        })">${i}</a>
+      </li>
+    `;
+  }
+  html += `
+    <li class="page-item ${currentPage === totalPages ? "disabled" : ""}">
+      <a class="page-link" href="javascript:void(0)" onclick="loadMyLeaves(${
+        currentPage * limit
+      })">Next</a>
+    </li>
+  `;
+
+  pagination.html(html);
+}
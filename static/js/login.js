@@ -5,6 +5,15 @@ $(document).ready(function () {
     window.location.href = "/";
   }
 
+  // Back from the single sign-on provider
+  const params = new URLSearchParams(window.location.search);
+  if (params.get("sso_error")) {
+    $("#errorMessage").text(params.get("sso_error"));
+    $("#errorAlert").removeClass("d-none");
+  } else if (params.get("sso") === "1") {
+    completeSSOLogin();
+  }
+
   // Handle login form submission
   $("#loginForm").on("submit", async function (e) {
     e.preventDefault();
@@ -28,7 +37,12 @@ $(document).ready(function () {
 
     try {
       // Use AuthService for login
-      await AuthService.login(email, password);
+      const result = await AuthService.login(email, password);
+
+      if (result.two_factor_required) {
+        await showTwoFactorStep(result);
+        return;
+      }
 
       // Show success message
       $successAlert.removeClass("d-none");
@@ -40,6 +54,7 @@ $(document).ready(function () {
     } catch (error) {
       // Show error message
       const errorMsg =
+        (error.status === 429 && error.responseJSON?.message) ||
         error.responseJSON?.error ||
         error.message ||
         "An error occurred during login";
@@ -52,3 +67,91 @@ $(document).ready(function () {
     }
   });
 });
+
+/**
+ * Finish a single sign-on, continuing with the second factor step when the account needs one
+ */
+async function completeSSOLogin() {
+  window.history.replaceState(null, "", "/login");
+  try {
+    const result = await AuthService.completeSSOLogin();
+
+    if (result.two_factor_required) {
+      await showTwoFactorStep(result);
+      return;
+    }
+
+    $("#loginForm").addClass("d-none");
+    $("#successAlert").removeClass("d-none");
+    setTimeout(() => {
+      window.location.href = "/";
+    }, 1500);
+  } catch (error) {
+    $("#errorMessage").text(
+      error.responseJSON?.message || error.message || "Single sign-on failed"
+    );
+    $("#errorAlert").removeClass("d-none");
+  }
+}
+
+/**
+ * Replace the password form with the second factor step
+ * @param {Object} challenge - { setup_required, challenge_token }
+ */
+async function showTwoFactorStep(challenge) {
+  if (challenge.setup_required) {
+    const setup = await AuthService.beginTwoFactorSetup(
+      challenge.challenge_token
+    );
+    TotpQrCode.render(
+      document.getElementById("twoFactorQrCode"),
+      setup.provisioning_uri
+    );
+    $("#twoFactorSecret").text(setup.secret);
+    $("#twoFactorSetup").removeClass("d-none");
+  }
+
+  $("#loginForm").addClass("d-none");
+  $("#twoFactorForm").removeClass("d-none");
+  $("#twoFactorCode").trigger("focus");
+
+  $("#twoFactorForm")
+    .off("submit")
+    .on("submit", async function (e) {
+      e.preventDefault();
+
+      const code = $("#twoFactorCode").val().trim();
+      const $twoFactorBtn = $("#twoFactorBtn");
+      $("#errorAlert").addClass("d-none");
+      $twoFactorBtn.prop("disabled", true);
+
+      try {
+        if (challenge.setup_required) {
+          const recoveryCodes = await AuthService.enableTwoFactor(
+            challenge.challenge_token,
+            code
+          );
+          const $list = $("#recoveryCodesList").empty();
+          recoveryCodes.forEach((recoveryCode) => {
+            $list.append($("<li>").addClass("list-group-item").text(recoveryCode));
+          });
+          $("#twoFactorForm").addClass("d-none");
+          $("#recoveryCodes").removeClass("d-none");
+          return;
+        }
+
+        await AuthService.verifyTwoFactor(challenge.challenge_token, code);
+        $("#twoFactorForm").addClass("d-none");
+        $("#successAlert").removeClass("d-none");
+        setTimeout(() => {
+          window.location.href = "/";
+        }, 1500);
+      } catch (error) {
+        $("#errorMessage").text(
+          error.responseJSON?.message || "Failed to verify the code"
+        );
+        $("#errorAlert").removeClass("d-none");
+        $twoFactorBtn.prop("disabled", false);
+      }
+    });
+}
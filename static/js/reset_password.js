@@ -0,0 +1,40 @@
+$(document).ready(function () {
+  // Keep the token out of the browser history and of the Referer of later requests
+  window.history.replaceState(null, "", "/reset-password");
+
+  $("#resetPasswordForm").on("submit", async function (e) {
+    e.preventDefault();
+
+    const token = $(this).data("token");
+    const newPassword = $("#newPassword").val();
+    const $submitBtn = $("#submitBtn");
+    const $errorAlert = $("#errorAlert");
+    const $errorMessage = $("#errorMessage");
+
+    $errorAlert.addClass("d-none");
+
+    if (newPassword !== $("#confirmPassword").val()) {
+      $errorMessage.text("The passwords do not match");
+      $errorAlert.removeClass("d-none");
+      return;
+    }
+
+    $submitBtn.prop("disabled", true);
+    try {
+      await AuthService.resetPassword(token, newPassword);
+      $("#resetPasswordForm").addClass("d-none");
+      $("#successAlert").removeClass("d-none");
+      setTimeout(() => {
+        window.location.href = "/login";
+      }, 1500);
+    } catch (error) {
+      const errorMsg =
+        error.responseJSON?.message ||
+        error.message ||
+        "Failed to reset password";
+      $errorMessage.text(errorMsg);
+      $errorAlert.removeClass("d-none");
+      $submitBtn.prop("disabled", false);
+    }
+  });
+});
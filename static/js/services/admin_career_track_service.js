@@ -0,0 +1,44 @@
+/**
+ * Admin Career Track Service
+ */
+const AdminCareerTrackService = {
+  /**
+   * Search career tracks with pagination
+   * @param {Object} params - { limit, offset }
+   * @returns {Promise}
+   */
+  searchCareerTracks: function (params) {
+    let url = `/admin/career-tracks/partial/search?limit=${
+      params.limit || 10
+    }&offset=${params.offset || 0}`;
+    return AdminAPI.get(url, { dataType: "html" });
+  },
+
+  /**
+   * Create a new career track
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  createCareerTrack: function (data) {
+    return AdminAPI.post("/admin/career-tracks", data);
+  },
+
+  /**
+   * Update an existing career track
+   * @param {number|string} careerTrackId
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  updateCareerTrack: function (careerTrackId, data) {
+    return AdminAPI.put(`/admin/career-tracks/${careerTrackId}`, data);
+  },
+
+  /**
+   * Delete a career track
+   * @param {number|string} careerTrackId
+   * @returns {Promise}
+   */
+  deleteCareerTrack: function (careerTrackId) {
+    return AdminAPI.delete(`/admin/career-tracks/${careerTrackId}`);
+  },
+};
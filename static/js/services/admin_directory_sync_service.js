@@ -0,0 +1,13 @@
+/**
+ * Admin Directory Sync Service, synchronises users from the LDAP directory
+ */
+const AdminDirectorySyncService = {
+  /**
+   * Run a sync now and return its report, a dry run saves nothing
+   * @param {boolean} dryRun
+   * @returns {Promise}
+   */
+  run: function (dryRun) {
+    return AdminAPI.post("/admin/directory-sync", { dry_run: dryRun });
+  },
+};
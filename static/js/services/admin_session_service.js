@@ -0,0 +1,34 @@
+/**
+ * Admin Session Service, manages the signed in admin's own sessions and password
+ */
+const AdminSessionService = {
+  /**
+   * Sign one of the other sessions out
+   * @param {number} sessionId
+   * @returns {Promise}
+   */
+  revoke: function (sessionId) {
+    return AdminAPI.delete(`/admin/security/sessions/${sessionId}`);
+  },
+
+  /**
+   * Sign out everywhere except the current session, returns { message, revoked }
+   * @returns {Promise}
+   */
+  revokeOthers: function () {
+    return AdminAPI.post("/admin/security/sessions/revoke-others", {});
+  },
+
+  /**
+   * Change the password, other sessions are signed out, returns { message, revoked }
+   * @param {string} currentPassword
+   * @param {string} newPassword
+   * @returns {Promise}
+   */
+  changePassword: function (currentPassword, newPassword) {
+    return AdminAPI.put("/admin/security/password", {
+      current_password: currentPassword,
+      new_password: newPassword,
+    });
+  },
+};
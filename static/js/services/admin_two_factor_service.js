@@ -0,0 +1,39 @@
+/**
+ * Admin Two-Factor Service, manages the signed in admin's own enrolment
+ */
+const AdminTwoFactorService = {
+  /**
+   * Start enrolment, returns { secret, provisioning_uri }
+   * @returns {Promise}
+   */
+  beginSetup: function () {
+    return AdminAPI.post("/admin/security/2fa/setup", {});
+  },
+
+  /**
+   * Confirm enrolment with a code, returns { recovery_codes }
+   * @param {string} code
+   * @returns {Promise}
+   */
+  enable: function (code) {
+    return AdminAPI.post("/admin/security/2fa/enable", { code });
+  },
+
+  /**
+   * Turn two-factor authentication off
+   * @param {string} code
+   * @returns {Promise}
+   */
+  disable: function (code) {
+    return AdminAPI.post("/admin/security/2fa/disable", { code });
+  },
+
+  /**
+   * Replace all recovery codes, returns { recovery_codes }
+   * @param {string} code
+   * @returns {Promise}
+   */
+  regenerateRecoveryCodes: function (code) {
+    return AdminAPI.post("/admin/security/2fa/recovery-codes", { code });
+  },
+};
@@ -44,4 +44,32 @@ const AdminUserService = {
   deleteUser: function (userId) {
     return AdminAPI.delete(`/admin/users/${userId}`);
   },
+
+  /**
+   * Unlock a user locked out after failed logins
+   * @param {number|string} userId
+   * @returns {Promise}
+   */
+  unlockUser: function (userId) {
+    return AdminAPI.post(`/admin/users/${userId}/unlock`, {});
+  },
+
+  /**
+   * Require or stop requiring two-factor authentication for a user
+   * @param {number|string} userId
+   * @param {boolean} required
+   * @returns {Promise}
+   */
+  updateTwoFactorRequirement: function (userId, required) {
+    return AdminAPI.put(`/admin/users/${userId}/2fa-requirement`, { required });
+  },
+
+  /**
+   * Remove the two-factor enrolment of a user who lost their device
+   * @param {number|string} userId
+   * @returns {Promise}
+   */
+  resetTwoFactor: function (userId) {
+    return AdminAPI.delete(`/admin/users/${userId}/2fa`);
+  },
 };
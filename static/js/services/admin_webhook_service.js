@@ -0,0 +1,44 @@
+/**
+ * Admin Webhook Service
+ */
+const AdminWebhookService = {
+  /**
+   * Create a webhook, the response holds its secret
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  createWebhook: function (data) {
+    return AdminAPI.post("/admin/webhooks", data);
+  },
+
+  /**
+   * Update an existing webhook, an empty secret keeps the current one
+   * @param {number|string} webhookId
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  updateWebhook: function (webhookId, data) {
+    return AdminAPI.put(`/admin/webhooks/${webhookId}`, data);
+  },
+
+  /**
+   * Delete a webhook together with its delivery log
+   * @param {number|string} webhookId
+   * @returns {Promise}
+   */
+  deleteWebhook: function (webhookId) {
+    return AdminAPI.delete(`/admin/webhooks/${webhookId}`);
+  },
+
+  /**
+   * Queue the event of a delivery again
+   * @param {number|string} webhookId
+   * @param {number|string} deliveryId
+   * @returns {Promise}
+   */
+  redeliver: function (webhookId, deliveryId) {
+    return AdminAPI.post(
+      `/admin/webhooks/${webhookId}/deliveries/${deliveryId}/redeliver`
+    );
+  },
+};
@@ -13,6 +13,50 @@ const AuthService = {
       user: { email, password },
     });
 
+    // The password was accepted but a second factor is needed:
+    // { two_factor_required, setup_required, challenge_token }
+    if (response && response.two_factor_required) {
+      return response;
+    }
+
+    if (response && response.user && response.user.access_token) {
+      this.setSession(response.user);
+      return response.user;
+    }
+    throw new Error("Invalid response from server");
+  },
+
+  /**
+   * Pick up the result of a single sign-on after the provider redirected back,
+   * resolves like login
+   * @returns {Promise}
+   */
+  completeSSOLogin: async function () {
+    const response = await API.post("/login/oidc/complete", {});
+
+    if (response && response.two_factor_required) {
+      return response;
+    }
+
+    if (response && response.user && response.user.access_token) {
+      this.setSession(response.user);
+      return response.user;
+    }
+    throw new Error("Invalid response from server");
+  },
+
+  /**
+   * Finish a login with a TOTP or recovery code
+   * @param {string} challengeToken
+   * @param {string} code
+   * @returns {Promise}
+   */
+  verifyTwoFactor: async function (challengeToken, code) {
+    const response = await API.post("/login/2fa", {
+      challenge_token: challengeToken,
+      code,
+    });
+
     if (response && response.user && response.user.access_token) {
       this.setSession(response.user);
       return response.user;
@@ -20,6 +64,53 @@ const AuthService = {
     throw new Error("Invalid response from server");
   },
 
+  /**
+   * Start the enrolment required before login, returns { secret, provisioning_uri }
+   * @param {string} challengeToken
+   * @returns {Promise}
+   */
+  beginTwoFactorSetup: function (challengeToken) {
+    return API.post("/login/2fa/setup", { challenge_token: challengeToken });
+  },
+
+  /**
+   * Confirm the enrolment and finish the login, returns the recovery codes
+   * @param {string} challengeToken
+   * @param {string} code
+   * @returns {Promise<string[]>}
+   */
+  enableTwoFactor: async function (challengeToken, code) {
+    const response = await API.post("/login/2fa/enable", {
+      challenge_token: challengeToken,
+      code,
+    });
+
+    if (response && response.user && response.user.access_token) {
+      this.setSession(response.user);
+      return response.recovery_codes;
+    }
+    throw new Error("Invalid response from server");
+  },
+
+  /**
+   * Ask for a link to reset the password to be emailed
+   * @param {string} email
+   * @returns {Promise}
+   */
+  requestPasswordReset: function (email) {
+    return API.post("/forgot-password", { email });
+  },
+
+  /**
+   * Choose a new password with the token from the emailed link
+   * @param {string} token
+   * @param {string} newPassword
+   * @returns {Promise}
+   */
+  resetPassword: function (token, newPassword) {
+    return API.post("/reset-password", { token, new_password: newPassword });
+  },
+
   /**
    * Logout user
    */
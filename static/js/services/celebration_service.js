@@ -0,0 +1,13 @@
+/**
+ * Celebration Service
+ */
+const CelebrationService = {
+  /**
+   * Get upcoming birthdays and work anniversaries in the current team
+   * @param {number} days
+   * @returns {Promise}
+   */
+  getUpcomingCelebrations: function (days = 30) {
+    return API.get(`/api/celebrations/upcoming?days=${days}`);
+  },
+};
@@ -0,0 +1,123 @@
+/**
+ * Leave Service
+ */
+const LeaveService = {
+  /**
+   * Submit a leave request
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  createLeave: function (data) {
+    return API.post("/api/leaves", data);
+  },
+
+  /**
+   * List current user's leave requests with pagination
+   * @param {number} limit
+   * @param {number} offset
+   * @returns {Promise}
+   */
+  listMyLeaves: function (limit = 10, offset = 0) {
+    return API.get(`/api/leaves?limit=${limit}&offset=${offset}`);
+  },
+
+  /**
+   * List leave requests waiting for current user's approval
+   * @returns {Promise}
+   */
+  listPendingLeaves: function () {
+    return API.get("/api/leaves/pending");
+  },
+
+  /**
+   * Approve a leave request
+   * @param {number} id
+   * @param {string} note
+   * @returns {Promise}
+   */
+  approveLeave: function (id, note = "") {
+    return API.put(`/api/leaves/${id}/approve`, { note: note || null });
+  },
+
+  /**
+   * Reject a leave request
+   * @param {number} id
+   * @param {string} note
+   * @returns {Promise}
+   */
+  rejectLeave: function (id, note = "") {
+    return API.put(`/api/leaves/${id}/reject`, { note: note || null });
+  },
+
+  /**
+   * Cancel own leave request
+   * @param {number} id
+   * @returns {Promise}
+   */
+  cancelLeave: function (id) {
+    return API.put(`/api/leaves/${id}/cancel`, {});
+  },
+
+  /**
+   * Get team leaves overlapping a date range
+   * @param {number} teamId
+   * @param {string} from - YYYY-MM-DD
+   * @param {string} to - YYYY-MM-DD
+   * @returns {Promise}
+   */
+  getTeamCalendar: function (teamId, from, to) {
+    return API.get(`/api/teams/${teamId}/leaves?from=${from}&to=${to}`);
+  },
+
+  /**
+   * Download an iCalendar feed as a file
+   * @param {string} url
+   * @param {string} filename
+   * @returns {Promise}
+   */
+  downloadICalendar: async function (url, filename) {
+    const content = await API.get(url, { dataType: "text" });
+    const blob = new Blob([content], { type: "text/calendar" });
+    const link = document.createElement("a");
+    link.href = URL.createObjectURL(blob);
+    link.download = filename;
+    link.click();
+    URL.revokeObjectURL(link.href);
+  },
+
+  /**
+   * Download current user's leaves as .ics
+   * @returns {Promise}
+   */
+  downloadMyICalendar: function () {
+    return this.downloadICalendar("/api/leaves/calendar.ics", "my-leaves.ics");
+  },
+
+  /**
+   * Download team leaves as .ics
+   * @param {number} teamId
+   * @returns {Promise}
+   */
+  downloadTeamICalendar: function (teamId) {
+    return this.downloadICalendar(
+      `/api/teams/${teamId}/leaves.ics`,
+      `team-${teamId}-leaves.ics`
+    );
+  },
+
+  /**
+   * Create secret feed URLs for calendar clients, the previous ones stop working
+   * @returns {Promise}
+   */
+  createCalendarFeed: function () {
+    return API.post("/api/leaves/calendar-feed", {});
+  },
+
+  /**
+   * Revoke the secret feed URLs of the current user
+   * @returns {Promise}
+   */
+  revokeCalendarFeed: function () {
+    return API.delete("/api/leaves/calendar-feed");
+  },
+};
@@ -0,0 +1,76 @@
+/**
+ * Timesheet Service
+ */
+const TimesheetService = {
+  /**
+   * Get current user's timesheet of the week containing a day
+   * @param {string} week - YYYY-MM-DD
+   * @returns {Promise}
+   */
+  getTimesheet: function (week) {
+    return API.get(`/api/timesheets?week=${week}`);
+  },
+
+  /**
+   * List projects current user can log time to
+   * @returns {Promise}
+   */
+  getProjects: function () {
+    return API.get("/api/timesheets/projects");
+  },
+
+  /**
+   * Create or replace a time entry
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  saveEntry: function (data) {
+    return API.put("/api/timesheets/entries", data);
+  },
+
+  /**
+   * Delete a time entry
+   * @param {number} id
+   * @returns {Promise}
+   */
+  deleteEntry: function (id) {
+    return API.delete(`/api/timesheets/entries/${id}`);
+  },
+
+  /**
+   * Submit a week for approval
+   * @param {string} weekStart - YYYY-MM-DD
+   * @returns {Promise}
+   */
+  submitTimesheet: function (weekStart) {
+    return API.post("/api/timesheets/submit", { week_start: weekStart });
+  },
+
+  /**
+   * List timesheets waiting for current user's approval
+   * @returns {Promise}
+   */
+  listPendingTimesheets: function () {
+    return API.get("/api/timesheets/pending");
+  },
+
+  /**
+   * Approve a timesheet
+   * @param {number} id
+   * @param {string} note
+   * @returns {Promise}
+   */
+  approveTimesheet: function (id, note = "") {
+    return API.put(`/api/timesheets/${id}/approve`, { note: note || null });
+  },
+
+  /**
+   * Reject a timesheet
+   * @param {number} id
+   * @param {string} note
+   * @returns {Promise}
+   */
+  rejectTimesheet: function (id, note = "") {
+    return API.put(`/api/timesheets/${id}/reject`, { note: note || null });
+  },
+};
@@ -19,6 +19,84 @@ const UserService = {
     return API.get(`/api/profile/${userId}`);
   },
 
+  /**
+   * Update current user preferences
+   * @param {Object} data
+   * @returns {Promise}
+   */
+  updatePreferences: function (data) {
+    return API.put("/api/profile/preferences", data);
+  },
+
+  /**
+   * Get the two-factor status of the current user
+   * @returns {Promise}
+   */
+  getTwoFactorStatus: function () {
+    return API.get("/api/profile/2fa");
+  },
+
+  /**
+   * Start two-factor enrolment, returns { secret, provisioning_uri }
+   * @returns {Promise}
+   */
+  beginTwoFactorSetup: function () {
+    return API.post("/api/profile/2fa/setup", {});
+  },
+
+  /**
+   * Confirm two-factor enrolment, returns { recovery_codes }
+   * @param {string} code
+   * @returns {Promise}
+   */
+  enableTwoFactor: function (code) {
+    return API.post("/api/profile/2fa/enable", { code });
+  },
+
+  /**
+   * Turn two-factor authentication off
+   * @param {string} code
+   * @returns {Promise}
+   */
+  disableTwoFactor: function (code) {
+    return API.post("/api/profile/2fa/disable", { code });
+  },
+
+  /**
+   * Replace all recovery codes, returns { recovery_codes }
+   * @param {string} code
+   * @returns {Promise}
+   */
+  regenerateRecoveryCodes: function (code) {
+    return API.post("/api/profile/2fa/recovery-codes", { code });
+  },
+
+  /**
+   * List the personal access tokens of the current user
+   * @returns {Promise}
+   */
+  getAPITokens: function () {
+    return API.get("/api/profile/tokens");
+  },
+
+  /**
+   * Create a personal access token, returns { token, api_token }
+   * @param {Object} data - { name, scopes, expires_in_days }
+   * @returns {Promise}
+   */
+  createAPIToken: function (data) {
+    return API.post("/api/profile/tokens", data);
+  },
+
+  /**
+   * Revoke a personal access token
+   * @param {number} tokenId
+   * @returns {Promise}
+   */
+  revokeAPIToken: function (tokenId) {
+    return API.delete(`/api/profile/tokens/${tokenId}`);
+  },
+
   /**
    * Update user profile (placeholder for future)
    * @param {Object} data
@@ -0,0 +1,171 @@
+let teamId = null;
+let currentMonth = null;
+let teamMembers = [];
+
+$(document).ready(function () {
+  if (!AuthService.isAuthenticated()) return;
+
+  // URL format: /teams/:id/calendar
+  const pathParts = window.location.pathname.split("/");
+  teamId = pathParts[pathParts.length - 2];
+
+  if (!teamId || isNaN(teamId)) {
+    alert("Invalid Team ID");
+    window.location.href = "/teams";
+    return;
+  }
+
+  const now = new Date();
+  currentMonth = new Date(now.getFullYear(), now.getMonth(), 1);
+
+  $("#prev-month").on("click", () => changeMonth(-1));
+  $("#next-month").on("click", () => changeMonth(1));
+  $("#today-month").on("click", function () {
+    const today = new Date();
+    currentMonth = new Date(today.getFullYear(), today.getMonth(), 1);
+    loadCalendar();
+  });
+  $("#download-team-ics").on("click", async function () {
+    try {
+      await LeaveService.downloadTeamICalendar(teamId);
+    } catch (error) {
+      console.error("Error downloading calendar:", error);
+      if (error.status !== 401) {
+        alert("Failed to download calendar.");
+      }
+    }
+  });
+
+  loadTeam();
+});
+
+/**
+ * Format a date as YYYY-MM-DD in local time
+ * @param {Date} date
+ * @returns {string}
+ */
+function toISODate(date) {
+  const month = String(date.getMonth() + 1).padStart(2, "0");
+  const day = String(date.getDate()).padStart(2, "0");
+  return `${date.getFullYear()}-${month}-${day}`;
+}
+
+/**
+ * Load team info and members, then the calendar
+ */
+async function loadTeam() {
+  try {
+    const [team, members] = await Promise.all([
+      TeamService.getTeamDetails(teamId),
+      TeamService.getTeamMembers(teamId, 100, 0),
+    ]);
+    $("#breadcrumb-team-link").text(team.name).attr("href", `/teams/${teamId}`);
+    teamMembers = members.members || [];
+    loadCalendar();
+  } catch (error) {
+    console.error("Error fetching team:", error);
+    if (error.status !== 401) {
+      alert("Failed to load team.");
+    }
+  }
+}
+
+/**
+ * Move the calendar by a number of months
+ * @param {number} delta
+ */
+function changeMonth(delta) {
+  currentMonth = new Date(
+    currentMonth.getFullYear(),
+    currentMonth.getMonth() + delta,
+    1
+  );
+  loadCalendar();
+}
+
+/**
+ * Fetch leaves of the displayed month and render the grid
+ */
+async function loadCalendar() {
+  const lastDay = new Date(
+    currentMonth.getFullYear(),
+    currentMonth.getMonth() + 1,
+    0
+  );
+  $("#calendar-title").text(
+    currentMonth.toLocaleDateString(undefined, { month: "long", year: "numeric" })
+  );
+
+  try {
+    const response = await LeaveService.getTeamCalendar(
+      teamId,
+      toISODate(currentMonth),
+      toISODate(lastDay)
+    );
+    renderCalendar(lastDay.getDate(), response.leaves || []);
+  } catch (error) {
+    console.error("Error fetching team calendar:", error);
+    if (error.status !== 401) {
+      alert("Failed to load team calendar.");
+    }
+  }
+}
+
+/**
+ * Render one row per member and one column per day of the month
+ * @param {number} daysInMonth
+ * @param {Array} leaves
+ */
+function renderCalendar(daysInMonth, leaves) {
+  const days = [];
+  for (let d = 1; d <= daysInMonth; d++) {
+    days.push(
+      new Date(currentMonth.getFullYear(), currentMonth.getMonth(), d)
+    );
+  }
+
+  let headHtml = '<tr><th class="member-name">Member</th>';
+  days.forEach((day) => {
+    const weekend = day.getDay() === 0 || day.getDay() === 6;
+    headHtml += `<th class="${weekend ? "weekend" : ""}">${day.getDate()}<br /><span class="text-muted">${day.toLocaleDateString(undefined, { weekday: "narrow" })}</span></th>`;
+  });
+  headHtml += "</tr>";
+  $("#calendar-head").html(headHtml);
+
+  // Members who left the team may still have leaves in the displayed month
+  const rows = new Map();
+  teamMembers.forEach((member) => rows.set(member.id, member.name));
+  leaves.forEach((leave) => {
+    if (!rows.has(leave.user.id)) rows.set(leave.user.id, leave.user.name);
+  });
+
+  if (rows.size === 0) {
+    $("#calendar-body").html(
+      `<tr><td colspan="${daysInMonth + 1}" class="text-center text-muted">No members in this team</td></tr>`
+    );
+    return;
+  }
+
+  let bodyHtml = "";
+  rows.forEach((name, userId) => {
+    const userLeaves = leaves.filter((leave) => leave.user.id === userId);
+    bodyHtml += `<tr><td class="member-name"><a href="/profile/${userId}" class="text-decoration-none">${name}</a></td>`;
+    days.forEach((day) => {
+      const date = toISODate(day);
+      const weekend = day.getDay() === 0 || day.getDay() === 6;
+      const leave = userLeaves.find(
+        (l) => l.start_date <= date && l.end_date >= date
+      );
+      if (!leave) {
+        bodyHtml += `<td class="${weekend ? "weekend" : ""}"></td>`;
+        return;
+      }
+      const halfDay =
+        (leave.start_half_day && leave.start_date === date) ||
+        (leave.end_half_day && leave.end_date === date);
+      bodyHtml += `<td class="leave-${leave.status}" title="${leave.type} leave (${leave.status})">${halfDay ? "&frac12;" : ""}</td>`;
+    });
+    bodyHtml += "</tr>";
+  });
+  $("#calendar-body").html(bodyHtml);
+}
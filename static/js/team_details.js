@@ -15,6 +15,7 @@ $(document).ready(function () {
     return;
   }
 
+  $("#team-calendar-link").attr("href", `/teams/${teamId}/calendar`);
   loadTeamDetails();
   loadTeamMembers(currentOffset);
 });
@@ -0,0 +1,305 @@
+let currentWeekStart = null;
+let projects = [];
+let timesheet = null;
+
+const TIMESHEET_STATUS_BADGES = {
+  draft: "bg-secondary",
+  submitted: "bg-warning text-dark",
+  approved: "bg-success",
+  rejected: "bg-danger",
+};
+
+$(document).ready(async function () {
+  if (!AuthService.isAuthenticated()) return;
+
+  currentWeekStart = startOfWeek(new Date());
+
+  $("#prev-week").on("click", () => changeWeek(-7));
+  $("#next-week").on("click", () => changeWeek(7));
+  $("#this-week").on("click", function () {
+    currentWeekStart = startOfWeek(new Date());
+    loadTimesheet();
+  });
+  $("#submit-timesheet").on("click", submitTimesheet);
+  $("#timesheet-body").on("change", "input", saveCell);
+
+  try {
+    const response = await TimesheetService.getProjects();
+    projects = response.projects || [];
+  } catch (error) {
+    console.error("Error fetching projects:", error);
+  }
+
+  loadTimesheet();
+  loadPendingTimesheets();
+});
+
+/**
+ * Get the Monday of the week containing date
+ * @param {Date} date
+ * @returns {Date}
+ */
+function startOfWeek(date) {
+  const result = new Date(date.getFullYear(), date.getMonth(), date.getDate());
+  result.setDate(result.getDate() - ((result.getDay() + 6) % 7));
+  return result;
+}
+
+/**
+ * Format a date as YYYY-MM-DD in local time
+ * @param {Date} date
+ * @returns {string}
+ */
+function toISODate(date) {
+  const month = String(date.getMonth() + 1).padStart(2, "0");
+  const day = String(date.getDate()).padStart(2, "0");
+  return `${date.getFullYear()}-${month}-${day}`;
+}
+
+/**
+ * Move the timesheet by a number of days
+ * @param {number} days
+ */
+function changeWeek(days) {
+  currentWeekStart = new Date(
+    currentWeekStart.getFullYear(),
+    currentWeekStart.getMonth(),
+    currentWeekStart.getDate() + days
+  );
+  loadTimesheet();
+}
+
+/**
+ * Extract the error message of a failed request
+ * @param {Object} error
+ * @param {string} fallback
+ * @returns {string}
+ */
+function errorMessage(error, fallback) {
+  return error.responseJSON && error.responseJSON.message
+    ? error.responseJSON.message
+    : fallback;
+}
+
+/**
+ * Fetch and render the displayed week
+ */
+async function loadTimesheet() {
+  try {
+    timesheet = await TimesheetService.getTimesheet(
+      toISODate(currentWeekStart)
+    );
+    renderTimesheet();
+  } catch (error) {
+    console.error("Error fetching timesheet:", error);
+    if (error.status !== 401) {
+      alert("Failed to load timesheet.");
+    }
+  }
+}
+
+/**
+ * Render one row per project and one column per day of the week
+ */
+function renderTimesheet() {
+  const days = [];
+  for (let i = 0; i < 7; i++) {
+    days.push(
+      new Date(
+        currentWeekStart.getFullYear(),
+        currentWeekStart.getMonth(),
+        currentWeekStart.getDate() + i
+      )
+    );
+  }
+  const editable =
+    timesheet.status === "draft" || timesheet.status === "rejected";
+  const today = toISODate(new Date());
+
+  $("#timesheet-title").text(
+    `${days[0].toLocaleDateString()} - ${days[6].toLocaleDateString()}`
+  );
+  $("#timesheet-status")
+    .attr("class", `badge text-capitalize ${TIMESHEET_STATUS_BADGES[timesheet.status]}`)
+    .text(timesheet.status);
+  if (timesheet.review_note) {
+    $("#timesheet-review-note")
+      .text(`Reviewer note: ${timesheet.review_note}`)
+      .removeClass("d-none");
+  } else {
+    $("#timesheet-review-note").addClass("d-none");
+  }
+  $("#submit-timesheet").prop(
+    "disabled",
+    !editable || timesheet.entries.length === 0
+  );
+
+  let headHtml = '<tr><th class="project-name">Project</th>';
+  days.forEach((day) => {
+    headHtml += `<th>${day.toLocaleDateString(undefined, { weekday: "short" })}<br /><small class="text-muted">${day.getDate()}</small></th>`;
+  });
+  headHtml += "<th>Total</th></tr>";
+  $("#timesheet-head").html(headHtml);
+
+  // Projects the user left may still have entries in past weeks
+  const rows = new Map();
+  projects.forEach((project) => rows.set(project.id, project));
+  timesheet.entries.forEach((entry) => {
+    if (!rows.has(entry.project.id)) rows.set(entry.project.id, entry.project);
+  });
+
+  if (rows.size === 0) {
+    $("#timesheet-body").html(
+      '<tr><td colspan="9" class="text-center text-muted">You are not a member of any project</td></tr>'
+    );
+    $("#timesheet-foot").html("");
+    return;
+  }
+
+  const dayTotals = new Array(7).fill(0);
+  let bodyHtml = "";
+  rows.forEach((project) => {
+    let projectTotal = 0;
+    bodyHtml += `<tr><td class="project-name fw-bold">${project.name} <span class="badge bg-secondary">${project.abbreviation}</span></td>`;
+    days.forEach((day, index) => {
+      const date = toISODate(day);
+      const entry = timesheet.entries.find(
+        (e) => e.project.id === project.id && e.work_date === date
+      );
+      const hours = entry ? entry.hours : "";
+      if (entry) {
+        projectTotal += entry.hours;
+        dayTotals[index] += entry.hours;
+      }
+      const disabled = !editable || date > today;
+      bodyHtml += `<td><input type="number" class="form-control form-control-sm" min="0" max="24" step="0.25" value="${hours}" data-project-id="${project.id}" data-date="${date}" data-entry-id="${entry ? entry.id : ""}" ${disabled ? "disabled" : ""} /></td>`;
+    });
+    bodyHtml += `<td class="fw-bold">${projectTotal.toFixed(2)}</td></tr>`;
+  });
+  $("#timesheet-body").html(bodyHtml);
+
+  let footHtml = '<tr class="table-light"><th class="project-name">Total</th>';
+  dayTotals.forEach((total) => {
+    footHtml += `<th>${total.toFixed(2)}</th>`;
+  });
+  footHtml += `<th>${timesheet.total_hours.toFixed(2)}</th></tr>`;
+  $("#timesheet-foot").html(footHtml);
+}
+
+/**
+ * Save or delete the entry of a changed cell
+ */
+async function saveCell() {
+  const $input = $(this);
+  const hours = parseFloat($input.val());
+  const entryId = $input.data("entry-id");
+
+  try {
+    if (isNaN(hours) || hours === 0) {
+      if (!entryId) return;
+      await TimesheetService.deleteEntry(entryId);
+    } else {
+      await TimesheetService.saveEntry({
+        project_id: $input.data("project-id"),
+        work_date: $input.data("date"),
+        hours: hours,
+      });
+    }
+  } catch (error) {
+    console.error("Error saving time entry:", error);
+    if (error.status !== 401) {
+      alert(errorMessage(error, "Failed to save time entry."));
+    }
+  }
+  loadTimesheet();
+}
+
+/**
+ * Submit the displayed week for approval
+ */
+async function submitTimesheet() {
+  if (!confirm("Submit this week for approval? You cannot edit it afterwards.")) return;
+
+  try {
+    await TimesheetService.submitTimesheet(toISODate(currentWeekStart));
+    loadTimesheet();
+  } catch (error) {
+    console.error("Error submitting timesheet:", error);
+    if (error.status !== 401) {
+      alert(errorMessage(error, "Failed to submit timesheet."));
+    }
+  }
+}
+
+/**
+ * Fetch and display timesheets waiting for approval
+ */
+async function loadPendingTimesheets() {
+  try {
+    const response = await TimesheetService.listPendingTimesheets();
+    renderPendingTimesheets(response.timesheets || []);
+  } catch (error) {
+    console.error("Error fetching pending timesheets:", error);
+  }
+}
+
+/**
+ * Render pending approvals, hidden when there is nothing to review
+ * @param {Array} timesheets
+ */
+function renderPendingTimesheets(timesheets) {
+  if (timesheets.length === 0) {
+    $("#pending-card").addClass("d-none");
+    return;
+  }
+
+  let html = "";
+  timesheets.forEach((item) => {
+    const projectHours = {};
+    item.entries.forEach((entry) => {
+      projectHours[entry.project.name] =
+        (projectHours[entry.project.name] || 0) + entry.hours;
+    });
+    const projectsText = Object.entries(projectHours)
+      .map(([name, hours]) => `${name}: ${hours.toFixed(2)}h`)
+      .join(", ");
+    html += `
+      <tr>
+        <td><a href="/profile/${item.user.id}" class="text-decoration-none">${item.user.name}</a></td>
+        <td>${new Date(item.week_start).toLocaleDateString()} - ${new Date(item.week_end).toLocaleDateString()}</td>
+        <td>${item.total_hours.toFixed(2)}</td>
+        <td class="small">${projectsText}</td>
+        <td class="text-end text-nowrap">
+          <button class="btn btn-sm btn-success" onclick="reviewTimesheet(${item.id}, true)">Approve</button>
+          <button class="btn btn-sm btn-outline-danger" onclick="reviewTimesheet(${item.id}, false)">Reject</button>
+        </td>
+      </tr>
+    `;
+  });
+  $("#pending-timesheets").html(html);
+  $("#pending-card").removeClass("d-none");
+}
+
+/**
+ * Approve or reject a timesheet
+ * @param {number} id
+ * @param {boolean} approve
+ */
+async function reviewTimesheet(id, approve) {
+  const note = prompt(approve ? "Approval note (optional)" : "Rejection reason (optional)");
+  if (note === null) return;
+
+  try {
+    if (approve) {
+      await TimesheetService.approveTimesheet(id, note);
+    } else {
+      await TimesheetService.rejectTimesheet(id, note);
+    }
+    loadPendingTimesheets();
+  } catch (error) {
+    console.error("Error reviewing timesheet:", error);
+    if (error.status !== 401) {
+      alert(errorMessage(error, "Failed to review timesheet."));
+    }
+  }
+}
@@ -25,6 +25,12 @@ async function loadUserProfile(userId = null) {
       data = await UserService.getProfile();
     }
     updateProfileDOM(data);
+    if (!userId || String(data.id) === localStorage.getItem("userId")) {
+      setupShowBirthdayToggle(data.show_birthday);
+      setupEmailPreferenceToggles(data);
+      loadTwoFactorStatus();
+      loadAPITokens();
+    }
   } catch (error) {
     console.error("Error fetching profile:", error);
     // API utility handles 401, so we only handle other errors here
@@ -114,3 +120,302 @@ function updateProfileDOM(data) {
   )}&background=random&size=150`;
   $('img[alt="avatar"]').attr("src", avatarUrl);
 }
+
+/**
+ * Show the birthday visibility toggle on the user's own profile
+ * @param {boolean} showBirthday
+ */
+function setupShowBirthdayToggle(showBirthday) {
+  const $toggle = $("#show-birthday-toggle");
+  $toggle.prop("checked", showBirthday);
+  $("#show-birthday-row").removeClass("d-none");
+
+  $toggle.off("change").on("change", async function () {
+    const checked = $(this).is(":checked");
+    $toggle.prop("disabled", true);
+    try {
+      await UserService.updatePreferences({ show_birthday: checked });
+    } catch (error) {
+      console.error("Error updating preferences:", error);
+      $toggle.prop("checked", !checked);
+      if (error.status !== 401) {
+        alert("Failed to update preferences. Please try again later.");
+      }
+    } finally {
+      $toggle.prop("disabled", false);
+    }
+  });
+}
+
+/**
+ * Show the email preference toggles on the user's own profile
+ * @param {Object} data - profile with email_team_changes and email_leave_reviews
+ */
+function setupEmailPreferenceToggles(data) {
+  const $toggles = $(".email-preference-toggle");
+  $toggles.each(function () {
+    $(this).prop("checked", data[$(this).data("preference")] !== false);
+  });
+  $("#email-preferences-row").removeClass("d-none");
+
+  $toggles.off("change").on("change", async function () {
+    const $toggle = $(this);
+    const checked = $toggle.is(":checked");
+    $toggle.prop("disabled", true);
+    try {
+      await UserService.updatePreferences({
+        [$toggle.data("preference")]: checked,
+      });
+    } catch (error) {
+      console.error("Error updating preferences:", error);
+      $toggle.prop("checked", !checked);
+      if (error.status !== 401) {
+        alert("Failed to update preferences. Please try again later.");
+      }
+    } finally {
+      $toggle.prop("disabled", false);
+    }
+  });
+}
+
+/**
+ * Show the two-factor card with actions matching the current status
+ */
+async function loadTwoFactorStatus() {
+  try {
+    const status = await UserService.getTwoFactorStatus();
+    renderTwoFactorStatus(status);
+  } catch (error) {
+    console.error("Error fetching two-factor status:", error);
+    return;
+  }
+
+  $("#two-factor-setup-btn")
+    .off("click")
+    .on("click", async function () {
+      try {
+        const setup = await UserService.beginTwoFactorSetup();
+        TotpQrCode.render(
+          document.getElementById("two-factor-qr"),
+          setup.provisioning_uri
+        );
+        $("#two-factor-secret").text(setup.secret);
+        $("#two-factor-setup, #two-factor-code-group, #two-factor-enable-btn").removeClass("d-none");
+        $(this).addClass("d-none");
+      } catch (error) {
+        showTwoFactorError(error, "Failed to start two-factor setup.");
+      }
+    });
+
+  $("#two-factor-enable-btn")
+    .off("click")
+    .on("click", async function () {
+      try {
+        const response = await UserService.enableTwoFactor(
+          $("#two-factor-code").val().trim()
+        );
+        $("#two-factor-setup").addClass("d-none");
+        showRecoveryCodes(response.recovery_codes);
+        renderTwoFactorStatus(await UserService.getTwoFactorStatus());
+      } catch (error) {
+        showTwoFactorError(error, "Failed to enable two-factor authentication.");
+      }
+    });
+
+  $("#two-factor-regenerate-btn")
+    .off("click")
+    .on("click", async function () {
+      if (
+        !confirm(
+          "Regenerating recovery codes invalidates all previous codes. Continue?"
+        )
+      ) {
+        return;
+      }
+      try {
+        const response = await UserService.regenerateRecoveryCodes(
+          $("#two-factor-code").val().trim()
+        );
+        showRecoveryCodes(response.recovery_codes);
+      } catch (error) {
+        showTwoFactorError(error, "Failed to regenerate recovery codes.");
+      }
+    });
+
+  $("#two-factor-disable-btn")
+    .off("click")
+    .on("click", async function () {
+      if (!confirm("Disable two-factor authentication for your account?")) {
+        return;
+      }
+      try {
+        await UserService.disableTwoFactor($("#two-factor-code").val().trim());
+        $("#two-factor-recovery-codes").addClass("d-none");
+        renderTwoFactorStatus(await UserService.getTwoFactorStatus());
+      } catch (error) {
+        showTwoFactorError(error, "Failed to disable two-factor authentication.");
+      }
+    });
+}
+
+/**
+ * @param {Object} status - { enabled, required, recovery_codes_remaining }
+ */
+function renderTwoFactorStatus(status) {
+  $("#two-factor-card").removeClass("d-none");
+  $("#two-factor-status")
+    .text(status.enabled ? "Enabled" : "Disabled")
+    .toggleClass("bg-success", status.enabled)
+    .toggleClass("bg-secondary", !status.enabled);
+  $("#two-factor-required").toggleClass("d-none", !status.required);
+
+  $("#two-factor-code").val("");
+  $("#two-factor-setup-btn").toggleClass("d-none", status.enabled);
+  $("#two-factor-enable-btn").addClass("d-none");
+  $("#two-factor-code-group").toggleClass("d-none", !status.enabled);
+  $("#two-factor-regenerate-btn").toggleClass("d-none", !status.enabled);
+  $("#two-factor-disable-btn").toggleClass(
+    "d-none",
+    !status.enabled || status.required
+  );
+}
+
+/**
+ * @param {string[]} codes
+ */
+function showRecoveryCodes(codes) {
+  const $list = $("#two-factor-recovery-codes-list").empty();
+  codes.forEach((code) => {
+    $list.append($("<li>").addClass("list-group-item").text(code));
+  });
+  $("#two-factor-recovery-codes").removeClass("d-none");
+}
+
+function showTwoFactorError(error, fallbackMessage) {
+  console.error(fallbackMessage, error);
+  if (error.status !== 401) {
+    alert(error.responseJSON?.message || fallbackMessage);
+  }
+}
+
+/**
+ * Show the API token card with the tokens of the current user
+ */
+async function loadAPITokens() {
+  try {
+    const response = await UserService.getAPITokens();
+    renderAPITokens(response.tokens);
+  } catch (error) {
+    console.error("Error fetching API tokens:", error);
+    return;
+  }
+  $("#api-tokens-card").removeClass("d-none");
+
+  $("#api-token-form")
+    .off("submit")
+    .on("submit", async function (e) {
+      e.preventDefault();
+      const scopes = $(".api-token-scope:checked")
+        .map(function () {
+          return $(this).val();
+        })
+        .get();
+      if (scopes.length === 0) {
+        alert("Select at least one scope.");
+        return;
+      }
+      try {
+        const response = await UserService.createAPIToken({
+          name: $("#api-token-name").val().trim(),
+          scopes: scopes,
+          expires_in_days: parseInt($("#api-token-expiry").val(), 10),
+        });
+        $("#api-token-value").text(response.token);
+        $("#api-token-created").removeClass("d-none");
+        $("#api-token-name").val("");
+        renderAPITokens((await UserService.getAPITokens()).tokens);
+      } catch (error) {
+        showAPITokenError(error, "Failed to create API token.");
+      }
+    });
+
+  $("#api-token-list")
+    .off("click", ".revoke-api-token-btn")
+    .on("click", ".revoke-api-token-btn", async function () {
+      const tokenId = $(this).data("id");
+      if (
+        !confirm(
+          "Revoke this token? Scripts using it will stop working immediately."
+        )
+      ) {
+        return;
+      }
+      try {
+        await UserService.revokeAPIToken(tokenId);
+        renderAPITokens((await UserService.getAPITokens()).tokens);
+      } catch (error) {
+        showAPITokenError(error, "Failed to revoke API token.");
+      }
+    });
+}
+
+/**
+ * @param {Object[]} tokens
+ */
+function renderAPITokens(tokens) {
+  const $list = $("#api-token-list").empty();
+  if (!tokens || tokens.length === 0) {
+    $list.html(
+      '<tr><td colspan="6" class="text-center text-muted">No API tokens</td></tr>'
+    );
+    return;
+  }
+
+  tokens.forEach((token) => {
+    const expired =
+      token.expires_at && new Date(token.expires_at) <= new Date();
+    const expires = token.expires_at
+      ? new Date(token.expires_at).toLocaleDateString()
+      : "Never";
+    const lastUsed = token.last_used_at
+      ? `${new Date(token.last_used_at).toLocaleString()}${
+          token.last_used_ip ? ` (${token.last_used_ip})` : ""
+        }`
+      : "Never";
+
+    const $scopes = $("<td>");
+    token.scopes.forEach((scope) => {
+      $scopes.append(
+        $("<span>").addClass("badge bg-secondary me-1").text(scope)
+      );
+    });
+
+    $list.append(
+      $("<tr>").append(
+        $("<td>").addClass("fw-bold").text(token.name),
+        $("<td>").append(
+          $("<code>").text(`${token.token_prefix}…`)
+        ),
+        $scopes,
+        $("<td>")
+          .text(expires)
+          .toggleClass("text-danger", Boolean(expired)),
+        $("<td>").addClass("small").text(lastUsed),
+        $("<td>").append(
+          $("<button>")
+            .attr("type", "button")
+            .addClass("btn btn-sm btn-outline-danger revoke-api-token-btn")
+            .data("id", token.id)
+            .text("Revoke")
+        )
+      )
+    );
+  });
+}
+
+function showAPITokenError(error, fallbackMessage) {
+  console.error(fallbackMessage, error);
+  if (error.status !== 401) {
+    alert(error.responseJSON?.message || fallbackMessage);
+  }
+}
@@ -0,0 +1,24 @@
+/**
+ * Renders TOTP provisioning URIs as QR codes for authenticator apps
+ */
+const TotpQrCode = {
+  /**
+   * Render the provisioning URI into the element
+   * @param {HTMLElement} element
+   * @param {string} provisioningUri
+   */
+  render: function (element, provisioningUri) {
+    if (!element || !provisioningUri) return;
+    element.innerHTML = "";
+    new QRCode(element, { text: provisioningUri, width: 180, height: 180 });
+  },
+};
+
+document.addEventListener("DOMContentLoaded", function () {
+  // Server rendered setup pages carry the URI on the element
+  document
+    .querySelectorAll("[data-provisioning-uri]")
+    .forEach((element) =>
+      TotpQrCode.render(element, element.dataset.provisioningUri)
+    );
+});